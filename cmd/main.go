@@ -14,6 +14,7 @@ import (
 	"github.com/slilp/go-wallet/internal/api/restapis"
 	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
 	"github.com/slilp/go-wallet/internal/config"
+	"github.com/slilp/go-wallet/internal/jobs"
 	"github.com/slilp/go-wallet/internal/middleware"
 	"github.com/slilp/go-wallet/internal/server"
 )
@@ -21,12 +22,22 @@ import (
 func main() {
 	config.InitConfig()
 
+	if len(os.Args) > 1 && os.Args[1] == "backfill-balance-after" {
+		server.BackfillBalanceAfter()
+		return
+	}
+
 	app := server.NewApplicationServer()
 	httpServer := restapis.NewHttpServer(app)
 
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	jobs.Start(jobsCtx, app.Jobs...)
+
 	r := gin.Default()
 
 	r.Use(middleware.AuthAccessTokenMiddleware)
+	r.Use(middleware.AuthAdminApiKeyMiddleware)
 
 	r.GET("/healthz", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "ok"})
@@ -45,6 +56,7 @@ func main() {
 	go func() {
 		<-quit
 		log.Println("Shutting down server...")
+		stopJobs()
 
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
@@ -0,0 +1 @@
+ALTER TABLE "wallets" ALTER COLUMN "balance" TYPE DECIMAL(20, 8);
\ No newline at end of file
@@ -0,0 +1,8 @@
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM "wallets" WHERE "balance" <> ROUND("balance", 2)) THEN
+        RAISE EXCEPTION 'wallets.balance has sub-cent values; reconcile them before converting to DECIMAL(20, 2)';
+    END IF;
+END $$;
+
+ALTER TABLE "wallets" ALTER COLUMN "balance" TYPE DECIMAL(20, 2);
\ No newline at end of file
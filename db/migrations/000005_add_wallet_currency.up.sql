@@ -0,0 +1 @@
+ALTER TABLE "wallets" ADD COLUMN "currency" VARCHAR(3) NOT NULL DEFAULT 'THB';
\ No newline at end of file
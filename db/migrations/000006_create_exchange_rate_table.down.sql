@@ -0,0 +1,4 @@
+ALTER TABLE "transactions" DROP COLUMN IF EXISTS "exchange_rate";
+ALTER TABLE "transactions" DROP COLUMN IF EXISTS "credited_amount";
+
+DROP TABLE IF EXISTS "exchange_rates";
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE "exchange_rates" (
+    "base_currency" VARCHAR(3) NOT NULL,
+    "quote_currency" VARCHAR(3) NOT NULL,
+    "rate" DECIMAL(20, 8) NOT NULL,
+    "spread" DECIMAL(10, 8) NOT NULL DEFAULT 0,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("base_currency", "quote_currency")
+);
+
+ALTER TABLE "transactions" ADD COLUMN "credited_amount" DECIMAL(20, 2);
+ALTER TABLE "transactions" ADD COLUMN "exchange_rate" DECIMAL(20, 8);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "postings";
+DROP TABLE IF EXISTS "journal_entries";
+DROP TABLE IF EXISTS "ledger_accounts";
\ No newline at end of file
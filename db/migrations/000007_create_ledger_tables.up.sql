@@ -0,0 +1,59 @@
+CREATE TABLE "ledger_accounts" (
+    "id" UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    "code" VARCHAR(50) NOT NULL,
+    "currency" VARCHAR(3) NOT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX "idx_ledger_accounts_code_currency" ON "ledger_accounts"("code", "currency") WHERE "code" <> 'wallet';
+
+CREATE TABLE "journal_entries" (
+    "id" UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    "transaction_id" VARCHAR(20),
+    "description" VARCHAR(255),
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY ("transaction_id") REFERENCES "transactions"("id")
+);
+
+CREATE INDEX "idx_journal_entries_transaction_id" ON "journal_entries"("transaction_id");
+
+CREATE TABLE "postings" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "journal_entry_id" UUID NOT NULL,
+    "account_id" UUID NOT NULL,
+    "direction" VARCHAR(6) NOT NULL CHECK ("direction" IN ('debit', 'credit')),
+    "amount" DECIMAL(20, 2) NOT NULL CHECK ("amount" > 0),
+    "currency" VARCHAR(3) NOT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY ("journal_entry_id") REFERENCES "journal_entries"("id"),
+    FOREIGN KEY ("account_id") REFERENCES "ledger_accounts"("id")
+);
+
+CREATE INDEX "idx_postings_journal_entry_id" ON "postings"("journal_entry_id");
+CREATE INDEX "idx_postings_account_id_created_at" ON "postings"("account_id", "created_at" DESC);
+
+INSERT INTO "ledger_accounts" ("code", "currency")
+SELECT "code", "currency"
+FROM (VALUES ('treasury'), ('fees'), ('suspense'), ('fx')) AS system_accounts("code")
+CROSS JOIN (VALUES ('THB'), ('USD'), ('EUR'), ('GBP'), ('SGD'), ('JPY'), ('KRW'), ('VND')) AS currencies("currency");
+
+INSERT INTO "ledger_accounts" ("id", "code", "currency", "created_at")
+SELECT "id", 'wallet', "currency", "created_at" FROM "wallets";
+
+-- Existing balances were never journaled; open them against suspense.
+INSERT INTO "journal_entries" ("description")
+SELECT DISTINCT 'Opening balance ' || "currency" FROM "wallets" WHERE "balance" > 0;
+
+INSERT INTO "postings" ("journal_entry_id", "account_id", "direction", "amount", "currency")
+SELECT "journal_entries"."id", "wallets"."id", 'credit', "wallets"."balance", "wallets"."currency"
+FROM "wallets"
+JOIN "journal_entries" ON "journal_entries"."description" = 'Opening balance ' || "wallets"."currency"
+WHERE "wallets"."balance" > 0;
+
+INSERT INTO "postings" ("journal_entry_id", "account_id", "direction", "amount", "currency")
+SELECT "journal_entries"."id", "ledger_accounts"."id", 'debit', SUM("wallets"."balance"), "wallets"."currency"
+FROM "wallets"
+JOIN "journal_entries" ON "journal_entries"."description" = 'Opening balance ' || "wallets"."currency"
+JOIN "ledger_accounts" ON "ledger_accounts"."code" = 'suspense' AND "ledger_accounts"."currency" = "wallets"."currency"
+WHERE "wallets"."balance" > 0
+GROUP BY "journal_entries"."id", "ledger_accounts"."id", "wallets"."currency";
\ No newline at end of file
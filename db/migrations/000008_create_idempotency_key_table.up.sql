@@ -0,0 +1,10 @@
+CREATE TABLE "idempotency_keys" (
+    "user_id" UUID NOT NULL,
+    "key" VARCHAR(255) NOT NULL,
+    "fingerprint" VARCHAR(64) NOT NULL,
+    "transaction_id" VARCHAR(20),
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("user_id", "key"),
+    FOREIGN KEY ("user_id") REFERENCES "users"("id"),
+    FOREIGN KEY ("transaction_id") REFERENCES "transactions"("id")
+);
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "idx_transactions_original_transaction_id";
+
+ALTER TABLE "transactions" DROP COLUMN IF EXISTS "refunded_amount";
+ALTER TABLE "transactions" DROP COLUMN IF EXISTS "original_transaction_id";
\ No newline at end of file
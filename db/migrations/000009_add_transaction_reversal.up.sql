@@ -0,0 +1,4 @@
+ALTER TABLE "transactions" ADD COLUMN "original_transaction_id" VARCHAR(20) REFERENCES "transactions"("id");
+ALTER TABLE "transactions" ADD COLUMN "refunded_amount" DECIMAL(20, 2) NOT NULL DEFAULT 0;
+
+CREATE INDEX "idx_transactions_original_transaction_id" ON "transactions"("original_transaction_id");
\ No newline at end of file
@@ -0,0 +1,16 @@
+CREATE TABLE "holds" (
+    "id" UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    "wallet_id" UUID NOT NULL,
+    "to_wallet_id" UUID NOT NULL,
+    "amount" DECIMAL(20, 2) NOT NULL CHECK ("amount" > 0),
+    "captured_amount" DECIMAL(20, 2),
+    "status" VARCHAR(20) NOT NULL DEFAULT 'active' CHECK ("status" IN ('active', 'captured', 'voided', 'expired')),
+    "transaction_id" VARCHAR(20),
+    "expires_at" TIMESTAMP NOT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY ("transaction_id") REFERENCES "transactions"("id")
+);
+
+CREATE INDEX "idx_holds_wallet_id_active" ON "holds"("wallet_id") WHERE "status" = 'active';
+CREATE INDEX "idx_holds_expires_at_active" ON "holds"("expires_at") WHERE "status" = 'active';
\ No newline at end of file
@@ -0,0 +1,34 @@
+CREATE TABLE "schedules" (
+    "id" UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    "user_id" UUID NOT NULL,
+    "from_wallet_id" UUID NOT NULL,
+    "to_wallet_id" UUID NOT NULL,
+    "amount" DECIMAL(20, 2) NOT NULL CHECK ("amount" > 0),
+    "frequency" VARCHAR(20) NOT NULL CHECK ("frequency" IN ('once', 'daily', 'weekly', 'monthly')),
+    "start_at" TIMESTAMP NOT NULL,
+    "end_at" TIMESTAMP,
+    "max_runs" INTEGER CHECK ("max_runs" > 0),
+    "run_count" INTEGER NOT NULL DEFAULT 0,
+    "next_run_at" TIMESTAMP,
+    "status" VARCHAR(20) NOT NULL DEFAULT 'active' CHECK ("status" IN ('active', 'completed', 'cancelled')),
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY ("user_id") REFERENCES "users"("id")
+);
+
+CREATE INDEX "idx_schedules_user_id" ON "schedules"("user_id");
+CREATE INDEX "idx_schedules_next_run_at_active" ON "schedules"("next_run_at") WHERE "status" = 'active';
+
+CREATE TABLE "schedule_runs" (
+    "id" UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    "schedule_id" UUID NOT NULL,
+    "run_at" TIMESTAMP NOT NULL,
+    "status" VARCHAR(20) NOT NULL CHECK ("status" IN ('succeeded', 'failed')),
+    "transaction_id" VARCHAR(20),
+    "error_message" TEXT,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY ("schedule_id") REFERENCES "schedules"("id"),
+    FOREIGN KEY ("transaction_id") REFERENCES "transactions"("id")
+);
+
+CREATE INDEX "idx_schedule_runs_schedule_id" ON "schedule_runs"("schedule_id");
\ No newline at end of file
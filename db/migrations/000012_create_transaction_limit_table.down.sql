@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "transaction_limits";
\ No newline at end of file
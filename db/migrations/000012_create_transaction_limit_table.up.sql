@@ -0,0 +1,19 @@
+CREATE TABLE "transaction_limits" (
+    "id" UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    "user_id" UUID,
+    "currency" VARCHAR(3) NOT NULL,
+    "per_transaction_max" DECIMAL(20, 2) CHECK ("per_transaction_max" > 0),
+    "daily_wallet_max" DECIMAL(20, 2) CHECK ("daily_wallet_max" > 0),
+    "monthly_wallet_max" DECIMAL(20, 2) CHECK ("monthly_wallet_max" > 0),
+    "daily_user_max" DECIMAL(20, 2) CHECK ("daily_user_max" > 0),
+    "monthly_user_max" DECIMAL(20, 2) CHECK ("monthly_user_max" > 0),
+    "max_transfers" INTEGER CHECK ("max_transfers" > 0),
+    "transfer_window_seconds" INTEGER CHECK ("transfer_window_seconds" > 0),
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE,
+    CHECK (("max_transfers" IS NULL) = ("transfer_window_seconds" IS NULL))
+);
+
+CREATE UNIQUE INDEX "idx_transaction_limits_global_currency" ON "transaction_limits"("currency") WHERE "user_id" IS NULL;
+CREATE UNIQUE INDEX "idx_transaction_limits_user_currency" ON "transaction_limits"("user_id", "currency") WHERE "user_id" IS NOT NULL;
\ No newline at end of file
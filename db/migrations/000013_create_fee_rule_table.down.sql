@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "idx_transactions_parent_transaction_id";
+
+ALTER TABLE "transactions" DROP COLUMN IF EXISTS "parent_transaction_id";
+
+DROP TABLE IF EXISTS "fee_rules";
\ No newline at end of file
@@ -0,0 +1,20 @@
+CREATE TABLE "fee_rules" (
+    "id" UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    "transaction_type" VARCHAR(20) NOT NULL CHECK ("transaction_type" IN ('transfer', 'withdraw')),
+    "currency" VARCHAR(3) NOT NULL,
+    "min_amount" DECIMAL(20, 2) NOT NULL DEFAULT 0 CHECK ("min_amount" >= 0),
+    "flat_fee" DECIMAL(20, 2) NOT NULL DEFAULT 0 CHECK ("flat_fee" >= 0),
+    "rate" DECIMAL(10, 8) NOT NULL DEFAULT 0 CHECK ("rate" >= 0 AND "rate" < 1),
+    "min_fee" DECIMAL(20, 2) CHECK ("min_fee" >= 0),
+    "max_fee" DECIMAL(20, 2) CHECK ("max_fee" >= 0),
+    "fee_wallet_id" UUID NOT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY ("fee_wallet_id") REFERENCES "wallets"("id"),
+    UNIQUE ("transaction_type", "currency", "min_amount"),
+    CHECK ("min_fee" IS NULL OR "max_fee" IS NULL OR "min_fee" <= "max_fee")
+);
+
+ALTER TABLE "transactions" ADD COLUMN "parent_transaction_id" VARCHAR(20) REFERENCES "transactions"("id");
+
+CREATE INDEX "idx_transactions_parent_transaction_id" ON "transactions"("parent_transaction_id");
\ No newline at end of file
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "idx_transactions_reference";
+
+ALTER TABLE "transactions" DROP COLUMN IF EXISTS "metadata";
+ALTER TABLE "transactions" DROP COLUMN IF EXISTS "reference";
+ALTER TABLE "transactions" DROP COLUMN IF EXISTS "description";
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE "transactions" ADD COLUMN "description" VARCHAR(255);
+ALTER TABLE "transactions" ADD COLUMN "reference" VARCHAR(100);
+ALTER TABLE "transactions" ADD COLUMN "metadata" JSONB;
+
+CREATE INDEX "idx_transactions_reference" ON "transactions"("reference");
\ No newline at end of file
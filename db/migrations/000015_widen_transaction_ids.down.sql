@@ -0,0 +1,14 @@
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM "transactions" WHERE LENGTH("id") > 20) THEN
+        RAISE EXCEPTION 'transactions has IDs longer than 20 characters; widening transaction IDs cannot be rolled back once they are issued';
+    END IF;
+END $$;
+
+ALTER TABLE "schedule_runs" ALTER COLUMN "transaction_id" TYPE VARCHAR(20);
+ALTER TABLE "holds" ALTER COLUMN "transaction_id" TYPE VARCHAR(20);
+ALTER TABLE "idempotency_keys" ALTER COLUMN "transaction_id" TYPE VARCHAR(20);
+ALTER TABLE "journal_entries" ALTER COLUMN "transaction_id" TYPE VARCHAR(20);
+ALTER TABLE "transactions" ALTER COLUMN "parent_transaction_id" TYPE VARCHAR(20);
+ALTER TABLE "transactions" ALTER COLUMN "original_transaction_id" TYPE VARCHAR(20);
+ALTER TABLE "transactions" ALTER COLUMN "id" TYPE VARCHAR(20);
\ No newline at end of file
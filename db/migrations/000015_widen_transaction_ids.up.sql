@@ -0,0 +1,7 @@
+ALTER TABLE "transactions" ALTER COLUMN "id" TYPE VARCHAR(32);
+ALTER TABLE "transactions" ALTER COLUMN "original_transaction_id" TYPE VARCHAR(32);
+ALTER TABLE "transactions" ALTER COLUMN "parent_transaction_id" TYPE VARCHAR(32);
+ALTER TABLE "journal_entries" ALTER COLUMN "transaction_id" TYPE VARCHAR(32);
+ALTER TABLE "idempotency_keys" ALTER COLUMN "transaction_id" TYPE VARCHAR(32);
+ALTER TABLE "holds" ALTER COLUMN "transaction_id" TYPE VARCHAR(32);
+ALTER TABLE "schedule_runs" ALTER COLUMN "transaction_id" TYPE VARCHAR(32);
\ No newline at end of file
@@ -0,0 +1,20 @@
+CREATE TABLE "payment_requests" (
+    "id" UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    "requester_id" UUID NOT NULL,
+    "payer_id" UUID NOT NULL,
+    "to_wallet_id" UUID NOT NULL,
+    "amount" DECIMAL(20, 2) NOT NULL CHECK ("amount" > 0),
+    "note" VARCHAR(255),
+    "status" VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK ("status" IN ('pending', 'accepted', 'declined', 'expired')),
+    "transaction_id" VARCHAR(32),
+    "expires_at" TIMESTAMP NOT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY ("requester_id") REFERENCES "users"("id"),
+    FOREIGN KEY ("payer_id") REFERENCES "users"("id"),
+    FOREIGN KEY ("transaction_id") REFERENCES "transactions"("id")
+);
+
+CREATE INDEX "idx_payment_requests_payer_id" ON "payment_requests"("payer_id");
+CREATE INDEX "idx_payment_requests_requester_id" ON "payment_requests"("requester_id");
+CREATE INDEX "idx_payment_requests_expires_at_pending" ON "payment_requests"("expires_at") WHERE "status" = 'pending';
\ No newline at end of file
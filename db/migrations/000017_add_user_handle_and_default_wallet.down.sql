@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "idx_users_handle";
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "default_wallet_id";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "handle";
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE "users" ADD COLUMN "handle" VARCHAR(30);
+ALTER TABLE "users" ADD COLUMN "default_wallet_id" UUID REFERENCES "wallets"("id") ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX "idx_users_handle" ON "users"("handle");
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE "wallets" ADD COLUMN "closed_at" TIMESTAMP;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE "wallets" DROP COLUMN IF EXISTS "status_changed_at", DROP COLUMN IF EXISTS "status_changed_by", DROP COLUMN IF EXISTS "status_reason", DROP COLUMN IF EXISTS "status";
\ No newline at end of file
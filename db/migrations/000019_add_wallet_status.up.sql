@@ -0,0 +1 @@
+ALTER TABLE "wallets" ADD COLUMN "status" VARCHAR(20) NOT NULL DEFAULT 'active', ADD COLUMN "status_reason" VARCHAR(255), ADD COLUMN "status_changed_by" VARCHAR(100), ADD COLUMN "status_changed_at" TIMESTAMP;
\ No newline at end of file
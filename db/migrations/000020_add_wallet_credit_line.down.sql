@@ -0,0 +1,5 @@
+DELETE FROM "ledger_accounts" WHERE "code" = 'interest';
+
+ALTER TABLE "wallets" DROP COLUMN IF EXISTS "interest_charged_on";
+ALTER TABLE "wallets" DROP COLUMN IF EXISTS "interest_rate";
+ALTER TABLE "wallets" DROP COLUMN IF EXISTS "credit_limit";
\ No newline at end of file
@@ -0,0 +1,7 @@
+ALTER TABLE "wallets" ADD COLUMN "credit_limit" DECIMAL(20, 2) NOT NULL DEFAULT 0 CHECK ("credit_limit" >= 0);
+ALTER TABLE "wallets" ADD COLUMN "interest_rate" DECIMAL(20, 8) NOT NULL DEFAULT 0 CHECK ("interest_rate" >= 0);
+ALTER TABLE "wallets" ADD COLUMN "interest_charged_on" DATE;
+
+INSERT INTO "ledger_accounts" ("code", "currency")
+SELECT 'interest', "currency"
+FROM (VALUES ('THB'), ('USD'), ('EUR'), ('GBP'), ('SGD'), ('JPY'), ('KRW'), ('VND')) AS currencies("currency");
\ No newline at end of file
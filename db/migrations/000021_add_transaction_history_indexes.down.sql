@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "idx_transactions_description_trgm";
+DROP INDEX IF EXISTS "idx_transactions_to_amount";
+DROP INDEX IF EXISTS "idx_transactions_from_amount";
+DROP INDEX IF EXISTS "idx_transactions_to_from_created_at";
+DROP INDEX IF EXISTS "idx_transactions_from_to_created_at";
+DROP INDEX IF EXISTS "idx_transactions_to_type_created_at";
+DROP INDEX IF EXISTS "idx_transactions_from_type_created_at";
\ No newline at end of file
@@ -0,0 +1,9 @@
+CREATE EXTENSION IF NOT EXISTS "pg_trgm";
+
+CREATE INDEX "idx_transactions_from_type_created_at" ON "transactions"("from", "type", "created_at" DESC);
+CREATE INDEX "idx_transactions_to_type_created_at" ON "transactions"("to", "type", "created_at" DESC);
+CREATE INDEX "idx_transactions_from_to_created_at" ON "transactions"("from", "to", "created_at" DESC);
+CREATE INDEX "idx_transactions_to_from_created_at" ON "transactions"("to", "from", "created_at" DESC);
+CREATE INDEX "idx_transactions_from_amount" ON "transactions"("from", ABS("amount"));
+CREATE INDEX "idx_transactions_to_amount" ON "transactions"("to", ABS("amount"));
+CREATE INDEX "idx_transactions_description_trgm" ON "transactions" USING GIN ("description" gin_trgm_ops);
\ No newline at end of file
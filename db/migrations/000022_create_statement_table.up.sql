@@ -0,0 +1,16 @@
+CREATE TABLE "statements" (
+    "id" UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    "wallet_id" UUID NOT NULL,
+    "period_start" DATE NOT NULL,
+    "period_end" DATE NOT NULL CHECK ("period_end" > "period_start"),
+    "currency" VARCHAR(3) NOT NULL,
+    "opening_balance" DECIMAL(20, 2) NOT NULL,
+    "closing_balance" DECIMAL(20, 2) NOT NULL,
+    "total_in" DECIMAL(20, 2) NOT NULL,
+    "total_out" DECIMAL(20, 2) NOT NULL,
+    "lines" JSONB NOT NULL,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY ("wallet_id") REFERENCES "wallets"("id")
+);
+
+CREATE UNIQUE INDEX "idx_statements_wallet_id_period_start" ON "statements"("wallet_id", "period_start");
\ No newline at end of file
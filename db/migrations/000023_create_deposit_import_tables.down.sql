@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "deposit_import_rows";
+DROP TABLE IF EXISTS "deposit_imports";
\ No newline at end of file
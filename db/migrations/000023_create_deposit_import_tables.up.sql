@@ -0,0 +1,37 @@
+CREATE TABLE "deposit_imports" (
+    "id" UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    "filename" VARCHAR(255) NOT NULL,
+    "file_hash" VARCHAR(64) NOT NULL,
+    "status" VARCHAR(20) NOT NULL CHECK ("status" IN ('rejected', 'pending', 'running', 'completed')),
+    "total_rows" INTEGER NOT NULL DEFAULT 0,
+    "invalid_rows" INTEGER NOT NULL DEFAULT 0,
+    "completed_rows" INTEGER NOT NULL DEFAULT 0,
+    "failed_rows" INTEGER NOT NULL DEFAULT 0,
+    "created_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    "completed_at" TIMESTAMP
+);
+
+CREATE UNIQUE INDEX "idx_deposit_imports_file_hash" ON "deposit_imports"("file_hash");
+CREATE INDEX "idx_deposit_imports_created_at_open" ON "deposit_imports"("created_at") WHERE "status" IN ('pending', 'running');
+
+CREATE TABLE "deposit_import_rows" (
+    "id" UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    "import_id" UUID NOT NULL,
+    "row_number" INTEGER NOT NULL,
+    "wallet_id" TEXT NOT NULL,
+    "user_id" UUID,
+    "amount" DECIMAL(20, 2),
+    "reference" TEXT NOT NULL,
+    "status" VARCHAR(20) NOT NULL CHECK ("status" IN ('pending', 'completed', 'failed', 'invalid', 'skipped')),
+    "transaction_id" VARCHAR(32),
+    "error_message" TEXT,
+    "updated_at" TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY ("import_id") REFERENCES "deposit_imports"("id"),
+    FOREIGN KEY ("user_id") REFERENCES "users"("id"),
+    FOREIGN KEY ("transaction_id") REFERENCES "transactions"("id")
+);
+
+CREATE UNIQUE INDEX "idx_deposit_import_rows_import_id_row_number" ON "deposit_import_rows"("import_id", "row_number");
+CREATE INDEX "idx_deposit_import_rows_pending" ON "deposit_import_rows"("import_id", "row_number") WHERE "status" = 'pending';
+CREATE UNIQUE INDEX "idx_deposit_import_rows_wallet_id_reference" ON "deposit_import_rows"("wallet_id", "reference") WHERE "status" IN ('pending', 'completed');
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE "transactions" DROP COLUMN IF EXISTS "to_balance_after";
+ALTER TABLE "transactions" DROP COLUMN IF EXISTS "from_balance_after";
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE "transactions" ADD COLUMN "from_balance_after" DECIMAL(20, 2);
+ALTER TABLE "transactions" ADD COLUMN "to_balance_after" DECIMAL(20, 2);
\ No newline at end of file
@@ -13,6 +13,33 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Upload a bulk deposit file
+	// (POST /admin/deposit-imports)
+	UploadDepositImport(c *gin.Context)
+	// Get a bulk deposit import
+	// (GET /admin/deposit-imports/{importId})
+	GetDepositImport(c *gin.Context, importId string)
+	// List the per-row results of a bulk deposit import
+	// (GET /admin/deposit-imports/{importId}/rows)
+	ListDepositImportRows(c *gin.Context, importId string, params ListDepositImportRowsParams)
+	// Load or replace exchange rates
+	// (PUT /admin/exchange-rates)
+	LoadExchangeRates(c *gin.Context)
+	// Replace the fee rules
+	// (PUT /admin/fees)
+	LoadFeeRules(c *gin.Context)
+	// Replace the global transaction limits
+	// (PUT /admin/limits)
+	LoadGlobalLimits(c *gin.Context)
+	// Replace a user's limit overrides
+	// (PUT /admin/users/{userId}/limits)
+	LoadUserLimits(c *gin.Context, userId string)
+	// Set a wallet's credit line
+	// (PUT /admin/wallets/{walletId}/credit-line)
+	UpdateWalletCreditLine(c *gin.Context, walletId string)
+	// Freeze, block or reactivate a wallet
+	// (PUT /admin/wallets/{walletId}/status)
+	UpdateWalletStatus(c *gin.Context, walletId string)
 	// User login
 	// (POST /public/login)
 	LoginUser(c *gin.Context)
@@ -21,28 +48,94 @@ type ServerInterface interface {
 	RegisterUser(c *gin.Context)
 	// Deposit into a wallet
 	// (POST /secure/deposit)
-	DepositPoints(c *gin.Context)
+	DepositPoints(c *gin.Context, params DepositPointsParams)
+	// List exchange rates
+	// (GET /secure/exchange-rates)
+	ListExchangeRates(c *gin.Context)
+	// Quote the fee of a transfer or withdrawal
+	// (POST /secure/fees/quote)
+	QuoteFee(c *gin.Context)
+	// Place a hold on a wallet
+	// (POST /secure/hold)
+	CreateHold(c *gin.Context)
+	// Capture a hold
+	// (POST /secure/hold/{holdId}/capture)
+	CaptureHold(c *gin.Context, holdId string)
+	// Void a hold
+	// (POST /secure/hold/{holdId}/void)
+	VoidHold(c *gin.Context, holdId string)
+	// Look up a transfer recipient
+	// (GET /secure/recipients)
+	LookupRecipient(c *gin.Context, params LookupRecipientParams)
+	// List payment requests
+	// (GET /secure/requests)
+	ListPaymentRequests(c *gin.Context, params ListPaymentRequestsParams)
+	// Request money from another user
+	// (POST /secure/requests)
+	CreatePaymentRequest(c *gin.Context)
+	// Accept a payment request
+	// (POST /secure/requests/{requestId}/accept)
+	AcceptPaymentRequest(c *gin.Context, requestId string)
+	// Decline a payment request
+	// (POST /secure/requests/{requestId}/decline)
+	DeclinePaymentRequest(c *gin.Context, requestId string)
+	// List scheduled transfers
+	// (GET /secure/schedules)
+	ListSchedules(c *gin.Context)
+	// Schedule a transfer
+	// (POST /secure/schedules)
+	CreateSchedule(c *gin.Context)
+	// Cancel a scheduled transfer
+	// (DELETE /secure/schedules/{scheduleId})
+	CancelSchedule(c *gin.Context, scheduleId string)
+	// Update a scheduled transfer
+	// (PUT /secure/schedules/{scheduleId})
+	UpdateSchedule(c *gin.Context, scheduleId string)
+	// List the runs of a scheduled transfer
+	// (GET /secure/schedules/{scheduleId}/runs)
+	ListScheduleRuns(c *gin.Context, scheduleId string)
+	// Reverse or partially refund a transaction
+	// (POST /secure/transaction/{transactionId}/reverse)
+	ReverseTransaction(c *gin.Context, transactionId string, params ReverseTransactionParams)
 	// Transfer between wallets
 	// (POST /secure/transfer)
-	TransferBalance(c *gin.Context)
+	TransferBalance(c *gin.Context, params TransferBalanceParams)
+	// Transfer from one wallet to many
+	// (POST /secure/transfer/batch)
+	BatchTransfer(c *gin.Context)
+	// Get the caller's settings
+	// (GET /secure/user/settings)
+	GetUserSettings(c *gin.Context)
+	// Update the caller's settings
+	// (PUT /secure/user/settings)
+	UpdateUserSettings(c *gin.Context)
 	// Create a new wallet
 	// (POST /secure/wallet)
 	CreateWallet(c *gin.Context)
-	// Delete a wallet by ID
+	// Close an empty wallet by ID
 	// (DELETE /secure/wallet/{walletId})
 	DeleteWallet(c *gin.Context, walletId string)
 	// Update wallet by ID
 	// (PUT /secure/wallet/{walletId})
 	UpdateWallet(c *gin.Context, walletId string)
+	// Close a wallet
+	// (POST /secure/wallet/{walletId}/close)
+	CloseWallet(c *gin.Context, walletId string)
+	// Get a monthly wallet statement
+	// (GET /secure/wallet/{walletId}/statements/{month})
+	GetWalletStatement(c *gin.Context, walletId string, month string, params GetWalletStatementParams)
 	// List wallet transactions
 	// (GET /secure/wallet/{walletId}/transactions)
 	ListWalletTransactions(c *gin.Context, walletId string, params ListWalletTransactionsParams)
+	// Export wallet transactions
+	// (GET /secure/wallet/{walletId}/transactions/export)
+	ExportWalletTransactions(c *gin.Context, walletId string, params ExportWalletTransactionsParams)
 	// Get all user wallets
 	// (GET /secure/wallets)
 	ListUserWallets(c *gin.Context)
 	// Withdraw from a wallet
 	// (POST /secure/withdraw)
-	WithdrawPoints(c *gin.Context)
+	WithdrawPoints(c *gin.Context, params WithdrawPointsParams)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -54,6 +147,207 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(c *gin.Context)
 
+// UploadDepositImport operation middleware
+func (siw *ServerInterfaceWrapper) UploadDepositImport(c *gin.Context) {
+
+	c.Set(AdminApiKeyScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UploadDepositImport(c)
+}
+
+// GetDepositImport operation middleware
+func (siw *ServerInterfaceWrapper) GetDepositImport(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "importId" -------------
+	var importId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "importId", c.Param("importId"), &importId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter importId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(AdminApiKeyScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetDepositImport(c, importId)
+}
+
+// ListDepositImportRows operation middleware
+func (siw *ServerInterfaceWrapper) ListDepositImportRows(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "importId" -------------
+	var importId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "importId", c.Param("importId"), &importId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter importId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(AdminApiKeyScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListDepositImportRowsParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", c.Request.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListDepositImportRows(c, importId, params)
+}
+
+// LoadExchangeRates operation middleware
+func (siw *ServerInterfaceWrapper) LoadExchangeRates(c *gin.Context) {
+
+	c.Set(AdminApiKeyScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.LoadExchangeRates(c)
+}
+
+// LoadFeeRules operation middleware
+func (siw *ServerInterfaceWrapper) LoadFeeRules(c *gin.Context) {
+
+	c.Set(AdminApiKeyScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.LoadFeeRules(c)
+}
+
+// LoadGlobalLimits operation middleware
+func (siw *ServerInterfaceWrapper) LoadGlobalLimits(c *gin.Context) {
+
+	c.Set(AdminApiKeyScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.LoadGlobalLimits(c)
+}
+
+// LoadUserLimits operation middleware
+func (siw *ServerInterfaceWrapper) LoadUserLimits(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", c.Param("userId"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter userId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(AdminApiKeyScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.LoadUserLimits(c, userId)
+}
+
+// UpdateWalletCreditLine operation middleware
+func (siw *ServerInterfaceWrapper) UpdateWalletCreditLine(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "walletId" -------------
+	var walletId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "walletId", c.Param("walletId"), &walletId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter walletId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(AdminApiKeyScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateWalletCreditLine(c, walletId)
+}
+
+// UpdateWalletStatus operation middleware
+func (siw *ServerInterfaceWrapper) UpdateWalletStatus(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "walletId" -------------
+	var walletId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "walletId", c.Param("walletId"), &walletId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter walletId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(AdminApiKeyScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateWalletStatus(c, walletId)
+}
+
 // LoginUser operation middleware
 func (siw *ServerInterfaceWrapper) LoginUser(c *gin.Context) {
 
@@ -64,11 +358,479 @@ func (siw *ServerInterfaceWrapper) LoginUser(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.LoginUser(c)
+	siw.Handler.LoginUser(c)
+}
+
+// RegisterUser operation middleware
+func (siw *ServerInterfaceWrapper) RegisterUser(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RegisterUser(c)
+}
+
+// DepositPoints operation middleware
+func (siw *ServerInterfaceWrapper) DepositPoints(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DepositPointsParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Idempotency-Key, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Idempotency-Key: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DepositPoints(c, params)
+}
+
+// ListExchangeRates operation middleware
+func (siw *ServerInterfaceWrapper) ListExchangeRates(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListExchangeRates(c)
+}
+
+// QuoteFee operation middleware
+func (siw *ServerInterfaceWrapper) QuoteFee(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.QuoteFee(c)
+}
+
+// CreateHold operation middleware
+func (siw *ServerInterfaceWrapper) CreateHold(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateHold(c)
+}
+
+// CaptureHold operation middleware
+func (siw *ServerInterfaceWrapper) CaptureHold(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "holdId" -------------
+	var holdId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "holdId", c.Param("holdId"), &holdId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter holdId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CaptureHold(c, holdId)
+}
+
+// VoidHold operation middleware
+func (siw *ServerInterfaceWrapper) VoidHold(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "holdId" -------------
+	var holdId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "holdId", c.Param("holdId"), &holdId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter holdId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.VoidHold(c, holdId)
+}
+
+// LookupRecipient operation middleware
+func (siw *ServerInterfaceWrapper) LookupRecipient(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LookupRecipientParams
+
+	// ------------- Optional query parameter "email" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "email", c.Request.URL.Query(), &params.Email)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter email: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "handle" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "handle", c.Request.URL.Query(), &params.Handle)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter handle: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.LookupRecipient(c, params)
+}
+
+// ListPaymentRequests operation middleware
+func (siw *ServerInterfaceWrapper) ListPaymentRequests(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListPaymentRequestsParams
+
+	// ------------- Optional query parameter "direction" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "direction", c.Request.URL.Query(), &params.Direction)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter direction: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListPaymentRequests(c, params)
+}
+
+// CreatePaymentRequest operation middleware
+func (siw *ServerInterfaceWrapper) CreatePaymentRequest(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreatePaymentRequest(c)
+}
+
+// AcceptPaymentRequest operation middleware
+func (siw *ServerInterfaceWrapper) AcceptPaymentRequest(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "requestId" -------------
+	var requestId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "requestId", c.Param("requestId"), &requestId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter requestId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.AcceptPaymentRequest(c, requestId)
+}
+
+// DeclinePaymentRequest operation middleware
+func (siw *ServerInterfaceWrapper) DeclinePaymentRequest(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "requestId" -------------
+	var requestId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "requestId", c.Param("requestId"), &requestId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter requestId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeclinePaymentRequest(c, requestId)
+}
+
+// ListSchedules operation middleware
+func (siw *ServerInterfaceWrapper) ListSchedules(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListSchedules(c)
+}
+
+// CreateSchedule operation middleware
+func (siw *ServerInterfaceWrapper) CreateSchedule(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateSchedule(c)
+}
+
+// CancelSchedule operation middleware
+func (siw *ServerInterfaceWrapper) CancelSchedule(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "scheduleId" -------------
+	var scheduleId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "scheduleId", c.Param("scheduleId"), &scheduleId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter scheduleId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CancelSchedule(c, scheduleId)
+}
+
+// UpdateSchedule operation middleware
+func (siw *ServerInterfaceWrapper) UpdateSchedule(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "scheduleId" -------------
+	var scheduleId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "scheduleId", c.Param("scheduleId"), &scheduleId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter scheduleId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateSchedule(c, scheduleId)
+}
+
+// ListScheduleRuns operation middleware
+func (siw *ServerInterfaceWrapper) ListScheduleRuns(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "scheduleId" -------------
+	var scheduleId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "scheduleId", c.Param("scheduleId"), &scheduleId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter scheduleId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListScheduleRuns(c, scheduleId)
+}
+
+// ReverseTransaction operation middleware
+func (siw *ServerInterfaceWrapper) ReverseTransaction(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "transactionId" -------------
+	var transactionId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "transactionId", c.Param("transactionId"), &transactionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter transactionId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ReverseTransactionParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Idempotency-Key, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Idempotency-Key: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ReverseTransaction(c, transactionId, params)
 }
 
-// RegisterUser operation middleware
-func (siw *ServerInterfaceWrapper) RegisterUser(c *gin.Context) {
+// TransferBalance operation middleware
+func (siw *ServerInterfaceWrapper) TransferBalance(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params TransferBalanceParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Idempotency-Key, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Idempotency-Key: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -77,11 +839,11 @@ func (siw *ServerInterfaceWrapper) RegisterUser(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.RegisterUser(c)
+	siw.Handler.TransferBalance(c, params)
 }
 
-// DepositPoints operation middleware
-func (siw *ServerInterfaceWrapper) DepositPoints(c *gin.Context) {
+// BatchTransfer operation middleware
+func (siw *ServerInterfaceWrapper) BatchTransfer(c *gin.Context) {
 
 	c.Set(BearerAuthScopes, []string{})
 
@@ -92,11 +854,26 @@ func (siw *ServerInterfaceWrapper) DepositPoints(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.DepositPoints(c)
+	siw.Handler.BatchTransfer(c)
 }
 
-// TransferBalance operation middleware
-func (siw *ServerInterfaceWrapper) TransferBalance(c *gin.Context) {
+// GetUserSettings operation middleware
+func (siw *ServerInterfaceWrapper) GetUserSettings(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetUserSettings(c)
+}
+
+// UpdateUserSettings operation middleware
+func (siw *ServerInterfaceWrapper) UpdateUserSettings(c *gin.Context) {
 
 	c.Set(BearerAuthScopes, []string{})
 
@@ -107,7 +884,7 @@ func (siw *ServerInterfaceWrapper) TransferBalance(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.TransferBalance(c)
+	siw.Handler.UpdateUserSettings(c)
 }
 
 // CreateWallet operation middleware
@@ -177,8 +954,34 @@ func (siw *ServerInterfaceWrapper) UpdateWallet(c *gin.Context) {
 	siw.Handler.UpdateWallet(c, walletId)
 }
 
-// ListWalletTransactions operation middleware
-func (siw *ServerInterfaceWrapper) ListWalletTransactions(c *gin.Context) {
+// CloseWallet operation middleware
+func (siw *ServerInterfaceWrapper) CloseWallet(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "walletId" -------------
+	var walletId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "walletId", c.Param("walletId"), &walletId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter walletId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CloseWallet(c, walletId)
+}
+
+// GetWalletStatement operation middleware
+func (siw *ServerInterfaceWrapper) GetWalletStatement(c *gin.Context) {
 
 	var err error
 
@@ -191,19 +994,57 @@ func (siw *ServerInterfaceWrapper) ListWalletTransactions(c *gin.Context) {
 		return
 	}
 
+	// ------------- Path parameter "month" -------------
+	var month string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "month", c.Param("month"), &month, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter month: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	c.Set(BearerAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params ListWalletTransactionsParams
+	var params GetWalletStatementParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", c.Request.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter format: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetWalletStatement(c, walletId, month, params)
+}
 
-	// ------------- Optional query parameter "page" -------------
+// ListWalletTransactions operation middleware
+func (siw *ServerInterfaceWrapper) ListWalletTransactions(c *gin.Context) {
+
+	var err error
 
-	err = runtime.BindQueryParameter("form", true, false, "page", c.Request.URL.Query(), &params.Page)
+	// ------------- Path parameter "walletId" -------------
+	var walletId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "walletId", c.Param("walletId"), &walletId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter walletId: %w", err), http.StatusBadRequest)
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListWalletTransactionsParams
+
 	// ------------- Optional query parameter "limit" -------------
 
 	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
@@ -212,6 +1053,102 @@ func (siw *ServerInterfaceWrapper) ListWalletTransactions(c *gin.Context) {
 		return
 	}
 
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "includeTotal" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "includeTotal", c.Request.URL.Query(), &params.IncludeTotal)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter includeTotal: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "reference" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "reference", c.Request.URL.Query(), &params.Reference)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter reference: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "type", c.Request.URL.Query(), &params.Type)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter type: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "direction" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "direction", c.Request.URL.Query(), &params.Direction)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter direction: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "createdFrom" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "createdFrom", c.Request.URL.Query(), &params.CreatedFrom)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter createdFrom: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "createdTo" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "createdTo", c.Request.URL.Query(), &params.CreatedTo)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter createdTo: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "minAmount" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "minAmount", c.Request.URL.Query(), &params.MinAmount)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter minAmount: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "maxAmount" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "maxAmount", c.Request.URL.Query(), &params.MaxAmount)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter maxAmount: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "counterpartyWalletId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "counterpartyWalletId", c.Request.URL.Query(), &params.CounterpartyWalletId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter counterpartyWalletId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "memo" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "memo", c.Request.URL.Query(), &params.Memo)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter memo: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", c.Request.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sort: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -222,6 +1159,123 @@ func (siw *ServerInterfaceWrapper) ListWalletTransactions(c *gin.Context) {
 	siw.Handler.ListWalletTransactions(c, walletId, params)
 }
 
+// ExportWalletTransactions operation middleware
+func (siw *ServerInterfaceWrapper) ExportWalletTransactions(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "walletId" -------------
+	var walletId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "walletId", c.Param("walletId"), &walletId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter walletId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportWalletTransactionsParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", c.Request.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter format: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "createdFrom" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "createdFrom", c.Request.URL.Query(), &params.CreatedFrom)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter createdFrom: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "createdTo" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "createdTo", c.Request.URL.Query(), &params.CreatedTo)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter createdTo: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "reference" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "reference", c.Request.URL.Query(), &params.Reference)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter reference: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "type", c.Request.URL.Query(), &params.Type)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter type: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "direction" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "direction", c.Request.URL.Query(), &params.Direction)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter direction: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "minAmount" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "minAmount", c.Request.URL.Query(), &params.MinAmount)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter minAmount: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "maxAmount" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "maxAmount", c.Request.URL.Query(), &params.MaxAmount)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter maxAmount: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "counterpartyWalletId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "counterpartyWalletId", c.Request.URL.Query(), &params.CounterpartyWalletId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter counterpartyWalletId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "memo" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "memo", c.Request.URL.Query(), &params.Memo)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter memo: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", c.Request.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sort: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ExportWalletTransactions(c, walletId, params)
+}
+
 // ListUserWallets operation middleware
 func (siw *ServerInterfaceWrapper) ListUserWallets(c *gin.Context) {
 
@@ -240,8 +1294,34 @@ func (siw *ServerInterfaceWrapper) ListUserWallets(c *gin.Context) {
 // WithdrawPoints operation middleware
 func (siw *ServerInterfaceWrapper) WithdrawPoints(c *gin.Context) {
 
+	var err error
+
 	c.Set(BearerAuthScopes, []string{})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params WithdrawPointsParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Idempotency-Key, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Idempotency-Key: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -249,7 +1329,7 @@ func (siw *ServerInterfaceWrapper) WithdrawPoints(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.WithdrawPoints(c)
+	siw.Handler.WithdrawPoints(c, params)
 }
 
 // GinServerOptions provides options for the Gin server.
@@ -279,14 +1359,45 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 		ErrorHandler:       errorHandler,
 	}
 
+	router.POST(options.BaseURL+"/admin/deposit-imports", wrapper.UploadDepositImport)
+	router.GET(options.BaseURL+"/admin/deposit-imports/:importId", wrapper.GetDepositImport)
+	router.GET(options.BaseURL+"/admin/deposit-imports/:importId/rows", wrapper.ListDepositImportRows)
+	router.PUT(options.BaseURL+"/admin/exchange-rates", wrapper.LoadExchangeRates)
+	router.PUT(options.BaseURL+"/admin/fees", wrapper.LoadFeeRules)
+	router.PUT(options.BaseURL+"/admin/limits", wrapper.LoadGlobalLimits)
+	router.PUT(options.BaseURL+"/admin/users/:userId/limits", wrapper.LoadUserLimits)
+	router.PUT(options.BaseURL+"/admin/wallets/:walletId/credit-line", wrapper.UpdateWalletCreditLine)
+	router.PUT(options.BaseURL+"/admin/wallets/:walletId/status", wrapper.UpdateWalletStatus)
 	router.POST(options.BaseURL+"/public/login", wrapper.LoginUser)
 	router.POST(options.BaseURL+"/public/register", wrapper.RegisterUser)
 	router.POST(options.BaseURL+"/secure/deposit", wrapper.DepositPoints)
+	router.GET(options.BaseURL+"/secure/exchange-rates", wrapper.ListExchangeRates)
+	router.POST(options.BaseURL+"/secure/fees/quote", wrapper.QuoteFee)
+	router.POST(options.BaseURL+"/secure/hold", wrapper.CreateHold)
+	router.POST(options.BaseURL+"/secure/hold/:holdId/capture", wrapper.CaptureHold)
+	router.POST(options.BaseURL+"/secure/hold/:holdId/void", wrapper.VoidHold)
+	router.GET(options.BaseURL+"/secure/recipients", wrapper.LookupRecipient)
+	router.GET(options.BaseURL+"/secure/requests", wrapper.ListPaymentRequests)
+	router.POST(options.BaseURL+"/secure/requests", wrapper.CreatePaymentRequest)
+	router.POST(options.BaseURL+"/secure/requests/:requestId/accept", wrapper.AcceptPaymentRequest)
+	router.POST(options.BaseURL+"/secure/requests/:requestId/decline", wrapper.DeclinePaymentRequest)
+	router.GET(options.BaseURL+"/secure/schedules", wrapper.ListSchedules)
+	router.POST(options.BaseURL+"/secure/schedules", wrapper.CreateSchedule)
+	router.DELETE(options.BaseURL+"/secure/schedules/:scheduleId", wrapper.CancelSchedule)
+	router.PUT(options.BaseURL+"/secure/schedules/:scheduleId", wrapper.UpdateSchedule)
+	router.GET(options.BaseURL+"/secure/schedules/:scheduleId/runs", wrapper.ListScheduleRuns)
+	router.POST(options.BaseURL+"/secure/transaction/:transactionId/reverse", wrapper.ReverseTransaction)
 	router.POST(options.BaseURL+"/secure/transfer", wrapper.TransferBalance)
+	router.POST(options.BaseURL+"/secure/transfer/batch", wrapper.BatchTransfer)
+	router.GET(options.BaseURL+"/secure/user/settings", wrapper.GetUserSettings)
+	router.PUT(options.BaseURL+"/secure/user/settings", wrapper.UpdateUserSettings)
 	router.POST(options.BaseURL+"/secure/wallet", wrapper.CreateWallet)
 	router.DELETE(options.BaseURL+"/secure/wallet/:walletId", wrapper.DeleteWallet)
 	router.PUT(options.BaseURL+"/secure/wallet/:walletId", wrapper.UpdateWallet)
+	router.POST(options.BaseURL+"/secure/wallet/:walletId/close", wrapper.CloseWallet)
+	router.GET(options.BaseURL+"/secure/wallet/:walletId/statements/:month", wrapper.GetWalletStatement)
 	router.GET(options.BaseURL+"/secure/wallet/:walletId/transactions", wrapper.ListWalletTransactions)
+	router.GET(options.BaseURL+"/secure/wallet/:walletId/transactions/export", wrapper.ExportWalletTransactions)
 	router.GET(options.BaseURL+"/secure/wallets", wrapper.ListUserWallets)
 	router.POST(options.BaseURL+"/secure/withdraw", wrapper.WithdrawPoints)
 }
@@ -5,23 +5,591 @@ package api_gen
 
 import (
 	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/slilp/go-wallet/internal/money"
+)
+
+const (
+	AdminApiKeyScopes = "adminApiKey.Scopes"
+	BearerAuthScopes  = "bearerAuth.Scopes"
+)
+
+// Defines values for BatchTransferLegResultStatus.
+const (
+	BatchTransferLegResultStatusCompleted BatchTransferLegResultStatus = "completed"
+	BatchTransferLegResultStatusFailed    BatchTransferLegResultStatus = "failed"
+)
+
+// Defines values for BatchTransferRequestMode.
+const (
+	BatchTransferRequestModeAllOrNothing BatchTransferRequestMode = "all_or_nothing"
+	BatchTransferRequestModeBestEffort   BatchTransferRequestMode = "best_effort"
 )
 
+// Defines values for BatchTransferResponseDataMode.
 const (
-	BearerAuthScopes = "bearerAuth.Scopes"
+	BatchTransferResponseDataModeAllOrNothing BatchTransferResponseDataMode = "all_or_nothing"
+	BatchTransferResponseDataModeBestEffort   BatchTransferResponseDataMode = "best_effort"
+)
+
+// Defines values for CreateScheduleRequestFrequency.
+const (
+	CreateScheduleRequestFrequencyDaily   CreateScheduleRequestFrequency = "daily"
+	CreateScheduleRequestFrequencyMonthly CreateScheduleRequestFrequency = "monthly"
+	CreateScheduleRequestFrequencyOnce    CreateScheduleRequestFrequency = "once"
+	CreateScheduleRequestFrequencyWeekly  CreateScheduleRequestFrequency = "weekly"
+)
+
+// Defines values for DepositImportResponseDataStatus.
+const (
+	DepositImportResponseDataStatusCompleted DepositImportResponseDataStatus = "completed"
+	DepositImportResponseDataStatusPending   DepositImportResponseDataStatus = "pending"
+	DepositImportResponseDataStatusRejected  DepositImportResponseDataStatus = "rejected"
+	DepositImportResponseDataStatusRunning   DepositImportResponseDataStatus = "running"
+)
+
+// Defines values for DepositImportRowDataStatus.
+const (
+	DepositImportRowDataStatusCompleted DepositImportRowDataStatus = "completed"
+	DepositImportRowDataStatusFailed    DepositImportRowDataStatus = "failed"
+	DepositImportRowDataStatusInvalid   DepositImportRowDataStatus = "invalid"
+	DepositImportRowDataStatusPending   DepositImportRowDataStatus = "pending"
+	DepositImportRowDataStatusSkipped   DepositImportRowDataStatus = "skipped"
+)
+
+// Defines values for FeeQuoteRequestTransactionType.
+const (
+	FeeQuoteRequestTransactionTypeTransfer FeeQuoteRequestTransactionType = "transfer"
+	FeeQuoteRequestTransactionTypeWithdraw FeeQuoteRequestTransactionType = "withdraw"
+)
+
+// Defines values for FeeRuleRequestTransactionType.
+const (
+	FeeRuleRequestTransactionTypeTransfer FeeRuleRequestTransactionType = "transfer"
+	FeeRuleRequestTransactionTypeWithdraw FeeRuleRequestTransactionType = "withdraw"
+)
+
+// Defines values for HoldResponseDataStatus.
+const (
+	HoldResponseDataStatusActive   HoldResponseDataStatus = "active"
+	HoldResponseDataStatusCaptured HoldResponseDataStatus = "captured"
+	HoldResponseDataStatusExpired  HoldResponseDataStatus = "expired"
+	HoldResponseDataStatusVoided   HoldResponseDataStatus = "voided"
+)
+
+// Defines values for LimitExceededErrorLimit.
+const (
+	DailyUserMax      LimitExceededErrorLimit = "daily_user_max"
+	DailyWalletMax    LimitExceededErrorLimit = "daily_wallet_max"
+	MaxTransfers      LimitExceededErrorLimit = "max_transfers"
+	MonthlyUserMax    LimitExceededErrorLimit = "monthly_user_max"
+	MonthlyWalletMax  LimitExceededErrorLimit = "monthly_wallet_max"
+	PerTransactionMax LimitExceededErrorLimit = "per_transaction_max"
+)
+
+// Defines values for PaymentRequestResponseDataStatus.
+const (
+	PaymentRequestResponseDataStatusAccepted PaymentRequestResponseDataStatus = "accepted"
+	PaymentRequestResponseDataStatusDeclined PaymentRequestResponseDataStatus = "declined"
+	PaymentRequestResponseDataStatusExpired  PaymentRequestResponseDataStatus = "expired"
+	PaymentRequestResponseDataStatusPending  PaymentRequestResponseDataStatus = "pending"
+)
+
+// Defines values for ScheduleResponseDataFrequency.
+const (
+	ScheduleResponseDataFrequencyDaily   ScheduleResponseDataFrequency = "daily"
+	ScheduleResponseDataFrequencyMonthly ScheduleResponseDataFrequency = "monthly"
+	ScheduleResponseDataFrequencyOnce    ScheduleResponseDataFrequency = "once"
+	ScheduleResponseDataFrequencyWeekly  ScheduleResponseDataFrequency = "weekly"
+)
+
+// Defines values for ScheduleResponseDataStatus.
+const (
+	ScheduleResponseDataStatusActive    ScheduleResponseDataStatus = "active"
+	ScheduleResponseDataStatusCancelled ScheduleResponseDataStatus = "cancelled"
+	ScheduleResponseDataStatusCompleted ScheduleResponseDataStatus = "completed"
+)
+
+// Defines values for ScheduleRunResponseDataStatus.
+const (
+	ScheduleRunResponseDataStatusFailed    ScheduleRunResponseDataStatus = "failed"
+	ScheduleRunResponseDataStatusSucceeded ScheduleRunResponseDataStatus = "succeeded"
 )
 
 // Defines values for TransactionResponseDataType.
 const (
-	Deposit  TransactionResponseDataType = "deposit"
-	Transfer TransactionResponseDataType = "transfer"
-	Withdraw TransactionResponseDataType = "withdraw"
+	TransactionResponseDataTypeDeposit  TransactionResponseDataType = "deposit"
+	TransactionResponseDataTypeFee      TransactionResponseDataType = "fee"
+	TransactionResponseDataTypeInterest TransactionResponseDataType = "interest"
+	TransactionResponseDataTypeReversal TransactionResponseDataType = "reversal"
+	TransactionResponseDataTypeTransfer TransactionResponseDataType = "transfer"
+	TransactionResponseDataTypeWithdraw TransactionResponseDataType = "withdraw"
+)
+
+// Defines values for UpdateWalletStatusRequestStatus.
+const (
+	UpdateWalletStatusRequestStatusActive        UpdateWalletStatusRequestStatus = "active"
+	UpdateWalletStatusRequestStatusCreditBlocked UpdateWalletStatusRequestStatus = "credit_blocked"
+	UpdateWalletStatusRequestStatusDebitBlocked  UpdateWalletStatusRequestStatus = "debit_blocked"
+	UpdateWalletStatusRequestStatusFrozen        UpdateWalletStatusRequestStatus = "frozen"
+)
+
+// Defines values for WalletBlockedErrorStatus.
+const (
+	WalletBlockedErrorStatusCreditBlocked WalletBlockedErrorStatus = "credit_blocked"
+	WalletBlockedErrorStatusDebitBlocked  WalletBlockedErrorStatus = "debit_blocked"
+	WalletBlockedErrorStatusFrozen        WalletBlockedErrorStatus = "frozen"
+)
+
+// Defines values for WalletResponseDataStatus.
+const (
+	WalletResponseDataStatusActive        WalletResponseDataStatus = "active"
+	WalletResponseDataStatusCreditBlocked WalletResponseDataStatus = "credit_blocked"
+	WalletResponseDataStatusDebitBlocked  WalletResponseDataStatus = "debit_blocked"
+	WalletResponseDataStatusFrozen        WalletResponseDataStatus = "frozen"
+)
+
+// Defines values for WalletStatusResponseDataStatus.
+const (
+	WalletStatusResponseDataStatusActive        WalletStatusResponseDataStatus = "active"
+	WalletStatusResponseDataStatusCreditBlocked WalletStatusResponseDataStatus = "credit_blocked"
+	WalletStatusResponseDataStatusDebitBlocked  WalletStatusResponseDataStatus = "debit_blocked"
+	WalletStatusResponseDataStatusFrozen        WalletStatusResponseDataStatus = "frozen"
 )
 
+// Defines values for ListDepositImportRowsParamsStatus.
+const (
+	ListDepositImportRowsParamsStatusCompleted ListDepositImportRowsParamsStatus = "completed"
+	ListDepositImportRowsParamsStatusFailed    ListDepositImportRowsParamsStatus = "failed"
+	ListDepositImportRowsParamsStatusInvalid   ListDepositImportRowsParamsStatus = "invalid"
+	ListDepositImportRowsParamsStatusPending   ListDepositImportRowsParamsStatus = "pending"
+	ListDepositImportRowsParamsStatusSkipped   ListDepositImportRowsParamsStatus = "skipped"
+)
+
+// Defines values for ListPaymentRequestsParamsDirection.
+const (
+	Incoming ListPaymentRequestsParamsDirection = "incoming"
+	Outgoing ListPaymentRequestsParamsDirection = "outgoing"
+)
+
+// Defines values for GetWalletStatementParamsFormat.
+const (
+	GetWalletStatementParamsFormatCsv  GetWalletStatementParamsFormat = "csv"
+	GetWalletStatementParamsFormatJson GetWalletStatementParamsFormat = "json"
+	GetWalletStatementParamsFormatPdf  GetWalletStatementParamsFormat = "pdf"
+)
+
+// Defines values for ListWalletTransactionsParamsType.
+const (
+	ListWalletTransactionsParamsTypeDeposit  ListWalletTransactionsParamsType = "deposit"
+	ListWalletTransactionsParamsTypeFee      ListWalletTransactionsParamsType = "fee"
+	ListWalletTransactionsParamsTypeInterest ListWalletTransactionsParamsType = "interest"
+	ListWalletTransactionsParamsTypeReversal ListWalletTransactionsParamsType = "reversal"
+	ListWalletTransactionsParamsTypeTransfer ListWalletTransactionsParamsType = "transfer"
+	ListWalletTransactionsParamsTypeWithdraw ListWalletTransactionsParamsType = "withdraw"
+)
+
+// Defines values for ListWalletTransactionsParamsDirection.
+const (
+	ListWalletTransactionsParamsDirectionIn  ListWalletTransactionsParamsDirection = "in"
+	ListWalletTransactionsParamsDirectionOut ListWalletTransactionsParamsDirection = "out"
+)
+
+// Defines values for ListWalletTransactionsParamsSort.
+const (
+	ListWalletTransactionsParamsSortLargest  ListWalletTransactionsParamsSort = "largest"
+	ListWalletTransactionsParamsSortNewest   ListWalletTransactionsParamsSort = "newest"
+	ListWalletTransactionsParamsSortOldest   ListWalletTransactionsParamsSort = "oldest"
+	ListWalletTransactionsParamsSortSmallest ListWalletTransactionsParamsSort = "smallest"
+)
+
+// Defines values for ExportWalletTransactionsParamsFormat.
+const (
+	ExportWalletTransactionsParamsFormatCsv   ExportWalletTransactionsParamsFormat = "csv"
+	ExportWalletTransactionsParamsFormatJsonl ExportWalletTransactionsParamsFormat = "jsonl"
+	ExportWalletTransactionsParamsFormatOfx   ExportWalletTransactionsParamsFormat = "ofx"
+)
+
+// Defines values for ExportWalletTransactionsParamsType.
+const (
+	Deposit  ExportWalletTransactionsParamsType = "deposit"
+	Fee      ExportWalletTransactionsParamsType = "fee"
+	Interest ExportWalletTransactionsParamsType = "interest"
+	Reversal ExportWalletTransactionsParamsType = "reversal"
+	Transfer ExportWalletTransactionsParamsType = "transfer"
+	Withdraw ExportWalletTransactionsParamsType = "withdraw"
+)
+
+// Defines values for ExportWalletTransactionsParamsDirection.
+const (
+	ExportWalletTransactionsParamsDirectionIn  ExportWalletTransactionsParamsDirection = "in"
+	ExportWalletTransactionsParamsDirectionOut ExportWalletTransactionsParamsDirection = "out"
+)
+
+// Defines values for ExportWalletTransactionsParamsSort.
+const (
+	ExportWalletTransactionsParamsSortLargest  ExportWalletTransactionsParamsSort = "largest"
+	ExportWalletTransactionsParamsSortNewest   ExportWalletTransactionsParamsSort = "newest"
+	ExportWalletTransactionsParamsSortOldest   ExportWalletTransactionsParamsSort = "oldest"
+	ExportWalletTransactionsParamsSortSmallest ExportWalletTransactionsParamsSort = "smallest"
+)
+
+// AcceptPaymentRequestRequest defines model for AcceptPaymentRequestRequest.
+type AcceptPaymentRequestRequest struct {
+	// FromWalletId Caller's wallet the payment is taken from.
+	FromWalletId string `json:"fromWalletId" validate:"required"`
+}
+
+// BatchTransferLeg defines model for BatchTransferLeg.
+type BatchTransferLeg struct {
+	// Amount Decimal amount with at most 2 fractional digits.
+	Amount money.Amount `json:"amount" validate:"required,gt=0"`
+
+	// Description Free-text memo shown in the transaction history.
+	Description *string `json:"description,omitempty" validate:"omitempty,max=255"`
+
+	// Metadata Arbitrary JSON stored with the transaction.
+	Metadata *map[string]interface{} `json:"metadata,omitempty"`
+
+	// Reference Caller's external reference ID, e.g. a payslip number.
+	Reference  *string `json:"reference,omitempty" validate:"omitempty,max=100"`
+	ToWalletId string  `json:"toWalletId" validate:"required"`
+}
+
+// BatchTransferLegResult defines model for BatchTransferLegResult.
+type BatchTransferLegResult struct {
+	Amount money.Amount `json:"amount"`
+
+	// ErrorMessage Why the leg failed, e.g. insufficient balance.
+	ErrorMessage *string `json:"errorMessage,omitempty"`
+
+	// Index Position of the leg in the request, starting at 0.
+	Index       int                          `json:"index"`
+	Status      BatchTransferLegResultStatus `json:"status"`
+	ToWalletId  string                       `json:"toWalletId"`
+	Transaction *TransactionResponseData     `json:"transaction,omitempty"`
+}
+
+// BatchTransferLegResultStatus defines model for BatchTransferLegResult.Status.
+type BatchTransferLegResultStatus string
+
+// BatchTransferRequest defines model for BatchTransferRequest.
+type BatchTransferRequest struct {
+	FromWalletId string                    `json:"fromWalletId" validate:"required"`
+	Legs         []BatchTransferLeg        `json:"legs" validate:"required,min=1,max=500,dive"`
+	Mode         *BatchTransferRequestMode `json:"mode,omitempty" validate:"omitempty,oneof=all_or_nothing best_effort"`
+}
+
+// BatchTransferRequestMode defines model for BatchTransferRequest.Mode.
+type BatchTransferRequestMode string
+
+// BatchTransferResponseData defines model for BatchTransferResponseData.
+type BatchTransferResponseData struct {
+	Failed       int    `json:"failed"`
+	FromWalletId string `json:"fromWalletId"`
+
+	// Legs One result per requested leg, in request order.
+	Legs      []BatchTransferLegResult      `json:"legs"`
+	Mode      BatchTransferResponseDataMode `json:"mode"`
+	Succeeded int                           `json:"succeeded"`
+
+	// TotalAmount Sum of the amounts of the completed legs, fees excluded.
+	TotalAmount money.Amount `json:"totalAmount"`
+}
+
+// BatchTransferResponseDataMode defines model for BatchTransferResponseData.Mode.
+type BatchTransferResponseDataMode string
+
+// CaptureHoldRequest defines model for CaptureHoldRequest.
+type CaptureHoldRequest struct {
+	// Amount Partial capture amount. Omit to capture the full hold.
+	Amount *money.Amount `json:"amount,omitempty" validate:"omitempty,gt=0"`
+}
+
+// CloseWalletRequest defines model for CloseWalletRequest.
+type CloseWalletRequest struct {
+	// SweepToWalletId Another of the caller's wallets that receives the remaining balance.
+	SweepToWalletId *string `json:"sweepToWalletId,omitempty"`
+}
+
+// CloseWalletResponseData defines model for CloseWalletResponseData.
+type CloseWalletResponseData struct {
+	SweepTransaction *TransactionResponseData `json:"sweepTransaction,omitempty"`
+	Wallet           WalletResponseData       `json:"wallet"`
+}
+
+// CreateHoldRequest defines model for CreateHoldRequest.
+type CreateHoldRequest struct {
+	// Amount Decimal amount with at most 2 fractional digits.
+	Amount money.Amount `json:"amount" validate:"required,gt=0"`
+
+	// ExpiresAt When the hold is released automatically. At most 30 days ahead.
+	ExpiresAt time.Time `json:"expiresAt" validate:"required"`
+
+	// ToWalletId Wallet that receives the funds on capture.
+	ToWalletId string `json:"toWalletId" validate:"required"`
+	WalletId   string `json:"walletId" validate:"required"`
+}
+
+// CreatePaymentRequestRequest defines model for CreatePaymentRequestRequest.
+type CreatePaymentRequestRequest struct {
+	// Amount Decimal amount with at most 2 fractional digits, in the currency of toWalletId.
+	Amount money.Amount `json:"amount" validate:"required,gt=0"`
+
+	// ExpiresAt When the request lapses. Defaults to 7 days ahead and may be at most 30 days ahead.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// Note Shown to the payer and used as the description of the resulting transfer.
+	Note *string `json:"note,omitempty" validate:"omitempty,max=255"`
+
+	// PayerEmail Email of the user asked to pay.
+	PayerEmail string `json:"payerEmail" validate:"required,email"`
+
+	// ToWalletId Caller's wallet that receives the payment.
+	ToWalletId string `json:"toWalletId" validate:"required"`
+}
+
+// CreateScheduleRequest defines model for CreateScheduleRequest.
+type CreateScheduleRequest struct {
+	// Amount Decimal amount with at most 2 fractional digits.
+	Amount money.Amount `json:"amount" validate:"required,gt=0"`
+
+	// EndAt No runs are scheduled after this time.
+	EndAt        *time.Time                     `json:"endAt,omitempty"`
+	Frequency    CreateScheduleRequestFrequency `json:"frequency" validate:"required,oneof=once daily weekly monthly"`
+	FromWalletId string                         `json:"fromWalletId" validate:"required"`
+
+	// MaxRuns Stop after this many runs.
+	MaxRuns *int `json:"maxRuns,omitempty" validate:"omitempty,gt=0"`
+
+	// StartAt First run. Later runs repeat at the same time of day; monthly runs on days missing from a month fall on its last day.
+	StartAt    time.Time `json:"startAt" validate:"required"`
+	ToWalletId string    `json:"toWalletId" validate:"required"`
+}
+
+// CreateScheduleRequestFrequency defines model for CreateScheduleRequest.Frequency.
+type CreateScheduleRequestFrequency string
+
+// CreateWalletRequest defines model for CreateWalletRequest.
+type CreateWalletRequest struct {
+	// Currency ISO 4217 currency code (THB, USD, EUR, GBP, SGD, JPY, KRW, VND).
+	Currency    string  `json:"currency" validate:"required,iso4217"`
+	Description *string `json:"description,omitempty"`
+	Name        string  `json:"name" validate:"required"`
+}
+
+// CursorPageResponseData defines model for CursorPageResponseData.
+type CursorPageResponseData struct {
+	// Limit The number of items per page.
+	Limit int `json:"limit"`
+
+	// NextCursor Pass as cursor to fetch the following page. Absent on the last page.
+	NextCursor *string `json:"nextCursor,omitempty"`
+
+	// PrevCursor Pass as cursor to fetch the preceding page. Absent on the first page.
+	PrevCursor *string `json:"prevCursor,omitempty"`
+
+	// TotalRecords The total number of matching records, only present when includeTotal is true.
+	TotalRecords *int `json:"totalRecords,omitempty"`
+}
+
+// DepositImportResponseData defines model for DepositImportResponseData.
+type DepositImportResponseData struct {
+	CompletedAt   *time.Time `json:"completedAt,omitempty"`
+	CompletedRows int        `json:"completedRows"`
+	CreatedAt     time.Time  `json:"createdAt"`
+
+	// FailedRows Rows that validated but whose deposit failed, for example because the wallet was frozen in the meantime.
+	FailedRows  int    `json:"failedRows"`
+	Filename    string `json:"filename"`
+	Id          string `json:"id"`
+	InvalidRows int    `json:"invalidRows"`
+
+	// Status rejected when any row failed validation, in which case nothing is deposited.
+	Status    DepositImportResponseDataStatus `json:"status"`
+	TotalRows int                             `json:"totalRows"`
+	UpdatedAt time.Time                       `json:"updatedAt"`
+}
+
+// DepositImportResponseDataStatus rejected when any row failed validation, in which case nothing is deposited.
+type DepositImportResponseDataStatus string
+
+// DepositImportRowData defines model for DepositImportRowData.
+type DepositImportRowData struct {
+	// Amount Absent when the uploaded amount is not a valid decimal.
+	Amount       *money.Amount `json:"amount,omitempty"`
+	ErrorMessage *string       `json:"errorMessage,omitempty"`
+	Reference    string        `json:"reference"`
+
+	// RowNumber Line of the uploaded file, the header being line 1.
+	RowNumber int `json:"rowNumber"`
+
+	// Status skipped rows were valid but belong to a rejected import.
+	Status        DepositImportRowDataStatus `json:"status"`
+	TransactionId *string                    `json:"transactionId,omitempty"`
+	WalletId      string                     `json:"walletId"`
+}
+
+// DepositImportRowDataStatus skipped rows were valid but belong to a rejected import.
+type DepositImportRowDataStatus string
+
 // DepositRequest defines model for DepositRequest.
 type DepositRequest struct {
-	Amount   float64 `json:"amount" validate:"required,min=0.01"`
-	WalletId string  `json:"walletId" validate:"required"`
+	// Amount Decimal amount with at most 2 fractional digits.
+	Amount money.Amount `json:"amount" validate:"required,gt=0"`
+
+	// Description Free-text memo shown in the transaction history.
+	Description *string `json:"description,omitempty" validate:"omitempty,max=255"`
+
+	// Metadata Arbitrary JSON stored with the transaction.
+	Metadata *map[string]interface{} `json:"metadata,omitempty"`
+
+	// Reference Caller's external reference ID, e.g. an order or invoice number.
+	Reference *string `json:"reference,omitempty" validate:"omitempty,max=100"`
+	WalletId  string  `json:"walletId" validate:"required"`
+}
+
+// ExchangeRateRequest defines model for ExchangeRateRequest.
+type ExchangeRateRequest struct {
+	// BaseCurrency Currency being sold (debited wallet).
+	BaseCurrency string `json:"baseCurrency" validate:"required,iso4217"`
+
+	// QuoteCurrency Currency being bought (credited wallet).
+	QuoteCurrency string `json:"quoteCurrency" validate:"required,iso4217"`
+
+	// Rate Units of quote currency per one unit of base currency, up to 8 fractional digits.
+	Rate money.Rate `json:"rate" validate:"required,gt=0"`
+
+	// Spread Fraction deducted from the rate, e.g. 0.005 for 0.5%. Defaults to 0.
+	Spread *money.Rate `json:"spread,omitempty"`
+}
+
+// ExchangeRateResponseData defines model for ExchangeRateResponseData.
+type ExchangeRateResponseData struct {
+	BaseCurrency string `json:"baseCurrency"`
+
+	// EffectiveRate Rate applied to transfers after deducting the spread.
+	EffectiveRate money.Rate `json:"effectiveRate"`
+	QuoteCurrency string     `json:"quoteCurrency"`
+	Rate          money.Rate `json:"rate"`
+	Spread        money.Rate `json:"spread"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+}
+
+// FeeQuoteRequest defines model for FeeQuoteRequest.
+type FeeQuoteRequest struct {
+	Amount          money.Amount                   `json:"amount" validate:"required,gt=0"`
+	TransactionType FeeQuoteRequestTransactionType `json:"transactionType" validate:"required,oneof=transfer withdraw"`
+
+	// WalletId Wallet that would be debited.
+	WalletId string `json:"walletId" validate:"required"`
+}
+
+// FeeQuoteRequestTransactionType defines model for FeeQuoteRequest.TransactionType.
+type FeeQuoteRequestTransactionType string
+
+// FeeQuoteResponseData defines model for FeeQuoteResponseData.
+type FeeQuoteResponseData struct {
+	Amount   money.Amount `json:"amount"`
+	Currency string       `json:"currency"`
+	Fee      money.Amount `json:"fee"`
+
+	// Total Amount plus fee, debited from the wallet.
+	Total           money.Amount `json:"total"`
+	TransactionType string       `json:"transactionType"`
+	WalletId        string       `json:"walletId"`
+}
+
+// FeeRuleRequest defines model for FeeRuleRequest.
+type FeeRuleRequest struct {
+	Currency string `json:"currency" validate:"required,iso4217"`
+
+	// FeeWalletId Wallet credited with the fee. Must hold the rule's currency.
+	FeeWalletId string        `json:"feeWalletId" validate:"required"`
+	FlatFee     *money.Amount `json:"flatFee,omitempty" validate:"omitempty,gte=0"`
+	MaxFee      *money.Amount `json:"maxFee,omitempty" validate:"omitempty,gte=0"`
+
+	// MinAmount Smallest amount this tier prices. Defaults to 0.
+	MinAmount *money.Amount `json:"minAmount,omitempty" validate:"omitempty,gte=0"`
+	MinFee    *money.Amount `json:"minFee,omitempty" validate:"omitempty,gte=0"`
+
+	// Rate Fraction of the amount charged, below 1, e.g. 0.015 for 1.5%. Rounded down to the currency's minor units.
+	Rate            *money.Rate                   `json:"rate,omitempty" validate:"omitempty,gte=0"`
+	TransactionType FeeRuleRequestTransactionType `json:"transactionType" validate:"required,oneof=transfer withdraw"`
+}
+
+// FeeRuleRequestTransactionType defines model for FeeRuleRequest.TransactionType.
+type FeeRuleRequestTransactionType string
+
+// HoldResponseData defines model for HoldResponseData.
+type HoldResponseData struct {
+	Amount         money.Amount           `json:"amount"`
+	CapturedAmount *money.Amount          `json:"capturedAmount,omitempty"`
+	CreatedAt      time.Time              `json:"createdAt"`
+	ExpiresAt      time.Time              `json:"expiresAt"`
+	Id             string                 `json:"id"`
+	Status         HoldResponseDataStatus `json:"status"`
+	ToWalletId     string                 `json:"toWalletId"`
+	TransactionId  *string                `json:"transactionId,omitempty"`
+	WalletId       string                 `json:"walletId"`
+}
+
+// HoldResponseDataStatus defines model for HoldResponseData.Status.
+type HoldResponseDataStatus string
+
+// LimitExceededError defines model for LimitExceededError.
+type LimitExceededError struct {
+	// ErrorCode Always LIMIT_EXCEEDED.
+	ErrorCode    string                  `json:"errorCode"`
+	ErrorMessage string                  `json:"errorMessage"`
+	Limit        LimitExceededErrorLimit `json:"limit"`
+
+	// ResetsAt When the limit allows this debit again. Absent for per_transaction_max.
+	ResetsAt *time.Time `json:"resetsAt,omitempty"`
+}
+
+// LimitExceededErrorLimit defines model for LimitExceededError.Limit.
+type LimitExceededErrorLimit string
+
+// LimitRequest defines model for LimitRequest.
+type LimitRequest struct {
+	// Currency Currency of the debited wallets the limits apply to.
+	Currency string `json:"currency" validate:"required,iso4217"`
+
+	// DailyUserMax Cap on debits from all of a user's wallets in this currency per UTC day.
+	DailyUserMax *money.Amount `json:"dailyUserMax,omitempty" validate:"omitempty,gt=0"`
+
+	// DailyWalletMax Cap on debits from one wallet per UTC day.
+	DailyWalletMax *money.Amount `json:"dailyWalletMax,omitempty" validate:"omitempty,gt=0"`
+
+	// MaxTransfers Maximum transfers by the user within transferWindowSeconds. Set both or neither.
+	MaxTransfers *int `json:"maxTransfers,omitempty" validate:"omitempty,gt=0"`
+
+	// MonthlyUserMax Cap on debits from all of a user's wallets in this currency per UTC calendar month.
+	MonthlyUserMax *money.Amount `json:"monthlyUserMax,omitempty" validate:"omitempty,gt=0"`
+
+	// MonthlyWalletMax Cap on debits from one wallet per UTC calendar month.
+	MonthlyWalletMax  *money.Amount `json:"monthlyWalletMax,omitempty" validate:"omitempty,gt=0"`
+	PerTransactionMax *money.Amount `json:"perTransactionMax,omitempty" validate:"omitempty,gt=0"`
+
+	// TransferWindowSeconds Length of the rolling window for maxTransfers.
+	TransferWindowSeconds *int `json:"transferWindowSeconds,omitempty" validate:"omitempty,gt=0"`
+}
+
+// LoadExchangeRatesRequest defines model for LoadExchangeRatesRequest.
+type LoadExchangeRatesRequest struct {
+	Rates []ExchangeRateRequest `json:"rates" validate:"required,min=1,dive"`
+}
+
+// LoadFeeRulesRequest defines model for LoadFeeRulesRequest.
+type LoadFeeRulesRequest struct {
+	Rules []FeeRuleRequest `json:"rules" validate:"required,dive"`
+}
+
+// LoadLimitsRequest defines model for LoadLimitsRequest.
+type LoadLimitsRequest struct {
+	Limits []LimitRequest `json:"limits" validate:"required,dive"`
 }
 
 // LoginRequest defines model for LoginRequest.
@@ -39,16 +607,29 @@ type LoginResponseData struct {
 	UserId      string `json:"userId"`
 }
 
-// PageLimitResponseData defines model for PageLimitResponseData.
-type PageLimitResponseData struct {
-	// Limit The number of items per page.
-	Limit int `json:"limit"`
+// PaymentRequestResponseData defines model for PaymentRequestResponseData.
+type PaymentRequestResponseData struct {
+	Amount         money.Amount                     `json:"amount"`
+	CreatedAt      time.Time                        `json:"createdAt"`
+	ExpiresAt      time.Time                        `json:"expiresAt"`
+	Id             string                           `json:"id"`
+	Note           *string                          `json:"note,omitempty"`
+	PayerEmail     string                           `json:"payerEmail"`
+	RequesterEmail string                           `json:"requesterEmail"`
+	Status         PaymentRequestResponseDataStatus `json:"status"`
+	ToWalletId     string                           `json:"toWalletId"`
+
+	// TransactionId Transfer that paid an accepted request.
+	TransactionId *string `json:"transactionId,omitempty"`
+}
 
-	// Page The current page index (starting from 1).
-	Page int `json:"page"`
+// PaymentRequestResponseDataStatus defines model for PaymentRequestResponseData.Status.
+type PaymentRequestResponseDataStatus string
 
-	// TotalRecords The total number of records available.
-	TotalRecords int `json:"totalRecords"`
+// RecipientResponseData defines model for RecipientResponseData.
+type RecipientResponseData struct {
+	// DisplayName Recipient's display name with all but the first letter of each word masked, e.g. "J*** D**".
+	DisplayName string `json:"displayName"`
 }
 
 // RegisterRequest defines model for RegisterRequest.
@@ -58,14 +639,140 @@ type RegisterRequest struct {
 	Password    string `json:"password" validate:"required"`
 }
 
+// ReverseTransactionRequest defines model for ReverseTransactionRequest.
+type ReverseTransactionRequest struct {
+	// Amount Partial refund in the original transaction's debited currency. Omit to refund the remaining amount.
+	Amount *money.Amount `json:"amount,omitempty" validate:"omitempty,gt=0"`
+
+	// Description Free-text memo shown in the transaction history.
+	Description *string `json:"description,omitempty" validate:"omitempty,max=255"`
+
+	// Metadata Arbitrary JSON stored with the transaction.
+	Metadata *map[string]interface{} `json:"metadata,omitempty"`
+
+	// Reference Caller's external reference ID, e.g. an order or invoice number.
+	Reference *string `json:"reference,omitempty" validate:"omitempty,max=100"`
+}
+
+// ScheduleResponseData defines model for ScheduleResponseData.
+type ScheduleResponseData struct {
+	Amount       money.Amount                  `json:"amount"`
+	CreatedAt    time.Time                     `json:"createdAt"`
+	EndAt        *time.Time                    `json:"endAt,omitempty"`
+	Frequency    ScheduleResponseDataFrequency `json:"frequency"`
+	FromWalletId string                        `json:"fromWalletId"`
+	Id           string                        `json:"id"`
+	MaxRuns      *int                          `json:"maxRuns,omitempty"`
+
+	// NextRunAt Absent once the schedule has completed or been cancelled.
+	NextRunAt *time.Time `json:"nextRunAt,omitempty"`
+
+	// RunCount Number of runs executed so far, successful or not.
+	RunCount   int                        `json:"runCount"`
+	StartAt    time.Time                  `json:"startAt"`
+	Status     ScheduleResponseDataStatus `json:"status"`
+	ToWalletId string                     `json:"toWalletId"`
+}
+
+// ScheduleResponseDataFrequency defines model for ScheduleResponseData.Frequency.
+type ScheduleResponseDataFrequency string
+
+// ScheduleResponseDataStatus defines model for ScheduleResponseData.Status.
+type ScheduleResponseDataStatus string
+
+// ScheduleRunResponseData defines model for ScheduleRunResponseData.
+type ScheduleRunResponseData struct {
+	CreatedAt time.Time `json:"createdAt"`
+
+	// ErrorMessage Why the transfer failed, e.g. insufficient balance.
+	ErrorMessage *string `json:"errorMessage,omitempty"`
+	Id           string  `json:"id"`
+
+	// RunAt Occurrence this run executed.
+	RunAt         time.Time                     `json:"runAt"`
+	Status        ScheduleRunResponseDataStatus `json:"status"`
+	TransactionId *string                       `json:"transactionId,omitempty"`
+}
+
+// ScheduleRunResponseDataStatus defines model for ScheduleRunResponseData.Status.
+type ScheduleRunResponseDataStatus string
+
+// StatementLineData defines model for StatementLineData.
+type StatementLineData struct {
+	// Amount Signed effect on the balance, positive for money in and negative for money out.
+	Amount               money.Amount `json:"amount"`
+	BalanceAfter         money.Amount `json:"balanceAfter"`
+	CounterpartyWalletId *string      `json:"counterpartyWalletId,omitempty"`
+	CreatedAt            time.Time    `json:"createdAt"`
+	Description          *string      `json:"description,omitempty"`
+	Reference            *string      `json:"reference,omitempty"`
+
+	// TransactionId Absent for ledger entries without a transaction, such as opening balances.
+	TransactionId *string `json:"transactionId,omitempty"`
+
+	// Type Transaction type, or adjustment for entries without a transaction.
+	Type string `json:"type"`
+}
+
+// StatementResponseData defines model for StatementResponseData.
+type StatementResponseData struct {
+	ClosingBalance money.Amount `json:"closingBalance"`
+	Currency       string       `json:"currency"`
+
+	// Final True when the month has ended and the statement can no longer change.
+	Final          bool                `json:"final"`
+	GeneratedAt    time.Time           `json:"generatedAt"`
+	Lines          []StatementLineData `json:"lines"`
+	OpeningBalance money.Amount        `json:"openingBalance"`
+
+	// PeriodEnd Start of the following month, exclusive.
+	PeriodEnd time.Time `json:"periodEnd"`
+
+	// PeriodStart Start of the month, inclusive.
+	PeriodStart time.Time `json:"periodStart"`
+
+	// StatementId Set once the statement of a finished month has been stored.
+	StatementId *string      `json:"statementId,omitempty"`
+	TotalIn     money.Amount `json:"totalIn"`
+	TotalOut    money.Amount `json:"totalOut"`
+	WalletId    string       `json:"walletId"`
+}
+
 // TransactionResponseData defines model for TransactionResponseData.
 type TransactionResponseData struct {
-	Amount       float64   `json:"amount"`
-	CreatedAt    time.Time `json:"createdAt"`
-	Description  *string   `json:"description,omitempty"`
-	FromWalletId string    `json:"fromWalletId"`
-	Id           string    `json:"id"`
-	ToWalletId   string    `json:"toWalletId"`
+	// Amount Decimal amount with at most 2 fractional digits.
+	Amount    money.Amount `json:"amount"`
+	CreatedAt time.Time    `json:"createdAt"`
+
+	// CreditedAmount Amount credited to the destination wallet when a transfer was converted between currencies.
+	CreditedAmount *money.Amount `json:"creditedAmount,omitempty"`
+	Description    *string       `json:"description,omitempty"`
+
+	// ExchangeRate Exchange rate applied to a cross-currency transfer.
+	ExchangeRate *money.Rate `json:"exchangeRate,omitempty"`
+
+	// Fee Fee charged on top of this transaction.
+	Fee *money.Amount `json:"fee,omitempty"`
+
+	// FromBalanceAfter Balance of the debited wallet right after this transaction. Absent for transactions written before balances were recorded and not backfilled.
+	FromBalanceAfter *money.Amount           `json:"fromBalanceAfter,omitempty"`
+	FromWalletId     string                  `json:"fromWalletId"`
+	Id               string                  `json:"id"`
+	Metadata         *map[string]interface{} `json:"metadata,omitempty"`
+
+	// OriginalTransactionId Transaction that this reversal refunds.
+	OriginalTransactionId *string `json:"originalTransactionId,omitempty"`
+
+	// ParentTransactionId Transfer or withdrawal that this fee was charged on.
+	ParentTransactionId *string `json:"parentTransactionId,omitempty"`
+	Reference           *string `json:"reference,omitempty"`
+
+	// RefundedAmount Total refunded so far by reversals of this transaction, in its debited currency.
+	RefundedAmount *money.Amount `json:"refundedAmount,omitempty"`
+
+	// ToBalanceAfter Balance of the credited wallet right after this transaction. Absent for transactions written before balances were recorded and not backfilled.
+	ToBalanceAfter *money.Amount `json:"toBalanceAfter,omitempty"`
+	ToWalletId     string        `json:"toWalletId"`
 
 	// Type Transaction type
 	Type TransactionResponseDataType `json:"type"`
@@ -74,11 +781,94 @@ type TransactionResponseData struct {
 // TransactionResponseDataType Transaction type
 type TransactionResponseDataType string
 
-// TransferRequest defines model for TransferRequest.
+// TransferRequest Exactly one of toWalletId, toEmail or toHandle addresses the recipient. Email and handle resolve to the recipient's default wallet.
 type TransferRequest struct {
-	Amount       float64 `json:"amount" validate:"required,min=0.01"`
+	// Amount Decimal amount with at most 2 fractional digits.
+	Amount money.Amount `json:"amount" validate:"required,gt=0"`
+
+	// Description Free-text memo shown in the transaction history.
+	Description  *string `json:"description,omitempty" validate:"omitempty,max=255"`
 	FromWalletId string  `json:"fromWalletId" validate:"required"`
-	ToWalletId   string  `json:"toWalletId" validate:"required"`
+
+	// Metadata Arbitrary JSON stored with the transaction.
+	Metadata *map[string]interface{} `json:"metadata,omitempty"`
+
+	// Reference Caller's external reference ID, e.g. an order or invoice number.
+	Reference  *string `json:"reference,omitempty" validate:"omitempty,max=100"`
+	ToEmail    *string `json:"toEmail,omitempty" validate:"omitempty,email"`
+	ToHandle   *string `json:"toHandle,omitempty"`
+	ToWalletId *string `json:"toWalletId,omitempty"`
+}
+
+// UpdateCreditLineRequest defines model for UpdateCreditLineRequest.
+type UpdateCreditLineRequest struct {
+	// CreditLimit How far below zero the wallet may go; 0 removes the credit line.
+	CreditLimit money.Amount `json:"creditLimit" validate:"gte=0"`
+
+	// InterestRate Annual interest rate charged on a negative balance, e.g. 0.18 for 18%. Defaults to 0.
+	InterestRate *money.Rate `json:"interestRate,omitempty" validate:"omitempty,gte=0"`
+}
+
+// UpdateScheduleRequest defines model for UpdateScheduleRequest.
+type UpdateScheduleRequest struct {
+	// Amount Decimal amount with at most 2 fractional digits.
+	Amount  money.Amount `json:"amount" validate:"required,gt=0"`
+	EndAt   *time.Time   `json:"endAt,omitempty"`
+	MaxRuns *int         `json:"maxRuns,omitempty" validate:"omitempty,gt=0"`
+}
+
+// UpdateUserSettingsRequest defines model for UpdateUserSettingsRequest.
+type UpdateUserSettingsRequest struct {
+	// DefaultWalletId One of the caller's wallets.
+	DefaultWalletId *string `json:"defaultWalletId,omitempty"`
+
+	// Handle Unique handle of 3 to 30 letters and digits, stored in lower case.
+	Handle *string `json:"handle,omitempty" validate:"omitempty,min=3,max=30,alphanum"`
+}
+
+// UpdateWalletStatusRequest defines model for UpdateWalletStatusRequest.
+type UpdateWalletStatusRequest struct {
+	// Actor Who made the change, e.g. the operator's email.
+	Actor string `json:"actor" validate:"required,max=100"`
+
+	// Reason Required unless the wallet is reactivated.
+	Reason *string                         `json:"reason,omitempty" validate:"required_unless=Status active,omitempty,max=255"`
+	Status UpdateWalletStatusRequestStatus `json:"status" validate:"required,oneof=active frozen debit_blocked credit_blocked"`
+}
+
+// UpdateWalletStatusRequestStatus defines model for UpdateWalletStatusRequest.Status.
+type UpdateWalletStatusRequestStatus string
+
+// UserSettingsResponseData defines model for UserSettingsResponseData.
+type UserSettingsResponseData struct {
+	// DefaultWalletId Wallet that receives transfers addressed by email or handle. When unset, the user's oldest wallet receives them.
+	DefaultWalletId *string `json:"defaultWalletId,omitempty"`
+	DisplayName     string  `json:"displayName"`
+	Email           string  `json:"email"`
+	Handle          *string `json:"handle,omitempty"`
+}
+
+// WalletBlockedError defines model for WalletBlockedError.
+type WalletBlockedError struct {
+	// ErrorCode Always WALLET_BLOCKED.
+	ErrorCode    string                   `json:"errorCode"`
+	ErrorMessage string                   `json:"errorMessage"`
+	Status       WalletBlockedErrorStatus `json:"status"`
+
+	// WalletId The wallet whose status blocked the movement.
+	WalletId string `json:"walletId"`
+}
+
+// WalletBlockedErrorStatus defines model for WalletBlockedError.Status.
+type WalletBlockedErrorStatus string
+
+// WalletCreditLineResponseData defines model for WalletCreditLineResponseData.
+type WalletCreditLineResponseData struct {
+	CreditLimit  money.Amount `json:"creditLimit"`
+	CreditUsed   money.Amount `json:"creditUsed"`
+	Currency     string       `json:"currency"`
+	InterestRate money.Rate   `json:"interestRate"`
+	WalletId     string       `json:"walletId"`
 }
 
 // WalletRequest defines model for WalletRequest.
@@ -89,17 +879,90 @@ type WalletRequest struct {
 
 // WalletResponseData defines model for WalletResponseData.
 type WalletResponseData struct {
-	Balance     float64   `json:"balance"`
-	Description *string   `json:"description,omitempty"`
-	Id          string    `json:"id"`
-	Name        string    `json:"name"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	// AvailableBalance Balance minus active holds.
+	AvailableBalance money.Amount `json:"availableBalance"`
+
+	// AvailableCredit Part of the credit limit still available after the balance and active holds.
+	AvailableCredit *money.Amount `json:"availableCredit,omitempty"`
+
+	// Balance Ledger balance, a decimal amount with at most 2 fractional digits.
+	Balance money.Amount `json:"balance"`
+
+	// ClosedAt Set once the wallet is closed.
+	ClosedAt *time.Time `json:"closedAt,omitempty"`
+
+	// CreditLimit How far below zero the wallet may go. Only set on credit-line wallets.
+	CreditLimit *money.Amount `json:"creditLimit,omitempty"`
+
+	// CreditUsed Part of the credit limit drawn by a negative balance.
+	CreditUsed *money.Amount `json:"creditUsed,omitempty"`
+
+	// Currency ISO 4217 currency code of the wallet.
+	Currency    string  `json:"currency"`
+	Description *string `json:"description,omitempty"`
+	Id          string  `json:"id"`
+
+	// InterestRate Annual interest rate charged daily on a negative balance, e.g. 0.18 for 18%.
+	InterestRate *money.Rate `json:"interestRate,omitempty"`
+	Name         string      `json:"name"`
+
+	// Status frozen blocks every movement, debit_blocked money leaving the wallet and credit_blocked money entering it.
+	Status    WalletResponseDataStatus `json:"status"`
+	UpdatedAt time.Time                `json:"updatedAt"`
 }
 
+// WalletResponseDataStatus frozen blocks every movement, debit_blocked money leaving the wallet and credit_blocked money entering it.
+type WalletResponseDataStatus string
+
+// WalletStatusResponseData defines model for WalletStatusResponseData.
+type WalletStatusResponseData struct {
+	ChangedAt *time.Time                     `json:"changedAt,omitempty"`
+	ChangedBy *string                        `json:"changedBy,omitempty"`
+	Reason    *string                        `json:"reason,omitempty"`
+	Status    WalletStatusResponseDataStatus `json:"status"`
+	WalletId  string                         `json:"walletId"`
+}
+
+// WalletStatusResponseDataStatus defines model for WalletStatusResponseData.Status.
+type WalletStatusResponseDataStatus string
+
 // WithdrawRequest defines model for WithdrawRequest.
 type WithdrawRequest struct {
-	Amount   float64 `json:"amount" validate:"required,min=0.01"`
-	WalletId string  `json:"walletId" validate:"required"`
+	// Amount Decimal amount with at most 2 fractional digits.
+	Amount money.Amount `json:"amount" validate:"required,gt=0"`
+
+	// Description Free-text memo shown in the transaction history.
+	Description *string `json:"description,omitempty" validate:"omitempty,max=255"`
+
+	// Metadata Arbitrary JSON stored with the transaction.
+	Metadata *map[string]interface{} `json:"metadata,omitempty"`
+
+	// Reference Caller's external reference ID, e.g. an order or invoice number.
+	Reference *string `json:"reference,omitempty" validate:"omitempty,max=100"`
+	WalletId  string  `json:"walletId" validate:"required"`
+}
+
+// IdempotencyKey defines model for IdempotencyKey.
+type IdempotencyKey = string
+
+// BatchTransferResponse defines model for BatchTransferResponse.
+type BatchTransferResponse struct {
+	Data *BatchTransferResponseData `json:"data,omitempty"`
+}
+
+// CloseWalletResponse defines model for CloseWalletResponse.
+type CloseWalletResponse struct {
+	Data *CloseWalletResponseData `json:"data,omitempty"`
+}
+
+// DepositImportResponse defines model for DepositImportResponse.
+type DepositImportResponse struct {
+	Data *DepositImportResponseData `json:"data,omitempty"`
+}
+
+// DepositImportRowsResponse defines model for DepositImportRowsResponse.
+type DepositImportRowsResponse struct {
+	Data *[]DepositImportRowData `json:"data,omitempty"`
 }
 
 // ErrorResponse defines model for ErrorResponse.
@@ -108,6 +971,39 @@ type ErrorResponse struct {
 	ErrorMessage string `json:"errorMessage"`
 }
 
+// FeeQuoteResponse defines model for FeeQuoteResponse.
+type FeeQuoteResponse struct {
+	Data *FeeQuoteResponseData `json:"data,omitempty"`
+}
+
+// HoldResponse defines model for HoldResponse.
+type HoldResponse struct {
+	Data *HoldResponseData `json:"data,omitempty"`
+}
+
+// LimitExceededResponse defines model for LimitExceededResponse.
+type LimitExceededResponse = LimitExceededError
+
+// ListExchangeRatesResponse defines model for ListExchangeRatesResponse.
+type ListExchangeRatesResponse struct {
+	Data *[]ExchangeRateResponseData `json:"data,omitempty"`
+}
+
+// ListPaymentRequestsResponse defines model for ListPaymentRequestsResponse.
+type ListPaymentRequestsResponse struct {
+	Data *[]PaymentRequestResponseData `json:"data,omitempty"`
+}
+
+// ListScheduleRunsResponse defines model for ListScheduleRunsResponse.
+type ListScheduleRunsResponse struct {
+	Data *[]ScheduleRunResponseData `json:"data,omitempty"`
+}
+
+// ListSchedulesResponse defines model for ListSchedulesResponse.
+type ListSchedulesResponse struct {
+	Data *[]ScheduleResponseData `json:"data,omitempty"`
+}
+
 // ListUserWalletsResponse defines model for ListUserWalletsResponse.
 type ListUserWalletsResponse struct {
 	Data *[]WalletResponseData `json:"data,omitempty"`
@@ -116,7 +1012,7 @@ type ListUserWalletsResponse struct {
 // ListWalletTransactionsResponse defines model for ListWalletTransactionsResponse.
 type ListWalletTransactionsResponse struct {
 	Data       *[]TransactionResponseData `json:"data,omitempty"`
-	Pagination *PageLimitResponseData     `json:"pagination,omitempty"`
+	Pagination *CursorPageResponseData    `json:"pagination,omitempty"`
 }
 
 // LoginResponse defines model for LoginResponse.
@@ -124,12 +1020,182 @@ type LoginResponse struct {
 	Data *LoginResponseData `json:"data,omitempty"`
 }
 
+// PaymentRequestResponse defines model for PaymentRequestResponse.
+type PaymentRequestResponse struct {
+	Data *PaymentRequestResponseData `json:"data,omitempty"`
+}
+
+// RecipientResponse defines model for RecipientResponse.
+type RecipientResponse struct {
+	Data *RecipientResponseData `json:"data,omitempty"`
+}
+
+// ScheduleResponse defines model for ScheduleResponse.
+type ScheduleResponse struct {
+	Data *ScheduleResponseData `json:"data,omitempty"`
+}
+
+// StatementResponse defines model for StatementResponse.
+type StatementResponse struct {
+	Data *StatementResponseData `json:"data,omitempty"`
+}
+
+// TransactionResponse defines model for TransactionResponse.
+type TransactionResponse struct {
+	Data *TransactionResponseData `json:"data,omitempty"`
+}
+
+// UserSettingsResponse defines model for UserSettingsResponse.
+type UserSettingsResponse struct {
+	Data *UserSettingsResponseData `json:"data,omitempty"`
+}
+
+// WalletBlockedResponse defines model for WalletBlockedResponse.
+type WalletBlockedResponse = WalletBlockedError
+
+// WalletCreditLineResponse defines model for WalletCreditLineResponse.
+type WalletCreditLineResponse struct {
+	Data *WalletCreditLineResponseData `json:"data,omitempty"`
+}
+
+// WalletStatusResponse defines model for WalletStatusResponse.
+type WalletStatusResponse struct {
+	Data *WalletStatusResponseData `json:"data,omitempty"`
+}
+
+// UploadDepositImportMultipartBody defines parameters for UploadDepositImport.
+type UploadDepositImportMultipartBody struct {
+	File openapi_types.File `json:"file"`
+}
+
+// ListDepositImportRowsParams defines parameters for ListDepositImportRows.
+type ListDepositImportRowsParams struct {
+	Status *ListDepositImportRowsParamsStatus `form:"status,omitempty" json:"status,omitempty" validate:"omitempty,oneof=pending completed failed invalid skipped"`
+}
+
+// ListDepositImportRowsParamsStatus defines parameters for ListDepositImportRows.
+type ListDepositImportRowsParamsStatus string
+
+// DepositPointsParams defines parameters for DepositPoints.
+type DepositPointsParams struct {
+	// IdempotencyKey Client-generated key. Retrying with the same key and body returns the original result; reusing it with a different body returns 422.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// LookupRecipientParams defines parameters for LookupRecipient.
+type LookupRecipientParams struct {
+	Email  *string `form:"email,omitempty" json:"email,omitempty"`
+	Handle *string `form:"handle,omitempty" json:"handle,omitempty"`
+}
+
+// ListPaymentRequestsParams defines parameters for ListPaymentRequests.
+type ListPaymentRequestsParams struct {
+	Direction *ListPaymentRequestsParamsDirection `form:"direction,omitempty" json:"direction,omitempty"`
+}
+
+// ListPaymentRequestsParamsDirection defines parameters for ListPaymentRequests.
+type ListPaymentRequestsParamsDirection string
+
+// ReverseTransactionParams defines parameters for ReverseTransaction.
+type ReverseTransactionParams struct {
+	// IdempotencyKey Client-generated key. Retrying with the same key and body returns the original result; reusing it with a different body returns 422.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// TransferBalanceParams defines parameters for TransferBalance.
+type TransferBalanceParams struct {
+	// IdempotencyKey Client-generated key. Retrying with the same key and body returns the original result; reusing it with a different body returns 422.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetWalletStatementParams defines parameters for GetWalletStatement.
+type GetWalletStatementParams struct {
+	Format *GetWalletStatementParamsFormat `form:"format,omitempty" json:"format,omitempty" validate:"omitempty,oneof=json csv pdf"`
+}
+
+// GetWalletStatementParamsFormat defines parameters for GetWalletStatement.
+type GetWalletStatementParamsFormat string
+
 // ListWalletTransactionsParams defines parameters for ListWalletTransactions.
 type ListWalletTransactionsParams struct {
-	Page  *int `form:"page,omitempty" json:"page,omitempty"`
-	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+	Limit                *int                                   `form:"limit,omitempty" json:"limit,omitempty" validate:"omitempty,min=1,max=100"`
+	Cursor               *string                                `form:"cursor,omitempty" json:"cursor,omitempty"`
+	IncludeTotal         *bool                                  `form:"includeTotal,omitempty" json:"includeTotal,omitempty"`
+	Reference            *string                                `form:"reference,omitempty" json:"reference,omitempty"`
+	Type                 *ListWalletTransactionsParamsType      `form:"type,omitempty" json:"type,omitempty" validate:"omitempty,oneof=deposit withdraw transfer reversal fee interest"`
+	Direction            *ListWalletTransactionsParamsDirection `form:"direction,omitempty" json:"direction,omitempty" validate:"omitempty,oneof=in out"`
+	CreatedFrom          *time.Time                             `form:"createdFrom,omitempty" json:"createdFrom,omitempty"`
+	CreatedTo            *time.Time                             `form:"createdTo,omitempty" json:"createdTo,omitempty"`
+	MinAmount            *string                                `form:"minAmount,omitempty" json:"minAmount,omitempty" validate:"omitempty,numeric"`
+	MaxAmount            *string                                `form:"maxAmount,omitempty" json:"maxAmount,omitempty" validate:"omitempty,numeric"`
+	CounterpartyWalletId *string                                `form:"counterpartyWalletId,omitempty" json:"counterpartyWalletId,omitempty"`
+	Memo                 *string                                `form:"memo,omitempty" json:"memo,omitempty" validate:"omitempty,max=100"`
+	Sort                 *ListWalletTransactionsParamsSort      `form:"sort,omitempty" json:"sort,omitempty" validate:"omitempty,oneof=newest oldest largest smallest"`
+}
+
+// ListWalletTransactionsParamsType defines parameters for ListWalletTransactions.
+type ListWalletTransactionsParamsType string
+
+// ListWalletTransactionsParamsDirection defines parameters for ListWalletTransactions.
+type ListWalletTransactionsParamsDirection string
+
+// ListWalletTransactionsParamsSort defines parameters for ListWalletTransactions.
+type ListWalletTransactionsParamsSort string
+
+// ExportWalletTransactionsParams defines parameters for ExportWalletTransactions.
+type ExportWalletTransactionsParams struct {
+	Format               *ExportWalletTransactionsParamsFormat    `form:"format,omitempty" json:"format,omitempty" validate:"omitempty,oneof=csv ofx jsonl"`
+	CreatedFrom          *time.Time                               `form:"createdFrom,omitempty" json:"createdFrom,omitempty" validate:"required"`
+	CreatedTo            *time.Time                               `form:"createdTo,omitempty" json:"createdTo,omitempty" validate:"required"`
+	Reference            *string                                  `form:"reference,omitempty" json:"reference,omitempty"`
+	Type                 *ExportWalletTransactionsParamsType      `form:"type,omitempty" json:"type,omitempty" validate:"omitempty,oneof=deposit withdraw transfer reversal fee interest"`
+	Direction            *ExportWalletTransactionsParamsDirection `form:"direction,omitempty" json:"direction,omitempty" validate:"omitempty,oneof=in out"`
+	MinAmount            *string                                  `form:"minAmount,omitempty" json:"minAmount,omitempty" validate:"omitempty,numeric"`
+	MaxAmount            *string                                  `form:"maxAmount,omitempty" json:"maxAmount,omitempty" validate:"omitempty,numeric"`
+	CounterpartyWalletId *string                                  `form:"counterpartyWalletId,omitempty" json:"counterpartyWalletId,omitempty"`
+	Memo                 *string                                  `form:"memo,omitempty" json:"memo,omitempty" validate:"omitempty,max=100"`
+	Sort                 *ExportWalletTransactionsParamsSort      `form:"sort,omitempty" json:"sort,omitempty" validate:"omitempty,oneof=newest oldest largest smallest"`
+}
+
+// ExportWalletTransactionsParamsFormat defines parameters for ExportWalletTransactions.
+type ExportWalletTransactionsParamsFormat string
+
+// ExportWalletTransactionsParamsType defines parameters for ExportWalletTransactions.
+type ExportWalletTransactionsParamsType string
+
+// ExportWalletTransactionsParamsDirection defines parameters for ExportWalletTransactions.
+type ExportWalletTransactionsParamsDirection string
+
+// ExportWalletTransactionsParamsSort defines parameters for ExportWalletTransactions.
+type ExportWalletTransactionsParamsSort string
+
+// WithdrawPointsParams defines parameters for WithdrawPoints.
+type WithdrawPointsParams struct {
+	// IdempotencyKey Client-generated key. Retrying with the same key and body returns the original result; reusing it with a different body returns 422.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
 }
 
+// UploadDepositImportMultipartRequestBody defines body for UploadDepositImport for multipart/form-data ContentType.
+type UploadDepositImportMultipartRequestBody UploadDepositImportMultipartBody
+
+// LoadExchangeRatesJSONRequestBody defines body for LoadExchangeRates for application/json ContentType.
+type LoadExchangeRatesJSONRequestBody = LoadExchangeRatesRequest
+
+// LoadFeeRulesJSONRequestBody defines body for LoadFeeRules for application/json ContentType.
+type LoadFeeRulesJSONRequestBody = LoadFeeRulesRequest
+
+// LoadGlobalLimitsJSONRequestBody defines body for LoadGlobalLimits for application/json ContentType.
+type LoadGlobalLimitsJSONRequestBody = LoadLimitsRequest
+
+// LoadUserLimitsJSONRequestBody defines body for LoadUserLimits for application/json ContentType.
+type LoadUserLimitsJSONRequestBody = LoadLimitsRequest
+
+// UpdateWalletCreditLineJSONRequestBody defines body for UpdateWalletCreditLine for application/json ContentType.
+type UpdateWalletCreditLineJSONRequestBody = UpdateCreditLineRequest
+
+// UpdateWalletStatusJSONRequestBody defines body for UpdateWalletStatus for application/json ContentType.
+type UpdateWalletStatusJSONRequestBody = UpdateWalletStatusRequest
+
 // LoginUserJSONRequestBody defines body for LoginUser for application/json ContentType.
 type LoginUserJSONRequestBody = LoginRequest
 
@@ -139,14 +1205,47 @@ type RegisterUserJSONRequestBody = RegisterRequest
 // DepositPointsJSONRequestBody defines body for DepositPoints for application/json ContentType.
 type DepositPointsJSONRequestBody = DepositRequest
 
+// QuoteFeeJSONRequestBody defines body for QuoteFee for application/json ContentType.
+type QuoteFeeJSONRequestBody = FeeQuoteRequest
+
+// CreateHoldJSONRequestBody defines body for CreateHold for application/json ContentType.
+type CreateHoldJSONRequestBody = CreateHoldRequest
+
+// CaptureHoldJSONRequestBody defines body for CaptureHold for application/json ContentType.
+type CaptureHoldJSONRequestBody = CaptureHoldRequest
+
+// CreatePaymentRequestJSONRequestBody defines body for CreatePaymentRequest for application/json ContentType.
+type CreatePaymentRequestJSONRequestBody = CreatePaymentRequestRequest
+
+// AcceptPaymentRequestJSONRequestBody defines body for AcceptPaymentRequest for application/json ContentType.
+type AcceptPaymentRequestJSONRequestBody = AcceptPaymentRequestRequest
+
+// CreateScheduleJSONRequestBody defines body for CreateSchedule for application/json ContentType.
+type CreateScheduleJSONRequestBody = CreateScheduleRequest
+
+// UpdateScheduleJSONRequestBody defines body for UpdateSchedule for application/json ContentType.
+type UpdateScheduleJSONRequestBody = UpdateScheduleRequest
+
+// ReverseTransactionJSONRequestBody defines body for ReverseTransaction for application/json ContentType.
+type ReverseTransactionJSONRequestBody = ReverseTransactionRequest
+
 // TransferBalanceJSONRequestBody defines body for TransferBalance for application/json ContentType.
 type TransferBalanceJSONRequestBody = TransferRequest
 
+// BatchTransferJSONRequestBody defines body for BatchTransfer for application/json ContentType.
+type BatchTransferJSONRequestBody = BatchTransferRequest
+
+// UpdateUserSettingsJSONRequestBody defines body for UpdateUserSettings for application/json ContentType.
+type UpdateUserSettingsJSONRequestBody = UpdateUserSettingsRequest
+
 // CreateWalletJSONRequestBody defines body for CreateWallet for application/json ContentType.
-type CreateWalletJSONRequestBody = WalletRequest
+type CreateWalletJSONRequestBody = CreateWalletRequest
 
 // UpdateWalletJSONRequestBody defines body for UpdateWallet for application/json ContentType.
 type UpdateWalletJSONRequestBody = WalletRequest
 
+// CloseWalletJSONRequestBody defines body for CloseWallet for application/json ContentType.
+type CloseWalletJSONRequestBody = CloseWalletRequest
+
 // WithdrawPointsJSONRequestBody defines body for WithdrawPoints for application/json ContentType.
 type WithdrawPointsJSONRequestBody = WithdrawRequest
@@ -0,0 +1,115 @@
+package restapis
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/utils"
+	"gorm.io/gorm"
+)
+
+// maxDepositImportFileSize is the largest upload accepted, in bytes.
+const maxDepositImportFileSize = 5 << 20
+
+// (POST /admin/deposit-imports)
+func (h *HttpServer) UploadDepositImport(ctx *gin.Context) {
+	file, header, err := ctx.Request.FormFile("file")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "File is required"})
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxDepositImportFileSize+1))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Failed to read import file"})
+		return
+	}
+	if len(content) > maxDepositImportFileSize {
+		ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Import file is too large"})
+		return
+	}
+
+	data, created, err := h.App.Commands.DepositImportService.HandleUpload(header.Filename, content)
+	if err != nil {
+		if errors.Is(err, consts.ErrImportFileEmpty) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Import file has no rows"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrImportFileMalformed) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Import file is not valid CSV"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrImportMissingColumns) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Import file must have wallet_id, amount and reference columns"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrImportTooManyRows) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Import file has too many rows"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrImportReferenceQueued) {
+			ctx.JSON(http.StatusConflict, api_gen.ErrorResponse{ErrorCode: "409", ErrorMessage: "Reference already queued by another import"})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, api_gen.ErrorResponse{ErrorCode: "500", ErrorMessage: "Failed to import deposits"})
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusAccepted
+	}
+	ctx.JSON(status, api_gen.DepositImportResponse{Data: data})
+}
+
+// (GET /admin/deposit-imports/{importId})
+func (h *HttpServer) GetDepositImport(ctx *gin.Context, importId string) {
+	data, err := h.App.Queries.GetDepositImportService.Handle(importId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, api_gen.ErrorResponse{ErrorCode: "404", ErrorMessage: "Deposit import not found"})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, api_gen.ErrorResponse{ErrorCode: "500", ErrorMessage: "Failed to get deposit import"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api_gen.DepositImportResponse{Data: data})
+}
+
+// (GET /admin/deposit-imports/{importId}/rows)
+func (h *HttpServer) ListDepositImportRows(ctx *gin.Context, importId string, params api_gen.ListDepositImportRowsParams) {
+	if !utils.ValidateRequestParams(ctx, params, h.App.Utils.Validate) {
+		return
+	}
+
+	var status *string
+	if params.Status != nil {
+		value := string(*params.Status)
+		status = &value
+	}
+
+	data, err := h.App.Queries.GetDepositImportService.HandleRows(importId, status)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, api_gen.ErrorResponse{ErrorCode: "404", ErrorMessage: "Deposit import not found"})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, api_gen.ErrorResponse{ErrorCode: "500", ErrorMessage: "Failed to list deposit import rows"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api_gen.DepositImportRowsResponse{Data: &data})
+}
@@ -0,0 +1,259 @@
+package restapis_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func (suite *RestApisTestSuite) TestUploadDepositImport() {
+	content := []byte("wallet_id,amount,reference\n<WalletID>,100,INV-1\n")
+	data := &api_gen.DepositImportResponseData{Id: "<ImportID>", Filename: "deposits.csv", Status: api_gen.DepositImportResponseDataStatusPending, TotalRows: 1}
+
+	testCases := []struct {
+		name        string
+		content     []byte
+		mock        func()
+		wantStatus  int
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:    "GivingNewFile_WhenUploadSuccess_ThenReturnAccepted",
+			content: content,
+			mock: func() {
+				suite.mockDepositImportService.EXPECT().HandleUpload("deposits.csv", content).Return(data, true, nil)
+			},
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name:    "GivingSameFileAgain_WhenUpload_ThenReturnExistingImport",
+			content: content,
+			mock: func() {
+				suite.mockDepositImportService.EXPECT().HandleUpload("deposits.csv", content).Return(data, false, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:        "GivingNoFile_WhenUpload_ThenReturnBadRequest",
+			mock:        func() {},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "File is required",
+		},
+		{
+			name:        "GivingOversizedFile_WhenUpload_ThenReturnBadRequest",
+			content:     bytes.Repeat([]byte("a"), 5<<20+1),
+			mock:        func() {},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Import file is too large",
+		},
+		{
+			name:    "GivingMissingColumns_WhenUpload_ThenReturnBadRequest",
+			content: content,
+			mock: func() {
+				suite.mockDepositImportService.EXPECT().HandleUpload(gomock.Any(), gomock.Any()).Return(nil, false, consts.ErrImportMissingColumns)
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Import file must have wallet_id, amount and reference columns",
+		},
+		{
+			name:    "GivingReferenceQueuedElsewhere_WhenUpload_ThenReturnConflict",
+			content: content,
+			mock: func() {
+				suite.mockDepositImportService.EXPECT().HandleUpload(gomock.Any(), gomock.Any()).Return(nil, false, consts.ErrImportReferenceQueued)
+			},
+			wantStatus:  http.StatusConflict,
+			wantErr:     true,
+			expectedErr: "Reference already queued by another import",
+		},
+		{
+			name:    "GivingFile_WhenUploadFail_ThenReturnInternalServerError",
+			content: content,
+			mock: func() {
+				suite.mockDepositImportService.EXPECT().HandleUpload(gomock.Any(), gomock.Any()).Return(nil, false, errors.New("fail"))
+			},
+			wantStatus:  http.StatusInternalServerError,
+			wantErr:     true,
+			expectedErr: "Failed to import deposits",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			var body bytes.Buffer
+			form := multipart.NewWriter(&body)
+			if tc.content != nil {
+				part, _ := form.CreateFormFile("file", "deposits.csv")
+				part.Write(tc.content)
+			}
+			form.Close()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/admin/deposit-imports", &body)
+			req.Header.Set("Content-Type", form.FormDataContentType())
+			suite.server.ServeHTTP(w, req)
+			suite.Equal(tc.wantStatus, w.Code)
+			if tc.wantErr {
+				var resp api_gen.ErrorResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal(strconv.Itoa(w.Code), resp.ErrorCode)
+				suite.Equal(tc.expectedErr, resp.ErrorMessage)
+			} else {
+				var resp api_gen.DepositImportResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal("<ImportID>", resp.Data.Id)
+			}
+		})
+	}
+}
+
+func (suite *RestApisTestSuite) TestGetDepositImport() {
+	testCases := []struct {
+		name        string
+		mock        func()
+		wantStatus  int
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivingImportId_WhenGetSuccess_ThenReturnOk",
+			mock: func() {
+				suite.mockGetDepositImportService.EXPECT().Handle("<ImportID>").Return(&api_gen.DepositImportResponseData{Id: "<ImportID>"}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "GivingUnknownImportId_WhenGet_ThenReturnNotFound",
+			mock: func() {
+				suite.mockGetDepositImportService.EXPECT().Handle("<ImportID>").Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus:  http.StatusNotFound,
+			wantErr:     true,
+			expectedErr: "Deposit import not found",
+		},
+		{
+			name: "GivingImportId_WhenGetFail_ThenReturnInternalServerError",
+			mock: func() {
+				suite.mockGetDepositImportService.EXPECT().Handle("<ImportID>").Return(nil, errors.New("fail"))
+			},
+			wantStatus:  http.StatusInternalServerError,
+			wantErr:     true,
+			expectedErr: "Failed to get deposit import",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/admin/deposit-imports/<ImportID>", nil)
+			suite.server.ServeHTTP(w, req)
+			suite.Equal(tc.wantStatus, w.Code)
+			if tc.wantErr {
+				var resp api_gen.ErrorResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal(strconv.Itoa(w.Code), resp.ErrorCode)
+				suite.Equal(tc.expectedErr, resp.ErrorMessage)
+			}
+		})
+	}
+}
+
+func (suite *RestApisTestSuite) TestListDepositImportRows() {
+	failed := "failed"
+
+	testCases := []struct {
+		name        string
+		path        string
+		mock        func()
+		wantStatus  int
+		wantErr     bool
+		expectedErr string
+		expectedLen int
+	}{
+		{
+			name: "GivingImportId_WhenListSuccess_ThenReturnRows",
+			path: "/admin/deposit-imports/<ImportID>/rows",
+			mock: func() {
+				suite.mockGetDepositImportService.EXPECT().HandleRows("<ImportID>", nil).Return([]api_gen.DepositImportRowData{
+					{RowNumber: 2, Status: api_gen.DepositImportRowDataStatusCompleted},
+					{RowNumber: 3, Status: api_gen.DepositImportRowDataStatusFailed},
+				}, nil)
+			},
+			wantStatus:  http.StatusOK,
+			expectedLen: 2,
+		},
+		{
+			name: "GivingStatusFilter_WhenListSuccess_ThenPassFilter",
+			path: "/admin/deposit-imports/<ImportID>/rows?status=failed",
+			mock: func() {
+				suite.mockGetDepositImportService.EXPECT().HandleRows("<ImportID>", &failed).Return([]api_gen.DepositImportRowData{
+					{RowNumber: 3, Status: api_gen.DepositImportRowDataStatusFailed},
+				}, nil)
+			},
+			wantStatus:  http.StatusOK,
+			expectedLen: 1,
+		},
+		{
+			name:        "GivingUnknownStatus_WhenList_ThenReturnBadRequest",
+			path:        "/admin/deposit-imports/<ImportID>/rows?status=done",
+			mock:        func() {},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Status oneof pending completed failed invalid skipped",
+		},
+		{
+			name: "GivingUnknownImportId_WhenList_ThenReturnNotFound",
+			path: "/admin/deposit-imports/<ImportID>/rows",
+			mock: func() {
+				suite.mockGetDepositImportService.EXPECT().HandleRows("<ImportID>", nil).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus:  http.StatusNotFound,
+			wantErr:     true,
+			expectedErr: "Deposit import not found",
+		},
+		{
+			name: "GivingImportId_WhenListFail_ThenReturnInternalServerError",
+			path: "/admin/deposit-imports/<ImportID>/rows",
+			mock: func() {
+				suite.mockGetDepositImportService.EXPECT().HandleRows("<ImportID>", nil).Return(nil, errors.New("fail"))
+			},
+			wantStatus:  http.StatusInternalServerError,
+			wantErr:     true,
+			expectedErr: "Failed to list deposit import rows",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tc.path, nil)
+			suite.server.ServeHTTP(w, req)
+			suite.Equal(tc.wantStatus, w.Code)
+			if tc.wantErr {
+				var resp api_gen.ErrorResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal(strconv.Itoa(w.Code), resp.ErrorCode)
+				suite.Equal(tc.expectedErr, resp.ErrorMessage)
+			} else {
+				var resp api_gen.DepositImportRowsResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Len(*resp.Data, tc.expectedLen)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package restapis
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/utils"
+)
+
+// (GET /secure/exchange-rates)
+func (h *HttpServer) ListExchangeRates(ctx *gin.Context) {
+
+	resp, err := h.App.Queries.ListExchangeRatesService.Handle()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, api_gen.ErrorResponse{ErrorCode: "500", ErrorMessage: "Failed to list exchange rates"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api_gen.ListExchangeRatesResponse{
+		Data: &resp,
+	})
+}
+
+// (PUT /admin/exchange-rates)
+func (h *HttpServer) LoadExchangeRates(ctx *gin.Context) {
+	var req api_gen.LoadExchangeRatesRequest
+	if !utils.BindAndValidateRequestBody(ctx, &req, h.App.Utils.Validate) {
+		return
+	}
+
+	if err := h.App.Commands.ExchangeRateService.HandleLoadRates(req); err != nil {
+		if errors.Is(err, consts.ErrUnsupportedCurrency) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Unsupported currency"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrInvalidExchangeRate) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Invalid exchange rate"})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, api_gen.ErrorResponse{ErrorCode: "500", ErrorMessage: "Failed to load exchange rates"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, nil)
+}
@@ -0,0 +1,150 @@
+package restapis_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/money"
+	"go.uber.org/mock/gomock"
+)
+
+func (suite *RestApisTestSuite) TestListExchangeRates() {
+	testCases := []struct {
+		name        string
+		mock        func()
+		wantStatus  int
+		wantErr     bool
+		expectedErr string
+		expectedLen int
+	}{
+		{
+			name: "GivingRates_WhenListSuccess_ThenReturnOk",
+			mock: func() {
+				suite.mockListExchangeRatesService.EXPECT().
+					Handle().
+					Return([]api_gen.ExchangeRateResponseData{
+						{BaseCurrency: "USD", QuoteCurrency: "THB", Rate: money.MustParseRate("35"), EffectiveRate: money.MustParseRate("35")},
+					}, nil)
+			},
+			wantStatus:  http.StatusOK,
+			wantErr:     false,
+			expectedLen: 1,
+		},
+		{
+			name: "GivingRates_WhenListFail_ThenReturnInternalServerError",
+			mock: func() {
+				suite.mockListExchangeRatesService.EXPECT().
+					Handle().
+					Return(nil, errors.New("fail"))
+			},
+			wantStatus:  http.StatusInternalServerError,
+			wantErr:     true,
+			expectedErr: "Failed to list exchange rates",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/secure/exchange-rates", nil)
+			suite.server.ServeHTTP(w, req)
+			suite.Equal(tc.wantStatus, w.Code)
+			if tc.wantErr {
+				var resp api_gen.ErrorResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal(strconv.Itoa(w.Code), resp.ErrorCode)
+				suite.Equal(tc.expectedErr, resp.ErrorMessage)
+			} else {
+				var resp api_gen.ListExchangeRatesResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Len(*resp.Data, tc.expectedLen)
+			}
+		})
+	}
+}
+
+func (suite *RestApisTestSuite) TestLoadExchangeRates() {
+	testCases := []struct {
+		name        string
+		reqBody     interface{}
+		mock        func()
+		wantStatus  int
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivingValidRequest_WhenLoadSuccess_ThenReturnOk",
+			reqBody: api_gen.LoadExchangeRatesRequest{
+				Rates: []api_gen.ExchangeRateRequest{
+					{BaseCurrency: "USD", QuoteCurrency: "THB", Rate: money.MustParseRate("35.5")},
+				},
+			},
+			mock: func() {
+				suite.mockExchangeRateService.EXPECT().HandleLoadRates(gomock.Any()).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantErr:    false,
+		},
+		{
+			name:        "GivingEmptyRates_WhenLoad_ThenReturnBadRequest",
+			reqBody:     api_gen.LoadExchangeRatesRequest{Rates: []api_gen.ExchangeRateRequest{}},
+			mock:        func() {},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Rates min 1",
+		},
+		{
+			name: "GivingInvalidRate_WhenLoad_ThenReturnBadRequest",
+			reqBody: api_gen.LoadExchangeRatesRequest{
+				Rates: []api_gen.ExchangeRateRequest{
+					{BaseCurrency: "THB", QuoteCurrency: "THB", Rate: money.MustParseRate("1")},
+				},
+			},
+			mock: func() {
+				suite.mockExchangeRateService.EXPECT().HandleLoadRates(gomock.Any()).Return(consts.ErrInvalidExchangeRate)
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Invalid exchange rate",
+		},
+		{
+			name: "GivingValidRequest_WhenLoadFail_ThenReturnInternalServerError",
+			reqBody: api_gen.LoadExchangeRatesRequest{
+				Rates: []api_gen.ExchangeRateRequest{
+					{BaseCurrency: "USD", QuoteCurrency: "THB", Rate: money.MustParseRate("35.5")},
+				},
+			},
+			mock: func() {
+				suite.mockExchangeRateService.EXPECT().HandleLoadRates(gomock.Any()).Return(errors.New("fail"))
+			},
+			wantStatus:  http.StatusInternalServerError,
+			wantErr:     true,
+			expectedErr: "Failed to load exchange rates",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			w := httptest.NewRecorder()
+			body, _ := json.Marshal(tc.reqBody)
+			req, _ := http.NewRequest("PUT", "/admin/exchange-rates", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			suite.server.ServeHTTP(w, req)
+			suite.Equal(tc.wantStatus, w.Code)
+			if tc.wantErr {
+				var resp api_gen.ErrorResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal(strconv.Itoa(w.Code), resp.ErrorCode)
+				suite.Equal(tc.expectedErr, resp.ErrorMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package restapis
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/utils"
+	"gorm.io/gorm"
+)
+
+// (POST /secure/fees/quote)
+func (h *HttpServer) QuoteFee(ctx *gin.Context) {
+	var req api_gen.FeeQuoteRequest
+	if !utils.BindAndValidateRequestBody(ctx, &req, h.App.Utils.Validate) {
+		return
+	}
+
+	userId := utils.GetMiddlewareUserId(ctx)
+
+	result, err := h.App.Commands.FeeService.HandleQuote(userId, req)
+	if err != nil {
+		if errors.Is(err, consts.ErrAmountScaleExceeded) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Amount exceeds wallet currency precision"})
+			return
+		}
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, api_gen.ErrorResponse{ErrorCode: "404", ErrorMessage: "Wallet not found"})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, api_gen.ErrorResponse{ErrorCode: "500", ErrorMessage: "Failed to quote fee"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api_gen.FeeQuoteResponse{Data: result})
+}
+
+// (PUT /admin/fees)
+func (h *HttpServer) LoadFeeRules(ctx *gin.Context) {
+	var req api_gen.LoadFeeRulesRequest
+	if !utils.BindAndValidateRequestBody(ctx, &req, h.App.Utils.Validate) {
+		return
+	}
+
+	if err := h.App.Commands.FeeService.HandleLoadRules(req); err != nil {
+		if errors.Is(err, consts.ErrUnsupportedCurrency) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Unsupported currency"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrAmountScaleExceeded) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Amount exceeds currency precision"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrInvalidFeeRule) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Invalid fee rule"})
+			return
+		}
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, api_gen.ErrorResponse{ErrorCode: "404", ErrorMessage: "Fee wallet not found"})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, api_gen.ErrorResponse{ErrorCode: "500", ErrorMessage: "Failed to load fee rules"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, nil)
+}
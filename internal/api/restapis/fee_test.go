@@ -0,0 +1,182 @@
+package restapis_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/money"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func (suite *RestApisTestSuite) TestQuoteFee() {
+	validBody := api_gen.FeeQuoteRequest{
+		TransactionType: api_gen.FeeQuoteRequestTransactionTypeTransfer,
+		WalletId:        "<WalletID>",
+		Amount:          money.MustParse("100"),
+	}
+
+	testCases := []struct {
+		name        string
+		reqBody     interface{}
+		mock        func()
+		wantStatus  int
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:    "GivingValidRequest_WhenQuoteSuccess_ThenReturnOk",
+			reqBody: validBody,
+			mock: func() {
+				suite.mockFeeService.EXPECT().HandleQuote("<UserID>", validBody).
+					Return(&api_gen.FeeQuoteResponseData{
+						TransactionType: "transfer",
+						WalletId:        "<WalletID>",
+						Currency:        "THB",
+						Amount:          money.MustParse("100"),
+						Fee:             money.MustParse("5"),
+						Total:           money.MustParse("105"),
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "GivingUnknownTransactionType_WhenQuote_ThenReturnBadRequest",
+			reqBody: map[string]interface{}{
+				"transactionType": "deposit",
+				"walletId":        "<WalletID>",
+				"amount":          100,
+			},
+			mock:        func() {},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "TransactionType oneof transfer withdraw",
+		},
+		{
+			name:    "GivingWalletOfAnotherUser_WhenQuote_ThenReturnNotFound",
+			reqBody: validBody,
+			mock: func() {
+				suite.mockFeeService.EXPECT().HandleQuote("<UserID>", gomock.Any()).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus:  http.StatusNotFound,
+			wantErr:     true,
+			expectedErr: "Wallet not found",
+		},
+		{
+			name:    "GivingValidRequest_WhenQuoteFail_ThenReturnInternalServerError",
+			reqBody: validBody,
+			mock: func() {
+				suite.mockFeeService.EXPECT().HandleQuote("<UserID>", gomock.Any()).Return(nil, errors.New("fail"))
+			},
+			wantStatus:  http.StatusInternalServerError,
+			wantErr:     true,
+			expectedErr: "Failed to quote fee",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			w := httptest.NewRecorder()
+			body, _ := json.Marshal(tc.reqBody)
+			req, _ := http.NewRequest("POST", "/secure/fees/quote", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			suite.server.ServeHTTP(w, req)
+			suite.Equal(tc.wantStatus, w.Code)
+			if tc.wantErr {
+				var resp api_gen.ErrorResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal(strconv.Itoa(w.Code), resp.ErrorCode)
+				suite.Equal(tc.expectedErr, resp.ErrorMessage)
+			} else {
+				var resp api_gen.FeeQuoteResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal(money.MustParse("105"), resp.Data.Total)
+			}
+		})
+	}
+}
+
+func (suite *RestApisTestSuite) TestLoadFeeRules() {
+	flatFee := money.MustParse("10")
+	validBody := api_gen.LoadFeeRulesRequest{
+		Rules: []api_gen.FeeRuleRequest{{
+			TransactionType: api_gen.FeeRuleRequestTransactionTypeWithdraw,
+			Currency:        "THB",
+			FlatFee:         &flatFee,
+			FeeWalletId:     "<FeeWalletID>",
+		}},
+	}
+
+	testCases := []struct {
+		name        string
+		reqBody     interface{}
+		mock        func()
+		wantStatus  int
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:    "GivingValidRequest_WhenLoadSuccess_ThenReturnOk",
+			reqBody: validBody,
+			mock: func() {
+				suite.mockFeeService.EXPECT().HandleLoadRules(validBody).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:    "GivingInvalidRule_WhenLoad_ThenReturnBadRequest",
+			reqBody: validBody,
+			mock: func() {
+				suite.mockFeeService.EXPECT().HandleLoadRules(gomock.Any()).Return(consts.ErrInvalidFeeRule)
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Invalid fee rule",
+		},
+		{
+			name:    "GivingUnknownFeeWallet_WhenLoad_ThenReturnNotFound",
+			reqBody: validBody,
+			mock: func() {
+				suite.mockFeeService.EXPECT().HandleLoadRules(gomock.Any()).Return(gorm.ErrRecordNotFound)
+			},
+			wantStatus:  http.StatusNotFound,
+			wantErr:     true,
+			expectedErr: "Fee wallet not found",
+		},
+		{
+			name:    "GivingValidRequest_WhenLoadFail_ThenReturnInternalServerError",
+			reqBody: validBody,
+			mock: func() {
+				suite.mockFeeService.EXPECT().HandleLoadRules(gomock.Any()).Return(errors.New("fail"))
+			},
+			wantStatus:  http.StatusInternalServerError,
+			wantErr:     true,
+			expectedErr: "Failed to load fee rules",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			w := httptest.NewRecorder()
+			body, _ := json.Marshal(tc.reqBody)
+			req, _ := http.NewRequest("PUT", "/admin/fees", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			suite.server.ServeHTTP(w, req)
+			suite.Equal(tc.wantStatus, w.Code)
+			if tc.wantErr {
+				var resp api_gen.ErrorResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal(strconv.Itoa(w.Code), resp.ErrorCode)
+				suite.Equal(tc.expectedErr, resp.ErrorMessage)
+			}
+		})
+	}
+}
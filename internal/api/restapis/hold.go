@@ -0,0 +1,149 @@
+package restapis
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/utils"
+	"gorm.io/gorm"
+)
+
+// (POST /secure/hold)
+func (h *HttpServer) CreateHold(ctx *gin.Context) {
+	var req api_gen.CreateHoldRequest
+	if !utils.BindAndValidateRequestBody(ctx, &req, h.App.Utils.Validate) {
+		return
+	}
+
+	if req.WalletId == req.ToWalletId {
+		ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Hold wallet and destination wallet cannot be the same"})
+		return
+	}
+
+	userId := utils.GetMiddlewareUserId(ctx)
+
+	result, err := h.App.Commands.HoldService.HandleCreate(userId, req)
+	if err != nil {
+		if errors.Is(err, consts.ErrInvalidHoldExpiry) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Hold must expire in the future and within 30 days"})
+			return
+		}
+
+		var blockedErr *consts.WalletBlockedError
+		if errors.As(err, &blockedErr) {
+			ctx.JSON(http.StatusLocked, walletBlockedResponse(blockedErr))
+			return
+		}
+
+		if errors.Is(err, consts.ErrInsufficientBalance) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Insufficient available balance"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrAmountScaleExceeded) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Amount exceeds wallet currency precision"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrWalletClosed) {
+			ctx.JSON(http.StatusConflict, api_gen.ErrorResponse{ErrorCode: "409", ErrorMessage: "Wallet is closed"})
+			return
+		}
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, api_gen.ErrorResponse{ErrorCode: "404", ErrorMessage: "Wallet not found"})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, api_gen.ErrorResponse{ErrorCode: "500", ErrorMessage: "Fail to create hold"})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, api_gen.HoldResponse{Data: result})
+}
+
+// (POST /secure/hold/{holdId}/capture)
+func (h *HttpServer) CaptureHold(ctx *gin.Context, holdId string) {
+	var req api_gen.CaptureHoldRequest
+	if !utils.BindAndValidateRequestBody(ctx, &req, h.App.Utils.Validate) {
+		return
+	}
+
+	userId := utils.GetMiddlewareUserId(ctx)
+
+	result, err := h.App.Commands.HoldService.HandleCapture(userId, holdId, req.Amount)
+	if err != nil {
+		if errors.Is(err, consts.ErrHoldNotActive) {
+			ctx.JSON(http.StatusConflict, api_gen.ErrorResponse{ErrorCode: "409", ErrorMessage: "Hold is no longer active"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrCaptureExceedsHold) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Capture exceeds held amount"})
+			return
+		}
+
+		var blockedErr *consts.WalletBlockedError
+		if errors.As(err, &blockedErr) {
+			ctx.JSON(http.StatusLocked, walletBlockedResponse(blockedErr))
+			return
+		}
+
+		var mismatchErr *consts.CurrencyMismatchError
+		if errors.As(err, &mismatchErr) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "No exchange rate from " + mismatchErr.FromCurrency + " to " + mismatchErr.ToCurrency})
+			return
+		}
+
+		if errors.Is(err, consts.ErrAmountScaleExceeded) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Amount exceeds wallet currency precision"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrConvertedAmountTooSmall) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Converted amount is too small"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrWalletClosed) {
+			ctx.JSON(http.StatusConflict, api_gen.ErrorResponse{ErrorCode: "409", ErrorMessage: "Wallet is closed"})
+			return
+		}
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, api_gen.ErrorResponse{ErrorCode: "404", ErrorMessage: "Hold not found"})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, api_gen.ErrorResponse{ErrorCode: "500", ErrorMessage: "Fail to capture hold"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api_gen.TransactionResponse{Data: result})
+}
+
+// (POST /secure/hold/{holdId}/void)
+func (h *HttpServer) VoidHold(ctx *gin.Context, holdId string) {
+	userId := utils.GetMiddlewareUserId(ctx)
+
+	result, err := h.App.Commands.HoldService.HandleVoid(userId, holdId)
+	if err != nil {
+		if errors.Is(err, consts.ErrHoldNotActive) {
+			ctx.JSON(http.StatusConflict, api_gen.ErrorResponse{ErrorCode: "409", ErrorMessage: "Hold is no longer active"})
+			return
+		}
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, api_gen.ErrorResponse{ErrorCode: "404", ErrorMessage: "Hold not found"})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, api_gen.ErrorResponse{ErrorCode: "500", ErrorMessage: "Fail to void hold"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api_gen.HoldResponse{Data: result})
+}
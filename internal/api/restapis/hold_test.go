@@ -0,0 +1,218 @@
+package restapis_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/money"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func (suite *RestApisTestSuite) TestCreateHold() {
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	testCases := []struct {
+		name        string
+		reqBody     api_gen.CreateHoldRequest
+		mock        func()
+		wantStatus  int
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:    "GivingValidRequest_WhenCreateSuccess_ThenReturnCreated",
+			reqBody: api_gen.CreateHoldRequest{WalletId: "<WalletID>", ToWalletId: "<ToWalletID>", Amount: money.MustParse("100"), ExpiresAt: expiresAt},
+			mock: func() {
+				suite.mockHoldService.EXPECT().
+					HandleCreate("<UserID>", gomock.Any()).
+					Return(&api_gen.HoldResponseData{Id: "<HoldID>", Status: "active"}, nil)
+			},
+			wantStatus: http.StatusCreated,
+			wantErr:    false,
+		},
+		{
+			name:        "GivingSameWallets_WhenCreate_ThenReturnBadRequest",
+			reqBody:     api_gen.CreateHoldRequest{WalletId: "<WalletID>", ToWalletId: "<WalletID>", Amount: money.MustParse("100"), ExpiresAt: expiresAt},
+			mock:        func() {},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Hold wallet and destination wallet cannot be the same",
+		},
+		{
+			name:    "GivingValidRequest_WhenInsufficientBalance_ThenReturnBadRequest",
+			reqBody: api_gen.CreateHoldRequest{WalletId: "<WalletID>", ToWalletId: "<ToWalletID>", Amount: money.MustParse("100"), ExpiresAt: expiresAt},
+			mock: func() {
+				suite.mockHoldService.EXPECT().
+					HandleCreate("<UserID>", gomock.Any()).
+					Return(nil, consts.ErrInsufficientBalance)
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Insufficient available balance",
+		},
+		{
+			name:    "GivingUnknownWallet_WhenCreate_ThenReturnNotFound",
+			reqBody: api_gen.CreateHoldRequest{WalletId: "<WalletID>", ToWalletId: "<ToWalletID>", Amount: money.MustParse("100"), ExpiresAt: expiresAt},
+			mock: func() {
+				suite.mockHoldService.EXPECT().
+					HandleCreate("<UserID>", gomock.Any()).
+					Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus:  http.StatusNotFound,
+			wantErr:     true,
+			expectedErr: "Wallet not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			w := httptest.NewRecorder()
+			body, _ := json.Marshal(tc.reqBody)
+			req, _ := http.NewRequest("POST", "/secure/hold", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			suite.server.ServeHTTP(w, req)
+			suite.Equal(tc.wantStatus, w.Code)
+			if tc.wantErr {
+				var resp api_gen.ErrorResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal(strconv.Itoa(w.Code), resp.ErrorCode)
+				suite.Equal(tc.expectedErr, resp.ErrorMessage)
+			}
+		})
+	}
+}
+
+func (suite *RestApisTestSuite) TestCaptureHold() {
+	partial := money.MustParse("40")
+
+	testCases := []struct {
+		name        string
+		reqBody     api_gen.CaptureHoldRequest
+		mock        func()
+		wantStatus  int
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:    "GivingPartialAmount_WhenCaptureSuccess_ThenReturnOk",
+			reqBody: api_gen.CaptureHoldRequest{Amount: &partial},
+			mock: func() {
+				suite.mockHoldService.EXPECT().
+					HandleCapture("<UserID>", "<HoldID>", &partial).
+					Return(&api_gen.TransactionResponseData{Id: "<TransactionID>"}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantErr:    false,
+		},
+		{
+			name:    "GivingEmptyBody_WhenHoldNotActive_ThenReturnConflict",
+			reqBody: api_gen.CaptureHoldRequest{},
+			mock: func() {
+				suite.mockHoldService.EXPECT().
+					HandleCapture("<UserID>", "<HoldID>", nil).
+					Return(nil, consts.ErrHoldNotActive)
+			},
+			wantStatus:  http.StatusConflict,
+			wantErr:     true,
+			expectedErr: "Hold is no longer active",
+		},
+		{
+			name:    "GivingPartialAmount_WhenCaptureExceedsHold_ThenReturnBadRequest",
+			reqBody: api_gen.CaptureHoldRequest{Amount: &partial},
+			mock: func() {
+				suite.mockHoldService.EXPECT().
+					HandleCapture("<UserID>", "<HoldID>", &partial).
+					Return(nil, consts.ErrCaptureExceedsHold)
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Capture exceeds held amount",
+		},
+		{
+			name:    "GivingUnknownHold_WhenCapture_ThenReturnNotFound",
+			reqBody: api_gen.CaptureHoldRequest{},
+			mock: func() {
+				suite.mockHoldService.EXPECT().
+					HandleCapture("<UserID>", "<HoldID>", nil).
+					Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus:  http.StatusNotFound,
+			wantErr:     true,
+			expectedErr: "Hold not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			w := httptest.NewRecorder()
+			body, _ := json.Marshal(tc.reqBody)
+			req, _ := http.NewRequest("POST", "/secure/hold/<HoldID>/capture", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			suite.server.ServeHTTP(w, req)
+			suite.Equal(tc.wantStatus, w.Code)
+			if tc.wantErr {
+				var resp api_gen.ErrorResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal(strconv.Itoa(w.Code), resp.ErrorCode)
+				suite.Equal(tc.expectedErr, resp.ErrorMessage)
+			}
+		})
+	}
+}
+
+func (suite *RestApisTestSuite) TestVoidHold() {
+	testCases := []struct {
+		name        string
+		mock        func()
+		wantStatus  int
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivingActiveHold_WhenVoidSuccess_ThenReturnOk",
+			mock: func() {
+				suite.mockHoldService.EXPECT().
+					HandleVoid("<UserID>", "<HoldID>").
+					Return(&api_gen.HoldResponseData{Id: "<HoldID>", Status: "voided"}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantErr:    false,
+		},
+		{
+			name: "GivingCapturedHold_WhenVoid_ThenReturnConflict",
+			mock: func() {
+				suite.mockHoldService.EXPECT().
+					HandleVoid("<UserID>", "<HoldID>").
+					Return(nil, consts.ErrHoldNotActive)
+			},
+			wantStatus:  http.StatusConflict,
+			wantErr:     true,
+			expectedErr: "Hold is no longer active",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/secure/hold/<HoldID>/void", nil)
+			suite.server.ServeHTTP(w, req)
+			suite.Equal(tc.wantStatus, w.Code)
+			if tc.wantErr {
+				var resp api_gen.ErrorResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal(strconv.Itoa(w.Code), resp.ErrorCode)
+				suite.Equal(tc.expectedErr, resp.ErrorMessage)
+			}
+		})
+	}
+}
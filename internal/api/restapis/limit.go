@@ -0,0 +1,51 @@
+package restapis
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/utils"
+	"gorm.io/gorm"
+)
+
+// (PUT /admin/limits)
+func (h *HttpServer) LoadGlobalLimits(ctx *gin.Context) {
+	h.loadLimits(ctx, nil)
+}
+
+// (PUT /admin/users/{userId}/limits)
+func (h *HttpServer) LoadUserLimits(ctx *gin.Context, userId string) {
+	h.loadLimits(ctx, &userId)
+}
+
+func (h *HttpServer) loadLimits(ctx *gin.Context, userId *string) {
+	var req api_gen.LoadLimitsRequest
+	if !utils.BindAndValidateRequestBody(ctx, &req, h.App.Utils.Validate) {
+		return
+	}
+
+	if err := h.App.Commands.LimitService.HandleLoadLimits(userId, req); err != nil {
+		if errors.Is(err, consts.ErrUnsupportedCurrency) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Unsupported currency"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrInvalidLimit) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Each currency may appear once, with maxTransfers and transferWindowSeconds set together"})
+			return
+		}
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, api_gen.ErrorResponse{ErrorCode: "404", ErrorMessage: "User not found"})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, api_gen.ErrorResponse{ErrorCode: "500", ErrorMessage: "Failed to load limits"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, nil)
+}
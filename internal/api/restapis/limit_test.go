@@ -0,0 +1,176 @@
+package restapis_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/services/commands"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func (suite *RestApisTestSuite) TestLoadLimits() {
+	dailyMax := money.MustParse("1000")
+	validBody := api_gen.LoadLimitsRequest{
+		Limits: []api_gen.LimitRequest{{Currency: "THB", DailyWalletMax: &dailyMax}},
+	}
+	userId := "<UserID>"
+
+	testCases := []struct {
+		name        string
+		path        string
+		reqBody     interface{}
+		mock        func()
+		wantStatus  int
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:    "GivingValidRequest_WhenLoadGlobalSuccess_ThenReturnOk",
+			path:    "/admin/limits",
+			reqBody: validBody,
+			mock: func() {
+				suite.mockLimitService.EXPECT().HandleLoadLimits(nil, validBody).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:    "GivingValidRequest_WhenLoadUserSuccess_ThenReturnOk",
+			path:    "/admin/users/<UserID>/limits",
+			reqBody: validBody,
+			mock: func() {
+				suite.mockLimitService.EXPECT().HandleLoadLimits(&userId, validBody).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:        "GivingMissingCurrency_WhenLoad_ThenReturnBadRequest",
+			path:        "/admin/limits",
+			reqBody:     api_gen.LoadLimitsRequest{Limits: []api_gen.LimitRequest{{DailyWalletMax: &dailyMax}}},
+			mock:        func() {},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Currency required",
+		},
+		{
+			name:    "GivingUnsupportedCurrency_WhenLoad_ThenReturnBadRequest",
+			path:    "/admin/limits",
+			reqBody: validBody,
+			mock: func() {
+				suite.mockLimitService.EXPECT().HandleLoadLimits(nil, gomock.Any()).Return(consts.ErrUnsupportedCurrency)
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Unsupported currency",
+		},
+		{
+			name:    "GivingUnknownUser_WhenLoadUser_ThenReturnNotFound",
+			path:    "/admin/users/<UserID>/limits",
+			reqBody: validBody,
+			mock: func() {
+				suite.mockLimitService.EXPECT().HandleLoadLimits(&userId, gomock.Any()).Return(gorm.ErrRecordNotFound)
+			},
+			wantStatus:  http.StatusNotFound,
+			wantErr:     true,
+			expectedErr: "User not found",
+		},
+		{
+			name:    "GivingValidRequest_WhenLoadFail_ThenReturnInternalServerError",
+			path:    "/admin/limits",
+			reqBody: validBody,
+			mock: func() {
+				suite.mockLimitService.EXPECT().HandleLoadLimits(nil, gomock.Any()).Return(errors.New("fail"))
+			},
+			wantStatus:  http.StatusInternalServerError,
+			wantErr:     true,
+			expectedErr: "Failed to load limits",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			w := httptest.NewRecorder()
+			body, _ := json.Marshal(tc.reqBody)
+			req, _ := http.NewRequest("PUT", tc.path, bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			suite.server.ServeHTTP(w, req)
+			suite.Equal(tc.wantStatus, w.Code)
+			if tc.wantErr {
+				var resp api_gen.ErrorResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal(strconv.Itoa(w.Code), resp.ErrorCode)
+				suite.Equal(tc.expectedErr, resp.ErrorMessage)
+			}
+		})
+	}
+}
+
+func (suite *RestApisTestSuite) TestLimitExceeded() {
+	resetsAt := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	toWallet := "<Wallet2>"
+
+	testCases := []struct {
+		name    string
+		path    string
+		reqBody interface{}
+		mock    func()
+		limit   api_gen.LimitExceededErrorLimit
+	}{
+		{
+			name: "GivingDailyCapReached_WhenTransferBalance_ThenReturnForbidden",
+			path: "/secure/transfer",
+			reqBody: api_gen.TransferRequest{
+				FromWalletId: "<Wallet1>",
+				ToWalletId:   &toWallet,
+				Amount:       money.MustParse("100"),
+			},
+			mock: func() {
+				suite.mockTransactionService.EXPECT().
+					HandleTransferBalance("<UserID>", "<Wallet1>", "<Wallet2>", money.MustParse("100"), commands.TransactionDetails{}, nil).
+					Return(nil, &consts.LimitExceededError{Limit: consts.LimitDailyWallet, ResetsAt: &resetsAt})
+			},
+			limit: api_gen.DailyWalletMax,
+		},
+		{
+			name: "GivingMonthlyCapReached_WhenWithdrawPoints_ThenReturnForbidden",
+			path: "/secure/withdraw",
+			reqBody: api_gen.WithdrawRequest{
+				WalletId: "<Wallet1>",
+				Amount:   money.MustParse("100"),
+			},
+			mock: func() {
+				suite.mockTransactionService.EXPECT().
+					HandleDepositWithDrawBalance("<UserID>", "<Wallet1>", money.MustParse("-100"), commands.TransactionDetails{}, nil).
+					Return(nil, &consts.LimitExceededError{Limit: consts.LimitMonthlyUser, ResetsAt: &resetsAt})
+			},
+			limit: api_gen.MonthlyUserMax,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			w := httptest.NewRecorder()
+			body, _ := json.Marshal(tc.reqBody)
+			req, _ := http.NewRequest("POST", tc.path, bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			suite.server.ServeHTTP(w, req)
+			suite.Equal(http.StatusForbidden, w.Code)
+
+			var resp api_gen.LimitExceededError
+			json.Unmarshal(w.Body.Bytes(), &resp)
+			suite.Equal("LIMIT_EXCEEDED", resp.ErrorCode)
+			suite.Equal(tc.limit, resp.Limit)
+			suite.True(resetsAt.Equal(*resp.ResetsAt))
+		})
+	}
+}
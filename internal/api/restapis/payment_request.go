@@ -0,0 +1,139 @@
+package restapis
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/utils"
+	"gorm.io/gorm"
+)
+
+// (POST /secure/requests)
+func (h *HttpServer) CreatePaymentRequest(ctx *gin.Context) {
+	var req api_gen.CreatePaymentRequestRequest
+	if !utils.BindAndValidateRequestBody(ctx, &req, h.App.Utils.Validate) {
+		return
+	}
+
+	userId := utils.GetMiddlewareUserId(ctx)
+
+	result, err := h.App.Commands.PaymentRequestService.HandleCreate(userId, req)
+	if err != nil {
+		if errors.Is(err, consts.ErrInvalidPaymentRequestExpiry) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Payment request must expire in the future and within 30 days"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrPaymentRequestToSelf) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Cannot request payment from yourself"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrAmountScaleExceeded) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Amount exceeds wallet currency precision"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrPayerNotFound) {
+			ctx.JSON(http.StatusNotFound, api_gen.ErrorResponse{ErrorCode: "404", ErrorMessage: "Payer not found"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrWalletClosed) {
+			ctx.JSON(http.StatusConflict, api_gen.ErrorResponse{ErrorCode: "409", ErrorMessage: "Wallet is closed"})
+			return
+		}
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, api_gen.ErrorResponse{ErrorCode: "404", ErrorMessage: "Wallet not found"})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, api_gen.ErrorResponse{ErrorCode: "500", ErrorMessage: "Fail to create payment request"})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, api_gen.PaymentRequestResponse{Data: result})
+}
+
+// (GET /secure/requests)
+func (h *HttpServer) ListPaymentRequests(ctx *gin.Context, params api_gen.ListPaymentRequestsParams) {
+	userId := utils.GetMiddlewareUserId(ctx)
+
+	incoming := params.Direction == nil || *params.Direction == api_gen.Incoming
+	result, err := h.App.Queries.ListPaymentRequestsService.Handle(userId, incoming)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, api_gen.ErrorResponse{ErrorCode: "500", ErrorMessage: "Failed to list payment requests"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api_gen.ListPaymentRequestsResponse{Data: &result})
+}
+
+// (POST /secure/requests/{requestId}/accept)
+func (h *HttpServer) AcceptPaymentRequest(ctx *gin.Context, requestId string) {
+	var req api_gen.AcceptPaymentRequestRequest
+	if !utils.BindAndValidateRequestBody(ctx, &req, h.App.Utils.Validate) {
+		return
+	}
+
+	userId := utils.GetMiddlewareUserId(ctx)
+
+	result, err := h.App.Commands.PaymentRequestService.HandleAccept(userId, requestId, req.FromWalletId)
+	if err != nil {
+		if errors.Is(err, consts.ErrPaymentRequestNotPending) {
+			ctx.JSON(http.StatusConflict, api_gen.ErrorResponse{ErrorCode: "409", ErrorMessage: "Payment request is no longer pending"})
+			return
+		}
+
+		var limitErr *consts.LimitExceededError
+		if errors.As(err, &limitErr) {
+			ctx.JSON(http.StatusForbidden, limitExceededResponse(limitErr))
+			return
+		}
+
+		var blockedErr *consts.WalletBlockedError
+		if errors.As(err, &blockedErr) {
+			ctx.JSON(http.StatusLocked, walletBlockedResponse(blockedErr))
+			return
+		}
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, api_gen.ErrorResponse{ErrorCode: "404", ErrorMessage: "Payment request or wallet not found"})
+			return
+		}
+
+		status, message := transferFailure(err)
+		ctx.JSON(status, api_gen.ErrorResponse{ErrorCode: strconv.Itoa(status), ErrorMessage: message})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api_gen.PaymentRequestResponse{Data: result})
+}
+
+// (POST /secure/requests/{requestId}/decline)
+func (h *HttpServer) DeclinePaymentRequest(ctx *gin.Context, requestId string) {
+	userId := utils.GetMiddlewareUserId(ctx)
+
+	result, err := h.App.Commands.PaymentRequestService.HandleDecline(userId, requestId)
+	if err != nil {
+		if errors.Is(err, consts.ErrPaymentRequestNotPending) {
+			ctx.JSON(http.StatusConflict, api_gen.ErrorResponse{ErrorCode: "409", ErrorMessage: "Payment request is no longer pending"})
+			return
+		}
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, api_gen.ErrorResponse{ErrorCode: "404", ErrorMessage: "Payment request not found"})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, api_gen.ErrorResponse{ErrorCode: "500", ErrorMessage: "Fail to decline payment request"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api_gen.PaymentRequestResponse{Data: result})
+}
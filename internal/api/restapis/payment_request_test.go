@@ -0,0 +1,299 @@
+package restapis_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/money"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func (suite *RestApisTestSuite) TestCreatePaymentRequest() {
+	testCases := []struct {
+		name        string
+		reqBody     api_gen.CreatePaymentRequestRequest
+		mock        func()
+		wantStatus  int
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:    "GivingValidRequest_WhenCreateSuccess_ThenReturnCreated",
+			reqBody: api_gen.CreatePaymentRequestRequest{PayerEmail: "payer@example.com", ToWalletId: "<ToWalletID>", Amount: money.MustParse("25")},
+			mock: func() {
+				suite.mockPaymentRequestService.EXPECT().
+					HandleCreate("<UserID>", gomock.Any()).
+					Return(&api_gen.PaymentRequestResponseData{Id: "<RequestID>", Status: "pending"}, nil)
+			},
+			wantStatus: http.StatusCreated,
+			wantErr:    false,
+		},
+		{
+			name:        "GivingInvalidEmail_WhenCreate_ThenReturnBadRequest",
+			reqBody:     api_gen.CreatePaymentRequestRequest{PayerEmail: "not-an-email", ToWalletId: "<ToWalletID>", Amount: money.MustParse("25")},
+			mock:        func() {},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "PayerEmail email",
+		},
+		{
+			name:    "GivingUnknownPayer_WhenCreate_ThenReturnNotFound",
+			reqBody: api_gen.CreatePaymentRequestRequest{PayerEmail: "unknown@example.com", ToWalletId: "<ToWalletID>", Amount: money.MustParse("25")},
+			mock: func() {
+				suite.mockPaymentRequestService.EXPECT().
+					HandleCreate("<UserID>", gomock.Any()).
+					Return(nil, consts.ErrPayerNotFound)
+			},
+			wantStatus:  http.StatusNotFound,
+			wantErr:     true,
+			expectedErr: "Payer not found",
+		},
+		{
+			name:    "GivingOwnEmail_WhenCreate_ThenReturnBadRequest",
+			reqBody: api_gen.CreatePaymentRequestRequest{PayerEmail: "me@example.com", ToWalletId: "<ToWalletID>", Amount: money.MustParse("25")},
+			mock: func() {
+				suite.mockPaymentRequestService.EXPECT().
+					HandleCreate("<UserID>", gomock.Any()).
+					Return(nil, consts.ErrPaymentRequestToSelf)
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Cannot request payment from yourself",
+		},
+		{
+			name:    "GivingUnknownWallet_WhenCreate_ThenReturnNotFound",
+			reqBody: api_gen.CreatePaymentRequestRequest{PayerEmail: "payer@example.com", ToWalletId: "<ToWalletID>", Amount: money.MustParse("25")},
+			mock: func() {
+				suite.mockPaymentRequestService.EXPECT().
+					HandleCreate("<UserID>", gomock.Any()).
+					Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus:  http.StatusNotFound,
+			wantErr:     true,
+			expectedErr: "Wallet not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			w := httptest.NewRecorder()
+			body, _ := json.Marshal(tc.reqBody)
+			req, _ := http.NewRequest("POST", "/secure/requests", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			suite.server.ServeHTTP(w, req)
+			suite.Equal(tc.wantStatus, w.Code)
+			if tc.wantErr {
+				var resp api_gen.ErrorResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal(strconv.Itoa(w.Code), resp.ErrorCode)
+				suite.Equal(tc.expectedErr, resp.ErrorMessage)
+			}
+		})
+	}
+}
+
+func (suite *RestApisTestSuite) TestListPaymentRequests() {
+	testCases := []struct {
+		name        string
+		query       string
+		mock        func()
+		wantStatus  int
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:  "GivingNoDirection_WhenList_ThenReturnIncoming",
+			query: "",
+			mock: func() {
+				suite.mockListPaymentRequestsService.EXPECT().
+					Handle("<UserID>", true).
+					Return([]api_gen.PaymentRequestResponseData{{Id: "<RequestID>"}}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantErr:    false,
+		},
+		{
+			name:  "GivingOutgoingDirection_WhenList_ThenReturnOutgoing",
+			query: "?direction=outgoing",
+			mock: func() {
+				suite.mockListPaymentRequestsService.EXPECT().
+					Handle("<UserID>", false).
+					Return([]api_gen.PaymentRequestResponseData{}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantErr:    false,
+		},
+		{
+			name:  "GivingUser_WhenListFails_ThenReturnInternalServerError",
+			query: "?direction=incoming",
+			mock: func() {
+				suite.mockListPaymentRequestsService.EXPECT().
+					Handle("<UserID>", true).
+					Return(nil, errors.New("list error"))
+			},
+			wantStatus:  http.StatusInternalServerError,
+			wantErr:     true,
+			expectedErr: "Failed to list payment requests",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/secure/requests"+tc.query, nil)
+			suite.server.ServeHTTP(w, req)
+			suite.Equal(tc.wantStatus, w.Code)
+			if tc.wantErr {
+				var resp api_gen.ErrorResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal(strconv.Itoa(w.Code), resp.ErrorCode)
+				suite.Equal(tc.expectedErr, resp.ErrorMessage)
+			}
+		})
+	}
+}
+
+func (suite *RestApisTestSuite) TestAcceptPaymentRequest() {
+	testCases := []struct {
+		name        string
+		reqBody     api_gen.AcceptPaymentRequestRequest
+		mock        func()
+		wantStatus  int
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:    "GivingPendingRequest_WhenAcceptSuccess_ThenReturnOk",
+			reqBody: api_gen.AcceptPaymentRequestRequest{FromWalletId: "<FromWalletID>"},
+			mock: func() {
+				suite.mockPaymentRequestService.EXPECT().
+					HandleAccept("<UserID>", "<RequestID>", "<FromWalletID>").
+					Return(&api_gen.PaymentRequestResponseData{Id: "<RequestID>", Status: "accepted"}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantErr:    false,
+		},
+		{
+			name:    "GivingDeclinedRequest_WhenAccept_ThenReturnConflict",
+			reqBody: api_gen.AcceptPaymentRequestRequest{FromWalletId: "<FromWalletID>"},
+			mock: func() {
+				suite.mockPaymentRequestService.EXPECT().
+					HandleAccept("<UserID>", "<RequestID>", "<FromWalletID>").
+					Return(nil, consts.ErrPaymentRequestNotPending)
+			},
+			wantStatus:  http.StatusConflict,
+			wantErr:     true,
+			expectedErr: "Payment request is no longer pending",
+		},
+		{
+			name:    "GivingUnknownRequest_WhenAccept_ThenReturnNotFound",
+			reqBody: api_gen.AcceptPaymentRequestRequest{FromWalletId: "<FromWalletID>"},
+			mock: func() {
+				suite.mockPaymentRequestService.EXPECT().
+					HandleAccept("<UserID>", "<RequestID>", "<FromWalletID>").
+					Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus:  http.StatusNotFound,
+			wantErr:     true,
+			expectedErr: "Payment request or wallet not found",
+		},
+		{
+			name:    "GivingPendingRequest_WhenInsufficientBalance_ThenReturnBadRequest",
+			reqBody: api_gen.AcceptPaymentRequestRequest{FromWalletId: "<FromWalletID>"},
+			mock: func() {
+				suite.mockPaymentRequestService.EXPECT().
+					HandleAccept("<UserID>", "<RequestID>", "<FromWalletID>").
+					Return(nil, consts.ErrInsufficientBalance)
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Insufficient balance",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			w := httptest.NewRecorder()
+			body, _ := json.Marshal(tc.reqBody)
+			req, _ := http.NewRequest("POST", "/secure/requests/<RequestID>/accept", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			suite.server.ServeHTTP(w, req)
+			suite.Equal(tc.wantStatus, w.Code)
+			if tc.wantErr {
+				var resp api_gen.ErrorResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal(strconv.Itoa(w.Code), resp.ErrorCode)
+				suite.Equal(tc.expectedErr, resp.ErrorMessage)
+			}
+		})
+	}
+}
+
+func (suite *RestApisTestSuite) TestDeclinePaymentRequest() {
+	testCases := []struct {
+		name        string
+		mock        func()
+		wantStatus  int
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivingPendingRequest_WhenDeclineSuccess_ThenReturnOk",
+			mock: func() {
+				suite.mockPaymentRequestService.EXPECT().
+					HandleDecline("<UserID>", "<RequestID>").
+					Return(&api_gen.PaymentRequestResponseData{Id: "<RequestID>", Status: "declined"}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantErr:    false,
+		},
+		{
+			name: "GivingExpiredRequest_WhenDecline_ThenReturnConflict",
+			mock: func() {
+				suite.mockPaymentRequestService.EXPECT().
+					HandleDecline("<UserID>", "<RequestID>").
+					Return(nil, consts.ErrPaymentRequestNotPending)
+			},
+			wantStatus:  http.StatusConflict,
+			wantErr:     true,
+			expectedErr: "Payment request is no longer pending",
+		},
+		{
+			name: "GivingUnknownRequest_WhenDecline_ThenReturnNotFound",
+			mock: func() {
+				suite.mockPaymentRequestService.EXPECT().
+					HandleDecline("<UserID>", "<RequestID>").
+					Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus:  http.StatusNotFound,
+			wantErr:     true,
+			expectedErr: "Payment request not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/secure/requests/<RequestID>/decline", nil)
+			suite.server.ServeHTTP(w, req)
+			suite.Equal(tc.wantStatus, w.Code)
+			if tc.wantErr {
+				var resp api_gen.ErrorResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal(strconv.Itoa(w.Code), resp.ErrorCode)
+				suite.Equal(tc.expectedErr, resp.ErrorMessage)
+			}
+		})
+	}
+}
@@ -16,25 +16,57 @@ import (
 
 type RestApisTestSuite struct {
 	suite.Suite
-	server                 *gin.Engine
-	mockRegisterService    *mock_commands.MockRegisterService
-	mockTransactionService *mock_commands.MockTransactionService
-	mockWalletService      *mock_commands.MockWalletService
+	server                    *gin.Engine
+	mockRegisterService       *mock_commands.MockRegisterService
+	mockTransactionService    *mock_commands.MockTransactionService
+	mockWalletService         *mock_commands.MockWalletService
+	mockExchangeRateService   *mock_commands.MockExchangeRateService
+	mockHoldService           *mock_commands.MockHoldService
+	mockScheduleService       *mock_commands.MockScheduleService
+	mockLimitService          *mock_commands.MockLimitService
+	mockFeeService            *mock_commands.MockFeeService
+	mockPaymentRequestService *mock_commands.MockPaymentRequestService
+	mockUserSettingsService   *mock_commands.MockUserSettingsService
+	mockStatementService      *mock_commands.MockStatementService
+	mockDepositImportService  *mock_commands.MockDepositImportService
 
-	mockListTransactionsService *mock_queries.MockListTransactionsService
-	mockListWalletsService      *mock_queries.MockListWalletsService
-	mockLoginService            *mock_queries.MockLoginService
+	mockListTransactionsService    *mock_queries.MockListTransactionsService
+	mockExportTransactionsService  *mock_queries.MockExportTransactionsService
+	mockListWalletsService         *mock_queries.MockListWalletsService
+	mockLoginService               *mock_queries.MockLoginService
+	mockListExchangeRatesService   *mock_queries.MockListExchangeRatesService
+	mockListSchedulesService       *mock_queries.MockListSchedulesService
+	mockListPaymentRequestsService *mock_queries.MockListPaymentRequestsService
+	mockFindRecipientService       *mock_queries.MockFindRecipientService
+	mockGetUserSettingsService     *mock_queries.MockGetUserSettingsService
+	mockGetDepositImportService    *mock_queries.MockGetDepositImportService
 }
 
 func (suite *RestApisTestSuite) SetupTest() {
 	ctrl := gomock.NewController(suite.T())
 
 	mockListTransactionsService := mock_queries.NewMockListTransactionsService(ctrl)
+	mockExportTransactionsService := mock_queries.NewMockExportTransactionsService(ctrl)
 	mockListWalletsService := mock_queries.NewMockListWalletsService(ctrl)
 	mockLoginService := mock_queries.NewMockLoginService(ctrl)
+	mockListExchangeRatesService := mock_queries.NewMockListExchangeRatesService(ctrl)
+	mockListSchedulesService := mock_queries.NewMockListSchedulesService(ctrl)
+	mockListPaymentRequestsService := mock_queries.NewMockListPaymentRequestsService(ctrl)
+	mockFindRecipientService := mock_queries.NewMockFindRecipientService(ctrl)
+	mockGetUserSettingsService := mock_queries.NewMockGetUserSettingsService(ctrl)
+	mockGetDepositImportService := mock_queries.NewMockGetDepositImportService(ctrl)
 	mockRegisterService := mock_commands.NewMockRegisterService(ctrl)
 	mockWalletService := mock_commands.NewMockWalletService(ctrl)
 	mockTransactionService := mock_commands.NewMockTransactionService(ctrl)
+	mockExchangeRateService := mock_commands.NewMockExchangeRateService(ctrl)
+	mockHoldService := mock_commands.NewMockHoldService(ctrl)
+	mockScheduleService := mock_commands.NewMockScheduleService(ctrl)
+	mockLimitService := mock_commands.NewMockLimitService(ctrl)
+	mockFeeService := mock_commands.NewMockFeeService(ctrl)
+	mockPaymentRequestService := mock_commands.NewMockPaymentRequestService(ctrl)
+	mockUserSettingsService := mock_commands.NewMockUserSettingsService(ctrl)
+	mockStatementService := mock_commands.NewMockStatementService(ctrl)
+	mockDepositImportService := mock_commands.NewMockDepositImportService(ctrl)
 
 	r := gin.Default()
 
@@ -47,14 +79,30 @@ func (suite *RestApisTestSuite) SetupTest() {
 	api_gen.RegisterHandlers(r, &restapis.HttpServer{
 		App: &server.Application{
 			Queries: server.Queries{
-				ListWalletsService:      mockListWalletsService,
-				ListTransactionsService: mockListTransactionsService,
-				LoginService:            mockLoginService,
+				ListWalletsService:         mockListWalletsService,
+				ListTransactionsService:    mockListTransactionsService,
+				ExportTransactionsService:  mockExportTransactionsService,
+				LoginService:               mockLoginService,
+				ListExchangeRatesService:   mockListExchangeRatesService,
+				ListSchedulesService:       mockListSchedulesService,
+				ListPaymentRequestsService: mockListPaymentRequestsService,
+				FindRecipientService:       mockFindRecipientService,
+				GetUserSettingsService:     mockGetUserSettingsService,
+				GetDepositImportService:    mockGetDepositImportService,
 			},
 			Commands: server.Commands{
-				RegisterService:    mockRegisterService,
-				WalletService:      mockWalletService,
-				TransactionService: mockTransactionService,
+				RegisterService:       mockRegisterService,
+				WalletService:         mockWalletService,
+				TransactionService:    mockTransactionService,
+				ExchangeRateService:   mockExchangeRateService,
+				HoldService:           mockHoldService,
+				ScheduleService:       mockScheduleService,
+				LimitService:          mockLimitService,
+				FeeService:            mockFeeService,
+				PaymentRequestService: mockPaymentRequestService,
+				UserSettingsService:   mockUserSettingsService,
+				StatementService:      mockStatementService,
+				DepositImportService:  mockDepositImportService,
 			},
 			Utils: server.Utils{
 				Validate: validator.New(),
@@ -63,12 +111,28 @@ func (suite *RestApisTestSuite) SetupTest() {
 	})
 
 	suite.mockListTransactionsService = mockListTransactionsService
+	suite.mockExportTransactionsService = mockExportTransactionsService
 	suite.mockListWalletsService = mockListWalletsService
 	suite.mockLoginService = mockLoginService
+	suite.mockListExchangeRatesService = mockListExchangeRatesService
+	suite.mockListSchedulesService = mockListSchedulesService
+	suite.mockListPaymentRequestsService = mockListPaymentRequestsService
+	suite.mockFindRecipientService = mockFindRecipientService
+	suite.mockGetUserSettingsService = mockGetUserSettingsService
+	suite.mockGetDepositImportService = mockGetDepositImportService
 
 	suite.mockRegisterService = mockRegisterService
 	suite.mockWalletService = mockWalletService
 	suite.mockTransactionService = mockTransactionService
+	suite.mockExchangeRateService = mockExchangeRateService
+	suite.mockHoldService = mockHoldService
+	suite.mockScheduleService = mockScheduleService
+	suite.mockLimitService = mockLimitService
+	suite.mockFeeService = mockFeeService
+	suite.mockPaymentRequestService = mockPaymentRequestService
+	suite.mockUserSettingsService = mockUserSettingsService
+	suite.mockStatementService = mockStatementService
+	suite.mockDepositImportService = mockDepositImportService
 
 	suite.server = r
 }
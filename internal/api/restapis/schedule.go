@@ -0,0 +1,146 @@
+package restapis
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/utils"
+	"gorm.io/gorm"
+)
+
+// (POST /secure/schedules)
+func (h *HttpServer) CreateSchedule(ctx *gin.Context) {
+	var req api_gen.CreateScheduleRequest
+	if !utils.BindAndValidateRequestBody(ctx, &req, h.App.Utils.Validate) {
+		return
+	}
+
+	if req.FromWalletId == req.ToWalletId {
+		ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "From and To wallet ID cannot be the same"})
+		return
+	}
+
+	userId := utils.GetMiddlewareUserId(ctx)
+
+	result, err := h.App.Commands.ScheduleService.HandleCreate(userId, req)
+	if err != nil {
+		if errors.Is(err, consts.ErrInvalidScheduleTime) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Schedule must start in the future and end after it starts"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrAmountScaleExceeded) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Amount exceeds wallet currency precision"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrWalletClosed) {
+			ctx.JSON(http.StatusConflict, api_gen.ErrorResponse{ErrorCode: "409", ErrorMessage: "Wallet is closed"})
+			return
+		}
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, api_gen.ErrorResponse{ErrorCode: "404", ErrorMessage: "Wallet not found"})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, api_gen.ErrorResponse{ErrorCode: "500", ErrorMessage: "Failed to create schedule"})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, api_gen.ScheduleResponse{Data: result})
+}
+
+// (GET /secure/schedules)
+func (h *HttpServer) ListSchedules(ctx *gin.Context) {
+	userId := utils.GetMiddlewareUserId(ctx)
+
+	resp, err := h.App.Queries.ListSchedulesService.Handle(userId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, api_gen.ErrorResponse{ErrorCode: "500", ErrorMessage: "Failed to list schedules"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api_gen.ListSchedulesResponse{
+		Data: &resp,
+	})
+}
+
+// (PUT /secure/schedules/{scheduleId})
+func (h *HttpServer) UpdateSchedule(ctx *gin.Context, scheduleId string) {
+	var req api_gen.UpdateScheduleRequest
+	if !utils.BindAndValidateRequestBody(ctx, &req, h.App.Utils.Validate) {
+		return
+	}
+
+	userId := utils.GetMiddlewareUserId(ctx)
+
+	result, err := h.App.Commands.ScheduleService.HandleUpdate(userId, scheduleId, req)
+	if err != nil {
+		if errors.Is(err, consts.ErrScheduleNotActive) {
+			ctx.JSON(http.StatusConflict, api_gen.ErrorResponse{ErrorCode: "409", ErrorMessage: "Schedule is no longer active"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrAmountScaleExceeded) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Amount exceeds wallet currency precision"})
+			return
+		}
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, api_gen.ErrorResponse{ErrorCode: "404", ErrorMessage: "Schedule not found"})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, api_gen.ErrorResponse{ErrorCode: "500", ErrorMessage: "Failed to update schedule"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api_gen.ScheduleResponse{Data: result})
+}
+
+// (DELETE /secure/schedules/{scheduleId})
+func (h *HttpServer) CancelSchedule(ctx *gin.Context, scheduleId string) {
+	userId := utils.GetMiddlewareUserId(ctx)
+
+	result, err := h.App.Commands.ScheduleService.HandleCancel(userId, scheduleId)
+	if err != nil {
+		if errors.Is(err, consts.ErrScheduleNotActive) {
+			ctx.JSON(http.StatusConflict, api_gen.ErrorResponse{ErrorCode: "409", ErrorMessage: "Schedule is no longer active"})
+			return
+		}
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, api_gen.ErrorResponse{ErrorCode: "404", ErrorMessage: "Schedule not found"})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, api_gen.ErrorResponse{ErrorCode: "500", ErrorMessage: "Failed to cancel schedule"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api_gen.ScheduleResponse{Data: result})
+}
+
+// (GET /secure/schedules/{scheduleId}/runs)
+func (h *HttpServer) ListScheduleRuns(ctx *gin.Context, scheduleId string) {
+	userId := utils.GetMiddlewareUserId(ctx)
+
+	resp, err := h.App.Queries.ListSchedulesService.HandleRuns(userId, scheduleId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, api_gen.ErrorResponse{ErrorCode: "404", ErrorMessage: "Schedule not found"})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, api_gen.ErrorResponse{ErrorCode: "500", ErrorMessage: "Failed to list schedule runs"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api_gen.ListScheduleRunsResponse{
+		Data: &resp,
+	})
+}
@@ -0,0 +1,291 @@
+package restapis_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/money"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func (suite *RestApisTestSuite) TestCreateSchedule() {
+	startAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	testCases := []struct {
+		name        string
+		reqBody     interface{}
+		mock        func()
+		wantStatus  int
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivingValidRequest_WhenCreateSuccess_ThenReturnCreated",
+			reqBody: api_gen.CreateScheduleRequest{
+				FromWalletId: "<FromWalletID>", ToWalletId: "<ToWalletID>", Amount: money.MustParse("25"),
+				Frequency: api_gen.CreateScheduleRequestFrequencyWeekly, StartAt: startAt,
+			},
+			mock: func() {
+				suite.mockScheduleService.EXPECT().
+					HandleCreate("<UserID>", gomock.Any()).
+					Return(&api_gen.ScheduleResponseData{Id: "<ScheduleID>", Status: "active"}, nil)
+			},
+			wantStatus: http.StatusCreated,
+			wantErr:    false,
+		},
+		{
+			name: "GivingUnknownFrequency_WhenCreate_ThenReturnBadRequest",
+			reqBody: map[string]interface{}{
+				"fromWalletId": "<FromWalletID>", "toWalletId": "<ToWalletID>", "amount": 25,
+				"frequency": "hourly", "startAt": startAt,
+			},
+			mock:        func() {},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Frequency oneof once daily weekly monthly",
+		},
+		{
+			name: "GivingSameWallets_WhenCreate_ThenReturnBadRequest",
+			reqBody: api_gen.CreateScheduleRequest{
+				FromWalletId: "<FromWalletID>", ToWalletId: "<FromWalletID>", Amount: money.MustParse("25"),
+				Frequency: api_gen.CreateScheduleRequestFrequencyOnce, StartAt: startAt,
+			},
+			mock:        func() {},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "From and To wallet ID cannot be the same",
+		},
+		{
+			name: "GivingPastStart_WhenCreate_ThenReturnBadRequest",
+			reqBody: api_gen.CreateScheduleRequest{
+				FromWalletId: "<FromWalletID>", ToWalletId: "<ToWalletID>", Amount: money.MustParse("25"),
+				Frequency: api_gen.CreateScheduleRequestFrequencyOnce, StartAt: startAt,
+			},
+			mock: func() {
+				suite.mockScheduleService.EXPECT().
+					HandleCreate("<UserID>", gomock.Any()).
+					Return(nil, consts.ErrInvalidScheduleTime)
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Schedule must start in the future and end after it starts",
+		},
+		{
+			name: "GivingUnknownWallet_WhenCreate_ThenReturnNotFound",
+			reqBody: api_gen.CreateScheduleRequest{
+				FromWalletId: "<FromWalletID>", ToWalletId: "<ToWalletID>", Amount: money.MustParse("25"),
+				Frequency: api_gen.CreateScheduleRequestFrequencyOnce, StartAt: startAt,
+			},
+			mock: func() {
+				suite.mockScheduleService.EXPECT().
+					HandleCreate("<UserID>", gomock.Any()).
+					Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus:  http.StatusNotFound,
+			wantErr:     true,
+			expectedErr: "Wallet not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			w := httptest.NewRecorder()
+			body, _ := json.Marshal(tc.reqBody)
+			req, _ := http.NewRequest("POST", "/secure/schedules", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			suite.server.ServeHTTP(w, req)
+			suite.Equal(tc.wantStatus, w.Code)
+			if tc.wantErr {
+				var resp api_gen.ErrorResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal(strconv.Itoa(w.Code), resp.ErrorCode)
+				suite.Equal(tc.expectedErr, resp.ErrorMessage)
+			}
+		})
+	}
+}
+
+func (suite *RestApisTestSuite) TestListSchedules() {
+	testCases := []struct {
+		name       string
+		mock       func()
+		wantStatus int
+	}{
+		{
+			name: "GivingUser_WhenListSuccess_ThenReturnOk",
+			mock: func() {
+				suite.mockListSchedulesService.EXPECT().Handle("<UserID>").
+					Return([]api_gen.ScheduleResponseData{{Id: "<ScheduleID>"}}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "GivingUser_WhenListFails_ThenReturnInternalServerError",
+			mock: func() {
+				suite.mockListSchedulesService.EXPECT().Handle("<UserID>").Return(nil, errors.New("list error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/secure/schedules", nil)
+			suite.server.ServeHTTP(w, req)
+			suite.Equal(tc.wantStatus, w.Code)
+		})
+	}
+}
+
+func (suite *RestApisTestSuite) TestUpdateSchedule() {
+	testCases := []struct {
+		name        string
+		mock        func()
+		wantStatus  int
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivingActiveSchedule_WhenUpdateSuccess_ThenReturnOk",
+			mock: func() {
+				suite.mockScheduleService.EXPECT().
+					HandleUpdate("<UserID>", "<ScheduleID>", api_gen.UpdateScheduleRequest{Amount: money.MustParse("30")}).
+					Return(&api_gen.ScheduleResponseData{Id: "<ScheduleID>"}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantErr:    false,
+		},
+		{
+			name: "GivingCancelledSchedule_WhenUpdate_ThenReturnConflict",
+			mock: func() {
+				suite.mockScheduleService.EXPECT().
+					HandleUpdate("<UserID>", "<ScheduleID>", gomock.Any()).
+					Return(nil, consts.ErrScheduleNotActive)
+			},
+			wantStatus:  http.StatusConflict,
+			wantErr:     true,
+			expectedErr: "Schedule is no longer active",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			w := httptest.NewRecorder()
+			body, _ := json.Marshal(api_gen.UpdateScheduleRequest{Amount: money.MustParse("30")})
+			req, _ := http.NewRequest("PUT", "/secure/schedules/<ScheduleID>", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			suite.server.ServeHTTP(w, req)
+			suite.Equal(tc.wantStatus, w.Code)
+			if tc.wantErr {
+				var resp api_gen.ErrorResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal(strconv.Itoa(w.Code), resp.ErrorCode)
+				suite.Equal(tc.expectedErr, resp.ErrorMessage)
+			}
+		})
+	}
+}
+
+func (suite *RestApisTestSuite) TestCancelSchedule() {
+	testCases := []struct {
+		name        string
+		mock        func()
+		wantStatus  int
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivingActiveSchedule_WhenCancelSuccess_ThenReturnOk",
+			mock: func() {
+				suite.mockScheduleService.EXPECT().HandleCancel("<UserID>", "<ScheduleID>").
+					Return(&api_gen.ScheduleResponseData{Id: "<ScheduleID>", Status: "cancelled"}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantErr:    false,
+		},
+		{
+			name: "GivingUnknownSchedule_WhenCancel_ThenReturnNotFound",
+			mock: func() {
+				suite.mockScheduleService.EXPECT().HandleCancel("<UserID>", "<ScheduleID>").
+					Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus:  http.StatusNotFound,
+			wantErr:     true,
+			expectedErr: "Schedule not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("DELETE", "/secure/schedules/<ScheduleID>", nil)
+			suite.server.ServeHTTP(w, req)
+			suite.Equal(tc.wantStatus, w.Code)
+			if tc.wantErr {
+				var resp api_gen.ErrorResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal(strconv.Itoa(w.Code), resp.ErrorCode)
+				suite.Equal(tc.expectedErr, resp.ErrorMessage)
+			}
+		})
+	}
+}
+
+func (suite *RestApisTestSuite) TestListScheduleRuns() {
+	testCases := []struct {
+		name        string
+		mock        func()
+		wantStatus  int
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivingSchedule_WhenListRunsSuccess_ThenReturnOk",
+			mock: func() {
+				suite.mockListSchedulesService.EXPECT().HandleRuns("<UserID>", "<ScheduleID>").
+					Return([]api_gen.ScheduleRunResponseData{{Id: "<RunID>", Status: "succeeded"}}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantErr:    false,
+		},
+		{
+			name: "GivingUnknownSchedule_WhenListRuns_ThenReturnNotFound",
+			mock: func() {
+				suite.mockListSchedulesService.EXPECT().HandleRuns("<UserID>", "<ScheduleID>").
+					Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus:  http.StatusNotFound,
+			wantErr:     true,
+			expectedErr: "Schedule not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/secure/schedules/<ScheduleID>/runs", nil)
+			suite.server.ServeHTTP(w, req)
+			suite.Equal(tc.wantStatus, w.Code)
+			if tc.wantErr {
+				var resp api_gen.ErrorResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal(strconv.Itoa(w.Code), resp.ErrorCode)
+				suite.Equal(tc.expectedErr, resp.ErrorMessage)
+			}
+		})
+	}
+}
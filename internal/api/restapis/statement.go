@@ -0,0 +1,72 @@
+package restapis
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/statement"
+	"github.com/slilp/go-wallet/internal/utils"
+	"gorm.io/gorm"
+)
+
+// (GET /secure/wallet/{walletId}/statements/{month})
+func (h *HttpServer) GetWalletStatement(ctx *gin.Context, walletId string, month string, params api_gen.GetWalletStatementParams) {
+	if !utils.ValidateRequestParams(ctx, params, h.App.Utils.Validate) {
+		return
+	}
+
+	period, err := time.Parse("2006-01", month)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Month must be in YYYY-MM format"})
+		return
+	}
+
+	userId := utils.GetMiddlewareUserId(ctx)
+
+	data, err := h.App.Commands.StatementService.HandleGenerate(userId, walletId, period)
+	if err != nil {
+		if errors.Is(err, consts.ErrStatementPeriodNotStarted) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Statement month has not started yet"})
+			return
+		}
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, api_gen.ErrorResponse{ErrorCode: "404", ErrorMessage: "Wallet not found"})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, api_gen.ErrorResponse{ErrorCode: "500", ErrorMessage: "Failed to generate statement"})
+		return
+	}
+
+	format := api_gen.GetWalletStatementParamsFormatJson
+	if params.Format != nil {
+		format = *params.Format
+	}
+
+	if format == api_gen.GetWalletStatementParamsFormatJson {
+		ctx.JSON(http.StatusOK, api_gen.StatementResponse{Data: data})
+		return
+	}
+
+	var body bytes.Buffer
+	contentType := "text/csv"
+	write := statement.WriteCSV
+	if format == api_gen.GetWalletStatementParamsFormatPdf {
+		contentType = "application/pdf"
+		write = statement.WritePDF
+	}
+	if err := write(&body, *data); err != nil {
+		ctx.JSON(http.StatusInternalServerError, api_gen.ErrorResponse{ErrorCode: "500", ErrorMessage: "Failed to render statement"})
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="statement-%s-%s.%s"`, walletId, month, format))
+	ctx.Data(http.StatusOK, contentType, body.Bytes())
+}
@@ -0,0 +1,152 @@
+package restapis_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/money"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func (suite *RestApisTestSuite) TestGetWalletStatement() {
+	march := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	statement := &api_gen.StatementResponseData{
+		WalletId:       "<WalletID>",
+		Currency:       "THB",
+		PeriodStart:    march,
+		PeriodEnd:      march.AddDate(0, 1, 0),
+		OpeningBalance: money.MustParse("10"),
+		TotalIn:        money.MustParse("5"),
+		ClosingBalance: money.MustParse("15"),
+		Final:          true,
+		Lines: []api_gen.StatementLineData{
+			{Type: "deposit", Amount: money.MustParse("5"), BalanceAfter: money.MustParse("15"), CreatedAt: march.Add(time.Hour)},
+		},
+	}
+
+	testCases := []struct {
+		name            string
+		path            string
+		mock            func()
+		wantStatus      int
+		wantErr         bool
+		expectedErr     string
+		wantContentType string
+		wantDisposition string
+	}{
+		{
+			name: "GivingMonth_WhenGenerateSuccess_ThenReturnJson",
+			path: "/secure/wallet/<WalletID>/statements/2024-03",
+			mock: func() {
+				suite.mockStatementService.EXPECT().HandleGenerate("<UserID>", "<WalletID>", march).Return(statement, nil)
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json; charset=utf-8",
+		},
+		{
+			name: "GivingCsvFormat_WhenGenerateSuccess_ThenReturnCsvAttachment",
+			path: "/secure/wallet/<WalletID>/statements/2024-03?format=csv",
+			mock: func() {
+				suite.mockStatementService.EXPECT().HandleGenerate("<UserID>", "<WalletID>", march).Return(statement, nil)
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv",
+			wantDisposition: `attachment; filename="statement-<WalletID>-2024-03.csv"`,
+		},
+		{
+			name: "GivingPdfFormat_WhenGenerateSuccess_ThenReturnPdfAttachment",
+			path: "/secure/wallet/<WalletID>/statements/2024-03?format=pdf",
+			mock: func() {
+				suite.mockStatementService.EXPECT().HandleGenerate("<UserID>", "<WalletID>", march).Return(statement, nil)
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/pdf",
+			wantDisposition: `attachment; filename="statement-<WalletID>-2024-03.pdf"`,
+		},
+		{
+			name: "GivingUnknownFormat_WhenGenerate_ThenReturnBadRequest",
+			path: "/secure/wallet/<WalletID>/statements/2024-03?format=xml",
+			mock: func() {
+				suite.mockStatementService.EXPECT().HandleGenerate(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Format oneof json csv pdf",
+		},
+		{
+			name: "GivingInvalidMonth_WhenGenerate_ThenReturnBadRequest",
+			path: "/secure/wallet/<WalletID>/statements/2024-13",
+			mock: func() {
+				suite.mockStatementService.EXPECT().HandleGenerate(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Month must be in YYYY-MM format",
+		},
+		{
+			name: "GivingFutureMonth_WhenGenerate_ThenReturnBadRequest",
+			path: "/secure/wallet/<WalletID>/statements/2024-03",
+			mock: func() {
+				suite.mockStatementService.EXPECT().HandleGenerate("<UserID>", "<WalletID>", march).Return(nil, consts.ErrStatementPeriodNotStarted)
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Statement month has not started yet",
+		},
+		{
+			name: "GivingUnknownWallet_WhenGenerate_ThenReturnNotFound",
+			path: "/secure/wallet/<WalletID>/statements/2024-03",
+			mock: func() {
+				suite.mockStatementService.EXPECT().HandleGenerate("<UserID>", "<WalletID>", march).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus:  http.StatusNotFound,
+			wantErr:     true,
+			expectedErr: "Wallet not found",
+		},
+		{
+			name: "GivingServiceFailure_WhenGenerate_ThenReturnInternalServerError",
+			path: "/secure/wallet/<WalletID>/statements/2024-03",
+			mock: func() {
+				suite.mockStatementService.EXPECT().HandleGenerate("<UserID>", "<WalletID>", march).Return(nil, errors.New("db error"))
+			},
+			wantStatus:  http.StatusInternalServerError,
+			wantErr:     true,
+			expectedErr: "Failed to generate statement",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tc.path, nil)
+			suite.server.ServeHTTP(w, req)
+			suite.Equal(tc.wantStatus, w.Code)
+			if tc.wantErr {
+				var resp api_gen.ErrorResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal(strconv.Itoa(w.Code), resp.ErrorCode)
+				suite.Equal(tc.expectedErr, resp.ErrorMessage)
+				return
+			}
+
+			suite.Equal(tc.wantContentType, w.Header().Get("Content-Type"))
+			suite.Equal(tc.wantDisposition, w.Header().Get("Content-Disposition"))
+			if tc.wantDisposition == "" {
+				var resp api_gen.StatementResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal(statement.ClosingBalance, resp.Data.ClosingBalance)
+				suite.Len(resp.Data.Lines, 1)
+			} else {
+				suite.NotEmpty(w.Body.Bytes())
+			}
+		})
+	}
+}
@@ -2,24 +2,50 @@ package restapis
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
 	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/services/commands"
 	"github.com/slilp/go-wallet/internal/utils"
 	"gorm.io/gorm"
 )
 
 // (GET /secure/wallet/{walletId}/transactions)
 func (h *HttpServer) ListWalletTransactions(ctx *gin.Context, walletId string, params api_gen.ListWalletTransactionsParams) {
+	if !utils.ValidateRequestParams(ctx, params, h.App.Utils.Validate) {
+		return
+	}
 
-	page, limit := utils.GetPaginationParams(params.Page, params.Limit)
+	limit := utils.GetLimitParam(params.Limit)
 
 	userId := utils.GetMiddlewareUserId(ctx)
 
-	totalCount, listData, err := h.App.Queries.ListTransactionsService.Handle(userId, walletId, page, limit)
+	listData, pagination, err := h.App.Queries.ListTransactionsService.Handle(userId, walletId, params, limit)
 	if err != nil {
+		if errors.Is(err, consts.ErrInvalidCursor) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Invalid cursor"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrAmountScaleExceeded) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Amount filter exceeds currency precision"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrInvalidAmountRange) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "minAmount must not exceed maxAmount"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrInvalidDateRange) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "createdFrom must be before createdTo"})
+			return
+		}
+
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			ctx.JSON(http.StatusNotFound, api_gen.ErrorResponse{ErrorCode: "404", ErrorMessage: "Wallet not found"})
 			return
@@ -30,57 +56,264 @@ func (h *HttpServer) ListWalletTransactions(ctx *gin.Context, walletId string, p
 	}
 
 	ctx.JSON(http.StatusOK, api_gen.ListWalletTransactionsResponse{
-		Data: &listData,
-		Pagination: &api_gen.PageLimitResponseData{
-			Page:         page,
-			Limit:        limit,
-			TotalRecords: int(totalCount),
-		},
+		Data:       &listData,
+		Pagination: pagination,
 	})
 }
 
+// (GET /secure/wallet/{walletId}/transactions/export)
+func (h *HttpServer) ExportWalletTransactions(ctx *gin.Context, walletId string, params api_gen.ExportWalletTransactionsParams) {
+	if !utils.ValidateRequestParams(ctx, params, h.App.Utils.Validate) {
+		return
+	}
+
+	format := api_gen.ExportWalletTransactionsParamsFormatCsv
+	if params.Format != nil {
+		format = *params.Format
+	}
+	contentType := map[api_gen.ExportWalletTransactionsParamsFormat]string{
+		api_gen.ExportWalletTransactionsParamsFormatCsv:   "text/csv",
+		api_gen.ExportWalletTransactionsParamsFormatOfx:   "application/x-ofx",
+		api_gen.ExportWalletTransactionsParamsFormatJsonl: "application/x-ndjson",
+	}[format]
+
+	userId := utils.GetMiddlewareUserId(ctx)
+
+	ctx.Header("Content-Type", contentType)
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="transactions-%s.%s"`, walletId, format))
+
+	err := h.App.Queries.ExportTransactionsService.Handle(userId, walletId, params, ctx.Writer)
+	if err != nil {
+		// Once rows have been sent the status is committed; the export is
+		// cut short and the error is left for the request log.
+		if ctx.Writer.Written() {
+			_ = ctx.Error(err)
+			ctx.Abort()
+			return
+		}
+
+		ctx.Writer.Header().Del("Content-Type")
+		ctx.Writer.Header().Del("Content-Disposition")
+
+		if errors.Is(err, consts.ErrAmountScaleExceeded) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Amount filter exceeds currency precision"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrInvalidAmountRange) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "minAmount must not exceed maxAmount"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrInvalidDateRange) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "createdFrom must be before createdTo"})
+			return
+		}
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, api_gen.ErrorResponse{ErrorCode: "404", ErrorMessage: "Wallet not found"})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, api_gen.ErrorResponse{ErrorCode: "500", ErrorMessage: "Failed to export transactions"})
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
 // (POST /secure/transfer)
-func (h *HttpServer) TransferBalance(ctx *gin.Context) {
+func (h *HttpServer) TransferBalance(ctx *gin.Context, params api_gen.TransferBalanceParams) {
 	var req api_gen.TransferRequest
 	if !utils.BindAndValidateRequestBody(ctx, &req, h.App.Utils.Validate) {
 		return
 	}
 
-	if req.FromWalletId == req.ToWalletId {
+	toWalletId, toEmail, toHandle := nonEmpty(req.ToWalletId), nonEmpty(req.ToEmail), nonEmpty(req.ToHandle)
+	addresses := 0
+	for _, address := range []*string{toWalletId, toEmail, toHandle} {
+		if address != nil {
+			addresses++
+		}
+	}
+	if addresses != 1 {
+		ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Exactly one of toWalletId, toEmail or toHandle is required"})
+		return
+	}
+
+	if params.IdempotencyKey != nil && len(*params.IdempotencyKey) > 255 {
+		ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Idempotency-Key must be at most 255 characters"})
+		return
+	}
+
+	if toWalletId == nil {
+		walletId, err := h.App.Queries.FindRecipientService.HandleWallet(toEmail, toHandle)
+		if err != nil {
+			status, message := recipientFailure(err)
+			ctx.JSON(status, api_gen.ErrorResponse{ErrorCode: strconv.Itoa(status), ErrorMessage: message})
+			return
+		}
+		toWalletId = &walletId
+	}
+
+	if req.FromWalletId == *toWalletId {
 		ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "From and To wallet ID cannot be the same"})
 		return
 	}
 
 	userId := utils.GetMiddlewareUserId(ctx)
 
-	if err := h.App.Commands.TransactionService.HandleTransferBalance(userId, req.FromWalletId, req.ToWalletId, req.Amount); err != nil {
-		if errors.Is(err, consts.ErrInsufficientBalance) {
-			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Insufficient balance"})
+	result, err := h.App.Commands.TransactionService.HandleTransferBalance(userId, req.FromWalletId, *toWalletId, req.Amount,
+		transactionDetails(req.Description, req.Reference, req.Metadata), params.IdempotencyKey)
+	if err != nil {
+		var limitErr *consts.LimitExceededError
+		if errors.As(err, &limitErr) {
+			ctx.JSON(http.StatusForbidden, limitExceededResponse(limitErr))
 			return
 		}
 
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			ctx.JSON(http.StatusNotFound, api_gen.ErrorResponse{ErrorCode: "404", ErrorMessage: "Wallet not found"})
+		var blockedErr *consts.WalletBlockedError
+		if errors.As(err, &blockedErr) {
+			ctx.JSON(http.StatusLocked, walletBlockedResponse(blockedErr))
+			return
+		}
+
+		status, message := transferFailure(err)
+		ctx.JSON(status, api_gen.ErrorResponse{ErrorCode: strconv.Itoa(status), ErrorMessage: message})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api_gen.TransactionResponse{Data: result})
+}
+
+// (POST /secure/transfer/batch)
+func (h *HttpServer) BatchTransfer(ctx *gin.Context) {
+	var req api_gen.BatchTransferRequest
+	if !utils.BindAndValidateRequestBody(ctx, &req, h.App.Utils.Validate) {
+		return
+	}
+
+	mode := api_gen.BatchTransferRequestModeAllOrNothing
+	if req.Mode != nil {
+		mode = *req.Mode
+	}
+
+	legs := make([]commands.BatchTransferLeg, len(req.Legs))
+	for i, leg := range req.Legs {
+		if leg.ToWalletId == req.FromWalletId {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: fmt.Sprintf("Leg %d: From and To wallet ID cannot be the same", i)})
+			return
+		}
+		legs[i] = commands.BatchTransferLeg{
+			To:      leg.ToWalletId,
+			Amount:  leg.Amount,
+			Details: transactionDetails(leg.Description, leg.Reference, leg.Metadata),
+		}
+	}
+
+	userId := utils.GetMiddlewareUserId(ctx)
+
+	results, err := h.App.Commands.TransactionService.HandleBatchTransfer(userId, req.FromWalletId, legs, mode == api_gen.BatchTransferRequestModeAllOrNothing)
+	if err != nil {
+		prefix := ""
+		var legErr *consts.BatchLegError
+		if errors.As(err, &legErr) {
+			prefix = fmt.Sprintf("Leg %d: ", legErr.Index)
+		}
+
+		var limitErr *consts.LimitExceededError
+		if errors.As(err, &limitErr) {
+			response := limitExceededResponse(limitErr)
+			response.ErrorMessage = prefix + response.ErrorMessage
+			ctx.JSON(http.StatusForbidden, response)
+			return
+		}
+
+		var blockedErr *consts.WalletBlockedError
+		if errors.As(err, &blockedErr) {
+			response := walletBlockedResponse(blockedErr)
+			response.ErrorMessage = prefix + response.ErrorMessage
+			ctx.JSON(http.StatusLocked, response)
 			return
 		}
 
-		ctx.JSON(http.StatusInternalServerError, api_gen.ErrorResponse{ErrorCode: "500", ErrorMessage: "Fail to transfer"})
+		status, message := transferFailure(err)
+		ctx.JSON(status, api_gen.ErrorResponse{ErrorCode: strconv.Itoa(status), ErrorMessage: prefix + message})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, nil)
+	data := api_gen.BatchTransferResponseData{
+		FromWalletId: req.FromWalletId,
+		Mode:         api_gen.BatchTransferResponseDataMode(mode),
+		Legs:         make([]api_gen.BatchTransferLegResult, len(results)),
+	}
+	for i, result := range results {
+		leg := api_gen.BatchTransferLegResult{
+			Index:       i,
+			ToWalletId:  req.Legs[i].ToWalletId,
+			Amount:      req.Legs[i].Amount,
+			Status:      api_gen.BatchTransferLegResultStatusCompleted,
+			Transaction: result.Transaction,
+		}
+		if result.Err != nil {
+			var message string
+			var limitErr *consts.LimitExceededError
+			if errors.As(result.Err, &limitErr) {
+				message = limitExceededResponse(limitErr).ErrorMessage
+			} else {
+				_, message = transferFailure(result.Err)
+			}
+			leg.Status = api_gen.BatchTransferLegResultStatusFailed
+			leg.ErrorMessage = &message
+			data.Failed++
+		} else {
+			data.Succeeded++
+			data.TotalAmount += req.Legs[i].Amount
+		}
+		data.Legs[i] = leg
+	}
+
+	ctx.JSON(http.StatusOK, api_gen.BatchTransferResponse{Data: &data})
 }
 
 // (POST /secure/deposit)
-func (h *HttpServer) DepositPoints(ctx *gin.Context) {
+func (h *HttpServer) DepositPoints(ctx *gin.Context, params api_gen.DepositPointsParams) {
 	var req api_gen.DepositRequest
 	if !utils.BindAndValidateRequestBody(ctx, &req, h.App.Utils.Validate) {
 		return
 	}
 
+	if params.IdempotencyKey != nil && len(*params.IdempotencyKey) > 255 {
+		ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Idempotency-Key must be at most 255 characters"})
+		return
+	}
+
 	userId := utils.GetMiddlewareUserId(ctx)
 
-	if err := h.App.Commands.TransactionService.HandleDepositWithDrawBalance(userId, req.WalletId, req.Amount); err != nil {
+	result, err := h.App.Commands.TransactionService.HandleDepositWithDrawBalance(userId, req.WalletId, req.Amount,
+		transactionDetails(req.Description, req.Reference, req.Metadata), params.IdempotencyKey)
+	if err != nil {
+		if errors.Is(err, consts.ErrIdempotencyKeyReused) {
+			ctx.JSON(http.StatusUnprocessableEntity, api_gen.ErrorResponse{ErrorCode: "422", ErrorMessage: "Idempotency key already used with a different request"})
+			return
+		}
+
+		var blockedErr *consts.WalletBlockedError
+		if errors.As(err, &blockedErr) {
+			ctx.JSON(http.StatusLocked, walletBlockedResponse(blockedErr))
+			return
+		}
+
+		if errors.Is(err, consts.ErrAmountScaleExceeded) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Amount exceeds wallet currency precision"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrWalletClosed) {
+			ctx.JSON(http.StatusConflict, api_gen.ErrorResponse{ErrorCode: "409", ErrorMessage: "Wallet is closed"})
+			return
+		}
+
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			ctx.JSON(http.StatusNotFound, api_gen.ErrorResponse{ErrorCode: "404", ErrorMessage: "Wallet not found"})
 			return
@@ -90,24 +323,58 @@ func (h *HttpServer) DepositPoints(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, nil)
+	ctx.JSON(http.StatusOK, api_gen.TransactionResponse{Data: result})
 }
 
 // (POST /secure/withdraw)
-func (h *HttpServer) WithdrawPoints(ctx *gin.Context) {
+func (h *HttpServer) WithdrawPoints(ctx *gin.Context, params api_gen.WithdrawPointsParams) {
 	var req api_gen.WithdrawRequest
 	if !utils.BindAndValidateRequestBody(ctx, &req, h.App.Utils.Validate) {
 		return
 	}
 
+	if params.IdempotencyKey != nil && len(*params.IdempotencyKey) > 255 {
+		ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Idempotency-Key must be at most 255 characters"})
+		return
+	}
+
 	userId := utils.GetMiddlewareUserId(ctx)
 
-	if err := h.App.Commands.TransactionService.HandleDepositWithDrawBalance(userId, req.WalletId, -req.Amount); err != nil {
+	result, err := h.App.Commands.TransactionService.HandleDepositWithDrawBalance(userId, req.WalletId, -req.Amount,
+		transactionDetails(req.Description, req.Reference, req.Metadata), params.IdempotencyKey)
+	if err != nil {
+		if errors.Is(err, consts.ErrIdempotencyKeyReused) {
+			ctx.JSON(http.StatusUnprocessableEntity, api_gen.ErrorResponse{ErrorCode: "422", ErrorMessage: "Idempotency key already used with a different request"})
+			return
+		}
+
+		var limitErr *consts.LimitExceededError
+		if errors.As(err, &limitErr) {
+			ctx.JSON(http.StatusForbidden, limitExceededResponse(limitErr))
+			return
+		}
+
+		var blockedErr *consts.WalletBlockedError
+		if errors.As(err, &blockedErr) {
+			ctx.JSON(http.StatusLocked, walletBlockedResponse(blockedErr))
+			return
+		}
+
 		if errors.Is(err, consts.ErrInsufficientBalance) {
 			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Insufficient balance"})
 			return
 		}
 
+		if errors.Is(err, consts.ErrAmountScaleExceeded) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Amount exceeds wallet currency precision"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrWalletClosed) {
+			ctx.JSON(http.StatusConflict, api_gen.ErrorResponse{ErrorCode: "409", ErrorMessage: "Wallet is closed"})
+			return
+		}
+
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			ctx.JSON(http.StatusNotFound, api_gen.ErrorResponse{ErrorCode: "404", ErrorMessage: "Wallet not found"})
 			return
@@ -117,5 +384,144 @@ func (h *HttpServer) WithdrawPoints(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, nil)
+	ctx.JSON(http.StatusOK, api_gen.TransactionResponse{Data: result})
+}
+
+// (POST /secure/transaction/{transactionId}/reverse)
+func (h *HttpServer) ReverseTransaction(ctx *gin.Context, transactionId string, params api_gen.ReverseTransactionParams) {
+	var req api_gen.ReverseTransactionRequest
+	if !utils.BindAndValidateRequestBody(ctx, &req, h.App.Utils.Validate) {
+		return
+	}
+
+	if params.IdempotencyKey != nil && len(*params.IdempotencyKey) > 255 {
+		ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Idempotency-Key must be at most 255 characters"})
+		return
+	}
+
+	userId := utils.GetMiddlewareUserId(ctx)
+
+	result, err := h.App.Commands.TransactionService.HandleReverseTransaction(userId, transactionId, req.Amount,
+		transactionDetails(req.Description, req.Reference, req.Metadata), params.IdempotencyKey)
+	if err != nil {
+		if errors.Is(err, consts.ErrIdempotencyKeyReused) {
+			ctx.JSON(http.StatusUnprocessableEntity, api_gen.ErrorResponse{ErrorCode: "422", ErrorMessage: "Idempotency key already used with a different request"})
+			return
+		}
+
+		var blockedErr *consts.WalletBlockedError
+		if errors.As(err, &blockedErr) {
+			ctx.JSON(http.StatusLocked, walletBlockedResponse(blockedErr))
+			return
+		}
+
+		if errors.Is(err, consts.ErrTransactionNotReversible) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Only deposits and transfers can be reversed"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrRefundExceedsOriginal) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Refund exceeds the remaining refundable amount"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrInsufficientBalance) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Insufficient balance"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrAmountScaleExceeded) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Amount exceeds wallet currency precision"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrConvertedAmountTooSmall) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Converted amount is too small"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrWalletClosed) {
+			ctx.JSON(http.StatusConflict, api_gen.ErrorResponse{ErrorCode: "409", ErrorMessage: "Wallet is closed"})
+			return
+		}
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, api_gen.ErrorResponse{ErrorCode: "404", ErrorMessage: "Transaction not found"})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, api_gen.ErrorResponse{ErrorCode: "500", ErrorMessage: "Fail to reverse transaction"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api_gen.TransactionResponse{Data: result})
+}
+
+// transferFailure maps a failed transfer to the status and message returned
+// to the caller. Limit errors carry more detail and are handled separately.
+func transferFailure(err error) (int, string) {
+	var mismatchErr *consts.CurrencyMismatchError
+	var blockedErr *consts.WalletBlockedError
+	switch {
+	case errors.Is(err, consts.ErrIdempotencyKeyReused):
+		return http.StatusUnprocessableEntity, "Idempotency key already used with a different request"
+	case errors.Is(err, consts.ErrInsufficientBalance):
+		return http.StatusBadRequest, "Insufficient balance"
+	case errors.As(err, &mismatchErr):
+		return http.StatusBadRequest, "No exchange rate from " + mismatchErr.FromCurrency + " to " + mismatchErr.ToCurrency
+	case errors.Is(err, consts.ErrAmountScaleExceeded):
+		return http.StatusBadRequest, "Amount exceeds wallet currency precision"
+	case errors.Is(err, consts.ErrConvertedAmountTooSmall):
+		return http.StatusBadRequest, "Converted amount is too small"
+	case errors.Is(err, consts.ErrWalletClosed):
+		return http.StatusConflict, "Wallet is closed"
+	case errors.As(err, &blockedErr):
+		return http.StatusLocked, walletBlockedResponse(blockedErr).ErrorMessage
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound, "Wallet not found"
+	default:
+		return http.StatusInternalServerError, "Fail to transfer"
+	}
+}
+
+func limitExceededResponse(err *consts.LimitExceededError) api_gen.LimitExceededError {
+	return api_gen.LimitExceededError{
+		ErrorCode:    "LIMIT_EXCEEDED",
+		ErrorMessage: "Transaction exceeds the " + err.Limit + " limit",
+		Limit:        api_gen.LimitExceededErrorLimit(err.Limit),
+		ResetsAt:     err.ResetsAt,
+	}
+}
+
+func walletBlockedResponse(err *consts.WalletBlockedError) api_gen.WalletBlockedError {
+	status := api_gen.WalletBlockedErrorStatus(err.Status)
+	message := "Wallet " + err.WalletID + " is frozen"
+	switch status {
+	case api_gen.WalletBlockedErrorStatusDebitBlocked:
+		message = "Wallet " + err.WalletID + " is blocked for debits"
+	case api_gen.WalletBlockedErrorStatusCreditBlocked:
+		message = "Wallet " + err.WalletID + " is blocked for credits"
+	}
+	return api_gen.WalletBlockedError{
+		ErrorCode:    "WALLET_BLOCKED",
+		ErrorMessage: message,
+		WalletId:     err.WalletID,
+		Status:       status,
+	}
+}
+
+func transactionDetails(description, reference *string, metadata *map[string]interface{}) commands.TransactionDetails {
+	details := commands.TransactionDetails{Description: description, Reference: reference}
+	if metadata != nil {
+		details.Metadata = *metadata
+	}
+	return details
+}
+
+// nonEmpty treats an empty optional string as absent.
+func nonEmpty(value *string) *string {
+	if value == nil || *value == "" {
+		return nil
+	}
+	return value
 }
@@ -4,45 +4,212 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
 	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/services/commands"
+	"go.uber.org/mock/gomock"
 	"gorm.io/gorm"
 )
 
 func (suite *RestApisTestSuite) TestTransferBalance() {
+	description := "Dinner"
+	reference := "INV-1001"
+	tooLongReference := strings.Repeat("x", 101)
+	fromWallet, toWallet := "<Wallet1>", "<Wallet2>"
+	email, handle, empty := "friend@example.com", "friend", ""
 	testCases := []struct {
-		name        string
-		reqBody     api_gen.TransferRequest
-		mock        func()
-		wantStatus  int
-		wantErr     bool
-		expectedErr string
+		name           string
+		reqBody        api_gen.TransferRequest
+		idempotencyKey string
+		mock           func()
+		wantStatus     int
+		wantErr        bool
+		expectedErr    string
 	}{
 		{
 			name: "GivingValidRequest_WhenTransferBalanceSuccess_ThenReturnOk",
 			reqBody: api_gen.TransferRequest{
 				FromWalletId: "<Wallet1>",
-				ToWalletId:   "<Wallet2>",
-				Amount:       100,
+				ToWalletId:   &toWallet,
+				Amount:       money.MustParse("100"),
+			},
+			mock: func() {
+				suite.mockTransactionService.EXPECT().
+					HandleTransferBalance("<UserID>", "<Wallet1>", "<Wallet2>", money.MustParse("100"), commands.TransactionDetails{}, nil).
+					Return(&api_gen.TransactionResponseData{Id: "<TransactionID>"}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantErr:    false,
+		},
+		{
+			name: "GivingRecipientEmail_WhenTransferBalanceSuccess_ThenTransferToDefaultWallet",
+			reqBody: api_gen.TransferRequest{
+				FromWalletId: "<Wallet1>",
+				ToEmail:      &email,
+				Amount:       money.MustParse("100"),
+			},
+			mock: func() {
+				suite.mockFindRecipientService.EXPECT().HandleWallet(&email, nil).Return("<Wallet2>", nil)
+				suite.mockTransactionService.EXPECT().
+					HandleTransferBalance("<UserID>", "<Wallet1>", "<Wallet2>", money.MustParse("100"), commands.TransactionDetails{}, nil).
+					Return(&api_gen.TransactionResponseData{Id: "<TransactionID>"}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantErr:    false,
+		},
+		{
+			name: "GivingRecipientHandleAndEmptyWalletId_WhenTransferBalanceSuccess_ThenTransferToDefaultWallet",
+			reqBody: api_gen.TransferRequest{
+				FromWalletId: "<Wallet1>",
+				ToWalletId:   &empty,
+				ToHandle:     &handle,
+				Amount:       money.MustParse("100"),
+			},
+			mock: func() {
+				suite.mockFindRecipientService.EXPECT().HandleWallet(nil, &handle).Return("<Wallet2>", nil)
+				suite.mockTransactionService.EXPECT().
+					HandleTransferBalance("<UserID>", "<Wallet1>", "<Wallet2>", money.MustParse("100"), commands.TransactionDetails{}, nil).
+					Return(&api_gen.TransactionResponseData{Id: "<TransactionID>"}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantErr:    false,
+		},
+		{
+			name: "GivingUnknownHandle_WhenTransferBalance_ThenReturnNotFound",
+			reqBody: api_gen.TransferRequest{
+				FromWalletId: "<Wallet1>",
+				ToHandle:     &handle,
+				Amount:       money.MustParse("100"),
+			},
+			mock: func() {
+				suite.mockFindRecipientService.EXPECT().HandleWallet(nil, &handle).Return("", consts.ErrRecipientNotFound)
+			},
+			wantStatus:  http.StatusNotFound,
+			wantErr:     true,
+			expectedErr: "Recipient not found",
+		},
+		{
+			name: "GivingOwnDefaultWallet_WhenTransferBalance_ThenReturnBadRequest",
+			reqBody: api_gen.TransferRequest{
+				FromWalletId: "<Wallet1>",
+				ToEmail:      &email,
+				Amount:       money.MustParse("100"),
+			},
+			mock: func() {
+				suite.mockFindRecipientService.EXPECT().HandleWallet(&email, nil).Return("<Wallet1>", nil)
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "From and To wallet ID cannot be the same",
+		},
+		{
+			name: "GivingWalletIdAndEmail_WhenTransferBalance_ThenReturnBadRequest",
+			reqBody: api_gen.TransferRequest{
+				FromWalletId: "<Wallet1>",
+				ToWalletId:   &toWallet,
+				ToEmail:      &email,
+				Amount:       money.MustParse("100"),
+			},
+			mock:        func() {},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Exactly one of toWalletId, toEmail or toHandle is required",
+		},
+		{
+			name: "GivingNoRecipient_WhenTransferBalance_ThenReturnBadRequest",
+			reqBody: api_gen.TransferRequest{
+				FromWalletId: "<Wallet1>",
+				Amount:       money.MustParse("100"),
+			},
+			mock:        func() {},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Exactly one of toWalletId, toEmail or toHandle is required",
+		},
+		{
+			name: "GivingDetails_WhenTransferBalanceSuccess_ThenDetailsPassedToService",
+			reqBody: api_gen.TransferRequest{
+				FromWalletId: "<Wallet1>",
+				ToWalletId:   &toWallet,
+				Amount:       money.MustParse("100"),
+				Description:  &description,
+				Reference:    &reference,
+				Metadata:     &map[string]interface{}{"orderId": "A-1"},
 			},
 			mock: func() {
 				suite.mockTransactionService.EXPECT().
-					HandleTransferBalance("<UserID>", "<Wallet1>", "<Wallet2>", float64(100)).
-					Return(nil)
+					HandleTransferBalance("<UserID>", "<Wallet1>", "<Wallet2>", money.MustParse("100"), commands.TransactionDetails{
+						Description: &description,
+						Reference:   &reference,
+						Metadata:    map[string]interface{}{"orderId": "A-1"},
+					}, nil).
+					Return(&api_gen.TransactionResponseData{Id: "<TransactionID>"}, nil)
 			},
 			wantStatus: http.StatusOK,
 			wantErr:    false,
 		},
+		{
+			name: "GivingTooLongReference_WhenTransferBalance_ThenReturnBadRequest",
+			reqBody: api_gen.TransferRequest{
+				FromWalletId: "<Wallet1>",
+				ToWalletId:   &toWallet,
+				Amount:       money.MustParse("100"),
+				Reference:    &tooLongReference,
+			},
+			mock:        func() {},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Reference max 100",
+		},
+		{
+			name: "GivingIdempotencyKey_WhenTransferBalanceSuccess_ThenKeyPassedToService",
+			reqBody: api_gen.TransferRequest{
+				FromWalletId: "<Wallet1>",
+				ToWalletId:   &toWallet,
+				Amount:       money.MustParse("100"),
+			},
+			idempotencyKey: "<IdempotencyKey>",
+			mock: func() {
+				key := "<IdempotencyKey>"
+				suite.mockTransactionService.EXPECT().
+					HandleTransferBalance("<UserID>", "<Wallet1>", "<Wallet2>", money.MustParse("100"), commands.TransactionDetails{}, &key).
+					Return(&api_gen.TransactionResponseData{Id: "<TransactionID>"}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantErr:    false,
+		},
+		{
+			name: "GivingReusedIdempotencyKey_WhenTransferBalance_ThenReturnUnprocessableEntity",
+			reqBody: api_gen.TransferRequest{
+				FromWalletId: "<Wallet1>",
+				ToWalletId:   &toWallet,
+				Amount:       money.MustParse("100"),
+			},
+			idempotencyKey: "<IdempotencyKey>",
+			mock: func() {
+				key := "<IdempotencyKey>"
+				suite.mockTransactionService.EXPECT().
+					HandleTransferBalance("<UserID>", "<Wallet1>", "<Wallet2>", money.MustParse("100"), commands.TransactionDetails{}, &key).
+					Return(nil, consts.ErrIdempotencyKeyReused)
+			},
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantErr:     true,
+			expectedErr: "Idempotency key already used with a different request",
+		},
 		{
 			name: "GivingFromToTheSameWallet_WhenTransferBalance_ThenReturnBadRequest",
 			reqBody: api_gen.TransferRequest{
 				FromWalletId: "<Wallet1>",
-				ToWalletId:   "<Wallet1>",
-				Amount:       100,
+				ToWalletId:   &fromWallet,
+				Amount:       money.MustParse("100"),
 			},
 			mock: func() {
 			},
@@ -54,29 +221,45 @@ func (suite *RestApisTestSuite) TestTransferBalance() {
 			name: "GivingInsufficientBalance_WhenTransferBalance_ThenReturnBadRequest",
 			reqBody: api_gen.TransferRequest{
 				FromWalletId: "<Wallet1>",
-				ToWalletId:   "<Wallet2>",
-				Amount:       100,
+				ToWalletId:   &toWallet,
+				Amount:       money.MustParse("100"),
 			},
 			mock: func() {
 				suite.mockTransactionService.EXPECT().
-					HandleTransferBalance("<UserID>", "<Wallet1>", "<Wallet2>", float64(100)).
-					Return(consts.ErrInsufficientBalance)
+					HandleTransferBalance("<UserID>", "<Wallet1>", "<Wallet2>", money.MustParse("100"), commands.TransactionDetails{}, nil).
+					Return(nil, consts.ErrInsufficientBalance)
 			},
 			wantStatus:  http.StatusBadRequest,
 			wantErr:     true,
 			expectedErr: "Insufficient balance",
 		},
+		{
+			name: "GivingWalletsWithoutExchangeRate_WhenTransferBalance_ThenReturnBadRequest",
+			reqBody: api_gen.TransferRequest{
+				FromWalletId: "<Wallet1>",
+				ToWalletId:   &toWallet,
+				Amount:       money.MustParse("100"),
+			},
+			mock: func() {
+				suite.mockTransactionService.EXPECT().
+					HandleTransferBalance("<UserID>", "<Wallet1>", "<Wallet2>", money.MustParse("100"), commands.TransactionDetails{}, nil).
+					Return(nil, &consts.CurrencyMismatchError{FromCurrency: "THB", ToCurrency: "USD"})
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "No exchange rate from THB to USD",
+		},
 		{
 			name: "GivingInvalidWalletId_WhenNotFound_ThenReturnNotFound",
 			reqBody: api_gen.TransferRequest{
 				FromWalletId: "<Wallet1>",
-				ToWalletId:   "<Wallet2>",
-				Amount:       100,
+				ToWalletId:   &toWallet,
+				Amount:       money.MustParse("100"),
 			},
 			mock: func() {
 				suite.mockTransactionService.EXPECT().
-					HandleTransferBalance("<UserID>", "<Wallet1>", "<Wallet2>", float64(100)).
-					Return(gorm.ErrRecordNotFound)
+					HandleTransferBalance("<UserID>", "<Wallet1>", "<Wallet2>", money.MustParse("100"), commands.TransactionDetails{}, nil).
+					Return(nil, gorm.ErrRecordNotFound)
 			},
 			wantStatus:  http.StatusNotFound,
 			wantErr:     true,
@@ -86,13 +269,13 @@ func (suite *RestApisTestSuite) TestTransferBalance() {
 			name: "GivingValidRequest_WhenTransferBalanceFail_ThenReturnInternalServerError",
 			reqBody: api_gen.TransferRequest{
 				FromWalletId: "<Wallet1>",
-				ToWalletId:   "<Wallet2>",
-				Amount:       100,
+				ToWalletId:   &toWallet,
+				Amount:       money.MustParse("100"),
 			},
 			mock: func() {
 				suite.mockTransactionService.EXPECT().
-					HandleTransferBalance("<UserID>", "<Wallet1>", "<Wallet2>", float64(100)).
-					Return(errors.New("some error"))
+					HandleTransferBalance("<UserID>", "<Wallet1>", "<Wallet2>", money.MustParse("100"), commands.TransactionDetails{}, nil).
+					Return(nil, errors.New("some error"))
 			},
 			wantStatus:  http.StatusInternalServerError,
 			wantErr:     true,
@@ -107,6 +290,9 @@ func (suite *RestApisTestSuite) TestTransferBalance() {
 			body, _ := json.Marshal(tc.reqBody)
 			req, _ := http.NewRequest("POST", "/secure/transfer", bytes.NewBuffer(body))
 			req.Header.Set("Content-Type", "application/json")
+			if tc.idempotencyKey != "" {
+				req.Header.Set("Idempotency-Key", tc.idempotencyKey)
+			}
 			suite.server.ServeHTTP(w, req)
 			suite.Equal(tc.wantStatus, w.Code)
 			if tc.wantErr {
@@ -114,6 +300,176 @@ func (suite *RestApisTestSuite) TestTransferBalance() {
 				json.Unmarshal(w.Body.Bytes(), &resp)
 				suite.Equal(strconv.Itoa(w.Code), resp.ErrorCode)
 				suite.Equal(tc.expectedErr, resp.ErrorMessage)
+			} else {
+				var resp api_gen.TransactionResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal("<TransactionID>", resp.Data.Id)
+			}
+		})
+	}
+}
+
+func (suite *RestApisTestSuite) TestBatchTransfer() {
+	bestEffort := api_gen.BatchTransferRequestModeBestEffort
+	payslip := "PAY-2026-10"
+	twoLegs := []api_gen.BatchTransferLeg{
+		{ToWalletId: "<Wallet2>", Amount: money.MustParse("100"), Reference: &payslip},
+		{ToWalletId: "<Wallet3>", Amount: money.MustParse("50")},
+	}
+	twoServiceLegs := []commands.BatchTransferLeg{
+		{To: "<Wallet2>", Amount: money.MustParse("100"), Details: commands.TransactionDetails{Reference: &payslip}},
+		{To: "<Wallet3>", Amount: money.MustParse("50")},
+	}
+	testCases := []struct {
+		name          string
+		reqBody       api_gen.BatchTransferRequest
+		mock          func()
+		wantStatus    int
+		wantErr       bool
+		expectedErr   string
+		wantSucceeded int
+		wantFailed    int
+		wantTotal     money.Amount
+		wantLegErrors []string
+	}{
+		{
+			name:    "GivingDefaultMode_WhenBatchTransferSuccess_ThenAllLegsCompleted",
+			reqBody: api_gen.BatchTransferRequest{FromWalletId: "<Wallet1>", Legs: twoLegs},
+			mock: func() {
+				suite.mockTransactionService.EXPECT().
+					HandleBatchTransfer("<UserID>", "<Wallet1>", twoServiceLegs, true).
+					Return([]commands.BatchTransferResult{
+						{Transaction: &api_gen.TransactionResponseData{Id: "<TransactionID1>"}},
+						{Transaction: &api_gen.TransactionResponseData{Id: "<TransactionID2>"}},
+					}, nil)
+			},
+			wantStatus:    http.StatusOK,
+			wantSucceeded: 2,
+			wantTotal:     money.MustParse("150"),
+			wantLegErrors: []string{"", ""},
+		},
+		{
+			name:    "GivingBestEffortMode_WhenOneLegFails_ThenFailedLegReported",
+			reqBody: api_gen.BatchTransferRequest{FromWalletId: "<Wallet1>", Mode: &bestEffort, Legs: twoLegs},
+			mock: func() {
+				suite.mockTransactionService.EXPECT().
+					HandleBatchTransfer("<UserID>", "<Wallet1>", twoServiceLegs, false).
+					Return([]commands.BatchTransferResult{
+						{Transaction: &api_gen.TransactionResponseData{Id: "<TransactionID1>"}},
+						{Err: gorm.ErrRecordNotFound},
+					}, nil)
+			},
+			wantStatus:    http.StatusOK,
+			wantSucceeded: 1,
+			wantFailed:    1,
+			wantTotal:     money.MustParse("100"),
+			wantLegErrors: []string{"", "Wallet not found"},
+		},
+		{
+			name:    "GivingBestEffortMode_WhenLegExceedsLimit_ThenLimitReported",
+			reqBody: api_gen.BatchTransferRequest{FromWalletId: "<Wallet1>", Mode: &bestEffort, Legs: twoLegs},
+			mock: func() {
+				suite.mockTransactionService.EXPECT().
+					HandleBatchTransfer("<UserID>", "<Wallet1>", twoServiceLegs, false).
+					Return([]commands.BatchTransferResult{
+						{Err: &consts.LimitExceededError{Limit: consts.LimitPerTransaction}},
+						{Transaction: &api_gen.TransactionResponseData{Id: "<TransactionID2>"}},
+					}, nil)
+			},
+			wantStatus:    http.StatusOK,
+			wantSucceeded: 1,
+			wantFailed:    1,
+			wantTotal:     money.MustParse("50"),
+			wantLegErrors: []string{"Transaction exceeds the per_transaction_max limit", ""},
+		},
+		{
+			name:        "GivingNoLegs_WhenBatchTransfer_ThenReturnBadRequest",
+			reqBody:     api_gen.BatchTransferRequest{FromWalletId: "<Wallet1>", Legs: []api_gen.BatchTransferLeg{}},
+			mock:        func() {},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Legs min 1",
+		},
+		{
+			name: "GivingLegToSourceWallet_WhenBatchTransfer_ThenReturnBadRequest",
+			reqBody: api_gen.BatchTransferRequest{FromWalletId: "<Wallet1>", Legs: []api_gen.BatchTransferLeg{
+				{ToWalletId: "<Wallet2>", Amount: money.MustParse("100")},
+				{ToWalletId: "<Wallet1>", Amount: money.MustParse("50")},
+			}},
+			mock:        func() {},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Leg 1: From and To wallet ID cannot be the same",
+		},
+		{
+			name:    "GivingInsufficientBalance_WhenBatchTransfer_ThenReturnBadRequest",
+			reqBody: api_gen.BatchTransferRequest{FromWalletId: "<Wallet1>", Legs: twoLegs},
+			mock: func() {
+				suite.mockTransactionService.EXPECT().
+					HandleBatchTransfer("<UserID>", "<Wallet1>", twoServiceLegs, true).
+					Return(nil, consts.ErrInsufficientBalance)
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Insufficient balance",
+		},
+		{
+			name:    "GivingAllOrNothingMode_WhenLegFails_ThenReturnLegError",
+			reqBody: api_gen.BatchTransferRequest{FromWalletId: "<Wallet1>", Legs: twoLegs},
+			mock: func() {
+				suite.mockTransactionService.EXPECT().
+					HandleBatchTransfer("<UserID>", "<Wallet1>", twoServiceLegs, true).
+					Return(nil, &consts.BatchLegError{Index: 1, Err: gorm.ErrRecordNotFound})
+			},
+			wantStatus:  http.StatusNotFound,
+			wantErr:     true,
+			expectedErr: "Leg 1: Wallet not found",
+		},
+		{
+			name:    "GivingUnexpectedError_WhenBatchTransfer_ThenReturnInternalServerError",
+			reqBody: api_gen.BatchTransferRequest{FromWalletId: "<Wallet1>", Legs: twoLegs},
+			mock: func() {
+				suite.mockTransactionService.EXPECT().
+					HandleBatchTransfer("<UserID>", "<Wallet1>", twoServiceLegs, true).
+					Return(nil, errors.New("some error"))
+			},
+			wantStatus:  http.StatusInternalServerError,
+			wantErr:     true,
+			expectedErr: "Fail to transfer",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			w := httptest.NewRecorder()
+			body, _ := json.Marshal(tc.reqBody)
+			req, _ := http.NewRequest("POST", "/secure/transfer/batch", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			suite.server.ServeHTTP(w, req)
+			suite.Equal(tc.wantStatus, w.Code)
+			if tc.wantErr {
+				var resp api_gen.ErrorResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal(strconv.Itoa(w.Code), resp.ErrorCode)
+				suite.Equal(tc.expectedErr, resp.ErrorMessage)
+			} else {
+				var resp api_gen.BatchTransferResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal(tc.wantSucceeded, resp.Data.Succeeded)
+				suite.Equal(tc.wantFailed, resp.Data.Failed)
+				suite.Equal(tc.wantTotal, resp.Data.TotalAmount)
+				suite.Len(resp.Data.Legs, len(tc.wantLegErrors))
+				for i, leg := range resp.Data.Legs {
+					suite.Equal(i, leg.Index)
+					if tc.wantLegErrors[i] == "" {
+						suite.Equal(api_gen.BatchTransferLegResultStatusCompleted, leg.Status)
+						suite.NotNil(leg.Transaction)
+					} else {
+						suite.Equal(api_gen.BatchTransferLegResultStatusFailed, leg.Status)
+						suite.Equal(tc.wantLegErrors[i], *leg.ErrorMessage)
+					}
+				}
 			}
 		})
 	}
@@ -122,7 +478,7 @@ func (suite *RestApisTestSuite) TestTransferBalance() {
 func (suite *RestApisTestSuite) TestDepositPoints() {
 	testCases := []struct {
 		name        string
-		reqBody     api_gen.DepositRequest
+		reqBody     interface{}
 		mock        func()
 		wantStatus  int
 		wantErr     bool
@@ -132,26 +488,37 @@ func (suite *RestApisTestSuite) TestDepositPoints() {
 			name: "GivingValidRequest_WhenDepositPointsSuccess_ThenReturnOk",
 			reqBody: api_gen.DepositRequest{
 				WalletId: "<Wallet1>",
-				Amount:   100,
+				Amount:   money.MustParse("100"),
 			},
 			mock: func() {
 				suite.mockTransactionService.EXPECT().
-					HandleDepositWithDrawBalance("<UserID>", "<Wallet1>", float64(100)).
-					Return(nil)
+					HandleDepositWithDrawBalance("<UserID>", "<Wallet1>", money.MustParse("100"), commands.TransactionDetails{}, nil).
+					Return(&api_gen.TransactionResponseData{Id: "<TransactionID>"}, nil)
 			},
 			wantStatus: http.StatusOK,
 			wantErr:    false,
 		},
+		{
+			name: "GivingAmountBeyondScale_WhenDepositPoints_ThenReturnBadRequest",
+			reqBody: map[string]interface{}{
+				"walletId": "<Wallet1>",
+				"amount":   0.001,
+			},
+			mock:        func() {},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: `amount "0.001": amount exceeds supported decimal places`,
+		},
 		{
 			name: "GivingInvalidRequest_WhenNotFound_ThenReturnNotFound",
 			reqBody: api_gen.DepositRequest{
 				WalletId: "<Wallet1>",
-				Amount:   100,
+				Amount:   money.MustParse("100"),
 			},
 			mock: func() {
 				suite.mockTransactionService.EXPECT().
-					HandleDepositWithDrawBalance("<UserID>", "<Wallet1>", float64(100)).
-					Return(gorm.ErrRecordNotFound)
+					HandleDepositWithDrawBalance("<UserID>", "<Wallet1>", money.MustParse("100"), commands.TransactionDetails{}, nil).
+					Return(nil, gorm.ErrRecordNotFound)
 			},
 			wantStatus:  http.StatusNotFound,
 			wantErr:     true,
@@ -161,12 +528,12 @@ func (suite *RestApisTestSuite) TestDepositPoints() {
 			name: "GivingValidRequest_WhenDepositPointsFail_ThenReturnInternalServerError",
 			reqBody: api_gen.DepositRequest{
 				WalletId: "<Wallet1>",
-				Amount:   100,
+				Amount:   money.MustParse("100"),
 			},
 			mock: func() {
 				suite.mockTransactionService.EXPECT().
-					HandleDepositWithDrawBalance("<UserID>", "<Wallet1>", float64(100)).
-					Return(errors.New("fail"))
+					HandleDepositWithDrawBalance("<UserID>", "<Wallet1>", money.MustParse("100"), commands.TransactionDetails{}, nil).
+					Return(nil, errors.New("fail"))
 			},
 			wantStatus:  http.StatusInternalServerError,
 			wantErr:     true,
@@ -206,12 +573,12 @@ func (suite *RestApisTestSuite) TestWithdrawPoints() {
 			name: "GivingValidRequest_WhenWithdrawPointsSuccess_ThenReturnOk",
 			reqBody: api_gen.WithdrawRequest{
 				WalletId: "<Wallet1>",
-				Amount:   100,
+				Amount:   money.MustParse("100"),
 			},
 			mock: func() {
 				suite.mockTransactionService.EXPECT().
-					HandleDepositWithDrawBalance("<UserID>", "<Wallet1>", float64(-100)).
-					Return(nil)
+					HandleDepositWithDrawBalance("<UserID>", "<Wallet1>", money.MustParse("-100"), commands.TransactionDetails{}, nil).
+					Return(&api_gen.TransactionResponseData{Id: "<TransactionID>"}, nil)
 			},
 			wantStatus: http.StatusOK,
 			wantErr:    false,
@@ -220,12 +587,12 @@ func (suite *RestApisTestSuite) TestWithdrawPoints() {
 			name: "GivingInvalidRequest_WhenInsufficientBalance_ThenReturnBadRequest",
 			reqBody: api_gen.WithdrawRequest{
 				WalletId: "<Wallet1>",
-				Amount:   100,
+				Amount:   money.MustParse("100"),
 			},
 			mock: func() {
 				suite.mockTransactionService.EXPECT().
-					HandleDepositWithDrawBalance("<UserID>", "<Wallet1>", float64(-100)).
-					Return(consts.ErrInsufficientBalance)
+					HandleDepositWithDrawBalance("<UserID>", "<Wallet1>", money.MustParse("-100"), commands.TransactionDetails{}, nil).
+					Return(nil, consts.ErrInsufficientBalance)
 			},
 			wantStatus:  http.StatusBadRequest,
 			wantErr:     true,
@@ -235,12 +602,12 @@ func (suite *RestApisTestSuite) TestWithdrawPoints() {
 			name: "GivingInvalidRequest_WhenNotFound_ThenReturnNotFound",
 			reqBody: api_gen.WithdrawRequest{
 				WalletId: "<Wallet1>",
-				Amount:   100,
+				Amount:   money.MustParse("100"),
 			},
 			mock: func() {
 				suite.mockTransactionService.EXPECT().
-					HandleDepositWithDrawBalance("<UserID>", "<Wallet1>", float64(-100)).
-					Return(gorm.ErrRecordNotFound)
+					HandleDepositWithDrawBalance("<UserID>", "<Wallet1>", money.MustParse("-100"), commands.TransactionDetails{}, nil).
+					Return(nil, gorm.ErrRecordNotFound)
 			},
 			wantStatus:  http.StatusNotFound,
 			wantErr:     true,
@@ -250,12 +617,12 @@ func (suite *RestApisTestSuite) TestWithdrawPoints() {
 			name: "GivingValidRequest_WhenWithdrawPointsFail_ThenReturnInternalServerError",
 			reqBody: api_gen.WithdrawRequest{
 				WalletId: "<Wallet1>",
-				Amount:   100,
+				Amount:   money.MustParse("100"),
 			},
 			mock: func() {
 				suite.mockTransactionService.EXPECT().
-					HandleDepositWithDrawBalance("<UserID>", "<Wallet1>", float64(-100)).
-					Return(errors.New("fail"))
+					HandleDepositWithDrawBalance("<UserID>", "<Wallet1>", money.MustParse("-100"), commands.TransactionDetails{}, nil).
+					Return(nil, errors.New("fail"))
 			},
 			wantStatus:  http.StatusInternalServerError,
 			wantErr:     true,
@@ -282,36 +649,238 @@ func (suite *RestApisTestSuite) TestWithdrawPoints() {
 	}
 }
 
+func (suite *RestApisTestSuite) TestReverseTransaction() {
+	partial := money.MustParse("20")
+
+	testCases := []struct {
+		name        string
+		reqBody     interface{}
+		mock        func()
+		wantStatus  int
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:    "GivingEmptyBody_WhenReverseSuccess_ThenReturnOk",
+			reqBody: api_gen.ReverseTransactionRequest{},
+			mock: func() {
+				suite.mockTransactionService.EXPECT().
+					HandleReverseTransaction("<UserID>", "<TransactionID>", nil, commands.TransactionDetails{}, nil).
+					Return(&api_gen.TransactionResponseData{Id: "<ReversalID>"}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantErr:    false,
+		},
+		{
+			name:    "GivingPartialAmount_WhenRefundExceedsOriginal_ThenReturnBadRequest",
+			reqBody: api_gen.ReverseTransactionRequest{Amount: &partial},
+			mock: func() {
+				suite.mockTransactionService.EXPECT().
+					HandleReverseTransaction("<UserID>", "<TransactionID>", &partial, commands.TransactionDetails{}, nil).
+					Return(nil, consts.ErrRefundExceedsOriginal)
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Refund exceeds the remaining refundable amount",
+		},
+		{
+			name:    "GivingWithdrawal_WhenReverse_ThenReturnBadRequest",
+			reqBody: api_gen.ReverseTransactionRequest{},
+			mock: func() {
+				suite.mockTransactionService.EXPECT().
+					HandleReverseTransaction("<UserID>", "<TransactionID>", nil, commands.TransactionDetails{}, nil).
+					Return(nil, consts.ErrTransactionNotReversible)
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Only deposits and transfers can be reversed",
+		},
+		{
+			name:        "GivingNegativeAmount_WhenReverse_ThenReturnBadRequest",
+			reqBody:     map[string]interface{}{"amount": -5},
+			mock:        func() {},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Amount gt 0",
+		},
+		{
+			name:    "GivingUnknownTransaction_WhenReverse_ThenReturnNotFound",
+			reqBody: api_gen.ReverseTransactionRequest{},
+			mock: func() {
+				suite.mockTransactionService.EXPECT().
+					HandleReverseTransaction("<UserID>", "<TransactionID>", nil, commands.TransactionDetails{}, nil).
+					Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus:  http.StatusNotFound,
+			wantErr:     true,
+			expectedErr: "Transaction not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			w := httptest.NewRecorder()
+			body, _ := json.Marshal(tc.reqBody)
+			req, _ := http.NewRequest("POST", "/secure/transaction/<TransactionID>/reverse", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			suite.server.ServeHTTP(w, req)
+			suite.Equal(tc.wantStatus, w.Code)
+			if tc.wantErr {
+				var resp api_gen.ErrorResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal(strconv.Itoa(w.Code), resp.ErrorCode)
+				suite.Equal(tc.expectedErr, resp.ErrorMessage)
+			}
+		})
+	}
+}
+
 func (suite *RestApisTestSuite) TestListWalletTransactions() {
+	limit := 30
+	pageParams := api_gen.ListWalletTransactionsParams{Limit: &limit}
+	pagination := &api_gen.CursorPageResponseData{Limit: 30}
+
 	testCases := []struct {
 		name           string
 		walletId       string
+		query          string
+		rawQuery       string
 		mock           func()
 		wantStatus     int
 		wantErr        bool
 		expectedErr    string
 		expectedTxsLen int
 	}{
+		{
+			name:     "GivingReferenceFilter_WhenListWalletTransactionsSuccess_ThenPassReferenceToService",
+			walletId: "<Wallet1>",
+			query:    "&reference=INV-1001",
+			mock: func() {
+				reference := "INV-1001"
+				params := pageParams
+				params.Reference = &reference
+				suite.mockListTransactionsService.EXPECT().
+					Handle("<UserID>", "<Wallet1>", params, 30).
+					Return([]api_gen.TransactionResponseData{
+						{
+							FromWalletId: "<Wallet1>",
+							ToWalletId:   "<Wallet2>",
+							Amount:       money.MustParse("50"),
+							Type:         "transfer",
+							Reference:    &reference,
+						},
+					}, pagination, nil)
+			},
+			wantStatus:     http.StatusOK,
+			wantErr:        false,
+			expectedTxsLen: 1,
+		},
+		{
+			name:     "GivingHistoryFilters_WhenListWalletTransactionsSuccess_ThenPassFiltersToService",
+			walletId: "<Wallet1>",
+			query:    "&type=transfer&direction=out&createdFrom=2024-03-01T00:00:00Z&minAmount=10.50&counterpartyWalletId=<Wallet2>&memo=rent&sort=largest",
+			mock: func() {
+				transferType, out, largest := api_gen.ListWalletTransactionsParamsTypeTransfer, api_gen.ListWalletTransactionsParamsDirectionOut, api_gen.ListWalletTransactionsParamsSortLargest
+				createdFrom := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+				minAmount, counterparty, memo := "10.50", "<Wallet2>", "rent"
+				params := pageParams
+				params.Type, params.Direction, params.Sort = &transferType, &out, &largest
+				params.CreatedFrom, params.MinAmount, params.CounterpartyWalletId, params.Memo = &createdFrom, &minAmount, &counterparty, &memo
+				suite.mockListTransactionsService.EXPECT().
+					Handle("<UserID>", "<Wallet1>", params, 30).
+					Return([]api_gen.TransactionResponseData{}, pagination, nil)
+			},
+			wantStatus:     http.StatusOK,
+			wantErr:        false,
+			expectedTxsLen: 0,
+		},
+		{
+			name:        "GivingLimitAboveMaximum_WhenListWalletTransactions_ThenReturnBadRequest",
+			walletId:    "<Wallet1>",
+			rawQuery:    "limit=500",
+			mock:        func() {},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Limit max 100",
+		},
+		{
+			name:     "GivingInvalidCursor_WhenListWalletTransactions_ThenReturnBadRequest",
+			walletId: "<Wallet1>",
+			query:    "&cursor=bogus",
+			mock: func() {
+				suite.mockListTransactionsService.EXPECT().
+					Handle("<UserID>", "<Wallet1>", gomock.Any(), 30).
+					Return(nil, nil, consts.ErrInvalidCursor)
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Invalid cursor",
+		},
+		{
+			name:        "GivingUnknownDirection_WhenListWalletTransactions_ThenReturnBadRequest",
+			walletId:    "<Wallet1>",
+			query:       "&direction=sideways",
+			mock:        func() {},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Direction oneof in out",
+		},
+		{
+			name:        "GivingNonNumericAmount_WhenListWalletTransactions_ThenReturnBadRequest",
+			walletId:    "<Wallet1>",
+			query:       "&maxAmount=lots",
+			mock:        func() {},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "MaxAmount numeric",
+		},
+		{
+			name:     "GivingInvertedAmountRange_WhenListWalletTransactions_ThenReturnBadRequest",
+			walletId: "<Wallet1>",
+			query:    "&minAmount=100&maxAmount=10",
+			mock: func() {
+				suite.mockListTransactionsService.EXPECT().
+					Handle("<UserID>", "<Wallet1>", gomock.Any(), 30).
+					Return(nil, nil, consts.ErrInvalidAmountRange)
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "minAmount must not exceed maxAmount",
+		},
+		{
+			name:     "GivingInvertedDateRange_WhenListWalletTransactions_ThenReturnBadRequest",
+			walletId: "<Wallet1>",
+			query:    "&createdFrom=2024-04-01T00:00:00Z&createdTo=2024-03-01T00:00:00Z",
+			mock: func() {
+				suite.mockListTransactionsService.EXPECT().
+					Handle("<UserID>", "<Wallet1>", gomock.Any(), 30).
+					Return(nil, nil, consts.ErrInvalidDateRange)
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "createdFrom must be before createdTo",
+		},
 		{
 			name:     "GivingValidRequest_WhenListWalletTransactionsSuccess_ThenReturnOk",
 			walletId: "<Wallet1>",
 			mock: func() {
 				suite.mockListTransactionsService.EXPECT().
-					Handle("<UserID>", "<Wallet1>", 1, 30).
-					Return(int64(100), []api_gen.TransactionResponseData{
+					Handle("<UserID>", "<Wallet1>", pageParams, 30).
+					Return([]api_gen.TransactionResponseData{
 						{
 							FromWalletId: "<Wallet1>",
 							ToWalletId:   "<Wallet2>",
-							Amount:       50,
+							Amount:       money.MustParse("50"),
 							Type:         "transfer",
 						},
 						{
 							FromWalletId: "<Wallet2>",
 							ToWalletId:   "<Wallet1>",
-							Amount:       30,
+							Amount:       money.MustParse("30"),
 							Type:         "transfer",
 						},
-					}, nil)
+					}, pagination, nil)
 			},
 			wantStatus:     http.StatusOK,
 			wantErr:        false,
@@ -322,8 +891,8 @@ func (suite *RestApisTestSuite) TestListWalletTransactions() {
 			walletId: "<Wallet1>",
 			mock: func() {
 				suite.mockListTransactionsService.EXPECT().
-					Handle("<UserID>", "<Wallet1>", 1, 30).
-					Return(int64(0), nil, gorm.ErrRecordNotFound)
+					Handle("<UserID>", "<Wallet1>", pageParams, 30).
+					Return(nil, nil, gorm.ErrRecordNotFound)
 			},
 			wantStatus:  http.StatusNotFound,
 			wantErr:     true,
@@ -334,8 +903,8 @@ func (suite *RestApisTestSuite) TestListWalletTransactions() {
 			walletId: "<Wallet1>",
 			mock: func() {
 				suite.mockListTransactionsService.EXPECT().
-					Handle("<UserID>", "<Wallet1>", 1, 30).
-					Return(int64(0), nil, errors.New("fail"))
+					Handle("<UserID>", "<Wallet1>", pageParams, 30).
+					Return(nil, nil, errors.New("fail"))
 			},
 			wantStatus:  http.StatusInternalServerError,
 			wantErr:     true,
@@ -347,7 +916,11 @@ func (suite *RestApisTestSuite) TestListWalletTransactions() {
 		suite.Run(tc.name, func() {
 			tc.mock()
 			w := httptest.NewRecorder()
-			req, _ := http.NewRequest("GET", "/secure/wallet/"+tc.walletId+"/transactions?page=1&limit=30", nil)
+			query := "limit=30" + tc.query
+			if tc.rawQuery != "" {
+				query = tc.rawQuery
+			}
+			req, _ := http.NewRequest("GET", "/secure/wallet/"+tc.walletId+"/transactions?"+query, nil)
 			suite.server.ServeHTTP(w, req)
 			suite.Equal(tc.wantStatus, w.Code)
 			if tc.wantErr {
@@ -366,3 +939,214 @@ func (suite *RestApisTestSuite) TestListWalletTransactions() {
 		})
 	}
 }
+
+func (suite *RestApisTestSuite) TestWalletBlockedResponses() {
+	toWallet := "<Wallet2>"
+
+	testCases := []struct {
+		name        string
+		path        string
+		reqBody     interface{}
+		mock        func()
+		status      api_gen.WalletBlockedErrorStatus
+		expectedErr string
+	}{
+		{
+			name: "GivingFrozenRecipient_WhenTransferBalance_ThenReturnLocked",
+			path: "/secure/transfer",
+			reqBody: api_gen.TransferRequest{
+				FromWalletId: "<Wallet1>",
+				ToWalletId:   &toWallet,
+				Amount:       money.MustParse("100"),
+			},
+			mock: func() {
+				suite.mockTransactionService.EXPECT().
+					HandleTransferBalance("<UserID>", "<Wallet1>", "<Wallet2>", money.MustParse("100"), commands.TransactionDetails{}, nil).
+					Return(nil, &consts.WalletBlockedError{WalletID: "<Wallet2>", Status: "frozen"})
+			},
+			status:      api_gen.WalletBlockedErrorStatusFrozen,
+			expectedErr: "Wallet <Wallet2> is frozen",
+		},
+		{
+			name: "GivingCreditBlockedWallet_WhenDepositPoints_ThenReturnLocked",
+			path: "/secure/deposit",
+			reqBody: api_gen.DepositRequest{
+				WalletId: "<Wallet1>",
+				Amount:   money.MustParse("100"),
+			},
+			mock: func() {
+				suite.mockTransactionService.EXPECT().
+					HandleDepositWithDrawBalance("<UserID>", "<Wallet1>", money.MustParse("100"), commands.TransactionDetails{}, nil).
+					Return(nil, &consts.WalletBlockedError{WalletID: "<Wallet1>", Status: "credit_blocked"})
+			},
+			status:      api_gen.WalletBlockedErrorStatusCreditBlocked,
+			expectedErr: "Wallet <Wallet1> is blocked for credits",
+		},
+		{
+			name: "GivingDebitBlockedWallet_WhenWithdrawPoints_ThenReturnLocked",
+			path: "/secure/withdraw",
+			reqBody: api_gen.WithdrawRequest{
+				WalletId: "<Wallet1>",
+				Amount:   money.MustParse("100"),
+			},
+			mock: func() {
+				suite.mockTransactionService.EXPECT().
+					HandleDepositWithDrawBalance("<UserID>", "<Wallet1>", money.MustParse("-100"), commands.TransactionDetails{}, nil).
+					Return(nil, &consts.WalletBlockedError{WalletID: "<Wallet1>", Status: "debit_blocked"})
+			},
+			status:      api_gen.WalletBlockedErrorStatusDebitBlocked,
+			expectedErr: "Wallet <Wallet1> is blocked for debits",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			w := httptest.NewRecorder()
+			body, _ := json.Marshal(tc.reqBody)
+			req, _ := http.NewRequest("POST", tc.path, bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			suite.server.ServeHTTP(w, req)
+			suite.Equal(http.StatusLocked, w.Code)
+
+			var resp api_gen.WalletBlockedError
+			json.Unmarshal(w.Body.Bytes(), &resp)
+			suite.Equal("WALLET_BLOCKED", resp.ErrorCode)
+			suite.Equal(tc.status, resp.Status)
+			suite.Equal(tc.expectedErr, resp.ErrorMessage)
+		})
+	}
+}
+
+func (suite *RestApisTestSuite) TestExportWalletTransactions() {
+	createdFrom := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	createdTo := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	rangeQuery := "?createdFrom=2024-01-01T00:00:00Z&createdTo=2024-02-01T00:00:00Z"
+	writeRows := func(rows string) func(string, string, api_gen.ExportWalletTransactionsParams, io.Writer) error {
+		return func(_, _ string, _ api_gen.ExportWalletTransactionsParams, w io.Writer) error {
+			_, err := io.WriteString(w, rows)
+			return err
+		}
+	}
+
+	testCases := []struct {
+		name            string
+		query           string
+		mock            func()
+		wantStatus      int
+		wantErr         bool
+		expectedErr     string
+		wantContentType string
+		wantDisposition string
+		wantBody        string
+	}{
+		{
+			name:  "GivingDateRange_WhenExportSuccess_ThenStreamCsvAttachment",
+			query: rangeQuery,
+			mock: func() {
+				suite.mockExportTransactionsService.EXPECT().
+					Handle("<UserID>", "<WalletID>", api_gen.ExportWalletTransactionsParams{CreatedFrom: &createdFrom, CreatedTo: &createdTo}, gomock.Any()).
+					DoAndReturn(writeRows("id,created_at\nTRN1,2024-01-01T01:00:00Z\n"))
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv",
+			wantDisposition: `attachment; filename="transactions-<WalletID>.csv"`,
+			wantBody:        "id,created_at\nTRN1,2024-01-01T01:00:00Z\n",
+		},
+		{
+			name:  "GivingOfxFormat_WhenExportSuccess_ThenStreamOfxAttachment",
+			query: rangeQuery + "&format=ofx",
+			mock: func() {
+				suite.mockExportTransactionsService.EXPECT().
+					Handle("<UserID>", "<WalletID>", gomock.Any(), gomock.Any()).
+					DoAndReturn(writeRows("<OFX></OFX>\n"))
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/x-ofx",
+			wantDisposition: `attachment; filename="transactions-<WalletID>.ofx"`,
+			wantBody:        "<OFX></OFX>\n",
+		},
+		{
+			name:  "GivingMissingDateRange_WhenExport_ThenReturnBadRequest",
+			query: "?createdFrom=2024-01-01T00:00:00Z",
+			mock: func() {
+				suite.mockExportTransactionsService.EXPECT().Handle(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "CreatedTo required",
+		},
+		{
+			name:  "GivingUnknownFormat_WhenExport_ThenReturnBadRequest",
+			query: rangeQuery + "&format=xlsx",
+			mock: func() {
+				suite.mockExportTransactionsService.EXPECT().Handle(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Format oneof csv ofx jsonl",
+		},
+		{
+			name:  "GivingInvertedDateRange_WhenExport_ThenReturnBadRequest",
+			query: "?createdFrom=2024-02-01T00:00:00Z&createdTo=2024-01-01T00:00:00Z",
+			mock: func() {
+				suite.mockExportTransactionsService.EXPECT().
+					Handle("<UserID>", "<WalletID>", gomock.Any(), gomock.Any()).
+					Return(consts.ErrInvalidDateRange)
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "createdFrom must be before createdTo",
+		},
+		{
+			name:  "GivingUnknownWallet_WhenExport_ThenReturnNotFound",
+			query: rangeQuery,
+			mock: func() {
+				suite.mockExportTransactionsService.EXPECT().
+					Handle("<UserID>", "<WalletID>", gomock.Any(), gomock.Any()).
+					Return(gorm.ErrRecordNotFound)
+			},
+			wantStatus:  http.StatusNotFound,
+			wantErr:     true,
+			expectedErr: "Wallet not found",
+		},
+		{
+			name:  "GivingFailureMidStream_WhenExport_ThenKeepStatusAndTruncate",
+			query: rangeQuery,
+			mock: func() {
+				suite.mockExportTransactionsService.EXPECT().
+					Handle("<UserID>", "<WalletID>", gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_, _ string, _ api_gen.ExportWalletTransactionsParams, w io.Writer) error {
+						io.WriteString(w, "id,created_at\n")
+						return errors.New("db error")
+					})
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv",
+			wantDisposition: `attachment; filename="transactions-<WalletID>.csv"`,
+			wantBody:        "id,created_at\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/secure/wallet/<WalletID>/transactions/export"+tc.query, nil)
+			suite.server.ServeHTTP(w, req)
+			suite.Equal(tc.wantStatus, w.Code)
+			if tc.wantErr {
+				var resp api_gen.ErrorResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal(strconv.Itoa(w.Code), resp.ErrorCode)
+				suite.Equal(tc.expectedErr, resp.ErrorMessage)
+				suite.Empty(w.Header().Get("Content-Disposition"))
+				return
+			}
+
+			suite.Equal(tc.wantContentType, w.Header().Get("Content-Type"))
+			suite.Equal(tc.wantDisposition, w.Header().Get("Content-Disposition"))
+			suite.Equal(tc.wantBody, w.Body.String())
+		})
+	}
+}
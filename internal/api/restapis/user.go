@@ -0,0 +1,84 @@
+package restapis
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/utils"
+	"gorm.io/gorm"
+)
+
+// (GET /secure/recipients)
+func (h *HttpServer) LookupRecipient(ctx *gin.Context, params api_gen.LookupRecipientParams) {
+	result, err := h.App.Queries.FindRecipientService.Handle(params.Email, params.Handle)
+	if err != nil {
+		status, message := recipientFailure(err)
+		ctx.JSON(status, api_gen.ErrorResponse{ErrorCode: strconv.Itoa(status), ErrorMessage: message})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api_gen.RecipientResponse{Data: result})
+}
+
+// (GET /secure/user/settings)
+func (h *HttpServer) GetUserSettings(ctx *gin.Context) {
+	userId := utils.GetMiddlewareUserId(ctx)
+
+	result, err := h.App.Queries.GetUserSettingsService.Handle(userId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, api_gen.ErrorResponse{ErrorCode: "500", ErrorMessage: "Failed to get user settings"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api_gen.UserSettingsResponse{Data: result})
+}
+
+// (PUT /secure/user/settings)
+func (h *HttpServer) UpdateUserSettings(ctx *gin.Context) {
+	var req api_gen.UpdateUserSettingsRequest
+	if !utils.BindAndValidateRequestBody(ctx, &req, h.App.Utils.Validate) {
+		return
+	}
+
+	userId := utils.GetMiddlewareUserId(ctx)
+
+	result, err := h.App.Commands.UserSettingsService.HandleUpdate(userId, req)
+	if err != nil {
+		if errors.Is(err, consts.ErrHandleTaken) {
+			ctx.JSON(http.StatusConflict, api_gen.ErrorResponse{ErrorCode: "409", ErrorMessage: "Handle is already taken"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrWalletClosed) {
+			ctx.JSON(http.StatusConflict, api_gen.ErrorResponse{ErrorCode: "409", ErrorMessage: "Wallet is closed"})
+			return
+		}
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, api_gen.ErrorResponse{ErrorCode: "404", ErrorMessage: "Wallet not found"})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, api_gen.ErrorResponse{ErrorCode: "500", ErrorMessage: "Failed to update user settings"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api_gen.UserSettingsResponse{Data: result})
+}
+
+func recipientFailure(err error) (int, string) {
+	switch {
+	case errors.Is(err, consts.ErrInvalidRecipient):
+		return http.StatusBadRequest, "Exactly one of email or handle is required"
+	case errors.Is(err, consts.ErrRecipientNotFound):
+		return http.StatusNotFound, "Recipient not found"
+	case errors.Is(err, consts.ErrRecipientHasNoWallet):
+		return http.StatusNotFound, "Recipient has no wallet"
+	default:
+		return http.StatusInternalServerError, "Failed to find recipient"
+	}
+}
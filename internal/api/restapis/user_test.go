@@ -0,0 +1,218 @@
+package restapis_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"gorm.io/gorm"
+)
+
+func (suite *RestApisTestSuite) TestLookupRecipient() {
+	email, handle := "friend@example.com", "friend"
+
+	testCases := []struct {
+		name        string
+		query       string
+		mock        func()
+		wantStatus  int
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:  "GivingEmail_WhenLookupSuccess_ThenReturnMaskedName",
+			query: "?email=friend@example.com",
+			mock: func() {
+				suite.mockFindRecipientService.EXPECT().
+					Handle(&email, nil).
+					Return(&api_gen.RecipientResponseData{DisplayName: "J*** D**"}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantErr:    false,
+		},
+		{
+			name:  "GivingUnknownHandle_WhenLookup_ThenReturnNotFound",
+			query: "?handle=friend",
+			mock: func() {
+				suite.mockFindRecipientService.EXPECT().
+					Handle(nil, &handle).
+					Return(nil, consts.ErrRecipientNotFound)
+			},
+			wantStatus:  http.StatusNotFound,
+			wantErr:     true,
+			expectedErr: "Recipient not found",
+		},
+		{
+			name:  "GivingNoQuery_WhenLookup_ThenReturnBadRequest",
+			query: "",
+			mock: func() {
+				suite.mockFindRecipientService.EXPECT().
+					Handle(nil, nil).
+					Return(nil, consts.ErrInvalidRecipient)
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Exactly one of email or handle is required",
+		},
+		{
+			name:  "GivingRecipientWithoutWallet_WhenLookup_ThenReturnNotFound",
+			query: "?handle=friend",
+			mock: func() {
+				suite.mockFindRecipientService.EXPECT().
+					Handle(nil, &handle).
+					Return(nil, consts.ErrRecipientHasNoWallet)
+			},
+			wantStatus:  http.StatusNotFound,
+			wantErr:     true,
+			expectedErr: "Recipient has no wallet",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/secure/recipients"+tc.query, nil)
+			suite.server.ServeHTTP(w, req)
+			suite.Equal(tc.wantStatus, w.Code)
+			if tc.wantErr {
+				var resp api_gen.ErrorResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal(strconv.Itoa(w.Code), resp.ErrorCode)
+				suite.Equal(tc.expectedErr, resp.ErrorMessage)
+			} else {
+				var resp api_gen.RecipientResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal("J*** D**", resp.Data.DisplayName)
+			}
+		})
+	}
+}
+
+func (suite *RestApisTestSuite) TestGetUserSettings() {
+	testCases := []struct {
+		name        string
+		mock        func()
+		wantStatus  int
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivingUser_WhenGetSettingsSuccess_ThenReturnOk",
+			mock: func() {
+				suite.mockGetUserSettingsService.EXPECT().
+					Handle("<UserID>").
+					Return(&api_gen.UserSettingsResponseData{Email: "<Email>", DisplayName: "<DisplayName>"}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantErr:    false,
+		},
+		{
+			name: "GivingUser_WhenGetSettingsFails_ThenReturnInternalServerError",
+			mock: func() {
+				suite.mockGetUserSettingsService.EXPECT().
+					Handle("<UserID>").
+					Return(nil, errors.New("query error"))
+			},
+			wantStatus:  http.StatusInternalServerError,
+			wantErr:     true,
+			expectedErr: "Failed to get user settings",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/secure/user/settings", nil)
+			suite.server.ServeHTTP(w, req)
+			suite.Equal(tc.wantStatus, w.Code)
+			if tc.wantErr {
+				var resp api_gen.ErrorResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal(strconv.Itoa(w.Code), resp.ErrorCode)
+				suite.Equal(tc.expectedErr, resp.ErrorMessage)
+			}
+		})
+	}
+}
+
+func (suite *RestApisTestSuite) TestUpdateUserSettings() {
+	handle, walletId, badHandle := "friend", "<WalletID>", "friend_1"
+
+	testCases := []struct {
+		name        string
+		reqBody     api_gen.UpdateUserSettingsRequest
+		mock        func()
+		wantStatus  int
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:    "GivingHandleAndWallet_WhenUpdateSuccess_ThenReturnOk",
+			reqBody: api_gen.UpdateUserSettingsRequest{Handle: &handle, DefaultWalletId: &walletId},
+			mock: func() {
+				suite.mockUserSettingsService.EXPECT().
+					HandleUpdate("<UserID>", api_gen.UpdateUserSettingsRequest{Handle: &handle, DefaultWalletId: &walletId}).
+					Return(&api_gen.UserSettingsResponseData{Handle: &handle, DefaultWalletId: &walletId}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantErr:    false,
+		},
+		{
+			name:        "GivingHandleWithSymbols_WhenUpdate_ThenReturnBadRequest",
+			reqBody:     api_gen.UpdateUserSettingsRequest{Handle: &badHandle},
+			mock:        func() {},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Handle alphanum",
+		},
+		{
+			name:    "GivingTakenHandle_WhenUpdate_ThenReturnConflict",
+			reqBody: api_gen.UpdateUserSettingsRequest{Handle: &handle},
+			mock: func() {
+				suite.mockUserSettingsService.EXPECT().
+					HandleUpdate("<UserID>", api_gen.UpdateUserSettingsRequest{Handle: &handle}).
+					Return(nil, consts.ErrHandleTaken)
+			},
+			wantStatus:  http.StatusConflict,
+			wantErr:     true,
+			expectedErr: "Handle is already taken",
+		},
+		{
+			name:    "GivingOtherUsersWallet_WhenUpdate_ThenReturnNotFound",
+			reqBody: api_gen.UpdateUserSettingsRequest{DefaultWalletId: &walletId},
+			mock: func() {
+				suite.mockUserSettingsService.EXPECT().
+					HandleUpdate("<UserID>", api_gen.UpdateUserSettingsRequest{DefaultWalletId: &walletId}).
+					Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus:  http.StatusNotFound,
+			wantErr:     true,
+			expectedErr: "Wallet not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			w := httptest.NewRecorder()
+			body, _ := json.Marshal(tc.reqBody)
+			req, _ := http.NewRequest("PUT", "/secure/user/settings", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			suite.server.ServeHTTP(w, req)
+			suite.Equal(tc.wantStatus, w.Code)
+			if tc.wantErr {
+				var resp api_gen.ErrorResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				suite.Equal(strconv.Itoa(w.Code), resp.ErrorCode)
+				suite.Equal(tc.expectedErr, resp.ErrorMessage)
+			}
+		})
+	}
+}
@@ -3,16 +3,18 @@ package restapis
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
 	"github.com/slilp/go-wallet/internal/utils"
 	"gorm.io/gorm"
 )
 
 // (POST /secure/wallet)
 func (h *HttpServer) CreateWallet(ctx *gin.Context) {
-	var req api_gen.WalletRequest
+	var req api_gen.CreateWalletRequest
 	if !utils.BindAndValidateRequestBody(ctx, &req, h.App.Utils.Validate) {
 		return
 	}
@@ -20,6 +22,11 @@ func (h *HttpServer) CreateWallet(ctx *gin.Context) {
 	userId := utils.GetMiddlewareUserId(ctx)
 
 	if err := h.App.Commands.WalletService.HandleCreate(userId, req); err != nil {
+		if errors.Is(err, consts.ErrUnsupportedCurrency) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Unsupported currency"})
+			return
+		}
+
 		ctx.JSON(http.StatusInternalServerError, api_gen.ErrorResponse{ErrorCode: "500", ErrorMessage: "Failed to create wallet"})
 		return
 	}
@@ -70,15 +77,113 @@ func (h *HttpServer) DeleteWallet(ctx *gin.Context, walletId string) {
 
 	userId := utils.GetMiddlewareUserId(ctx)
 
-	if err := h.App.Commands.WalletService.HandleDelete(userId, walletId); err != nil {
+	if _, err := h.App.Commands.WalletService.HandleClose(userId, walletId, nil); err != nil {
+		status, message := closeWalletFailure(err)
+		ctx.JSON(status, api_gen.ErrorResponse{ErrorCode: strconv.Itoa(status), ErrorMessage: message})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// (POST /secure/wallet/{walletId}/close)
+func (h *HttpServer) CloseWallet(ctx *gin.Context, walletId string) {
+	var req api_gen.CloseWalletRequest
+	if !utils.BindAndValidateRequestBody(ctx, &req, h.App.Utils.Validate) {
+		return
+	}
+
+	sweepTo := nonEmpty(req.SweepToWalletId)
+	if sweepTo != nil && *sweepTo == walletId {
+		ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Sweep wallet must differ from the closed wallet"})
+		return
+	}
+
+	userId := utils.GetMiddlewareUserId(ctx)
+
+	result, err := h.App.Commands.WalletService.HandleClose(userId, walletId, sweepTo)
+	if err != nil {
+		status, message := closeWalletFailure(err)
+		ctx.JSON(status, api_gen.ErrorResponse{ErrorCode: strconv.Itoa(status), ErrorMessage: message})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api_gen.CloseWalletResponse{Data: result})
+}
+
+// (PUT /admin/wallets/{walletId}/status)
+func (h *HttpServer) UpdateWalletStatus(ctx *gin.Context, walletId string) {
+	var req api_gen.UpdateWalletStatusRequest
+	if !utils.BindAndValidateRequestBody(ctx, &req, h.App.Utils.Validate) {
+		return
+	}
+
+	result, err := h.App.Commands.WalletService.HandleUpdateStatus(walletId, req)
+	if err != nil {
+		if errors.Is(err, consts.ErrWalletClosed) {
+			ctx.JSON(http.StatusConflict, api_gen.ErrorResponse{ErrorCode: "409", ErrorMessage: "Wallet is closed"})
+			return
+		}
+
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			ctx.JSON(http.StatusNotFound, api_gen.ErrorResponse{ErrorCode: "404", ErrorMessage: "Wallet not found"})
 			return
 		}
 
-		ctx.JSON(http.StatusInternalServerError, api_gen.ErrorResponse{ErrorCode: "500", ErrorMessage: "Failed to delete wallet"})
+		ctx.JSON(http.StatusInternalServerError, api_gen.ErrorResponse{ErrorCode: "500", ErrorMessage: "Failed to update wallet status"})
 		return
 	}
 
-	ctx.Status(http.StatusNoContent)
+	ctx.JSON(http.StatusOK, api_gen.WalletStatusResponse{Data: result})
+}
+
+// (PUT /admin/wallets/{walletId}/credit-line)
+func (h *HttpServer) UpdateWalletCreditLine(ctx *gin.Context, walletId string) {
+	var req api_gen.UpdateCreditLineRequest
+	if !utils.BindAndValidateRequestBody(ctx, &req, h.App.Utils.Validate) {
+		return
+	}
+
+	result, err := h.App.Commands.WalletService.HandleUpdateCreditLine(walletId, req)
+	if err != nil {
+		if errors.Is(err, consts.ErrAmountScaleExceeded) {
+			ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: "Amount exceeds wallet currency precision"})
+			return
+		}
+
+		if errors.Is(err, consts.ErrWalletClosed) {
+			ctx.JSON(http.StatusConflict, api_gen.ErrorResponse{ErrorCode: "409", ErrorMessage: "Wallet is closed"})
+			return
+		}
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, api_gen.ErrorResponse{ErrorCode: "404", ErrorMessage: "Wallet not found"})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, api_gen.ErrorResponse{ErrorCode: "500", ErrorMessage: "Failed to update credit line"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, api_gen.WalletCreditLineResponse{Data: result})
+}
+
+func closeWalletFailure(err error) (int, string) {
+	var mismatchErr *consts.CurrencyMismatchError
+	switch {
+	case errors.Is(err, consts.ErrWalletNotEmpty):
+		return http.StatusConflict, "Wallet balance must be zero or swept to another wallet"
+	case errors.Is(err, consts.ErrWalletHasActiveHolds):
+		return http.StatusConflict, "Wallet has active holds"
+	case errors.Is(err, consts.ErrWalletClosed):
+		return http.StatusConflict, "Wallet is closed"
+	case errors.As(err, &mismatchErr):
+		return http.StatusBadRequest, "No exchange rate from " + mismatchErr.FromCurrency + " to " + mismatchErr.ToCurrency
+	case errors.Is(err, consts.ErrConvertedAmountTooSmall):
+		return http.StatusBadRequest, "Converted amount is too small"
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound, "Wallet not found"
+	default:
+		return http.StatusInternalServerError, "Failed to close wallet"
+	}
 }
@@ -7,9 +7,12 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 
 	"github.com/aarondl/null/v9"
 	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/money"
 	"go.uber.org/mock/gomock"
 	"gorm.io/gorm"
 )
@@ -25,9 +28,10 @@ func (suite *RestApisTestSuite) TestCreateWallet() {
 	}{
 		{
 			name: "GivingValidRequest_WhenCreateWalletSuccess_ThenReturnCreated",
-			reqBody: api_gen.WalletRequest{
+			reqBody: api_gen.CreateWalletRequest{
 				Name:        "Test Wallet",
 				Description: null.StringFrom("Test Description").Ptr(),
+				Currency:    "THB",
 			},
 			mock: func() {
 				suite.mockWalletService.EXPECT().
@@ -39,17 +43,38 @@ func (suite *RestApisTestSuite) TestCreateWallet() {
 		},
 		{
 			name:        "GivingInvalidRequest_WhenCreateWallet_ThenReturnBadRequest",
-			reqBody:     api_gen.WalletRequest{},
+			reqBody:     api_gen.CreateWalletRequest{Currency: "THB"},
 			mock:        func() {},
 			wantStatus:  http.StatusBadRequest,
 			wantErr:     true,
 			expectedErr: "Name required",
 		},
+		{
+			name:        "GivingInvalidCurrencyCode_WhenCreateWallet_ThenReturnBadRequest",
+			reqBody:     api_gen.CreateWalletRequest{Name: "Test Wallet", Currency: "BAHT"},
+			mock:        func() {},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Currency iso4217",
+		},
+		{
+			name:    "GivingUnsupportedCurrency_WhenCreateWallet_ThenReturnBadRequest",
+			reqBody: api_gen.CreateWalletRequest{Name: "Test Wallet", Currency: "CHF"},
+			mock: func() {
+				suite.mockWalletService.EXPECT().
+					HandleCreate("<UserID>", gomock.Any()).
+					Return(consts.ErrUnsupportedCurrency)
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantErr:     true,
+			expectedErr: "Unsupported currency",
+		},
 		{
 			name: "GivingValidRequest_WhenCreateWalletFail_ThenReturnInternalServerError",
-			reqBody: api_gen.WalletRequest{
+			reqBody: api_gen.CreateWalletRequest{
 				Name:        "Test Wallet",
 				Description: null.StringFrom("Test Description").Ptr(),
+				Currency:    "THB",
 			},
 			mock: func() {
 				suite.mockWalletService.EXPECT().
@@ -100,8 +125,8 @@ func (suite *RestApisTestSuite) TestListUserWallets() {
 			userId: "<UserID>",
 			mock: func() {
 				expectedWallets := []api_gen.WalletResponseData{
-					{Id: "wallet1", Name: "Wallet 1", Description: null.StringFrom("Description 1").Ptr(), Balance: 100.50},
-					{Id: "wallet2", Name: "Wallet 2", Description: null.StringFrom("Description 2").Ptr(), Balance: 200.75},
+					{Id: "wallet1", Name: "Wallet 1", Description: null.StringFrom("Description 1").Ptr(), Balance: money.MustParse("100.50")},
+					{Id: "wallet2", Name: "Wallet 2", Description: null.StringFrom("Description 2").Ptr(), Balance: money.MustParse("200.75")},
 				}
 				suite.mockListWalletsService.EXPECT().
 					Handle("<UserID>").
@@ -109,8 +134,8 @@ func (suite *RestApisTestSuite) TestListUserWallets() {
 			},
 			expectedStatus: http.StatusOK,
 			expectedData: []api_gen.WalletResponseData{
-				{Id: "wallet1", Name: "Wallet 1", Description: null.StringFrom("Description 1").Ptr(), Balance: 100.50},
-				{Id: "wallet2", Name: "Wallet 2", Description: null.StringFrom("Description 2").Ptr(), Balance: 200.75},
+				{Id: "wallet1", Name: "Wallet 1", Description: null.StringFrom("Description 1").Ptr(), Balance: money.MustParse("100.50")},
+				{Id: "wallet2", Name: "Wallet 2", Description: null.StringFrom("Description 2").Ptr(), Balance: money.MustParse("200.75")},
 			},
 		},
 		{
@@ -272,8 +297,8 @@ func (suite *RestApisTestSuite) TestDeleteWallet() {
 			walletId: "<WalletID>",
 			mock: func() {
 				suite.mockWalletService.EXPECT().
-					HandleDelete("<UserID>", "<WalletID>").
-					Return(nil)
+					HandleClose("<UserID>", "<WalletID>", nil).
+					Return(&api_gen.CloseWalletResponseData{}, nil)
 			},
 			expectedStatus: http.StatusNoContent,
 		},
@@ -283,8 +308,8 @@ func (suite *RestApisTestSuite) TestDeleteWallet() {
 			walletId: "<WalletID>",
 			mock: func() {
 				suite.mockWalletService.EXPECT().
-					HandleDelete("<UserID>", "<WalletID>").
-					Return(gorm.ErrRecordNotFound)
+					HandleClose("<UserID>", "<WalletID>", nil).
+					Return(nil, gorm.ErrRecordNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
 			expectedError: &api_gen.ErrorResponse{
@@ -298,13 +323,28 @@ func (suite *RestApisTestSuite) TestDeleteWallet() {
 			walletId: "<WalletID>",
 			mock: func() {
 				suite.mockWalletService.EXPECT().
-					HandleDelete("<UserID>", "<WalletID>").
-					Return(fmt.Errorf("service error"))
+					HandleClose("<UserID>", "<WalletID>", nil).
+					Return(nil, fmt.Errorf("service error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedError: &api_gen.ErrorResponse{
 				ErrorCode:    "500",
-				ErrorMessage: "Failed to delete wallet",
+				ErrorMessage: "Failed to close wallet",
+			},
+		},
+		{
+			name:     "GivingFundedWallet_WhenDeleteWallet_ThenReturnConflict",
+			userId:   "<UserID>",
+			walletId: "<WalletID>",
+			mock: func() {
+				suite.mockWalletService.EXPECT().
+					HandleClose("<UserID>", "<WalletID>", nil).
+					Return(nil, consts.ErrWalletNotEmpty)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedError: &api_gen.ErrorResponse{
+				ErrorCode:    "409",
+				ErrorMessage: "Wallet balance must be zero or swept to another wallet",
 			},
 		},
 	}
@@ -329,3 +369,335 @@ func (suite *RestApisTestSuite) TestDeleteWallet() {
 		})
 	}
 }
+
+func (suite *RestApisTestSuite) TestCloseWallet() {
+	sweepTo := "<SweepWalletID>"
+	walletId := "<WalletID>"
+
+	tests := []struct {
+		name           string
+		walletId       string
+		requestBody    interface{}
+		mock           func()
+		expectedStatus int
+		expectedError  *api_gen.ErrorResponse
+	}{
+		{
+			name:        "GivingSweepTarget_WhenCloseWalletSuccess_ThenReturnOk",
+			walletId:    "<WalletID>",
+			requestBody: api_gen.CloseWalletRequest{SweepToWalletId: &sweepTo},
+			mock: func() {
+				suite.mockWalletService.EXPECT().
+					HandleClose("<UserID>", "<WalletID>", &sweepTo).
+					Return(&api_gen.CloseWalletResponseData{
+						Wallet:           api_gen.WalletResponseData{Id: "<WalletID>"},
+						SweepTransaction: &api_gen.TransactionResponseData{Id: "<TransactionID>"},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "GivingEmptyBody_WhenCloseEmptyWallet_ThenReturnOk",
+			walletId:    "<WalletID>",
+			requestBody: map[string]interface{}{},
+			mock: func() {
+				suite.mockWalletService.EXPECT().
+					HandleClose("<UserID>", "<WalletID>", nil).
+					Return(&api_gen.CloseWalletResponseData{Wallet: api_gen.WalletResponseData{Id: "<WalletID>"}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "GivingSweepToSameWallet_WhenCloseWallet_ThenReturnBadRequest",
+			walletId:       "<WalletID>",
+			requestBody:    api_gen.CloseWalletRequest{SweepToWalletId: &walletId},
+			mock:           func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError: &api_gen.ErrorResponse{
+				ErrorCode:    "400",
+				ErrorMessage: "Sweep wallet must differ from the closed wallet",
+			},
+		},
+		{
+			name:        "GivingWalletWithHolds_WhenCloseWallet_ThenReturnConflict",
+			walletId:    "<WalletID>",
+			requestBody: api_gen.CloseWalletRequest{SweepToWalletId: &sweepTo},
+			mock: func() {
+				suite.mockWalletService.EXPECT().
+					HandleClose("<UserID>", "<WalletID>", &sweepTo).
+					Return(nil, consts.ErrWalletHasActiveHolds)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedError: &api_gen.ErrorResponse{
+				ErrorCode:    "409",
+				ErrorMessage: "Wallet has active holds",
+			},
+		},
+		{
+			name:        "GivingClosedWallet_WhenCloseWallet_ThenReturnConflict",
+			walletId:    "<WalletID>",
+			requestBody: api_gen.CloseWalletRequest{},
+			mock: func() {
+				suite.mockWalletService.EXPECT().
+					HandleClose("<UserID>", "<WalletID>", nil).
+					Return(nil, consts.ErrWalletClosed)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedError: &api_gen.ErrorResponse{
+				ErrorCode:    "409",
+				ErrorMessage: "Wallet is closed",
+			},
+		},
+		{
+			name:        "GivingSweepTargetInOtherCurrency_WhenNoExchangeRate_ThenReturnBadRequest",
+			walletId:    "<WalletID>",
+			requestBody: api_gen.CloseWalletRequest{SweepToWalletId: &sweepTo},
+			mock: func() {
+				suite.mockWalletService.EXPECT().
+					HandleClose("<UserID>", "<WalletID>", &sweepTo).
+					Return(nil, &consts.CurrencyMismatchError{FromCurrency: "THB", ToCurrency: "USD"})
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError: &api_gen.ErrorResponse{
+				ErrorCode:    "400",
+				ErrorMessage: "No exchange rate from THB to USD",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			tt.mock()
+
+			body, _ := json.Marshal(tt.requestBody)
+			w := httptest.NewRecorder()
+			httpReq, _ := http.NewRequest("POST", "/secure/wallet/"+tt.walletId+"/close", bytes.NewBuffer(body))
+			httpReq.Header.Set("Content-Type", "application/json")
+
+			suite.server.ServeHTTP(w, httpReq)
+
+			suite.Equal(tt.expectedStatus, w.Code)
+
+			if tt.expectedError != nil {
+				var response api_gen.ErrorResponse
+				json.Unmarshal(w.Body.Bytes(), &response)
+				suite.Equal(tt.expectedError.ErrorCode, response.ErrorCode)
+				suite.Equal(tt.expectedError.ErrorMessage, response.ErrorMessage)
+			}
+		})
+	}
+}
+
+func (suite *RestApisTestSuite) TestUpdateWalletStatus() {
+	reason := "Suspected fraud"
+	tooLongReason := strings.Repeat("x", 256)
+	frozen := api_gen.UpdateWalletStatusRequest{Status: api_gen.UpdateWalletStatusRequestStatusFrozen, Reason: &reason, Actor: "ops@example.com"}
+	reactivate := api_gen.UpdateWalletStatusRequest{Status: api_gen.UpdateWalletStatusRequestStatusActive, Actor: "ops@example.com"}
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		mock           func()
+		expectedStatus int
+		expectedError  *api_gen.ErrorResponse
+	}{
+		{
+			name:        "GivingFreezeRequest_WhenUpdateStatusSuccess_ThenReturnOk",
+			requestBody: frozen,
+			mock: func() {
+				suite.mockWalletService.EXPECT().
+					HandleUpdateStatus("<WalletID>", frozen).
+					Return(&api_gen.WalletStatusResponseData{WalletId: "<WalletID>", Status: api_gen.WalletStatusResponseDataStatusFrozen}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "GivingReactivateWithoutReason_WhenUpdateStatusSuccess_ThenReturnOk",
+			requestBody: reactivate,
+			mock: func() {
+				suite.mockWalletService.EXPECT().
+					HandleUpdateStatus("<WalletID>", reactivate).
+					Return(&api_gen.WalletStatusResponseData{WalletId: "<WalletID>", Status: api_gen.WalletStatusResponseDataStatusActive}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "GivingFreezeWithoutReason_WhenUpdateStatus_ThenReturnBadRequest",
+			requestBody:    api_gen.UpdateWalletStatusRequest{Status: api_gen.UpdateWalletStatusRequestStatusFrozen, Actor: "ops@example.com"},
+			mock:           func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError: &api_gen.ErrorResponse{
+				ErrorCode:    "400",
+				ErrorMessage: "Reason required_unless Status active",
+			},
+		},
+		{
+			name:           "GivingTooLongReason_WhenUpdateStatus_ThenReturnBadRequest",
+			requestBody:    api_gen.UpdateWalletStatusRequest{Status: api_gen.UpdateWalletStatusRequestStatusFrozen, Reason: &tooLongReason, Actor: "ops@example.com"},
+			mock:           func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError: &api_gen.ErrorResponse{
+				ErrorCode:    "400",
+				ErrorMessage: "Reason max 255",
+			},
+		},
+		{
+			name:           "GivingUnknownStatus_WhenUpdateStatus_ThenReturnBadRequest",
+			requestBody:    map[string]interface{}{"status": "suspended", "reason": reason, "actor": "ops@example.com"},
+			mock:           func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError: &api_gen.ErrorResponse{
+				ErrorCode:    "400",
+				ErrorMessage: "Status oneof active frozen debit_blocked credit_blocked",
+			},
+		},
+		{
+			name:        "GivingClosedWallet_WhenUpdateStatus_ThenReturnConflict",
+			requestBody: frozen,
+			mock: func() {
+				suite.mockWalletService.EXPECT().
+					HandleUpdateStatus("<WalletID>", frozen).
+					Return(nil, consts.ErrWalletClosed)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedError: &api_gen.ErrorResponse{
+				ErrorCode:    "409",
+				ErrorMessage: "Wallet is closed",
+			},
+		},
+		{
+			name:        "GivingUnknownWallet_WhenUpdateStatus_ThenReturnNotFound",
+			requestBody: frozen,
+			mock: func() {
+				suite.mockWalletService.EXPECT().
+					HandleUpdateStatus("<WalletID>", frozen).
+					Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError: &api_gen.ErrorResponse{
+				ErrorCode:    "404",
+				ErrorMessage: "Wallet not found",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			tt.mock()
+
+			body, _ := json.Marshal(tt.requestBody)
+			w := httptest.NewRecorder()
+			httpReq, _ := http.NewRequest("PUT", "/admin/wallets/<WalletID>/status", bytes.NewBuffer(body))
+			httpReq.Header.Set("Content-Type", "application/json")
+
+			suite.server.ServeHTTP(w, httpReq)
+
+			suite.Equal(tt.expectedStatus, w.Code)
+
+			if tt.expectedError != nil {
+				var response api_gen.ErrorResponse
+				json.Unmarshal(w.Body.Bytes(), &response)
+				suite.Equal(tt.expectedError.ErrorCode, response.ErrorCode)
+				suite.Equal(tt.expectedError.ErrorMessage, response.ErrorMessage)
+			}
+		})
+	}
+}
+
+func (suite *RestApisTestSuite) TestUpdateWalletCreditLine() {
+	interestRate := money.MustParseRate("0.18")
+	creditLine := api_gen.UpdateCreditLineRequest{CreditLimit: money.MustParse("500"), InterestRate: &interestRate}
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		mock           func()
+		expectedStatus int
+		expectedError  *api_gen.ErrorResponse
+	}{
+		{
+			name:        "GivingCreditLine_WhenUpdateCreditLineSuccess_ThenReturnOk",
+			requestBody: creditLine,
+			mock: func() {
+				suite.mockWalletService.EXPECT().
+					HandleUpdateCreditLine("<WalletID>", creditLine).
+					Return(&api_gen.WalletCreditLineResponseData{WalletId: "<WalletID>", Currency: "THB", CreditLimit: money.MustParse("500"), InterestRate: interestRate}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "GivingNegativeCreditLimit_WhenUpdateCreditLine_ThenReturnBadRequest",
+			requestBody:    map[string]interface{}{"creditLimit": "-1.00"},
+			mock:           func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError: &api_gen.ErrorResponse{
+				ErrorCode:    "400",
+				ErrorMessage: "CreditLimit gte 0",
+			},
+		},
+		{
+			name:        "GivingLimitFinerThanCurrency_WhenUpdateCreditLine_ThenReturnBadRequest",
+			requestBody: creditLine,
+			mock: func() {
+				suite.mockWalletService.EXPECT().
+					HandleUpdateCreditLine("<WalletID>", creditLine).
+					Return(nil, consts.ErrAmountScaleExceeded)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError: &api_gen.ErrorResponse{
+				ErrorCode:    "400",
+				ErrorMessage: "Amount exceeds wallet currency precision",
+			},
+		},
+		{
+			name:        "GivingClosedWallet_WhenUpdateCreditLine_ThenReturnConflict",
+			requestBody: creditLine,
+			mock: func() {
+				suite.mockWalletService.EXPECT().
+					HandleUpdateCreditLine("<WalletID>", creditLine).
+					Return(nil, consts.ErrWalletClosed)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedError: &api_gen.ErrorResponse{
+				ErrorCode:    "409",
+				ErrorMessage: "Wallet is closed",
+			},
+		},
+		{
+			name:        "GivingUnknownWallet_WhenUpdateCreditLine_ThenReturnNotFound",
+			requestBody: creditLine,
+			mock: func() {
+				suite.mockWalletService.EXPECT().
+					HandleUpdateCreditLine("<WalletID>", creditLine).
+					Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError: &api_gen.ErrorResponse{
+				ErrorCode:    "404",
+				ErrorMessage: "Wallet not found",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			tt.mock()
+
+			body, _ := json.Marshal(tt.requestBody)
+			w := httptest.NewRecorder()
+			httpReq, _ := http.NewRequest("PUT", "/admin/wallets/<WalletID>/credit-line", bytes.NewBuffer(body))
+			httpReq.Header.Set("Content-Type", "application/json")
+
+			suite.server.ServeHTTP(w, httpReq)
+
+			suite.Equal(tt.expectedStatus, w.Code)
+
+			if tt.expectedError != nil {
+				var response api_gen.ErrorResponse
+				json.Unmarshal(w.Body.Bytes(), &response)
+				suite.Equal(tt.expectedError.ErrorCode, response.ErrorCode)
+				suite.Equal(tt.expectedError.ErrorMessage, response.ErrorMessage)
+			}
+		})
+	}
+}
@@ -18,6 +18,8 @@ type AppConfig struct {
 	DBUsername          string `mapstructure:"DB_USERNAME"`
 	DBPassword          string `mapstructure:"DB_PASSWORD"`
 	DBMode              string `mapstructure:"DB_MODE"`
+	AdminApiKey         string `mapstructure:"ADMIN_API_KEY"`
+	NodeID              int    `mapstructure:"NODE_ID"`
 }
 
 func InitConfig() {
@@ -40,4 +42,10 @@ func InitConfig() {
 		log.Fatalf("Unable to decode into struct, %v", err)
 	}
 
+	// Replicas sharing a node ID hand out colliding transaction IDs, so
+	// there is no default to fall back on.
+	if !viper.IsSet("NODE_ID") {
+		log.Fatal("NODE_ID must be set to a node ID unique to this replica")
+	}
+
 }
@@ -1,7 +1,118 @@
 package consts
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 var (
 	ErrInsufficientBalance = errors.New("insufficient balance")
+	ErrInvalidAmount       = errors.New("invalid amount")
+	ErrAmountScaleExceeded = errors.New("amount exceeds supported decimal places")
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
+
+	ErrConvertedAmountTooSmall = errors.New("converted amount is too small")
+	ErrInvalidExchangeRate     = errors.New("invalid exchange rate")
+
+	ErrUnbalancedJournalEntry = errors.New("journal entry debits and credits do not balance")
+	ErrIdempotencyKeyReused   = errors.New("idempotency key reused with a different request")
+
+	ErrTransactionNotReversible = errors.New("transaction cannot be reversed")
+	ErrRefundExceedsOriginal    = errors.New("refund exceeds the remaining refundable amount")
+
+	ErrHoldNotActive      = errors.New("hold is not active")
+	ErrCaptureExceedsHold = errors.New("capture exceeds held amount")
+	ErrInvalidHoldExpiry  = errors.New("invalid hold expiry")
+
+	ErrScheduleNotActive   = errors.New("schedule is not active")
+	ErrInvalidScheduleTime = errors.New("invalid schedule time")
+
+	ErrInvalidLimit = errors.New("invalid transaction limit")
+
+	ErrInvalidFeeRule = errors.New("invalid fee rule")
+
+	ErrPaymentRequestNotPending    = errors.New("payment request is not pending")
+	ErrInvalidPaymentRequestExpiry = errors.New("invalid payment request expiry")
+	ErrPaymentRequestToSelf        = errors.New("cannot request payment from yourself")
+	ErrPayerNotFound               = errors.New("payer not found")
+
+	ErrInvalidRecipient     = errors.New("exactly one recipient address is required")
+	ErrRecipientNotFound    = errors.New("recipient not found")
+	ErrRecipientHasNoWallet = errors.New("recipient has no wallet")
+	ErrHandleTaken          = errors.New("handle is already taken")
+
+	ErrInvalidAmountRange = errors.New("minimum amount exceeds maximum amount")
+	ErrInvalidDateRange   = errors.New("start of date range is not before its end")
+	ErrInvalidCursor      = errors.New("invalid pagination cursor")
+
+	ErrStatementPeriodNotStarted = errors.New("statement period has not started")
+
+	ErrImportFileEmpty       = errors.New("import file has no rows")
+	ErrImportFileMalformed   = errors.New("import file is not valid CSV")
+	ErrImportMissingColumns  = errors.New("import file must have wallet_id, amount and reference columns")
+	ErrImportTooManyRows     = errors.New("import file has too many rows")
+	ErrImportReferenceQueued = errors.New("reference already queued by another import")
+
+	ErrWalletClosed         = errors.New("wallet is closed")
+	ErrWalletNotEmpty       = errors.New("wallet balance is not zero")
+	ErrWalletHasActiveHolds = errors.New("wallet has active holds")
+)
+
+type CurrencyMismatchError struct {
+	FromCurrency string
+	ToCurrency   string
+}
+
+func (e *CurrencyMismatchError) Error() string {
+	return fmt.Sprintf("currency mismatch: cannot transfer from %s to %s", e.FromCurrency, e.ToCurrency)
+}
+
+const (
+	LimitPerTransaction = "per_transaction_max"
+	LimitDailyWallet    = "daily_wallet_max"
+	LimitMonthlyWallet  = "monthly_wallet_max"
+	LimitDailyUser      = "daily_user_max"
+	LimitMonthlyUser    = "monthly_user_max"
+	LimitTransferCount  = "max_transfers"
 )
+
+// LimitExceededError names the transaction limit a debit would break and,
+// for cumulative limits, when enough of it frees up again.
+type LimitExceededError struct {
+	Limit    string
+	ResetsAt *time.Time
+}
+
+func (e *LimitExceededError) Error() string {
+	if e.ResetsAt == nil {
+		return fmt.Sprintf("transaction limit exceeded: %s", e.Limit)
+	}
+	return fmt.Sprintf("transaction limit exceeded: %s, resets at %s", e.Limit, e.ResetsAt.Format(time.RFC3339))
+}
+
+// BatchLegError wraps the failure of one leg of an all-or-nothing batch
+// transfer, which rolled the whole batch back.
+type BatchLegError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchLegError) Error() string {
+	return fmt.Sprintf("batch leg %d: %v", e.Index, e.Err)
+}
+
+func (e *BatchLegError) Unwrap() error {
+	return e.Err
+}
+
+// WalletBlockedError reports that operations froze the wallet, or blocked
+// the direction (debit or credit) a movement needed.
+type WalletBlockedError struct {
+	WalletID string
+	Status   string
+}
+
+func (e *WalletBlockedError) Error() string {
+	return fmt.Sprintf("wallet %s is %s", e.WalletID, e.Status)
+}
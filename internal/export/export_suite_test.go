@@ -0,0 +1,16 @@
+package export_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ExportTestSuite struct {
+	suite.Suite
+}
+
+func TestExportTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(ExportTestSuite))
+}
@@ -0,0 +1,124 @@
+package export
+
+import (
+	"bufio"
+	"encoding/xml"
+	"io"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/money"
+)
+
+// Account describes the wallet and range an OFX export covers. Balance is
+// the wallet's current balance, reported as the ledger balance.
+type Account struct {
+	WalletId string
+	Currency string
+	From     time.Time
+	To       time.Time
+	Balance  money.Amount
+}
+
+const ofxDateFormat = "20060102150405.000[0:UTC]"
+
+type ofxWriter struct {
+	writer  *bufio.Writer
+	account Account
+	started bool
+}
+
+// NewOFXWriter writes an OFX 2.2 bank statement with one STMTTRN per
+// transaction, which accounting tools can import directly.
+func NewOFXWriter(w io.Writer, account Account) Writer {
+	return &ofxWriter{writer: bufio.NewWriter(w), account: account}
+}
+
+func (o *ofxWriter) start() {
+	if o.started {
+		return
+	}
+	o.started = true
+
+	now := ofxDate(time.Now())
+	o.raw(`<?xml version="1.0" encoding="UTF-8" standalone="no"?>` + "\n")
+	o.raw(`<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>` + "\n")
+	o.raw("<OFX>\n<SIGNONMSGSRSV1><SONRS>")
+	o.raw("<STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>")
+	o.element("DTSERVER", now)
+	o.raw("<LANGUAGE>ENG</LANGUAGE></SONRS></SIGNONMSGSRSV1>\n")
+	o.raw("<BANKMSGSRSV1><STMTTRNRS><TRNUID>0</TRNUID>")
+	o.raw("<STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>\n<STMTRS>")
+	o.element("CURDEF", o.account.Currency)
+	o.raw("<BANKACCTFROM><BANKID>GOWALLET</BANKID>")
+	o.element("ACCTID", o.account.WalletId)
+	o.raw("<ACCTTYPE>CHECKING</ACCTTYPE></BANKACCTFROM>\n<BANKTRANLIST>")
+	o.element("DTSTART", ofxDate(o.account.From))
+	o.element("DTEND", ofxDate(o.account.To))
+	o.raw("\n")
+}
+
+func (o *ofxWriter) Write(tx api_gen.TransactionResponseData) error {
+	o.start()
+
+	amount := NetAmount(tx, o.account.WalletId)
+	o.raw("<STMTTRN>")
+	o.element("TRNTYPE", ofxTransactionType(string(tx.Type), amount))
+	o.element("DTPOSTED", ofxDate(tx.CreatedAt))
+	o.element("TRNAMT", amount.String())
+	o.element("FITID", tx.Id)
+	if tx.Reference != nil {
+		o.element("REFNUM", *tx.Reference)
+	}
+	if tx.Description != nil {
+		o.element("MEMO", *tx.Description)
+	}
+	o.raw("</STMTTRN>\n")
+
+	// bufio keeps the first error, so checking here reports a broken client
+	// before the rest of the history is read.
+	_, err := o.writer.Write(nil)
+	return err
+}
+
+func (o *ofxWriter) Close() error {
+	o.start()
+	o.raw("</BANKTRANLIST>\n<LEDGERBAL>")
+	o.element("BALAMT", o.account.Balance.String())
+	o.element("DTASOF", ofxDate(time.Now()))
+	o.raw("</LEDGERBAL>\n</STMTRS></STMTTRNRS></BANKMSGSRSV1>\n</OFX>\n")
+	return o.writer.Flush()
+}
+
+func (o *ofxWriter) raw(s string) {
+	o.writer.WriteString(s)
+}
+
+func (o *ofxWriter) element(name, value string) {
+	o.raw("<" + name + ">")
+	xml.EscapeText(o.writer, []byte(value))
+	o.raw("</" + name + ">")
+}
+
+func ofxDate(t time.Time) string {
+	return t.UTC().Format(ofxDateFormat)
+}
+
+func ofxTransactionType(txType string, amount money.Amount) string {
+	switch txType {
+	case "deposit":
+		return "DEP"
+	case "transfer":
+		return "XFER"
+	case "interest":
+		return "INT"
+	case "fee":
+		if amount < 0 {
+			return "FEE"
+		}
+	}
+	if amount < 0 {
+		return "DEBIT"
+	}
+	return "CREDIT"
+}
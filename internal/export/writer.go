@@ -0,0 +1,141 @@
+// Package export writes a wallet's transaction history as CSV, OFX or JSON
+// Lines, one transaction at a time.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/money"
+)
+
+// Writer receives transactions in the order they are exported. Nothing is
+// written before the first Write or Close, and Close must be called to
+// finish the document.
+type Writer interface {
+	Write(tx api_gen.TransactionResponseData) error
+	Close() error
+}
+
+// CSVColumns are the header of a CSV export. New columns are only ever
+// appended so existing spreadsheets and imports keep working.
+var CSVColumns = []string{
+	"id", "created_at", "type", "direction", "from_wallet_id", "to_wallet_id",
+	"amount", "credited_amount", "exchange_rate", "net_amount",
+	"description", "reference", "original_transaction_id", "parent_transaction_id",
+}
+
+type csvWriter struct {
+	writer   *csv.Writer
+	walletId string
+	started  bool
+}
+
+func NewCSVWriter(w io.Writer, walletId string) Writer {
+	return &csvWriter{writer: csv.NewWriter(w), walletId: walletId}
+}
+
+func (c *csvWriter) start() error {
+	if c.started {
+		return nil
+	}
+	c.started = true
+	return c.writer.Write(CSVColumns)
+}
+
+func (c *csvWriter) Write(tx api_gen.TransactionResponseData) error {
+	if err := c.start(); err != nil {
+		return err
+	}
+
+	var creditedAmount, exchangeRate string
+	if tx.CreditedAmount != nil {
+		creditedAmount = tx.CreditedAmount.String()
+	}
+	if tx.ExchangeRate != nil {
+		exchangeRate = tx.ExchangeRate.String()
+	}
+	return c.writer.Write([]string{
+		tx.Id,
+		tx.CreatedAt.UTC().Format(time.RFC3339),
+		string(tx.Type),
+		direction(tx, c.walletId),
+		tx.FromWalletId,
+		tx.ToWalletId,
+		tx.Amount.String(),
+		creditedAmount,
+		exchangeRate,
+		NetAmount(tx, c.walletId).String(),
+		csvText(deref(tx.Description)),
+		csvText(deref(tx.Reference)),
+		deref(tx.OriginalTransactionId),
+		deref(tx.ParentTransactionId),
+	})
+}
+
+func (c *csvWriter) Close() error {
+	if err := c.start(); err != nil {
+		return err
+	}
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+type jsonLinesWriter struct {
+	encoder *json.Encoder
+}
+
+// NewJSONLinesWriter writes each transaction as one line holding the same
+// object the list endpoint returns.
+func NewJSONLinesWriter(w io.Writer) Writer {
+	return &jsonLinesWriter{encoder: json.NewEncoder(w)}
+}
+
+func (j *jsonLinesWriter) Write(tx api_gen.TransactionResponseData) error {
+	return j.encoder.Encode(tx)
+}
+
+func (j *jsonLinesWriter) Close() error {
+	return nil
+}
+
+// NetAmount is the signed change tx made to the wallet's balance, in the
+// wallet's currency: negative when money left it, and the converted amount
+// when a cross-currency transfer credited it. Withdrawals are stored
+// negative and transfers positive, so outgoing amounts are normalised first.
+func NetAmount(tx api_gen.TransactionResponseData, walletId string) money.Amount {
+	if tx.FromWalletId == walletId {
+		return tx.Amount.Abs().Neg()
+	}
+	if tx.CreditedAmount != nil {
+		return *tx.CreditedAmount
+	}
+	return tx.Amount
+}
+
+func direction(tx api_gen.TransactionResponseData, walletId string) string {
+	if tx.FromWalletId == walletId {
+		return "out"
+	}
+	return "in"
+}
+
+// csvText quotes free text that a spreadsheet would otherwise evaluate as a
+// formula. Descriptions and references can be set by the counterparty.
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
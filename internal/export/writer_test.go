@@ -0,0 +1,133 @@
+package export_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/export"
+	"github.com/slilp/go-wallet/internal/money"
+)
+
+func sampleTransactions() []api_gen.TransactionResponseData {
+	createdAt := time.Date(2024, time.March, 1, 9, 30, 0, 0, time.UTC)
+	description, reference := `Dinner, "team" & co`, "INV-1"
+	credited, rate := money.MustParse("2.75"), money.MustParseRate("0.0275")
+	return []api_gen.TransactionResponseData{
+		{Id: "TRN1", Type: "deposit", ToWalletId: "<WalletID>", Amount: money.MustParse("100"), CreatedAt: createdAt},
+		{Id: "TRN2", Type: "transfer", FromWalletId: "<WalletID>", ToWalletId: "<OtherWalletID>", Amount: money.MustParse("40.50"),
+			Description: &description, Reference: &reference, CreatedAt: createdAt.Add(time.Hour)},
+		{Id: "TRN3", Type: "transfer", FromWalletId: "<OtherWalletID>", ToWalletId: "<WalletID>", Amount: money.MustParse("100"),
+			CreditedAmount: &credited, ExchangeRate: &rate, CreatedAt: createdAt.Add(2 * time.Hour)},
+		{Id: "TRN4", Type: "withdraw", FromWalletId: "<WalletID>", Amount: money.MustParse("-25"), CreatedAt: createdAt.Add(3 * time.Hour)},
+	}
+}
+
+func (suite *ExportTestSuite) TestCSVWriter_EscapesFormulas() {
+	testCases := []struct {
+		name        string
+		description string
+		want        string
+	}{
+		{name: "GivenEquals_ThenQuoted", description: `=HYPERLINK("http://x")`, want: `"'=HYPERLINK(""http://x"")"`},
+		{name: "GivenPlus_ThenQuoted", description: "+1+2", want: "'+1+2"},
+		{name: "GivenMinus_ThenQuoted", description: "-1+2", want: "'-1+2"},
+		{name: "GivenAt_ThenQuoted", description: "@SUM(A1)", want: "'@SUM(A1)"},
+		{name: "GivenTab_ThenQuoted", description: "\t=1", want: "'\t=1"},
+		{name: "GivenCarriageReturn_ThenQuoted", description: "\r=1", want: "\"'\r=1\""},
+		{name: "GivenPlainText_ThenUnchanged", description: "Rent - March", want: "Rent - March"},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			var buf bytes.Buffer
+			writer := export.NewCSVWriter(&buf, "<WalletID>")
+			suite.NoError(writer.Write(api_gen.TransactionResponseData{Id: "TRN1", Type: "deposit", ToWalletId: "<WalletID>",
+				Amount: money.MustParse("1"), Description: &tc.description, Reference: &tc.description}))
+			suite.NoError(writer.Close())
+			suite.Contains(buf.String(), ",1.00,,,1.00,"+tc.want+","+tc.want+",,\n")
+		})
+	}
+}
+
+func (suite *ExportTestSuite) TestCSVWriter() {
+	testCases := []struct {
+		name         string
+		transactions []api_gen.TransactionResponseData
+		want         []string
+	}{
+		{
+			name:         "GivenTransactions_WhenWrite_ThenHeaderAndSignedRows",
+			transactions: sampleTransactions(),
+			want: []string{
+				"id,created_at,type,direction,from_wallet_id,to_wallet_id,amount,credited_amount,exchange_rate,net_amount,description,reference,original_transaction_id,parent_transaction_id",
+				"TRN1,2024-03-01T09:30:00Z,deposit,in,,<WalletID>,100.00,,,100.00,,,,",
+				`TRN2,2024-03-01T10:30:00Z,transfer,out,<WalletID>,<OtherWalletID>,40.50,,,-40.50,"Dinner, ""team"" & co",INV-1,,`,
+				"TRN3,2024-03-01T11:30:00Z,transfer,in,<OtherWalletID>,<WalletID>,100.00,2.75,0.02750000,2.75,,,,",
+				"TRN4,2024-03-01T12:30:00Z,withdraw,out,<WalletID>,,-25.00,,,-25.00,,,,",
+			},
+		},
+		{
+			name: "GivenNoTransactions_WhenClose_ThenHeaderOnly",
+			want: []string{strings.Join(export.CSVColumns, ",")},
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			var buf bytes.Buffer
+			writer := export.NewCSVWriter(&buf, "<WalletID>")
+			for _, tx := range tc.transactions {
+				suite.NoError(writer.Write(tx))
+			}
+			suite.NoError(writer.Close())
+			suite.Equal(strings.Join(tc.want, "\n")+"\n", buf.String())
+		})
+	}
+}
+
+func (suite *ExportTestSuite) TestJSONLinesWriter() {
+	var buf bytes.Buffer
+	writer := export.NewJSONLinesWriter(&buf)
+	for _, tx := range sampleTransactions() {
+		suite.NoError(writer.Write(tx))
+	}
+	suite.NoError(writer.Close())
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	suite.Len(lines, 4)
+	var decoded api_gen.TransactionResponseData
+	suite.NoError(json.Unmarshal([]byte(lines[1]), &decoded))
+	suite.Equal(sampleTransactions()[1], decoded)
+}
+
+func (suite *ExportTestSuite) TestOFXWriter() {
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	writer := export.NewOFXWriter(&buf, export.Account{
+		WalletId: "<WalletID>",
+		Currency: "THB",
+		From:     from,
+		To:       from.AddDate(0, 1, 0),
+		Balance:  money.MustParse("62.25"),
+	})
+	for _, tx := range sampleTransactions() {
+		suite.NoError(writer.Write(tx))
+	}
+	suite.NoError(writer.Close())
+	doc := buf.String()
+
+	suite.True(strings.HasPrefix(doc, `<?xml version="1.0"`))
+	suite.Contains(doc, "<CURDEF>THB</CURDEF>")
+	suite.Contains(doc, "<ACCTID>&lt;WalletID&gt;</ACCTID>")
+	suite.Contains(doc, "<DTSTART>20240301000000.000[0:UTC]</DTSTART><DTEND>20240401000000.000[0:UTC]</DTEND>")
+	suite.Contains(doc, "<STMTTRN><TRNTYPE>DEP</TRNTYPE><DTPOSTED>20240301093000.000[0:UTC]</DTPOSTED><TRNAMT>100.00</TRNAMT><FITID>TRN1</FITID></STMTTRN>")
+	suite.Contains(doc, "<TRNTYPE>XFER</TRNTYPE><DTPOSTED>20240301103000.000[0:UTC]</DTPOSTED><TRNAMT>-40.50</TRNAMT><FITID>TRN2</FITID><REFNUM>INV-1</REFNUM><MEMO>Dinner, &#34;team&#34; &amp; co</MEMO>")
+	suite.Contains(doc, "<TRNAMT>2.75</TRNAMT><FITID>TRN3</FITID>")
+	suite.Contains(doc, "<TRNTYPE>DEBIT</TRNTYPE><DTPOSTED>20240301123000.000[0:UTC]</DTPOSTED><TRNAMT>-25.00</TRNAMT><FITID>TRN4</FITID>")
+	suite.Contains(doc, "<LEDGERBAL><BALAMT>62.25</BALAMT>")
+	suite.True(strings.HasSuffix(doc, "</OFX>\n"))
+	suite.Equal(4, strings.Count(doc, "<STMTTRN>"))
+}
@@ -0,0 +1,3 @@
+package idgen
+
+var NewGeneratorWithClock = newGenerator
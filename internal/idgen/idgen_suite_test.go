@@ -0,0 +1,16 @@
+package idgen_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type IdGenTestSuite struct {
+	suite.Suite
+}
+
+func TestIdGenTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(IdGenTestSuite))
+}
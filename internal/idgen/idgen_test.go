@@ -0,0 +1,136 @@
+package idgen_test
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/idgen"
+)
+
+func fixedClock(times ...time.Time) func() time.Time {
+	i := 0
+	return func() time.Time {
+		t := times[i]
+		if i < len(times)-1 {
+			i++
+		}
+		return t
+	}
+}
+
+func (suite *IdGenTestSuite) TestNewGenerator() {
+	testCases := []struct {
+		name    string
+		nodeId  int
+		wantErr bool
+	}{
+		{name: "GivenZeroNode_ThenSuccess", nodeId: 0},
+		{name: "GivenMaxNode_ThenSuccess", nodeId: idgen.MaxNodeID},
+		{name: "GivenNegativeNode_ThenError", nodeId: -1, wantErr: true},
+		{name: "GivenNodeAboveMax_ThenError", nodeId: idgen.MaxNodeID + 1, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			generator, err := idgen.NewGenerator("TRN", tc.nodeId)
+			if tc.wantErr {
+				suite.Error(err)
+				suite.Nil(generator)
+			} else {
+				suite.NoError(err)
+				suite.Len(generator.NewID(), 22)
+			}
+		})
+	}
+}
+
+func (suite *IdGenTestSuite) TestNewID() {
+	start := time.UnixMilli(1767225600000)
+
+	testCases := []struct {
+		name  string
+		clock func() time.Time
+		node  int
+		want  []string
+	}{
+		{
+			name:  "GivenSameMillisecond_ThenSequenceIncrements",
+			clock: fixedClock(start),
+			node:  1,
+			want:  []string{"TRNV01KDVDNA0001000000", "TRNV01KDVDNA0001000001", "TRNV01KDVDNA0001000002"},
+		},
+		{
+			name:  "GivenClockAdvances_ThenSequenceResets",
+			clock: fixedClock(start, start, start.Add(time.Millisecond)),
+			node:  1,
+			want:  []string{"TRNV01KDVDNA0001000000", "TRNV01KDVDNA0001000001", "TRNV01KDVDNA0101000000"},
+		},
+		{
+			name:  "GivenClockMovesBackwards_ThenIdsStayMonotonic",
+			clock: fixedClock(start, start.Add(-time.Second)),
+			node:  1,
+			want:  []string{"TRNV01KDVDNA0001000000", "TRNV01KDVDNA0001000001"},
+		},
+		{
+			name:  "GivenDifferentNode_ThenNodeEncoded",
+			clock: fixedClock(start),
+			node:  idgen.MaxNodeID,
+			want:  []string{"TRNV01KDVDNA00ZZ000000"},
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			generator, err := idgen.NewGeneratorWithClock("TRN", tc.node, tc.clock)
+			suite.NoError(err)
+			for _, want := range tc.want {
+				suite.Equal(want, generator.NewID())
+			}
+		})
+	}
+}
+
+func (suite *IdGenTestSuite) TestNewID_ConcurrentIdsAreUniqueAndSorted() {
+	generator, err := idgen.NewGenerator("TRN", 7)
+	suite.NoError(err)
+
+	const workers, perWorker = 8, 2000
+	ids := make([][]string, workers)
+	var wg sync.WaitGroup
+	for w := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				ids[w] = append(ids[w], generator.NewID())
+			}
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[string]struct{}, workers*perWorker)
+	for _, workerIds := range ids {
+		suite.True(sort.StringsAreSorted(workerIds))
+		for _, id := range workerIds {
+			seen[id] = struct{}{}
+		}
+	}
+	suite.Len(seen, workers*perWorker)
+}
+
+func (suite *IdGenTestSuite) TestNewID_SortsAfterLegacyIds() {
+	generator, err := idgen.NewGeneratorWithClock("TRN", 0, fixedClock(time.UnixMilli(1767225600000)))
+	suite.NoError(err)
+
+	legacy := []string{"TRN17356896001234567", "TRN99999999999999999"}
+	for _, id := range legacy {
+		suite.Less(id, generator.NewID())
+	}
+}
+
+func (suite *IdGenTestSuite) TestNewSequential() {
+	generator := idgen.NewSequential("TRN")
+	suite.Equal("TRN000000000000000001", generator.NewID())
+	suite.Equal("TRN000000000000000002", generator.NewID())
+}
@@ -0,0 +1,40 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/repositories"
+)
+
+type creditInterestJob struct {
+	walletRepo repositories.WalletRepository
+	interval   time.Duration
+}
+
+// NewCreditInterestJob charges a day's interest on wallets drawn below zero on
+// their credit line. Each wallet is charged at most once per UTC day, so the
+// job can run more often than daily.
+func NewCreditInterestJob(walletRepo repositories.WalletRepository, interval time.Duration) Job {
+	return &creditInterestJob{walletRepo: walletRepo, interval: interval}
+}
+
+func (j *creditInterestJob) Name() string {
+	return "credit-interest"
+}
+
+func (j *creditInterestJob) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *creditInterestJob) RunOnce(ctx context.Context) error {
+	charged, err := j.walletRepo.ChargeInterest(time.Now())
+	if err != nil {
+		return err
+	}
+	if charged > 0 {
+		log.Printf("Charged interest on %d wallets", charged)
+	}
+	return nil
+}
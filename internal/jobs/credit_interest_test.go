@@ -0,0 +1,53 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/jobs"
+	mock_repositories "github.com/slilp/go-wallet/internal/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreditInterestJob_RunOnce(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(*mock_repositories.MockWalletRepository)
+		wantErr bool
+	}{
+		{
+			name: "GivenOverdrawnWallets_WhenRunOnce_ThenChargeInterest",
+			mock: func(mockWalletRepo *mock_repositories.MockWalletRepository) {
+				mockWalletRepo.EXPECT().ChargeInterest(gomock.Any()).Return(3, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "GivenRepoError_WhenRunOnce_ThenReturnError",
+			mock: func(mockWalletRepo *mock_repositories.MockWalletRepository) {
+				mockWalletRepo.EXPECT().ChargeInterest(gomock.Any()).Return(0, errors.New("repo error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockWalletRepo := mock_repositories.NewMockWalletRepository(ctrl)
+			tc.mock(mockWalletRepo)
+
+			job := jobs.NewCreditInterestJob(mockWalletRepo, time.Hour)
+			err := job.RunOnce(context.Background())
+
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/repositories"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"github.com/slilp/go-wallet/internal/services/commands"
+)
+
+// depositImportChunk is how many rows are deposited per claimed chunk; each
+// chunk commits its progress before the next one starts.
+const depositImportChunk = 100
+
+type depositImportJob struct {
+	depositImportRepo  repositories.DepositImportRepository
+	transactionService commands.TransactionService
+	interval           time.Duration
+}
+
+// NewDepositImportJob deposits the rows of queued imports chunk by chunk.
+// Every row carries its own idempotency key, so a chunk interrupted before
+// its outcome was recorded is replayed without depositing twice.
+func NewDepositImportJob(depositImportRepo repositories.DepositImportRepository, transactionService commands.TransactionService, interval time.Duration) Job {
+	return &depositImportJob{depositImportRepo: depositImportRepo, transactionService: transactionService, interval: interval}
+}
+
+func (j *depositImportJob) Name() string {
+	return "deposit-import"
+}
+
+func (j *depositImportJob) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *depositImportJob) RunOnce(ctx context.Context) error {
+	total := 0
+	defer func() {
+		if total > 0 {
+			log.Printf("Processed %d deposit import rows", total)
+		}
+	}()
+
+	for ctx.Err() == nil {
+		processed, err := j.depositImportRepo.RunChunk(depositImportChunk, j.execute)
+		total += processed
+		if err != nil {
+			return err
+		}
+		if processed == 0 {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (j *depositImportJob) execute(row entity.DepositImportRow) entity.DepositImportRow {
+	key := "deposit-import:" + row.ID
+	details := commands.TransactionDetails{
+		Reference: &row.Reference,
+		Metadata:  map[string]interface{}{"depositImportId": row.ImportID},
+	}
+	tx, err := j.transactionService.HandleDepositWithDrawBalance(*row.UserID, row.WalletID, *row.Amount, details, &key)
+	if err != nil {
+		log.Printf("Deposit import row %s failed: %v", row.ID, err)
+		message := err.Error()
+		row.ErrorMessage = &message
+		return row
+	}
+	row.TransactionID = &tx.Id
+	return row
+}
@@ -0,0 +1,105 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/jobs"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	mock_repositories "github.com/slilp/go-wallet/internal/repositories/mocks"
+	"github.com/slilp/go-wallet/internal/services/commands"
+	mock_commands "github.com/slilp/go-wallet/internal/services/commands/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDepositImportJob_RunOnce(t *testing.T) {
+	amount := money.MustParse("100")
+	row := entity.DepositImportRow{
+		ID:        "<RowID>",
+		ImportID:  "<ImportID>",
+		WalletID:  "<WalletID>",
+		UserID:    ptr("<UserID>"),
+		Amount:    &amount,
+		Reference: "INV-1",
+	}
+	details := commands.TransactionDetails{
+		Reference: ptr("INV-1"),
+		Metadata:  map[string]interface{}{"depositImportId": "<ImportID>"},
+	}
+
+	testCases := []struct {
+		name    string
+		mock    func(*mock_commands.MockTransactionService)
+		wantRow entity.DepositImportRow
+	}{
+		{
+			name: "GivenPendingRow_WhenDepositSucceeds_ThenRecordTransaction",
+			mock: func(mockTransactionService *mock_commands.MockTransactionService) {
+				key := "deposit-import:<RowID>"
+				mockTransactionService.EXPECT().
+					HandleDepositWithDrawBalance("<UserID>", "<WalletID>", amount, details, &key).
+					Return(&api_gen.TransactionResponseData{Id: "<TransactionID>"}, nil)
+			},
+			wantRow: func() entity.DepositImportRow {
+				r := row
+				r.TransactionID = ptr("<TransactionID>")
+				return r
+			}(),
+		},
+		{
+			name: "GivenPendingRow_WhenWalletClosed_ThenRecordFailure",
+			mock: func(mockTransactionService *mock_commands.MockTransactionService) {
+				mockTransactionService.EXPECT().
+					HandleDepositWithDrawBalance("<UserID>", "<WalletID>", amount, details, gomock.Any()).
+					Return(nil, consts.ErrWalletClosed)
+			},
+			wantRow: func() entity.DepositImportRow {
+				r := row
+				r.ErrorMessage = ptr(consts.ErrWalletClosed.Error())
+				return r
+			}(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockDepositImportRepo := mock_repositories.NewMockDepositImportRepository(ctrl)
+			mockTransactionService := mock_commands.NewMockTransactionService(ctrl)
+			tc.mock(mockTransactionService)
+
+			var result entity.DepositImportRow
+			gomock.InOrder(
+				mockDepositImportRepo.EXPECT().RunChunk(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(size int, execute func(entity.DepositImportRow) entity.DepositImportRow) (int, error) {
+						result = execute(row)
+						return 1, nil
+					}),
+				mockDepositImportRepo.EXPECT().RunChunk(gomock.Any(), gomock.Any()).Return(0, nil),
+			)
+
+			job := jobs.NewDepositImportJob(mockDepositImportRepo, mockTransactionService, time.Second)
+			err := job.RunOnce(context.Background())
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantRow, result)
+		})
+	}
+}
+
+func TestDepositImportJob_RunOnce_StopsOnError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDepositImportRepo := mock_repositories.NewMockDepositImportRepository(ctrl)
+	mockDepositImportRepo.EXPECT().RunChunk(gomock.Any(), gomock.Any()).Return(0, errors.New("db error"))
+
+	job := jobs.NewDepositImportJob(mockDepositImportRepo, mock_commands.NewMockTransactionService(ctrl), time.Second)
+	err := job.RunOnce(context.Background())
+
+	assert.EqualError(t, err, "db error")
+}
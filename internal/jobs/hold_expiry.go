@@ -0,0 +1,40 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/repositories"
+)
+
+type holdExpiryJob struct {
+	holdRepo repositories.HoldRepository
+	interval time.Duration
+}
+
+// NewHoldExpiryJob releases holds whose expiry has passed so they stop
+// showing as active. Available balance already ignores expired holds; this
+// only keeps the stored status accurate.
+func NewHoldExpiryJob(holdRepo repositories.HoldRepository, interval time.Duration) Job {
+	return &holdExpiryJob{holdRepo: holdRepo, interval: interval}
+}
+
+func (j *holdExpiryJob) Name() string {
+	return "hold-expiry"
+}
+
+func (j *holdExpiryJob) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *holdExpiryJob) RunOnce(ctx context.Context) error {
+	released, err := j.holdRepo.ReleaseExpired()
+	if err != nil {
+		return err
+	}
+	if released > 0 {
+		log.Printf("Released %d expired holds", released)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/jobs"
+	mock_repositories "github.com/slilp/go-wallet/internal/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHoldExpiryJob_RunOnce(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(*mock_repositories.MockHoldRepository)
+		wantErr bool
+	}{
+		{
+			name: "GivenExpiredHolds_WhenRunOnce_ThenReleaseThem",
+			mock: func(mockHoldRepo *mock_repositories.MockHoldRepository) {
+				mockHoldRepo.EXPECT().ReleaseExpired().Return(int64(2), nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "GivenRepoError_WhenRunOnce_ThenReturnError",
+			mock: func(mockHoldRepo *mock_repositories.MockHoldRepository) {
+				mockHoldRepo.EXPECT().ReleaseExpired().Return(int64(0), errors.New("repo error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockHoldRepo := mock_repositories.NewMockHoldRepository(ctrl)
+			tc.mock(mockHoldRepo)
+
+			job := jobs.NewHoldExpiryJob(mockHoldRepo, time.Minute)
+			err := job.RunOnce(context.Background())
+
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
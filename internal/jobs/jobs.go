@@ -0,0 +1,38 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Job is a unit of background work that runs on a fixed interval.
+type Job interface {
+	Name() string
+	Interval() time.Duration
+	RunOnce(ctx context.Context) error
+}
+
+// Start runs every job on its own ticker until ctx is cancelled. Failures
+// are logged and retried on the next tick.
+func Start(ctx context.Context, jobs ...Job) {
+	for _, job := range jobs {
+		go run(ctx, job)
+	}
+}
+
+func run(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := job.RunOnce(ctx); err != nil {
+				log.Printf("Job %s failed: %v", job.Name(), err)
+			}
+		}
+	}
+}
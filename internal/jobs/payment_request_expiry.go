@@ -0,0 +1,40 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/repositories"
+)
+
+type paymentRequestExpiryJob struct {
+	paymentRequestRepo repositories.PaymentRequestRepository
+	interval           time.Duration
+}
+
+// NewPaymentRequestExpiryJob marks pending payment requests past their expiry
+// as expired. Accept and decline already refuse them; this only keeps the
+// stored status accurate.
+func NewPaymentRequestExpiryJob(paymentRequestRepo repositories.PaymentRequestRepository, interval time.Duration) Job {
+	return &paymentRequestExpiryJob{paymentRequestRepo: paymentRequestRepo, interval: interval}
+}
+
+func (j *paymentRequestExpiryJob) Name() string {
+	return "payment-request-expiry"
+}
+
+func (j *paymentRequestExpiryJob) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *paymentRequestExpiryJob) RunOnce(ctx context.Context) error {
+	expired, err := j.paymentRequestRepo.ExpirePending()
+	if err != nil {
+		return err
+	}
+	if expired > 0 {
+		log.Printf("Expired %d payment requests", expired)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/jobs"
+	mock_repositories "github.com/slilp/go-wallet/internal/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPaymentRequestExpiryJob_RunOnce(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(*mock_repositories.MockPaymentRequestRepository)
+		wantErr bool
+	}{
+		{
+			name: "GivenExpiredRequests_WhenRunOnce_ThenExpireThem",
+			mock: func(mockPaymentRequestRepo *mock_repositories.MockPaymentRequestRepository) {
+				mockPaymentRequestRepo.EXPECT().ExpirePending().Return(int64(2), nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "GivenRepoError_WhenRunOnce_ThenReturnError",
+			mock: func(mockPaymentRequestRepo *mock_repositories.MockPaymentRequestRepository) {
+				mockPaymentRequestRepo.EXPECT().ExpirePending().Return(int64(0), errors.New("repo error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockPaymentRequestRepo := mock_repositories.NewMockPaymentRequestRepository(ctrl)
+			tc.mock(mockPaymentRequestRepo)
+
+			job := jobs.NewPaymentRequestExpiryJob(mockPaymentRequestRepo, time.Minute)
+			err := job.RunOnce(context.Background())
+
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
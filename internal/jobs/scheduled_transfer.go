@@ -0,0 +1,57 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/repositories"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"github.com/slilp/go-wallet/internal/services/commands"
+)
+
+// scheduledTransferBatch caps how many schedules one tick executes so a
+// backlog cannot monopolise the executor.
+const scheduledTransferBatch = 100
+
+type scheduledTransferJob struct {
+	scheduleRepo       repositories.ScheduleRepository
+	transactionService commands.TransactionService
+	interval           time.Duration
+}
+
+// NewScheduledTransferJob executes due schedules through the regular
+// transfer path and records each run's outcome. Every occurrence carries its
+// own idempotency key, so a run whose outcome was lost to a crash is replayed
+// without moving money twice.
+func NewScheduledTransferJob(scheduleRepo repositories.ScheduleRepository, transactionService commands.TransactionService, interval time.Duration) Job {
+	return &scheduledTransferJob{scheduleRepo: scheduleRepo, transactionService: transactionService, interval: interval}
+}
+
+func (j *scheduledTransferJob) Name() string {
+	return "scheduled-transfer"
+}
+
+func (j *scheduledTransferJob) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *scheduledTransferJob) RunOnce(ctx context.Context) error {
+	processed, err := j.scheduleRepo.RunDue(scheduledTransferBatch, j.execute)
+	if processed > 0 {
+		log.Printf("Executed %d scheduled transfers", processed)
+	}
+	return err
+}
+
+func (j *scheduledTransferJob) execute(schedule entity.Schedule) entity.ScheduleRun {
+	key := fmt.Sprintf("schedule:%s:%d", schedule.ID, schedule.RunCount)
+	tx, err := j.transactionService.HandleTransferBalance(schedule.UserID, schedule.FromWalletID, schedule.ToWalletID, schedule.Amount, commands.TransactionDetails{}, &key)
+	if err != nil {
+		log.Printf("Scheduled transfer %s failed: %v", schedule.ID, err)
+		message := err.Error()
+		return entity.ScheduleRun{Status: "failed", ErrorMessage: &message}
+	}
+	return entity.ScheduleRun{Status: "succeeded", TransactionID: &tx.Id}
+}
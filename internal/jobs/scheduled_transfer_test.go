@@ -0,0 +1,81 @@
+package jobs_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/jobs"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	mock_repositories "github.com/slilp/go-wallet/internal/repositories/mocks"
+	"github.com/slilp/go-wallet/internal/services/commands"
+	mock_commands "github.com/slilp/go-wallet/internal/services/commands/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestScheduledTransferJob_RunOnce(t *testing.T) {
+	schedule := entity.Schedule{
+		ID:           "<ScheduleID>",
+		UserID:       "<UserID>",
+		FromWalletID: "<FromWalletID>",
+		ToWalletID:   "<ToWalletID>",
+		Amount:       money.MustParse("25"),
+		RunCount:     3,
+	}
+
+	testCases := []struct {
+		name    string
+		mock    func(*mock_commands.MockTransactionService)
+		wantRun entity.ScheduleRun
+	}{
+		{
+			name: "GivenDueSchedule_WhenTransferSucceeds_ThenRecordSuccess",
+			mock: func(mockTransactionService *mock_commands.MockTransactionService) {
+				key := "schedule:<ScheduleID>:3"
+				mockTransactionService.EXPECT().
+					HandleTransferBalance("<UserID>", "<FromWalletID>", "<ToWalletID>", money.MustParse("25"), commands.TransactionDetails{}, &key).
+					Return(&api_gen.TransactionResponseData{Id: "<TransactionID>"}, nil)
+			},
+			wantRun: entity.ScheduleRun{Status: "succeeded", TransactionID: ptr("<TransactionID>")},
+		},
+		{
+			name: "GivenDueSchedule_WhenInsufficientBalance_ThenRecordFailure",
+			mock: func(mockTransactionService *mock_commands.MockTransactionService) {
+				mockTransactionService.EXPECT().
+					HandleTransferBalance("<UserID>", "<FromWalletID>", "<ToWalletID>", money.MustParse("25"), commands.TransactionDetails{}, gomock.Any()).
+					Return(nil, consts.ErrInsufficientBalance)
+			},
+			wantRun: entity.ScheduleRun{Status: "failed", ErrorMessage: ptr("insufficient balance")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockScheduleRepo := mock_repositories.NewMockScheduleRepository(ctrl)
+			mockTransactionService := mock_commands.NewMockTransactionService(ctrl)
+			tc.mock(mockTransactionService)
+
+			var run entity.ScheduleRun
+			mockScheduleRepo.EXPECT().RunDue(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(limit int, execute func(entity.Schedule) entity.ScheduleRun) (int, error) {
+					run = execute(schedule)
+					return 1, nil
+				})
+
+			job := jobs.NewScheduledTransferJob(mockScheduleRepo, mockTransactionService, time.Minute)
+			err := job.RunOnce(context.Background())
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantRun, run)
+		})
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
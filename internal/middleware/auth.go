@@ -1,11 +1,13 @@
 package middleware
 
 import (
+	"crypto/subtle"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/config"
 	"github.com/slilp/go-wallet/internal/utils"
 )
 
@@ -43,3 +45,23 @@ func AuthAccessTokenMiddleware(c *gin.Context) {
 
 	c.Next()
 }
+
+func AuthAdminApiKeyMiddleware(c *gin.Context) {
+
+	path := c.Request.URL.Path
+
+	if strings.HasPrefix(path, "/admin") {
+
+		apiKey := c.GetHeader("X-Admin-Key")
+		if config.Config.AdminApiKey == "" || subtle.ConstantTimeCompare([]byte(apiKey), []byte(config.Config.AdminApiKey)) != 1 {
+			c.JSON(http.StatusUnauthorized, api_gen.ErrorResponse{
+				ErrorCode:    "401",
+				ErrorMessage: "Unauthorized",
+			})
+			c.Abort()
+			return
+		}
+	}
+
+	c.Next()
+}
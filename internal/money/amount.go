@@ -0,0 +1,191 @@
+package money
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/slilp/go-wallet/internal/consts"
+)
+
+// Scale is the number of decimal places every Amount carries, matching the
+// DECIMAL(20,2) columns amounts are stored in.
+const Scale = 2
+
+var scaleFactor = pow10(Scale)
+
+// Amount is a monetary value held as an integer number of minor units
+// (1/100 at Scale 2), so arithmetic and comparisons never drift.
+type Amount int64
+
+func FromMinorUnits(units int64) Amount {
+	return Amount(units)
+}
+
+// Parse reads a plain decimal string such as "100", "-3.5" or "0.25".
+// Non-zero digits beyond Scale are rejected with consts.ErrAmountScaleExceeded.
+func Parse(s string) (Amount, error) {
+	units, err := parseFixed(s, Scale)
+	if err != nil {
+		return 0, err
+	}
+	return Amount(units), nil
+}
+
+func MustParse(s string) Amount {
+	a, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+func (a Amount) MinorUnits() int64 {
+	return int64(a)
+}
+
+func (a Amount) Neg() Amount {
+	return -a
+}
+
+func (a Amount) Abs() Amount {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+func (a Amount) String() string {
+	return formatFixed(int64(a), Scale)
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON accepts both JSON numbers and quoted decimal strings.
+func (a *Amount) UnmarshalJSON(b []byte) error {
+	s := strings.TrimSpace(string(b))
+	if s == "null" {
+		return nil
+	}
+	s = strings.Trim(s, `"`)
+
+	parsed, err := Parse(s)
+	if err != nil {
+		return fmt.Errorf("amount %q: %w", s, err)
+	}
+	*a = parsed
+	return nil
+}
+
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
+
+func (a *Amount) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*a = 0
+		return nil
+	case int64:
+		if v > math.MaxInt64/scaleFactor || v < math.MinInt64/scaleFactor {
+			return consts.ErrInvalidAmount
+		}
+		*a = Amount(v * scaleFactor)
+		return nil
+	case float64:
+		return a.scanString(strconv.FormatFloat(v, 'f', -1, 64))
+	case []byte:
+		return a.scanString(string(v))
+	case string:
+		return a.scanString(v)
+	default:
+		return fmt.Errorf("cannot scan %T into money.Amount", src)
+	}
+}
+
+func (a *Amount) scanString(s string) error {
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+func parseFixed(s string, scale int) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, consts.ErrInvalidAmount
+	}
+
+	negative := false
+	switch s[0] {
+	case '-':
+		negative = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	intPart, fracPart, hasDot := strings.Cut(s, ".")
+	if intPart == "" || (hasDot && fracPart == "") || !isDigits(intPart) || !isDigits(fracPart) {
+		return 0, consts.ErrInvalidAmount
+	}
+
+	if len(fracPart) > scale {
+		if strings.Trim(fracPart[scale:], "0") != "" {
+			return 0, consts.ErrAmountScaleExceeded
+		}
+		fracPart = fracPart[:scale]
+	}
+	fracPart += strings.Repeat("0", scale-len(fracPart))
+
+	units, err := strconv.ParseInt(intPart+fracPart, 10, 64)
+	if err != nil {
+		return 0, consts.ErrInvalidAmount
+	}
+
+	if negative {
+		units = -units
+	}
+	return units, nil
+}
+
+func formatFixed(units int64, scale int) string {
+	sign := ""
+	u := uint64(units)
+	if units < 0 {
+		sign = "-"
+		u = uint64(-units)
+	}
+
+	digits := strconv.FormatUint(u, 10)
+	if scale == 0 {
+		return sign + digits
+	}
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func pow10(n int) int64 {
+	p := int64(1)
+	for i := 0; i < n; i++ {
+		p *= 10
+	}
+	return p
+}
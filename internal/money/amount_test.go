@@ -0,0 +1,93 @@
+package money_test
+
+import (
+	"encoding/json"
+
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/money"
+)
+
+func (suite *MoneyTestSuite) TestParse() {
+	testCases := []struct {
+		name        string
+		input       string
+		want        money.Amount
+		wantErr     bool
+		expectedErr error
+	}{
+		{name: "GivenWholeNumber_ThenMinorUnitsReturned", input: "100", want: money.FromMinorUnits(10000)},
+		{name: "GivenOneDecimal_ThenPadded", input: "3.5", want: money.FromMinorUnits(350)},
+		{name: "GivenTwoDecimals_ThenExact", input: "0.01", want: money.FromMinorUnits(1)},
+		{name: "GivenNegative_ThenNegativeUnits", input: "-50.25", want: money.FromMinorUnits(-5025)},
+		{name: "GivenTrailingZerosBeyondScale_ThenAccepted", input: "12.34000000", want: money.FromMinorUnits(1234)},
+		{name: "GivenThreeDecimals_ThenScaleExceeded", input: "0.001", wantErr: true, expectedErr: consts.ErrAmountScaleExceeded},
+		{name: "GivenExponent_ThenInvalid", input: "1e2", wantErr: true, expectedErr: consts.ErrInvalidAmount},
+		{name: "GivenEmpty_ThenInvalid", input: "", wantErr: true, expectedErr: consts.ErrInvalidAmount},
+		{name: "GivenDanglingDot_ThenInvalid", input: "1.", wantErr: true, expectedErr: consts.ErrInvalidAmount},
+		{name: "GivenOverflow_ThenInvalid", input: "999999999999999999999", wantErr: true, expectedErr: consts.ErrInvalidAmount},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			got, err := money.Parse(tc.input)
+			if tc.wantErr {
+				suite.ErrorIs(err, tc.expectedErr)
+			} else {
+				suite.NoError(err)
+				suite.Equal(tc.want, got)
+			}
+		})
+	}
+}
+
+func (suite *MoneyTestSuite) TestString() {
+	suite.Equal("0.00", money.FromMinorUnits(0).String())
+	suite.Equal("0.05", money.FromMinorUnits(5).String())
+	suite.Equal("-0.05", money.FromMinorUnits(-5).String())
+	suite.Equal("1234.50", money.FromMinorUnits(123450).String())
+}
+
+func (suite *MoneyTestSuite) TestJSON() {
+	type payload struct {
+		Amount money.Amount `json:"amount"`
+	}
+
+	var p payload
+	suite.NoError(json.Unmarshal([]byte(`{"amount":10.1}`), &p))
+	suite.Equal(money.FromMinorUnits(1010), p.Amount)
+
+	suite.NoError(json.Unmarshal([]byte(`{"amount":"7.25"}`), &p))
+	suite.Equal(money.FromMinorUnits(725), p.Amount)
+
+	suite.ErrorIs(json.Unmarshal([]byte(`{"amount":0.105}`), &p), consts.ErrAmountScaleExceeded)
+
+	out, err := json.Marshal(payload{Amount: money.FromMinorUnits(10050)})
+	suite.NoError(err)
+	suite.JSONEq(`{"amount":100.50}`, string(out))
+}
+
+func (suite *MoneyTestSuite) TestScan() {
+	testCases := []struct {
+		name  string
+		input any
+		want  money.Amount
+	}{
+		{name: "GivenNumericText_ThenParsed", input: []byte("100.00000000"), want: money.FromMinorUnits(10000)},
+		{name: "GivenString_ThenParsed", input: "12.30", want: money.FromMinorUnits(1230)},
+		{name: "GivenInteger_ThenWholeUnits", input: int64(7), want: money.FromMinorUnits(700)},
+		{name: "GivenFloat_ThenParsed", input: float64(0.1), want: money.FromMinorUnits(10)},
+		{name: "GivenNil_ThenZero", input: nil, want: money.FromMinorUnits(0)},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			var got money.Amount
+			suite.NoError(got.Scan(tc.input))
+			suite.Equal(tc.want, got)
+		})
+	}
+
+	value, err := money.FromMinorUnits(-1999).Value()
+	suite.NoError(err)
+	suite.Equal("-19.99", value)
+}
@@ -0,0 +1,54 @@
+package money
+
+import "strings"
+
+// Currency is an ISO 4217 alphabetic code.
+type Currency string
+
+const DefaultCurrency Currency = "THB"
+
+// currencyExponents lists the supported currencies with their number of
+// minor-unit digits. Exponents never exceed Scale.
+var currencyExponents = map[Currency]int{
+	"THB": 2,
+	"USD": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"SGD": 2,
+	"JPY": 0,
+	"KRW": 0,
+	"VND": 0,
+}
+
+func ParseCurrency(code string) (Currency, bool) {
+	c := Currency(strings.ToUpper(strings.TrimSpace(code)))
+	if !c.IsSupported() {
+		return "", false
+	}
+	return c, true
+}
+
+func (c Currency) IsSupported() bool {
+	_, ok := currencyExponents[c]
+	return ok
+}
+
+// Exponent returns the number of minor-unit digits of the currency. Unknown
+// codes fall back to Scale.
+func (c Currency) Exponent() int {
+	if e, ok := currencyExponents[c]; ok {
+		return e
+	}
+	return Scale
+}
+
+// Allows reports whether the amount can be expressed in the currency's minor
+// units, e.g. 10.50 is valid for THB but not for JPY.
+func (c Currency) Allows(a Amount) bool {
+	step := pow10(Scale - c.Exponent())
+	return int64(a)%step == 0
+}
+
+func (c Currency) String() string {
+	return string(c)
+}
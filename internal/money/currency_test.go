@@ -0,0 +1,46 @@
+package money_test
+
+import (
+	"github.com/slilp/go-wallet/internal/money"
+)
+
+func (suite *MoneyTestSuite) TestParseCurrency() {
+	testCases := []struct {
+		name   string
+		input  string
+		want   money.Currency
+		wantOk bool
+	}{
+		{name: "GivenUpperCaseCode_ThenSupported", input: "USD", want: "USD", wantOk: true},
+		{name: "GivenLowerCaseCode_ThenNormalized", input: " jpy ", want: "JPY", wantOk: true},
+		{name: "GivenUnknownCode_ThenUnsupported", input: "XYZ", want: "", wantOk: false},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			got, ok := money.ParseCurrency(tc.input)
+			suite.Equal(tc.wantOk, ok)
+			suite.Equal(tc.want, got)
+		})
+	}
+}
+
+func (suite *MoneyTestSuite) TestCurrencyAllows() {
+	testCases := []struct {
+		name     string
+		currency money.Currency
+		amount   string
+		want     bool
+	}{
+		{name: "GivenTHBWithCents_ThenAllowed", currency: "THB", amount: "10.25", want: true},
+		{name: "GivenJPYWholeAmount_ThenAllowed", currency: "JPY", amount: "1500", want: true},
+		{name: "GivenJPYWithFraction_ThenRejected", currency: "JPY", amount: "1500.50", want: false},
+		{name: "GivenUnknownCurrency_ThenFallsBackToScale", currency: "XYZ", amount: "0.01", want: true},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.Equal(tc.want, tc.currency.Allows(money.MustParse(tc.amount)))
+		})
+	}
+}
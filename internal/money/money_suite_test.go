@@ -0,0 +1,16 @@
+package money_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MoneyTestSuite struct {
+	suite.Suite
+}
+
+func TestMoneyTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(MoneyTestSuite))
+}
@@ -0,0 +1,146 @@
+package money
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/slilp/go-wallet/internal/consts"
+)
+
+// RateScale is the number of decimal places carried by a Rate, matching the
+// DECIMAL(20,8) exchange rate columns.
+const RateScale = 8
+
+var rateFactor = pow10(RateScale)
+
+// Rate is a fixed-point decimal used for exchange rates and spreads, held as
+// an integer number of 1e-8 units.
+type Rate int64
+
+func ParseRate(s string) (Rate, error) {
+	units, err := parseFixed(s, RateScale)
+	if err != nil {
+		return 0, err
+	}
+	return Rate(units), nil
+}
+
+func MustParseRate(s string) Rate {
+	r, err := ParseRate(s)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// WithSpread returns the rate reduced by the given fractional spread, e.g. a
+// spread of 0.01 turns 35.00 into 34.65. The result is rounded down.
+func (r Rate) WithSpread(spread Rate) Rate {
+	adjusted := new(big.Int).Mul(big.NewInt(int64(r)), big.NewInt(rateFactor-int64(spread)))
+	adjusted.Quo(adjusted, big.NewInt(rateFactor))
+	return Rate(adjusted.Int64())
+}
+
+// Convert multiplies the amount by the rate and rounds the result down to the
+// minor units of the target currency.
+func (a Amount) Convert(r Rate, to Currency) (Amount, error) {
+	units := new(big.Int).Mul(big.NewInt(int64(a)), big.NewInt(int64(r)))
+	units.Quo(units, big.NewInt(rateFactor))
+
+	step := big.NewInt(pow10(Scale - to.Exponent()))
+	units.Sub(units, new(big.Int).Rem(units, step))
+
+	if !units.IsInt64() {
+		return 0, consts.ErrInvalidAmount
+	}
+	return Amount(units.Int64()), nil
+}
+
+// Prorate returns the share part/whole of the amount, rounded down to the
+// minor units of the given currency. It is used to split a converted amount
+// when only part of the source amount is refunded.
+func (a Amount) Prorate(part, whole Amount, c Currency) (Amount, error) {
+	if whole <= 0 {
+		return 0, consts.ErrInvalidAmount
+	}
+
+	units := new(big.Int).Mul(big.NewInt(int64(a)), big.NewInt(int64(part)))
+	units.Quo(units, big.NewInt(int64(whole)))
+	step := big.NewInt(pow10(Scale - c.Exponent()))
+	units.Sub(units, new(big.Int).Rem(units, step))
+	if !units.IsInt64() {
+		return 0, consts.ErrInvalidAmount
+	}
+	return Amount(units.Int64()), nil
+}
+
+// Accrue returns simple interest on the amount at an annual rate over the
+// given number of days on an actual/365 basis, rounded down to the minor
+// units of the currency.
+func (a Amount) Accrue(annualRate Rate, days int, c Currency) (Amount, error) {
+	units := new(big.Int).Mul(big.NewInt(int64(a)), big.NewInt(int64(annualRate)))
+	units.Mul(units, big.NewInt(int64(days)))
+	units.Quo(units, new(big.Int).Mul(big.NewInt(rateFactor), big.NewInt(365)))
+	step := big.NewInt(pow10(Scale - c.Exponent()))
+	units.Sub(units, new(big.Int).Rem(units, step))
+	if !units.IsInt64() {
+		return 0, consts.ErrInvalidAmount
+	}
+	return Amount(units.Int64()), nil
+}
+
+func (r Rate) String() string {
+	return formatFixed(int64(r), RateScale)
+}
+
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+func (r *Rate) UnmarshalJSON(b []byte) error {
+	s := strings.TrimSpace(string(b))
+	if s == "null" {
+		return nil
+	}
+	s = strings.Trim(s, `"`)
+
+	parsed, err := ParseRate(s)
+	if err != nil {
+		return fmt.Errorf("rate %q: %w", s, err)
+	}
+	*r = parsed
+	return nil
+}
+
+func (r Rate) Value() (driver.Value, error) {
+	return r.String(), nil
+}
+
+func (r *Rate) Scan(src any) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+		*r = 0
+		return nil
+	case int64:
+		s = strconv.FormatInt(v, 10)
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return fmt.Errorf("cannot scan %T into money.Rate", src)
+	}
+
+	parsed, err := ParseRate(s)
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
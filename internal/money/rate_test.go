@@ -0,0 +1,102 @@
+package money_test
+
+import (
+	"encoding/json"
+
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/money"
+)
+
+func (suite *MoneyTestSuite) TestParseRate() {
+	r, err := money.ParseRate("35.12345678")
+	suite.NoError(err)
+	suite.Equal("35.12345678", r.String())
+
+	_, err = money.ParseRate("0.123456789")
+	suite.ErrorIs(err, consts.ErrAmountScaleExceeded)
+
+	var payload struct {
+		Rate money.Rate `json:"rate"`
+	}
+	suite.NoError(json.Unmarshal([]byte(`{"rate":0.0285}`), &payload))
+	suite.Equal(money.MustParseRate("0.0285"), payload.Rate)
+}
+
+func (suite *MoneyTestSuite) TestRateWithSpread() {
+	suite.Equal(money.MustParseRate("34.65"), money.MustParseRate("35").WithSpread(money.MustParseRate("0.01")))
+	suite.Equal(money.MustParseRate("35"), money.MustParseRate("35").WithSpread(0))
+}
+
+func (suite *MoneyTestSuite) TestConvert() {
+	testCases := []struct {
+		name   string
+		amount string
+		rate   string
+		to     money.Currency
+		want   string
+	}{
+		{name: "GivenTHBToUSD_ThenRoundedDownToCents", amount: "1000", rate: "0.02857142", to: "USD", want: "28.57"},
+		{name: "GivenUSDToTHB_ThenExact", amount: "10.50", rate: "35", to: "THB", want: "367.50"},
+		{name: "GivenUSDToJPY_ThenRoundedDownToWholeYen", amount: "10.99", rate: "151.237", to: "JPY", want: "1662"},
+		{name: "GivenTinyAmount_ThenZero", amount: "0.01", rate: "0.001", to: "USD", want: "0"},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			got, err := money.MustParse(tc.amount).Convert(money.MustParseRate(tc.rate), tc.to)
+			suite.NoError(err)
+			suite.Equal(money.MustParse(tc.want), got)
+		})
+	}
+}
+
+func (suite *MoneyTestSuite) TestProrate() {
+	testCases := []struct {
+		name   string
+		amount string
+		part   string
+		whole  string
+		to     money.Currency
+		want   string
+	}{
+		{name: "GivenFullPart_ThenWholeAmount", amount: "346.50", part: "10", whole: "10", to: "THB", want: "346.50"},
+		{name: "GivenHalfPart_ThenHalfAmount", amount: "346.50", part: "5", whole: "10", to: "THB", want: "173.25"},
+		{name: "GivenThirdPart_ThenRoundedDownToCents", amount: "100", part: "1", whole: "3", to: "USD", want: "33.33"},
+		{name: "GivenJPY_ThenRoundedDownToWholeYen", amount: "1662", part: "3", whole: "10.99", to: "JPY", want: "453"},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			got, err := money.MustParse(tc.amount).Prorate(money.MustParse(tc.part), money.MustParse(tc.whole), tc.to)
+			suite.NoError(err)
+			suite.Equal(money.MustParse(tc.want), got)
+		})
+	}
+
+	_, err := money.MustParse("10").Prorate(money.MustParse("1"), 0, "THB")
+	suite.ErrorIs(err, consts.ErrInvalidAmount)
+}
+
+func (suite *MoneyTestSuite) TestAccrue() {
+	testCases := []struct {
+		name   string
+		amount string
+		rate   string
+		days   int
+		to     money.Currency
+		want   string
+	}{
+		{name: "GivenOneDay_ThenRoundedDownToCents", amount: "10000", rate: "0.18", days: 1, to: "THB", want: "4.93"},
+		{name: "GivenSeveralDays_ThenAccruedTogether", amount: "10000", rate: "0.18", days: 3, to: "THB", want: "14.79"},
+		{name: "GivenJPY_ThenRoundedDownToWholeYen", amount: "100000", rate: "0.15", days: 1, to: "JPY", want: "41"},
+		{name: "GivenZeroRate_ThenZero", amount: "10000", rate: "0", days: 1, to: "THB", want: "0"},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			got, err := money.MustParse(tc.amount).Accrue(money.MustParseRate(tc.rate), tc.days, tc.to)
+			suite.NoError(err)
+			suite.Equal(money.MustParse(tc.want), got)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"errors"
+	"log"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	pgDeadlockDetected     = "40P01"
+	pgSerializationFailure = "40001"
+	pgUniqueViolation      = "23505"
+
+	maxTransactionAttempts = 5
+	transactionRetryDelay  = 10 * time.Millisecond
+)
+
+// runInTransaction runs fn in a database transaction and, when Postgres
+// aborts it with a deadlock or serialization failure, retries it from the
+// start with jittered exponential backoff. fn must not keep state across
+// attempts other than what it assigns on success.
+func runInTransaction(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	for attempt := 1; ; attempt++ {
+		err := db.Transaction(fn)
+		if !isRetryableTransactionError(err) || attempt == maxTransactionAttempts {
+			return err
+		}
+
+		delay := transactionRetryDelay << (attempt - 1)
+		delay += time.Duration(rand.Int63n(int64(delay)))
+		log.Printf("Retrying transaction in %s after attempt %d failed: %v", delay, attempt, err)
+		time.Sleep(delay)
+	}
+}
+
+func isRetryableTransactionError(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == pgDeadlockDetected || pgErr.Code == pgSerializationFailure
+}
+
+// lockWallets locks the given wallets FOR UPDATE in ID order, so concurrent
+// transactions touching an overlapping set of wallets queue behind each other
+// instead of deadlocking. Empty and duplicate IDs are ignored; wallets that do
+// not exist are missing from the result. Any closed wallet fails the lock with
+// consts.ErrWalletClosed, since no money may move into or out of it.
+func lockWallets(tx *gorm.DB, walletIds ...string) (map[string]entity.Wallet, error) {
+	seen := make(map[string]bool, len(walletIds))
+	ids := make([]string, 0, len(walletIds))
+	for _, id := range walletIds {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	var wallets []entity.Wallet
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ?", ids).
+		Order("id").
+		Find(&wallets).Error; err != nil {
+		log.Printf("Failed to lock wallets: %v", err)
+		return nil, err
+	}
+
+	locked := make(map[string]entity.Wallet, len(wallets))
+	for _, wallet := range wallets {
+		if wallet.IsClosed() {
+			log.Printf("Wallet %s is closed", wallet.ID)
+			return nil, consts.ErrWalletClosed
+		}
+		locked[wallet.ID] = wallet
+	}
+	return locked, nil
+}
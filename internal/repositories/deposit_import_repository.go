@@ -0,0 +1,226 @@
+package repositories
+
+import (
+	"log"
+
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	depositImportStatusRejected  = "rejected"
+	depositImportStatusPending   = "pending"
+	depositImportStatusRunning   = "running"
+	depositImportStatusCompleted = "completed"
+
+	depositImportRowStatusPending   = "pending"
+	depositImportRowStatusCompleted = "completed"
+	depositImportRowStatusFailed    = "failed"
+	depositImportRowStatusInvalid   = "invalid"
+	depositImportRowStatusSkipped   = "skipped"
+)
+
+// depositImportRowBatch is how many rows go into one INSERT when an import
+// is stored.
+const depositImportRowBatch = 500
+
+// DepositReference is a reference already deposited, or queued for deposit,
+// into a wallet.
+type DepositReference struct {
+	WalletID  string
+	Reference string
+}
+
+//go:generate mockgen -source=./deposit_import_repository.go -destination=./mocks/mock_deposit_import_repository.go -package=mock_repositories
+type DepositImportRepository interface {
+	Create(depositImport entity.DepositImport, rows []entity.DepositImportRow) (*entity.DepositImport, bool, error)
+	FindByID(importId string) (*entity.DepositImport, error)
+	ListRows(importId string, status *string) ([]entity.DepositImportRow, error)
+	FindUsedReferences(references []string) ([]DepositReference, error)
+	RunChunk(size int, execute func(row entity.DepositImportRow) entity.DepositImportRow) (int, error)
+}
+
+type depositImportRepository struct {
+	db *gorm.DB
+}
+
+func NewDepositImportRepository(db *gorm.DB) DepositImportRepository {
+	return &depositImportRepository{db: db}
+}
+
+// Create stores an import and its rows. Rows carrying an ErrorMessage are
+// invalid; if there are any the whole import is rejected and no row is
+// queued. When an import of the same file already exists it is returned
+// unchanged with created set to false.
+func (r *depositImportRepository) Create(depositImport entity.DepositImport, rows []entity.DepositImportRow) (*entity.DepositImport, bool, error) {
+	created := false
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		depositImport.TotalRows = len(rows)
+		depositImport.InvalidRows = 0
+		for _, row := range rows {
+			if row.ErrorMessage != nil {
+				depositImport.InvalidRows++
+			}
+		}
+		depositImport.Status = depositImportStatusPending
+		if depositImport.InvalidRows > 0 {
+			depositImport.Status = depositImportStatusRejected
+		}
+
+		result := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "file_hash"}}, DoNothing: true}).Create(&depositImport)
+		if result.Error != nil {
+			log.Printf("Create deposit import error: %v", result.Error)
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return tx.Where(&entity.DepositImport{FileHash: depositImport.FileHash}).First(&depositImport).Error
+		}
+		created = true
+
+		for i := range rows {
+			rows[i].ImportID = depositImport.ID
+			switch {
+			case rows[i].ErrorMessage != nil:
+				rows[i].Status = depositImportRowStatusInvalid
+			case depositImport.Status == depositImportStatusRejected:
+				rows[i].Status = depositImportRowStatusSkipped
+			default:
+				rows[i].Status = depositImportRowStatusPending
+			}
+		}
+		if len(rows) > 0 {
+			if err := tx.CreateInBatches(rows, depositImportRowBatch).Error; err != nil {
+				log.Printf("Create deposit import rows error: %v", err)
+				if isUniqueViolation(err) {
+					return consts.ErrImportReferenceQueued
+				}
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, false, err
+	}
+	return &depositImport, created, nil
+}
+
+func (r *depositImportRepository) FindByID(importId string) (*entity.DepositImport, error) {
+	var depositImport entity.DepositImport
+	if err := r.db.Where(&entity.DepositImport{ID: importId}).First(&depositImport).Error; err != nil {
+		log.Printf("Find deposit import error: %v", err)
+		return nil, err
+	}
+	return &depositImport, nil
+}
+
+func (r *depositImportRepository) ListRows(importId string, status *string) ([]entity.DepositImportRow, error) {
+	var rows []entity.DepositImportRow
+	query := r.db.Where(&entity.DepositImportRow{ImportID: importId})
+	if status != nil {
+		query = query.Where(&entity.DepositImportRow{Status: *status})
+	}
+	if err := query.Order("row_number").Find(&rows).Error; err != nil {
+		log.Printf("List deposit import rows error: %v", err)
+		return nil, err
+	}
+	return rows, nil
+}
+
+// FindUsedReferences returns every wallet and reference pair among
+// references that was already deposited, or is queued by another import.
+func (r *depositImportRepository) FindUsedReferences(references []string) ([]DepositReference, error) {
+	var used []DepositReference
+	if len(references) == 0 {
+		return used, nil
+	}
+	if err := r.db.Raw(`SELECT "to" AS wallet_id, reference FROM transactions WHERE type = 'deposit' AND "to" IS NOT NULL AND reference IN ?
+		UNION
+		SELECT wallet_id, reference FROM deposit_import_rows WHERE status IN ? AND reference IN ?`,
+		references, []string{depositImportRowStatusPending, depositImportRowStatusCompleted}, references).
+		Scan(&used).Error; err != nil {
+		log.Printf("Find used deposit references error: %v", err)
+		return nil, err
+	}
+	return used, nil
+}
+
+// RunChunk claims the oldest import that still has pending rows and hands up
+// to size of them to execute, in file order. execute reports the outcome by
+// setting TransactionID or ErrorMessage on the row it returns. The import is
+// locked with SKIP LOCKED for the whole chunk, so replicas never run the same
+// rows, and it is marked completed once no pending row is left. It returns
+// the number of rows processed, 0 when there was nothing to do.
+func (r *depositImportRepository) RunChunk(size int, execute func(row entity.DepositImportRow) entity.DepositImportRow) (int, error) {
+	processed := 0
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		var imports []entity.DepositImport
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status IN ?", []string{depositImportStatusPending, depositImportStatusRunning}).
+			Order("created_at").
+			Limit(1).
+			Find(&imports).Error; err != nil {
+			log.Printf("Claim deposit import error: %v", err)
+			return err
+		}
+		if len(imports) == 0 {
+			return nil
+		}
+		depositImport := imports[0]
+
+		var rows []entity.DepositImportRow
+		if err := tx.Where(&entity.DepositImportRow{ImportID: depositImport.ID, Status: depositImportRowStatusPending}).
+			Order("row_number").
+			Limit(size).
+			Find(&rows).Error; err != nil {
+			log.Printf("Load pending deposit import rows error: %v", err)
+			return err
+		}
+
+		completed, failed := 0, 0
+		for _, row := range rows {
+			result := execute(row)
+			status := depositImportRowStatusCompleted
+			if result.ErrorMessage != nil {
+				status = depositImportRowStatusFailed
+				failed++
+			} else {
+				completed++
+			}
+			if err := tx.Model(&entity.DepositImportRow{}).
+				Where(&entity.DepositImportRow{ID: row.ID}).
+				Updates(map[string]interface{}{
+					"error_message":  result.ErrorMessage,
+					"status":         status,
+					"transaction_id": result.TransactionID,
+					"updated_at":     gorm.Expr("NOW()"),
+				}).Error; err != nil {
+				log.Printf("Record deposit import row error: %v", err)
+				return err
+			}
+		}
+		processed = len(rows)
+
+		updates := map[string]interface{}{
+			"completed_rows": gorm.Expr("completed_rows + ?", completed),
+			"failed_rows":    gorm.Expr("failed_rows + ?", failed),
+			"status":         depositImportStatusRunning,
+			"updated_at":     gorm.Expr("NOW()"),
+		}
+		if len(rows) < size {
+			updates["status"] = depositImportStatusCompleted
+			updates["completed_at"] = gorm.Expr("NOW()")
+		}
+		if err := tx.Model(&entity.DepositImport{}).
+			Where(&entity.DepositImport{ID: depositImport.ID}).
+			Updates(updates).Error; err != nil {
+			log.Printf("Advance deposit import error: %v", err)
+			return err
+		}
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	return processed, nil
+}
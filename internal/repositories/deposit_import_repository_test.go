@@ -0,0 +1,345 @@
+package repositories_test
+
+import (
+	"errors"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"gorm.io/gorm"
+)
+
+func (suite *DepositImportRepositoryTestSuite) TestCreate() {
+	amount := money.MustParse("10")
+	userId := "<UserID>"
+	problem := "unknown wallet"
+	validRow := entity.DepositImportRow{RowNumber: 2, WalletID: "<WalletID>", UserID: &userId, Amount: &amount, Reference: "INV-1"}
+	invalidRow := entity.DepositImportRow{RowNumber: 3, WalletID: "<Unknown>", Amount: &amount, Reference: "INV-2", ErrorMessage: &problem}
+
+	testCases := []struct {
+		name          string
+		rows          []entity.DepositImportRow
+		mock          func(sqlmock.Sqlmock)
+		wantErr       bool
+		expectedErr   error
+		wantCreated   bool
+		wantStatus    string
+		wantInvalid   int
+		wantRowStatus []string
+	}{
+		{
+			name: "GivenValidRows_WhenCreate_ThenQueuePendingImport",
+			rows: []entity.DepositImportRow{validRow},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "deposit_imports" .* ON CONFLICT \("file_hash"\) DO NOTHING RETURNING`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("<ImportID>"))
+				mock.ExpectQuery(`INSERT INTO "deposit_import_rows"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("<RowID>"))
+				mock.ExpectCommit()
+			},
+			wantCreated:   true,
+			wantStatus:    "pending",
+			wantRowStatus: []string{"pending"},
+		},
+		{
+			name: "GivenInvalidRow_WhenCreate_ThenRejectImport",
+			rows: []entity.DepositImportRow{validRow, invalidRow},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "deposit_imports"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("<ImportID>"))
+				mock.ExpectQuery(`INSERT INTO "deposit_import_rows"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("<RowID1>").AddRow("<RowID2>"))
+				mock.ExpectCommit()
+			},
+			wantCreated:   true,
+			wantStatus:    "rejected",
+			wantInvalid:   1,
+			wantRowStatus: []string{"skipped", "invalid"},
+		},
+		{
+			name: "GivenSameFileHash_WhenCreate_ThenReturnExistingImport",
+			rows: []entity.DepositImportRow{validRow},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "deposit_imports"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectQuery(`SELECT \* FROM "deposit_imports" WHERE "deposit_imports"\."file_hash" = \$1`).
+					WithArgs("<FileHash>", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "file_hash", "status", "total_rows", "completed_rows"}).
+						AddRow("<ImportID>", "<FileHash>", "completed", 1, 1))
+				mock.ExpectCommit()
+			},
+			wantCreated: false,
+			wantStatus:  "completed",
+		},
+		{
+			name: "GivenReferenceQueuedConcurrently_WhenCreate_ThenError",
+			rows: []entity.DepositImportRow{validRow},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "deposit_imports"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("<ImportID>"))
+				mock.ExpectQuery(`INSERT INTO "deposit_import_rows"`).
+					WillReturnError(&pgconn.PgError{Code: "23505"})
+				mock.ExpectRollback()
+			},
+			wantErr:     true,
+			expectedErr: consts.ErrImportReferenceQueued,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+			rows := append([]entity.DepositImportRow{}, tc.rows...)
+			depositImport, created, err := suite.depositImportRepo.Create(entity.DepositImport{Filename: "deposits.csv", FileHash: "<FileHash>"}, rows)
+			if tc.wantErr {
+				suite.ErrorIs(err, tc.expectedErr)
+				suite.Nil(depositImport)
+			} else {
+				suite.NoError(err)
+				suite.Equal(tc.wantCreated, created)
+				suite.Equal("<ImportID>", depositImport.ID)
+				suite.Equal(tc.wantStatus, depositImport.Status)
+				suite.Equal(tc.wantInvalid, depositImport.InvalidRows)
+				for i, status := range tc.wantRowStatus {
+					suite.Equal(status, rows[i].Status)
+					suite.Equal("<ImportID>", rows[i].ImportID)
+				}
+			}
+			suite.NoError(suite.sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (suite *DepositImportRepositoryTestSuite) TestFindByID() {
+	testCases := []struct {
+		name        string
+		mock        func(sqlmock.Sqlmock)
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "GivenImport_WhenFindByID_ThenReturnImport",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "deposit_imports" WHERE "deposit_imports"\."id" = \$1 ORDER BY "deposit_imports"\."id" LIMIT \$2`).
+					WithArgs("<ImportID>", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow("<ImportID>", "running"))
+			},
+		},
+		{
+			name: "GivenNoImport_WhenFindByID_ThenNotFound",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "deposit_imports"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			wantErr:     true,
+			expectedErr: gorm.ErrRecordNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+			depositImport, err := suite.depositImportRepo.FindByID("<ImportID>")
+			if tc.wantErr {
+				suite.ErrorIs(err, tc.expectedErr)
+				suite.Nil(depositImport)
+			} else {
+				suite.NoError(err)
+				suite.Equal("running", depositImport.Status)
+			}
+			suite.NoError(suite.sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (suite *DepositImportRepositoryTestSuite) TestListRows() {
+	failed := "failed"
+
+	testCases := []struct {
+		name    string
+		status  *string
+		mock    func(sqlmock.Sqlmock)
+		wantLen int
+		wantErr bool
+	}{
+		{
+			name: "GivenImport_WhenListRows_ThenReturnRowsInFileOrder",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "deposit_import_rows" WHERE "deposit_import_rows"\."import_id" = \$1 ORDER BY row_number`).
+					WithArgs("<ImportID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "row_number", "status"}).
+						AddRow("<RowID1>", 2, "completed").
+						AddRow("<RowID2>", 3, "failed"))
+			},
+			wantLen: 2,
+		},
+		{
+			name:   "GivenStatusFilter_WhenListRows_ThenFilterByStatus",
+			status: &failed,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "deposit_import_rows" WHERE "deposit_import_rows"\."import_id" = \$1 AND "deposit_import_rows"\."status" = \$2 ORDER BY row_number`).
+					WithArgs("<ImportID>", "failed").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "row_number", "status"}).AddRow("<RowID2>", 3, "failed"))
+			},
+			wantLen: 1,
+		},
+		{
+			name: "GivenDbError_WhenListRows_ThenError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "deposit_import_rows"`).WillReturnError(errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+			rows, err := suite.depositImportRepo.ListRows("<ImportID>", tc.status)
+			if tc.wantErr {
+				suite.Error(err)
+			} else {
+				suite.NoError(err)
+				suite.Len(rows, tc.wantLen)
+			}
+			suite.NoError(suite.sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (suite *DepositImportRepositoryTestSuite) TestFindUsedReferences() {
+	suite.sqlMock.ExpectQuery(`SELECT "to" AS wallet_id, reference FROM transactions WHERE type = 'deposit' .* UNION\s+SELECT wallet_id, reference FROM deposit_import_rows WHERE status IN \(\$3,\$4\) AND reference IN \(\$5,\$6\)`).
+		WithArgs("INV-1", "INV-2", "pending", "completed", "INV-1", "INV-2").
+		WillReturnRows(sqlmock.NewRows([]string{"wallet_id", "reference"}).AddRow("<WalletID>", "INV-1"))
+
+	used, err := suite.depositImportRepo.FindUsedReferences([]string{"INV-1", "INV-2"})
+
+	suite.NoError(err)
+	suite.Equal([]repositories.DepositReference{{WalletID: "<WalletID>", Reference: "INV-1"}}, used)
+	suite.NoError(suite.sqlMock.ExpectationsWereMet())
+}
+
+func (suite *DepositImportRepositoryTestSuite) TestRunChunk() {
+	amount := money.MustParse("10")
+	failure := "wallet is closed"
+
+	testCases := []struct {
+		name          string
+		size          int
+		mock          func(sqlmock.Sqlmock)
+		execute       func(entity.DepositImportRow) entity.DepositImportRow
+		wantProcessed int
+		wantErr       bool
+	}{
+		{
+			name: "GivenNoQueuedImport_WhenRunChunk_ThenDoNothing",
+			size: 2,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "deposit_imports" WHERE status IN \(\$1,\$2\) ORDER BY created_at LIMIT \$3 FOR UPDATE SKIP LOCKED`).
+					WithArgs("pending", "running", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "GivenFullChunk_WhenRunChunk_ThenRecordOutcomesAndKeepRunning",
+			size: 2,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "deposit_imports" WHERE status IN`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow("<ImportID>", "pending"))
+				mock.ExpectQuery(`SELECT \* FROM "deposit_import_rows" WHERE "deposit_import_rows"\."import_id" = \$1 AND "deposit_import_rows"\."status" = \$2 ORDER BY row_number LIMIT \$3`).
+					WithArgs("<ImportID>", "pending", 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "import_id", "row_number", "wallet_id", "user_id", "amount", "reference", "status"}).
+						AddRow("<RowID1>", "<ImportID>", 2, "<WalletID>", "<UserID>", "10", "INV-1", "pending").
+						AddRow("<RowID2>", "<ImportID>", 3, "<WalletID>", "<UserID>", "10", "INV-2", "pending"))
+				mock.ExpectExec(`UPDATE "deposit_import_rows" SET "error_message"=\$1,"status"=\$2,"transaction_id"=\$3,"updated_at"=NOW\(\) WHERE "deposit_import_rows"\."id" = \$4`).
+					WithArgs(nil, "completed", "<TransactionID>", "<RowID1>").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`UPDATE "deposit_import_rows"`).
+					WithArgs(failure, "failed", nil, "<RowID2>").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`UPDATE "deposit_imports" SET "completed_rows"=completed_rows \+ \$1,"failed_rows"=failed_rows \+ \$2,"status"=\$3,"updated_at"=NOW\(\) WHERE "deposit_imports"\."id" = \$4`).
+					WithArgs(1, 1, "running", "<ImportID>").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			execute: func(row entity.DepositImportRow) entity.DepositImportRow {
+				if row.Reference == "INV-2" {
+					row.ErrorMessage = &failure
+					return row
+				}
+				transactionId := "<TransactionID>"
+				row.TransactionID = &transactionId
+				return row
+			},
+			wantProcessed: 2,
+		},
+		{
+			name: "GivenLastRows_WhenRunChunk_ThenCompleteImport",
+			size: 2,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "deposit_imports" WHERE status IN`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow("<ImportID>", "running"))
+				mock.ExpectQuery(`SELECT \* FROM "deposit_import_rows"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "import_id", "row_number", "wallet_id", "user_id", "amount", "reference", "status"}).
+						AddRow("<RowID3>", "<ImportID>", 4, "<WalletID>", "<UserID>", "10", "INV-3", "pending"))
+				mock.ExpectExec(`UPDATE "deposit_import_rows"`).
+					WithArgs(nil, "completed", "<TransactionID>", "<RowID3>").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`UPDATE "deposit_imports" SET "completed_at"=NOW\(\),"completed_rows"=completed_rows \+ \$1,"failed_rows"=failed_rows \+ \$2,"status"=\$3`).
+					WithArgs(1, 0, "completed", "<ImportID>").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			execute: func(row entity.DepositImportRow) entity.DepositImportRow {
+				suite.Equal(amount, *row.Amount)
+				transactionId := "<TransactionID>"
+				row.TransactionID = &transactionId
+				return row
+			},
+			wantProcessed: 1,
+		},
+		{
+			name: "GivenRecordFails_WhenRunChunk_ThenRollback",
+			size: 2,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "deposit_imports" WHERE status IN`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow("<ImportID>", "running"))
+				mock.ExpectQuery(`SELECT \* FROM "deposit_import_rows"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "import_id", "row_number", "status"}).
+						AddRow("<RowID1>", "<ImportID>", 2, "pending"))
+				mock.ExpectExec(`UPDATE "deposit_import_rows"`).WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+			},
+			execute: func(row entity.DepositImportRow) entity.DepositImportRow {
+				return row
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+			processed, err := suite.depositImportRepo.RunChunk(tc.size, tc.execute)
+			if tc.wantErr {
+				suite.Error(err)
+			} else {
+				suite.NoError(err)
+			}
+			suite.Equal(tc.wantProcessed, processed)
+			suite.NoError(suite.sqlMock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/slilp/go-wallet/internal/money"
+)
+
+// DepositImport is one uploaded bulk deposit file. FileHash is the SHA-256 of
+// the file, so uploading the same file again finds this import instead of
+// creating another.
+type DepositImport struct {
+	ID            string     `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	Filename      string     `gorm:"type:varchar(255);not null"`
+	FileHash      string     `gorm:"type:varchar(64);not null;uniqueIndex"`
+	Status        string     `gorm:"type:varchar(20);not null"`
+	TotalRows     int        `gorm:"type:integer;not null;default:0"`
+	InvalidRows   int        `gorm:"type:integer;not null;default:0"`
+	CompletedRows int        `gorm:"type:integer;not null;default:0"`
+	FailedRows    int        `gorm:"type:integer;not null;default:0"`
+	CreatedAt     time.Time  `gorm:"type:timestamp;not null;default:now()"`
+	UpdatedAt     time.Time  `gorm:"type:timestamp;not null;default:now()"`
+	CompletedAt   *time.Time `gorm:"type:timestamp"`
+}
+
+// DepositImportRow is one line of an import and its outcome. WalletID and
+// Reference hold what was uploaded even when the row is invalid; UserID and
+// Amount are only set once they validate.
+type DepositImportRow struct {
+	ID            string        `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	ImportID      string        `gorm:"type:uuid;not null"`
+	RowNumber     int           `gorm:"type:integer;not null"`
+	WalletID      string        `gorm:"type:text;not null"`
+	UserID        *string       `gorm:"type:uuid"`
+	Amount        *money.Amount `gorm:"type:decimal(20,2)"`
+	Reference     string        `gorm:"type:text;not null"`
+	Status        string        `gorm:"type:varchar(20);not null"`
+	TransactionID *string       `gorm:"type:varchar(32)"`
+	ErrorMessage  *string       `gorm:"type:text"`
+	UpdatedAt     time.Time     `gorm:"type:timestamp;not null;default:now()"`
+}
@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/slilp/go-wallet/internal/money"
+)
+
+type ExchangeRate struct {
+	BaseCurrency  money.Currency `gorm:"type:varchar(3);primaryKey"`
+	QuoteCurrency money.Currency `gorm:"type:varchar(3);primaryKey"`
+	Rate          money.Rate     `gorm:"type:decimal(20,8);not null"`
+	Spread        money.Rate     `gorm:"type:decimal(10,8);not null;default:0"`
+	CreatedAt     time.Time      `gorm:"type:timestamp;not null;default:now()"`
+	UpdatedAt     time.Time      `gorm:"type:timestamp;not null;default:now()"`
+}
+
+func (r ExchangeRate) EffectiveRate() money.Rate {
+	return r.Rate.WithSpread(r.Spread)
+}
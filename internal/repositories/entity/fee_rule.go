@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/slilp/go-wallet/internal/money"
+)
+
+// FeeRule prices one tier of a transaction type in Currency: it applies to
+// amounts from MinAmount up to the next tier's MinAmount. The fee is FlatFee
+// plus Rate of the amount, clamped to MinFee and MaxFee, and is credited to
+// FeeWalletID.
+type FeeRule struct {
+	ID              string         `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	TransactionType string         `gorm:"type:varchar(20);not null"`
+	Currency        money.Currency `gorm:"type:varchar(3);not null"`
+	MinAmount       money.Amount   `gorm:"type:decimal(20,2);not null;default:0"`
+	FlatFee         money.Amount   `gorm:"type:decimal(20,2);not null;default:0"`
+	Rate            money.Rate     `gorm:"type:decimal(10,8);not null;default:0"`
+	MinFee          *money.Amount  `gorm:"type:decimal(20,2)"`
+	MaxFee          *money.Amount  `gorm:"type:decimal(20,2)"`
+	FeeWalletID     string         `gorm:"type:uuid;not null"`
+	CreatedAt       time.Time      `gorm:"type:timestamp;not null;default:now()"`
+	UpdatedAt       time.Time      `gorm:"type:timestamp;not null;default:now()"`
+}
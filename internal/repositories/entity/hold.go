@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/slilp/go-wallet/internal/money"
+)
+
+// Hold reserves part of a wallet's balance for a later capture into
+// ToWalletID. Active, unexpired holds reduce the wallet's available balance.
+type Hold struct {
+	ID             string        `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	WalletID       string        `gorm:"type:uuid;not null;index"`
+	ToWalletID     string        `gorm:"type:uuid;not null"`
+	Amount         money.Amount  `gorm:"type:decimal(20,2);not null"`
+	CapturedAmount *money.Amount `gorm:"type:decimal(20,2)"`
+	Status         string        `gorm:"type:varchar(20);not null"`
+	TransactionID  *string       `gorm:"type:varchar(32)"`
+	ExpiresAt      time.Time     `gorm:"type:timestamp;not null"`
+	CreatedAt      time.Time     `gorm:"type:timestamp;not null;default:now()"`
+	UpdatedAt      time.Time     `gorm:"type:timestamp;not null;default:now()"`
+}
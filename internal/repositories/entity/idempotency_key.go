@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// IdempotencyKey remembers the transaction a client key produced, so a retry
+// with the same key replays it instead of moving money again.
+type IdempotencyKey struct {
+	UserID        string    `gorm:"type:uuid;primaryKey"`
+	Key           string    `gorm:"type:varchar(255);primaryKey"`
+	Fingerprint   string    `gorm:"type:varchar(64);not null"`
+	TransactionID *string   `gorm:"type:varchar(32)"`
+	CreatedAt     time.Time `gorm:"type:timestamp;not null;default:now()"`
+}
@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/slilp/go-wallet/internal/money"
+)
+
+// LedgerAccount is either a wallet account (sharing the wallet's ID) or a
+// system account such as treasury, fees, suspense or fx, one per currency.
+type LedgerAccount struct {
+	ID        string         `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	Code      string         `gorm:"type:varchar(50);not null"`
+	Currency  money.Currency `gorm:"type:varchar(3);not null"`
+	CreatedAt time.Time      `gorm:"type:timestamp;not null;default:now()"`
+}
+
+type JournalEntry struct {
+	ID            string    `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	TransactionID *string   `gorm:"type:varchar(32);index"`
+	Description   *string   `gorm:"type:varchar(255)"`
+	CreatedAt     time.Time `gorm:"type:timestamp;not null;default:now()"`
+}
+
+type Posting struct {
+	ID             int64          `gorm:"primaryKey;autoIncrement"`
+	JournalEntryID string         `gorm:"type:uuid;not null"`
+	AccountID      string         `gorm:"type:uuid;not null;index"`
+	Direction      string         `gorm:"type:varchar(6);not null"`
+	Amount         money.Amount   `gorm:"type:decimal(20,2);not null"`
+	Currency       money.Currency `gorm:"type:varchar(3);not null"`
+	CreatedAt      time.Time      `gorm:"type:timestamp;not null;default:now()"`
+}
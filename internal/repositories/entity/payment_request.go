@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/slilp/go-wallet/internal/money"
+)
+
+// PaymentRequest asks PayerID to pay Amount into RequesterID's ToWalletID.
+// Only pending, unexpired requests can be accepted or declined.
+type PaymentRequest struct {
+	ID            string       `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	RequesterID   string       `gorm:"type:uuid;not null;index"`
+	PayerID       string       `gorm:"type:uuid;not null;index"`
+	ToWalletID    string       `gorm:"type:uuid;not null"`
+	Amount        money.Amount `gorm:"type:decimal(20,2);not null"`
+	Note          *string      `gorm:"type:varchar(255)"`
+	Status        string       `gorm:"type:varchar(20);not null"`
+	TransactionID *string      `gorm:"type:varchar(32)"`
+	ExpiresAt     time.Time    `gorm:"type:timestamp;not null"`
+	CreatedAt     time.Time    `gorm:"type:timestamp;not null;default:now()"`
+	UpdatedAt     time.Time    `gorm:"type:timestamp;not null;default:now()"`
+	Requester     User         `gorm:"foreignKey:RequesterID"`
+	Payer         User         `gorm:"foreignKey:PayerID"`
+}
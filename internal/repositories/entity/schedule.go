@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/slilp/go-wallet/internal/money"
+)
+
+// Schedule is a one-off or recurring transfer executed in the background.
+// NextRunAt is nil once the schedule is no longer active.
+type Schedule struct {
+	ID           string       `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID       string       `gorm:"type:uuid;not null;index"`
+	FromWalletID string       `gorm:"type:uuid;not null"`
+	ToWalletID   string       `gorm:"type:uuid;not null"`
+	Amount       money.Amount `gorm:"type:decimal(20,2);not null"`
+	Frequency    string       `gorm:"type:varchar(20);not null"`
+	StartAt      time.Time    `gorm:"type:timestamp;not null"`
+	EndAt        *time.Time   `gorm:"type:timestamp"`
+	MaxRuns      *int         `gorm:"type:integer"`
+	RunCount     int          `gorm:"type:integer;not null;default:0"`
+	NextRunAt    *time.Time   `gorm:"type:timestamp"`
+	Status       string       `gorm:"type:varchar(20);not null"`
+	CreatedAt    time.Time    `gorm:"type:timestamp;not null;default:now()"`
+	UpdatedAt    time.Time    `gorm:"type:timestamp;not null;default:now()"`
+}
+
+// Occurrence returns the time of the n-th run (counting from 0) and whether
+// the schedule still runs then. Monthly runs keep StartAt's day of month,
+// falling back to the last day of shorter months.
+func (s Schedule) Occurrence(n int) (time.Time, bool) {
+	var at time.Time
+	switch s.Frequency {
+	case "once":
+		if n > 0 {
+			return time.Time{}, false
+		}
+		at = s.StartAt
+	case "daily":
+		at = s.StartAt.AddDate(0, 0, n)
+	case "weekly":
+		at = s.StartAt.AddDate(0, 0, 7*n)
+	case "monthly":
+		at = addMonths(s.StartAt, n)
+	default:
+		return time.Time{}, false
+	}
+
+	if s.MaxRuns != nil && n >= *s.MaxRuns {
+		return time.Time{}, false
+	}
+	if s.EndAt != nil && at.After(*s.EndAt) {
+		return time.Time{}, false
+	}
+	return at, true
+}
+
+func addMonths(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+type ScheduleRun struct {
+	ID            string    `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	ScheduleID    string    `gorm:"type:uuid;not null;index"`
+	RunAt         time.Time `gorm:"type:timestamp;not null"`
+	Status        string    `gorm:"type:varchar(20);not null"`
+	TransactionID *string   `gorm:"type:varchar(32)"`
+	ErrorMessage  *string   `gorm:"type:text"`
+	CreatedAt     time.Time `gorm:"type:timestamp;not null;default:now()"`
+}
@@ -0,0 +1,78 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedule_Occurrence(t *testing.T) {
+	startAt := time.Date(2030, 1, 31, 9, 0, 0, 0, time.UTC)
+	endAt := time.Date(2030, 2, 10, 0, 0, 0, 0, time.UTC)
+	maxRuns := 2
+
+	testCases := []struct {
+		name     string
+		schedule entity.Schedule
+		n        int
+		want     time.Time
+		wantOk   bool
+	}{
+		{
+			name:     "GivenOnce_WhenFirstRun_ThenStartAt",
+			schedule: entity.Schedule{Frequency: "once", StartAt: startAt},
+			n:        0,
+			want:     startAt,
+			wantOk:   true,
+		},
+		{
+			name:     "GivenOnce_WhenSecondRun_ThenNone",
+			schedule: entity.Schedule{Frequency: "once", StartAt: startAt},
+			n:        1,
+			wantOk:   false,
+		},
+		{
+			name:     "GivenWeekly_WhenThirdRun_ThenTwoWeeksLater",
+			schedule: entity.Schedule{Frequency: "weekly", StartAt: startAt},
+			n:        2,
+			want:     time.Date(2030, 2, 14, 9, 0, 0, 0, time.UTC),
+			wantOk:   true,
+		},
+		{
+			name:     "GivenMonthlyOn31st_WhenFebruary_ThenLastDayOfMonth",
+			schedule: entity.Schedule{Frequency: "monthly", StartAt: startAt},
+			n:        1,
+			want:     time.Date(2030, 2, 28, 9, 0, 0, 0, time.UTC),
+			wantOk:   true,
+		},
+		{
+			name:     "GivenMonthlyOn31st_WhenMarch_ThenKeepDay",
+			schedule: entity.Schedule{Frequency: "monthly", StartAt: startAt},
+			n:        2,
+			want:     time.Date(2030, 3, 31, 9, 0, 0, 0, time.UTC),
+			wantOk:   true,
+		},
+		{
+			name:     "GivenEndAt_WhenRunAfterEnd_ThenNone",
+			schedule: entity.Schedule{Frequency: "daily", StartAt: startAt, EndAt: &endAt},
+			n:        11,
+			wantOk:   false,
+		},
+		{
+			name:     "GivenMaxRuns_WhenRunsExhausted_ThenNone",
+			schedule: entity.Schedule{Frequency: "daily", StartAt: startAt, MaxRuns: &maxRuns},
+			n:        2,
+			wantOk:   false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := tc.schedule.Occurrence(tc.n)
+			assert.Equal(t, tc.wantOk, ok)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
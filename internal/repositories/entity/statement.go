@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/money"
+)
+
+// Statement is the stored snapshot of a wallet's statement for a closed
+// period [PeriodStart, PeriodEnd). Once stored it is never regenerated, so a
+// closed month always renders the same.
+type Statement struct {
+	ID             string         `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	WalletID       string         `gorm:"type:uuid;not null;uniqueIndex:idx_statements_wallet_id_period_start"`
+	PeriodStart    time.Time      `gorm:"type:date;not null;uniqueIndex:idx_statements_wallet_id_period_start"`
+	PeriodEnd      time.Time      `gorm:"type:date;not null"`
+	Currency       money.Currency `gorm:"type:varchar(3);not null"`
+	OpeningBalance money.Amount   `gorm:"type:decimal(20,2);not null"`
+	ClosingBalance money.Amount   `gorm:"type:decimal(20,2);not null"`
+	TotalIn        money.Amount   `gorm:"type:decimal(20,2);not null"`
+	TotalOut       money.Amount   `gorm:"type:decimal(20,2);not null"`
+	Lines          StatementLines `gorm:"type:jsonb;not null"`
+	CreatedAt      time.Time      `gorm:"type:timestamp;not null;default:now()"`
+}
+
+// StatementLine is one journal entry on a wallet. Amount is signed: positive
+// for money in, negative for money out. Entries without a transaction, such
+// as opening balances, have no TransactionID.
+type StatementLine struct {
+	TransactionID        *string      `json:"transactionId,omitempty"`
+	Type                 string       `json:"type"`
+	Description          *string      `json:"description,omitempty"`
+	Reference            *string      `json:"reference,omitempty"`
+	CounterpartyWalletID *string      `json:"counterpartyWalletId,omitempty"`
+	Amount               money.Amount `json:"amount"`
+	BalanceAfter         money.Amount `json:"balanceAfter"`
+	CreatedAt            time.Time    `json:"createdAt"`
+}
+
+type StatementLines []StatementLine
+
+func (l StatementLines) Value() (driver.Value, error) {
+	if l == nil {
+		l = StatementLines{}
+	}
+	b, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (l *StatementLines) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	default:
+		return fmt.Errorf("cannot scan %T into entity.StatementLines", src)
+	}
+}
@@ -2,14 +2,27 @@ package entity
 
 import (
 	"time"
+
+	"github.com/slilp/go-wallet/internal/money"
 )
 
+// Transaction moves money out of From, into To, or both. FromBalanceAfter
+// and ToBalanceAfter hold each wallet's balance right after it was posted.
 type Transaction struct {
-	ID        string    `gorm:"type:varchar(20);primaryKey"`
-	From      *string   `gorm:"type:uuid;index"`
-	To        *string   `gorm:"type:uuid;index"`
-	Amount    float64   `gorm:"type:decimal(20,2);not null"`
-	Type      string    `gorm:"type:varchar(20);not null"`
-	CreatedAt time.Time `gorm:"type:timestamp;not null;default:now()"`
-	UpdatedAt time.Time `gorm:"type:timestamp;not null;default:now()"`
+	ID                    string             `gorm:"type:varchar(32);primaryKey"`
+	From                  *string            `gorm:"type:uuid;index"`
+	To                    *string            `gorm:"type:uuid;index"`
+	Amount                money.Amount       `gorm:"type:decimal(20,2);not null"`
+	Type                  string             `gorm:"type:varchar(20);not null"`
+	CreditedAmount        *money.Amount      `gorm:"type:decimal(20,2)"`
+	ExchangeRate          *money.Rate        `gorm:"type:decimal(20,8)"`
+	OriginalTransactionID *string            `gorm:"type:varchar(32);index"`
+	RefundedAmount        money.Amount       `gorm:"type:decimal(20,2);not null;default:0"`
+	FromBalanceAfter      *money.Amount      `gorm:"type:decimal(20,2)"`
+	ToBalanceAfter        *money.Amount      `gorm:"type:decimal(20,2)"`
+	ParentTransactionID   *string            `gorm:"type:varchar(32);index"`
+	Fee                   *Transaction       `gorm:"-"`
+	Details               TransactionDetails `gorm:"embedded"`
+	CreatedAt             time.Time          `gorm:"type:timestamp;not null;default:now()"`
+	UpdatedAt             time.Time          `gorm:"type:timestamp;not null;default:now()"`
 }
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// TransactionDetails is caller-supplied context attached to a movement.
+type TransactionDetails struct {
+	Description *string  `gorm:"type:varchar(255)"`
+	Reference   *string  `gorm:"type:varchar(100);index"`
+	Metadata    Metadata `gorm:"type:jsonb"`
+}
+
+// Metadata is arbitrary JSON stored alongside a transaction.
+type Metadata map[string]interface{}
+
+func (m Metadata) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (m *Metadata) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*m = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, m)
+	case string:
+		return json.Unmarshal([]byte(v), m)
+	default:
+		return fmt.Errorf("cannot scan %T into entity.Metadata", src)
+	}
+}
@@ -0,0 +1,61 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetadata_Scan(t *testing.T) {
+	testCases := []struct {
+		name    string
+		src     any
+		want    entity.Metadata
+		wantErr bool
+	}{
+		{
+			name: "GivenJSONBytes_WhenScan_ThenDecoded",
+			src:  []byte(`{"orderId":"A-1","items":2}`),
+			want: entity.Metadata{"orderId": "A-1", "items": float64(2)},
+		},
+		{
+			name: "GivenJSONString_WhenScan_ThenDecoded",
+			src:  `{"orderId":"A-1"}`,
+			want: entity.Metadata{"orderId": "A-1"},
+		},
+		{
+			name: "GivenNull_WhenScan_ThenNil",
+			src:  nil,
+			want: nil,
+		},
+		{
+			name:    "GivenUnsupportedType_WhenScan_ThenError",
+			src:     int64(1),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got entity.Metadata
+			err := got.Scan(tc.src)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestMetadata_Value(t *testing.T) {
+	value, err := entity.Metadata{"orderId": "A-1"}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"orderId":"A-1"}`, value)
+
+	value, err = entity.Metadata(nil).Value()
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+}
@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/slilp/go-wallet/internal/money"
+)
+
+// TransactionLimit caps debits from wallets in Currency. Rows without a
+// UserID are the global defaults; a user's row overrides them field by field.
+// Nil fields impose no limit.
+type TransactionLimit struct {
+	ID                    string         `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID                *string        `gorm:"type:uuid"`
+	Currency              money.Currency `gorm:"type:varchar(3);not null"`
+	PerTransactionMax     *money.Amount  `gorm:"type:decimal(20,2)"`
+	DailyWalletMax        *money.Amount  `gorm:"type:decimal(20,2)"`
+	MonthlyWalletMax      *money.Amount  `gorm:"type:decimal(20,2)"`
+	DailyUserMax          *money.Amount  `gorm:"type:decimal(20,2)"`
+	MonthlyUserMax        *money.Amount  `gorm:"type:decimal(20,2)"`
+	MaxTransfers          *int           `gorm:"type:integer"`
+	TransferWindowSeconds *int           `gorm:"type:integer"`
+	CreatedAt             time.Time      `gorm:"type:timestamp;not null;default:now()"`
+	UpdatedAt             time.Time      `gorm:"type:timestamp;not null;default:now()"`
+}
@@ -5,11 +5,13 @@ import (
 )
 
 type User struct {
-	ID          string    `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
-	Email       string    `gorm:"type:varchar(255);not null"`
-	Password    string    `gorm:"type:varchar(255);not null"`
-	DisplayName string    `gorm:"type:varchar(255);not null"`
-	CreatedAt   time.Time `gorm:"autoCreateTime"`
-	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
-	Wallets     []Wallet  `gorm:"foreignKey:UserID"`
+	ID              string    `gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	Email           string    `gorm:"type:varchar(255);not null"`
+	Password        string    `gorm:"type:varchar(255);not null"`
+	DisplayName     string    `gorm:"type:varchar(255);not null"`
+	Handle          *string   `gorm:"type:varchar(30);uniqueIndex"`
+	DefaultWalletID *string   `gorm:"type:uuid"`
+	CreatedAt       time.Time `gorm:"autoCreateTime"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime"`
+	Wallets         []Wallet  `gorm:"foreignKey:UserID"`
 }
@@ -2,14 +2,57 @@ package entity
 
 import (
 	"time"
+
+	"github.com/slilp/go-wallet/internal/money"
+)
+
+const (
+	WalletStatusActive        = "active"
+	WalletStatusFrozen        = "frozen"
+	WalletStatusDebitBlocked  = "debit_blocked"
+	WalletStatusCreditBlocked = "credit_blocked"
 )
 
+// Wallet holds a balance in one currency. Closed wallets keep their history
+// but can no longer move money. Status is set by operations to block debits,
+// credits or both, with the reason and actor of the last change. A wallet
+// with a CreditLimit may go negative by up to that amount and is charged
+// InterestRate (annual) on the negative balance.
 type Wallet struct {
-	ID          string    `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
-	UserID      string    `gorm:"type:uuid;not null;index"`
-	Name        string    `gorm:"type:varchar(100);not null"`
-	Description *string   `gorm:"type:varchar(255)"`
-	Balance     float64   `gorm:"type:decimal(20,8);not null;default:0"`
-	CreatedAt   time.Time `gorm:"type:timestamp;not null;default:now()"`
-	UpdatedAt   time.Time `gorm:"type:timestamp;not null;default:now()"`
+	ID                string         `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID            string         `gorm:"type:uuid;not null;index"`
+	Name              string         `gorm:"type:varchar(100);not null"`
+	Description       *string        `gorm:"type:varchar(255)"`
+	Currency          money.Currency `gorm:"type:varchar(3);not null;default:THB"`
+	Balance           money.Amount   `gorm:"type:decimal(20,2);not null;default:0"`
+	CreditLimit       money.Amount   `gorm:"type:decimal(20,2);not null;default:0"`
+	InterestRate      money.Rate     `gorm:"type:decimal(20,8);not null;default:0"`
+	InterestChargedOn *time.Time     `gorm:"type:date"`
+	Status            string         `gorm:"type:varchar(20);not null;default:active"`
+	StatusReason      *string        `gorm:"type:varchar(255)"`
+	StatusChangedBy   *string        `gorm:"type:varchar(100)"`
+	StatusChangedAt   *time.Time     `gorm:"type:timestamp"`
+	CreatedAt         time.Time      `gorm:"type:timestamp;not null;default:now()"`
+	UpdatedAt         time.Time      `gorm:"type:timestamp;not null;default:now()"`
+	ClosedAt          *time.Time     `gorm:"type:timestamp"`
+}
+
+func (w Wallet) IsClosed() bool {
+	return w.ClosedAt != nil
+}
+
+func (w Wallet) CanDebit() bool {
+	return w.Status != WalletStatusFrozen && w.Status != WalletStatusDebitBlocked
+}
+
+func (w Wallet) CanCredit() bool {
+	return w.Status != WalletStatusFrozen && w.Status != WalletStatusCreditBlocked
+}
+
+// CreditUsed is the part of the credit line drawn by a negative balance.
+func (w Wallet) CreditUsed() money.Amount {
+	if w.Balance >= 0 {
+		return 0
+	}
+	return w.Balance.Neg()
 }
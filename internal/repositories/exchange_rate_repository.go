@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"log"
+
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockgen -source=./exchange_rate_repository.go -destination=./mocks/mock_exchange_rate_repository.go -package=mock_repositories
+type ExchangeRateRepository interface {
+	Upsert(rates []entity.ExchangeRate) error
+	ListAll() ([]entity.ExchangeRate, error)
+}
+
+type exchangeRateRepository struct {
+	db *gorm.DB
+}
+
+func NewExchangeRateRepository(db *gorm.DB) ExchangeRateRepository {
+	return &exchangeRateRepository{db: db}
+}
+
+func (r *exchangeRateRepository) Upsert(rates []entity.ExchangeRate) error {
+	if err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "base_currency"}, {Name: "quote_currency"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate", "spread", "updated_at"}),
+	}).Create(&rates).Error; err != nil {
+		log.Printf("Upsert exchange rates error: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (r *exchangeRateRepository) ListAll() ([]entity.ExchangeRate, error) {
+	var rates []entity.ExchangeRate
+	if err := r.db.Order("base_currency, quote_currency").Find(&rates).Error; err != nil {
+		log.Printf("ListAll exchange rates error: %v", err)
+		return nil, err
+	}
+	return rates, nil
+}
@@ -0,0 +1,115 @@
+package repositories_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+)
+
+func (suite *ExchangeRateRepositoryTestSuite) TestUpsert() {
+	testCases := []struct {
+		name        string
+		mock        func(sqlmock.Sqlmock)
+		input       []entity.ExchangeRate
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenRates_WhenUpsertSuccess_ThenSuccess",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "exchange_rates" .* ON CONFLICT \("base_currency","quote_currency"\) DO UPDATE SET "rate"="excluded"\."rate","spread"="excluded"\."spread","updated_at"="excluded"\."updated_at"`).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(time.Now(), time.Now()))
+				mock.ExpectCommit()
+			},
+			input: []entity.ExchangeRate{
+				{BaseCurrency: "USD", QuoteCurrency: "THB", Rate: money.MustParseRate("35.5")},
+			},
+			wantErr:     false,
+			expectedErr: "",
+		},
+		{
+			name: "GivenRates_WhenUpsertFail_ThenError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "exchange_rates"`).
+					WillReturnError(errors.New("upsert failed"))
+				mock.ExpectRollback()
+			},
+			input: []entity.ExchangeRate{
+				{BaseCurrency: "USD", QuoteCurrency: "THB", Rate: money.MustParseRate("35.5")},
+			},
+			wantErr:     true,
+			expectedErr: "upsert failed",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+
+			err := suite.exchangeRateRepo.Upsert(tc.input)
+
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+			} else {
+				suite.NoError(err)
+			}
+
+			suite.sqlMock.ExpectationsWereMet()
+		})
+	}
+}
+
+func (suite *ExchangeRateRepositoryTestSuite) TestListAll() {
+	testCases := []struct {
+		name        string
+		mock        func(sqlmock.Sqlmock)
+		wantLen     int
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenRates_WhenListSuccess_ThenReturnRates",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"base_currency", "quote_currency", "rate", "spread", "created_at", "updated_at"}).
+					AddRow("USD", "THB", "35.50000000", "0.00500000", time.Now(), time.Now())
+				mock.ExpectQuery(`SELECT \* FROM "exchange_rates" ORDER BY base_currency, quote_currency`).
+					WillReturnRows(rows)
+			},
+			wantLen: 1,
+			wantErr: false,
+		},
+		{
+			name: "GivenRates_WhenListFail_ThenError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "exchange_rates"`).
+					WillReturnError(errors.New("query failed"))
+			},
+			wantErr:     true,
+			expectedErr: "query failed",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+
+			result, err := suite.exchangeRateRepo.ListAll()
+
+			if tc.wantErr {
+				suite.Nil(result)
+				suite.EqualError(err, tc.expectedErr)
+			} else {
+				suite.NoError(err)
+				suite.Len(result, tc.wantLen)
+				suite.Equal(money.MustParseRate("35.5"), result[0].Rate)
+			}
+
+			suite.sqlMock.ExpectationsWereMet()
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package repositories
+
+import (
+	"log"
+
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/idgen"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"gorm.io/gorm"
+)
+
+// Fee is charged on top of a debit and credited to WalletID. It is computed
+// before the debited wallet is locked.
+type Fee struct {
+	Amount   money.Amount
+	WalletID string
+}
+
+func (f *Fee) amount() money.Amount {
+	if f == nil {
+		return 0
+	}
+	return f.Amount
+}
+
+func (f *Fee) walletID() string {
+	if f == nil {
+		return ""
+	}
+	return f.WalletID
+}
+
+//go:generate mockgen -source=./fee_repository.go -destination=./mocks/mock_fee_repository.go -package=mock_repositories
+type FeeRepository interface {
+	Replace(rules []entity.FeeRule) error
+	FindForWallet(userId, walletId, transactionType string) (*entity.Wallet, []entity.FeeRule, error)
+}
+
+type feeRepository struct {
+	db *gorm.DB
+}
+
+func NewFeeRepository(db *gorm.DB) FeeRepository {
+	return &feeRepository{db: db}
+}
+
+// Replace swaps every fee rule for the given set. Each rule's fee wallet must
+// hold the rule's currency.
+func (r *feeRepository) Replace(rules []entity.FeeRule) error {
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		walletCurrencies := map[string]money.Currency{}
+		for _, rule := range rules {
+			currency, ok := walletCurrencies[rule.FeeWalletID]
+			if !ok {
+				var wallet entity.Wallet
+				if err := tx.Where(&entity.Wallet{ID: rule.FeeWalletID}).First(&wallet).Error; err != nil {
+					return err
+				}
+				currency = wallet.Currency
+				walletCurrencies[rule.FeeWalletID] = currency
+			}
+			if currency != rule.Currency {
+				log.Printf("Fee wallet %s holds %s, rule charges %s", rule.FeeWalletID, currency, rule.Currency)
+				return consts.ErrInvalidFeeRule
+			}
+		}
+
+		if err := tx.Where("1 = 1").Delete(&entity.FeeRule{}).Error; err != nil {
+			return err
+		}
+		if len(rules) == 0 {
+			return nil
+		}
+		return tx.Create(&rules).Error
+	}); err != nil {
+		log.Printf("Replace fee rules error: %v", err)
+		return err
+	}
+	return nil
+}
+
+// FindForWallet returns the user's wallet with the fee rules for debiting it
+// by transactionType. It does not lock the wallet.
+func (r *feeRepository) FindForWallet(userId, walletId, transactionType string) (*entity.Wallet, []entity.FeeRule, error) {
+	var wallet entity.Wallet
+	if err := r.db.Where(&entity.Wallet{ID: walletId, UserID: userId}).First(&wallet).Error; err != nil {
+		log.Printf("Find fee wallet error: %v", err)
+		return nil, nil, err
+	}
+
+	var rules []entity.FeeRule
+	if err := r.db.Where(&entity.FeeRule{TransactionType: transactionType, Currency: wallet.Currency}).
+		Order("min_amount").
+		Find(&rules).Error; err != nil {
+		log.Printf("Find fee rules error: %v", err)
+		return nil, nil, err
+	}
+	return &wallet, rules, nil
+}
+
+// chargeFee debits the fee from the locked fromWallet into feeWallet as a
+// "fee" transaction linked to parent, within the parent's DB transaction.
+// feeWallet is the entry for fee.WalletID in the map returned by lockWallets;
+// a zero wallet means it did not exist.
+func chargeFee(tx *gorm.DB, ids idgen.Generator, fromWallet, feeWallet entity.Wallet, parent *entity.Transaction, fee *Fee) error {
+	if feeWallet.ID != fee.WalletID {
+		log.Printf("Fee wallet %s not found", fee.WalletID)
+		return gorm.ErrRecordNotFound
+	}
+	if err := ensureCanCredit(feeWallet); err != nil {
+		return err
+	}
+
+	feeRecord := entity.Transaction{
+		ID:                  ids.NewID(),
+		From:                &fromWallet.ID,
+		To:                  &feeWallet.ID,
+		Amount:              fee.Amount,
+		Type:                "fee",
+		ParentTransactionID: &parent.ID,
+	}
+	if err := tx.Create(&feeRecord).Error; err != nil {
+		log.Printf("Create fee transaction error: %v", err)
+		return err
+	}
+
+	if err := postJournalEntry(tx, &feeRecord, []ledgerLine{
+		debitWallet(fromWallet.ID, fromWallet.Currency, fee.Amount),
+		creditWallet(feeWallet.ID, feeWallet.Currency, fee.Amount),
+	}); err != nil {
+		return err
+	}
+
+	parent.Fee = &feeRecord
+	return nil
+}
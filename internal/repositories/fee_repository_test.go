@@ -0,0 +1,142 @@
+package repositories_test
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"gorm.io/gorm"
+)
+
+const findWalletQuery = `SELECT \* FROM "wallets" WHERE "wallets"\."id" = \$1 ORDER BY "wallets"\."id" LIMIT \$2`
+
+func (suite *FeeRepositoryTestSuite) TestReplace() {
+	maxFee := money.MustParse("50")
+	rules := []entity.FeeRule{
+		{TransactionType: "transfer", Currency: "THB", FlatFee: money.MustParse("5"), FeeWalletID: "<FeeWalletID>"},
+		{TransactionType: "transfer", Currency: "THB", MinAmount: money.MustParse("1000"), Rate: money.MustParseRate("0.01"), MaxFee: &maxFee, FeeWalletID: "<FeeWalletID>"},
+	}
+
+	testCases := []struct {
+		name        string
+		rules       []entity.FeeRule
+		mock        func(sqlmock.Sqlmock)
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:  "GivenRulesWithMatchingFeeWallet_WhenReplace_ThenRulesSwapped",
+			rules: rules,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(findWalletQuery).
+					WithArgs("<FeeWalletID>", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "currency"}).AddRow("<FeeWalletID>", "THB"))
+				mock.ExpectExec(`DELETE FROM "fee_rules" WHERE 1 = 1`).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectQuery(`INSERT INTO "fee_rules"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).
+						AddRow("<RuleID1>", nil, nil).AddRow("<RuleID2>", nil, nil))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:  "GivenEmptySet_WhenReplace_ThenAllRulesRemoved",
+			rules: []entity.FeeRule{},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "fee_rules" WHERE 1 = 1`).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:  "GivenFeeWalletInOtherCurrency_WhenReplace_ThenInvalidFeeRule",
+			rules: rules,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(findWalletQuery).
+					WithArgs("<FeeWalletID>", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "currency"}).AddRow("<FeeWalletID>", "USD"))
+				mock.ExpectRollback()
+			},
+			wantErr:     true,
+			expectedErr: "invalid fee rule",
+		},
+		{
+			name:  "GivenUnknownFeeWallet_WhenReplace_ThenNotFound",
+			rules: rules,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(findWalletQuery).
+					WithArgs("<FeeWalletID>", 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+				mock.ExpectRollback()
+			},
+			wantErr:     true,
+			expectedErr: gorm.ErrRecordNotFound.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+			err := suite.feeRepo.Replace(tc.rules)
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+			} else {
+				suite.NoError(err)
+			}
+			suite.NoError(suite.sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (suite *FeeRepositoryTestSuite) TestFindForWallet() {
+	testCases := []struct {
+		name        string
+		mock        func(sqlmock.Sqlmock)
+		expectedLen int
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenOwnedWallet_WhenFindForWallet_ThenRulesOfItsCurrency",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE "wallets"\."id" = \$1 AND "wallets"\."user_id" = \$2 ORDER BY "wallets"\."id" LIMIT \$3`).
+					WithArgs("<WalletID>", "<UserID>", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "currency"}).AddRow("<WalletID>", "<UserID>", "THB"))
+				mock.ExpectQuery(`SELECT \* FROM "fee_rules" WHERE "fee_rules"\."transaction_type" = \$1 AND "fee_rules"\."currency" = \$2 ORDER BY min_amount`).
+					WithArgs("withdraw", "THB").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "transaction_type", "currency", "flat_fee", "fee_wallet_id"}).
+						AddRow("<RuleID>", "withdraw", "THB", "10.00", "<FeeWalletID>"))
+			},
+			expectedLen: 1,
+		},
+		{
+			name: "GivenWalletOfAnotherUser_WhenFindForWallet_ThenNotFound",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "wallets"`).
+					WithArgs("<WalletID>", "<UserID>", 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+			wantErr:     true,
+			expectedErr: gorm.ErrRecordNotFound.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+			wallet, rules, err := suite.feeRepo.FindForWallet("<UserID>", "<WalletID>", "withdraw")
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(wallet)
+			} else {
+				suite.NoError(err)
+				suite.Equal(money.Currency("THB"), wallet.Currency)
+				suite.Len(rules, tc.expectedLen)
+			}
+			suite.NoError(suite.sqlMock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package repositories
+
+import (
+	"log"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/idgen"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	holdStatusActive   = "active"
+	holdStatusCaptured = "captured"
+	holdStatusVoided   = "voided"
+	holdStatusExpired  = "expired"
+)
+
+//go:generate mockgen -source=./hold_repository.go -destination=./mocks/mock_hold_repository.go -package=mock_repositories
+type HoldRepository interface {
+	Create(userId string, hold entity.Hold) (*entity.Hold, error)
+	Capture(userId, holdId string, amount *money.Amount) (*entity.Transaction, error)
+	Void(userId, holdId string) (*entity.Hold, error)
+	ReleaseExpired() (int64, error)
+	SumActiveByWallets(walletIds []string) (map[string]money.Amount, error)
+}
+
+type holdRepository struct {
+	db  *gorm.DB
+	ids idgen.Generator
+}
+
+func NewHoldRepository(db *gorm.DB, ids idgen.Generator) HoldRepository {
+	return &holdRepository{db: db, ids: ids}
+}
+
+// Create places a hold on a wallet owned by userId. The wallet is locked so
+// the available-balance check cannot race with transfers or other holds.
+func (r *holdRepository) Create(userId string, hold entity.Hold) (*entity.Hold, error) {
+	if err := runInTransaction(r.db, func(tx *gorm.DB) error {
+		var wallet entity.Wallet
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(&entity.Wallet{ID: hold.WalletID, UserID: userId}).
+			First(&wallet).Error; err != nil {
+			log.Printf("Failed to lock hold wallet: %v", err)
+			return err
+		}
+
+		if !wallet.Currency.Allows(hold.Amount) {
+			log.Printf("Hold amount %s exceeds %s minor units", hold.Amount, wallet.Currency)
+			return consts.ErrAmountScaleExceeded
+		}
+
+		var toWallet entity.Wallet
+		if err := tx.Where(&entity.Wallet{ID: hold.ToWalletID}).First(&toWallet).Error; err != nil {
+			log.Printf("Failed to find hold destination wallet: %v", err)
+			return err
+		}
+
+		if wallet.IsClosed() || toWallet.IsClosed() {
+			log.Printf("Hold between %s and %s touches a closed wallet", wallet.ID, toWallet.ID)
+			return consts.ErrWalletClosed
+		}
+		if err := ensureCanDebit(wallet); err != nil {
+			return err
+		}
+		if err := ensureCanCredit(toWallet); err != nil {
+			return err
+		}
+
+		available, err := availableBalance(tx, wallet)
+		if err != nil {
+			return err
+		}
+		if available < hold.Amount {
+			log.Printf("Insufficient balance: wallet %s has %s available, hold needs %s", wallet.ID, available, hold.Amount)
+			return consts.ErrInsufficientBalance
+		}
+
+		hold.Status = holdStatusActive
+		if err := tx.Create(&hold).Error; err != nil {
+			log.Printf("Create hold error: %v", err)
+			return err
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return &hold, nil
+}
+
+// Capture turns an active hold into a transfer to its destination wallet,
+// which must belong to userId. A partial capture releases the remainder.
+func (r *holdRepository) Capture(userId, holdId string, amount *money.Amount) (*entity.Transaction, error) {
+	var result *entity.Transaction
+	if err := runInTransaction(r.db, func(tx *gorm.DB) error {
+		hold, err := lockMerchantHold(tx, userId, holdId)
+		if err != nil {
+			return err
+		}
+
+		capture := hold.Amount
+		if amount != nil {
+			capture = *amount
+		}
+		if capture <= 0 || capture > hold.Amount {
+			log.Printf("Capture %s exceeds hold %s of %s", capture, hold.ID, hold.Amount)
+			return consts.ErrCaptureExceedsHold
+		}
+
+		// Release the hold before transferring so it no longer counts
+		// against the available balance the transfer checks.
+		if err := tx.Model(&entity.Hold{}).
+			Where(&entity.Hold{ID: hold.ID}).
+			Updates(map[string]interface{}{"status": holdStatusCaptured, "captured_amount": capture, "updated_at": gorm.Expr("NOW()")}).Error; err != nil {
+			log.Printf("Update hold status error: %v", err)
+			return err
+		}
+
+		wallets, err := lockWallets(tx, hold.WalletID, hold.ToWalletID)
+		if err != nil {
+			return err
+		}
+		fromWallet, ok := wallets[hold.WalletID]
+		if !ok {
+			log.Printf("Hold wallet %s not found", hold.WalletID)
+			return gorm.ErrRecordNotFound
+		}
+
+		txRecord, err := transferFunds(tx, r.ids, fromWallet, wallets[hold.ToWalletID], capture, entity.TransactionDetails{}, nil, entity.Wallet{})
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Model(&entity.Hold{}).
+			Where(&entity.Hold{ID: hold.ID}).
+			UpdateColumn("transaction_id", txRecord.ID).Error; err != nil {
+			log.Printf("Link hold transaction error: %v", err)
+			return err
+		}
+
+		result = txRecord
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *holdRepository) Void(userId, holdId string) (*entity.Hold, error) {
+	var result *entity.Hold
+	if err := runInTransaction(r.db, func(tx *gorm.DB) error {
+		hold, err := lockMerchantHold(tx, userId, holdId)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Model(&entity.Hold{}).
+			Where(&entity.Hold{ID: hold.ID}).
+			Updates(map[string]interface{}{"status": holdStatusVoided, "updated_at": gorm.Expr("NOW()")}).Error; err != nil {
+			log.Printf("Void hold error: %v", err)
+			return err
+		}
+
+		hold.Status = holdStatusVoided
+		result = hold
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ReleaseExpired marks active holds past their expiry as expired. It is a
+// single UPDATE, so running it from several replicas at once is harmless.
+func (r *holdRepository) ReleaseExpired() (int64, error) {
+	result := r.db.Model(&entity.Hold{}).
+		Where("status = ? AND expires_at <= NOW()", holdStatusActive).
+		Updates(map[string]interface{}{"status": holdStatusExpired, "updated_at": gorm.Expr("NOW()")})
+	if result.Error != nil {
+		log.Printf("ReleaseExpired error: %v", result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *holdRepository) SumActiveByWallets(walletIds []string) (map[string]money.Amount, error) {
+	var rows []struct {
+		WalletID string
+		Held     money.Amount
+	}
+	if err := r.db.Model(&entity.Hold{}).
+		Select("wallet_id, COALESCE(SUM(amount), 0) AS held").
+		Where("wallet_id IN ? AND status = ? AND expires_at > NOW()", walletIds, holdStatusActive).
+		Group("wallet_id").
+		Scan(&rows).Error; err != nil {
+		log.Printf("SumActiveByWallets error: %v", err)
+		return nil, err
+	}
+
+	held := make(map[string]money.Amount, len(rows))
+	for _, row := range rows {
+		held[row.WalletID] = row.Held
+	}
+	return held, nil
+}
+
+// lockMerchantHold locks an active hold whose destination wallet belongs to
+// userId. Holds owned by someone else are reported as not found.
+func lockMerchantHold(tx *gorm.DB, userId, holdId string) (*entity.Hold, error) {
+	var hold entity.Hold
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(&entity.Hold{ID: holdId}).
+		First(&hold).Error; err != nil {
+		log.Printf("Failed to lock hold: %v", err)
+		return nil, err
+	}
+
+	var toWallet entity.Wallet
+	if err := tx.Where(&entity.Wallet{ID: hold.ToWalletID, UserID: userId}).First(&toWallet).Error; err != nil {
+		log.Printf("Hold %s is not payable to user %s: %v", hold.ID, userId, err)
+		return nil, err
+	}
+
+	if hold.Status != holdStatusActive || !hold.ExpiresAt.After(time.Now()) {
+		log.Printf("Hold %s is %s and expires at %s", hold.ID, hold.Status, hold.ExpiresAt)
+		return nil, consts.ErrHoldNotActive
+	}
+	return &hold, nil
+}
+
+// availableBalance is what the wallet can still spend: its balance plus any
+// credit limit, minus its active, unexpired holds. Callers must hold the
+// wallet's row lock.
+func availableBalance(tx *gorm.DB, wallet entity.Wallet) (money.Amount, error) {
+	var held money.Amount
+	if err := tx.Model(&entity.Hold{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("wallet_id = ? AND status = ? AND expires_at > NOW()", wallet.ID, holdStatusActive).
+		Scan(&held).Error; err != nil {
+		log.Printf("Sum active holds error: %v", err)
+		return 0, err
+	}
+	return wallet.Balance + wallet.CreditLimit - held, nil
+}
@@ -0,0 +1,327 @@
+package repositories_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"gorm.io/gorm"
+)
+
+const (
+	lockHoldQuery  = `SELECT \* FROM "holds" WHERE "holds"\."id" = \$1 ORDER BY "holds"\."id" LIMIT \$2 FOR UPDATE`
+	merchantWallet = `SELECT \* FROM "wallets" WHERE "wallets"\."id" = \$1 AND "wallets"\."user_id" = \$2 ORDER BY "wallets"\."id" LIMIT \$3`
+	sumActiveHolds = `SELECT COALESCE\(SUM\(amount\), 0\) FROM "holds" WHERE wallet_id = \$1 AND status = \$2 AND expires_at > NOW\(\)`
+	lockTwoWallets = `SELECT \* FROM "wallets" WHERE id IN \(\$1,\$2\) ORDER BY id FOR UPDATE`
+)
+
+var holdColumns = []string{"id", "wallet_id", "to_wallet_id", "amount", "status", "expires_at"}
+
+func (suite *HoldRepositoryTestSuite) TestCreate() {
+	testCases := []struct {
+		name        string
+		mock        func(sqlmock.Sqlmock)
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenEnoughAvailableBalance_WhenCreate_ThenHoldActive",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE "wallets"\."id" = \$1 AND "wallets"\."user_id" = \$2 ORDER BY "wallets"\."id" LIMIT \$3 FOR UPDATE`).
+					WithArgs("<WalletID>", "<UserID>", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "balance", "currency"}).AddRow("<WalletID>", "100.00", "THB"))
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE "wallets"\."id" = \$1 ORDER BY "wallets"\."id" LIMIT \$2`).
+					WithArgs("<MerchantWalletID>", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "currency"}).AddRow("<MerchantWalletID>", "THB"))
+				mock.ExpectQuery(sumActiveHolds).
+					WithArgs("<WalletID>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("40.00"))
+				mock.ExpectQuery(`INSERT INTO "holds"`).
+					WithArgs("<WalletID>", "<MerchantWalletID>", "60.00", nil, "active", nil, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow("<HoldID>", nil, nil))
+				mock.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "GivenExistingHolds_WhenAvailableBalanceTooLow_ThenInsufficientBalance",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE "wallets"\."id" = \$1 AND "wallets"\."user_id" = \$2 ORDER BY "wallets"\."id" LIMIT \$3 FOR UPDATE`).
+					WithArgs("<WalletID>", "<UserID>", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "balance", "currency"}).AddRow("<WalletID>", "100.00", "THB"))
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE "wallets"\."id" = \$1 ORDER BY "wallets"\."id" LIMIT \$2`).
+					WithArgs("<MerchantWalletID>", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "currency"}).AddRow("<MerchantWalletID>", "THB"))
+				mock.ExpectQuery(sumActiveHolds).
+					WithArgs("<WalletID>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("40.01"))
+				mock.ExpectRollback()
+			},
+			wantErr:     true,
+			expectedErr: "insufficient balance",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+			hold, err := suite.holdRepo.Create("<UserID>", entity.Hold{
+				WalletID:   "<WalletID>",
+				ToWalletID: "<MerchantWalletID>",
+				Amount:     money.MustParse("60"),
+				ExpiresAt:  time.Now().Add(time.Hour),
+			})
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(hold)
+			} else {
+				suite.NoError(err)
+				suite.Equal("<HoldID>", hold.ID)
+				suite.Equal("active", hold.Status)
+			}
+			suite.sqlMock.ExpectationsWereMet()
+		})
+	}
+}
+
+func (suite *HoldRepositoryTestSuite) TestCapture() {
+	partial := money.MustParse("20")
+
+	testCases := []struct {
+		name        string
+		mock        func(sqlmock.Sqlmock)
+		amount      *money.Amount
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenActiveHold_WhenPartialCapture_ThenTransferredAndRemainderReleased",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockHoldQuery).
+					WithArgs("<HoldID>", 1).
+					WillReturnRows(sqlmock.NewRows(holdColumns).
+						AddRow("<HoldID>", "<WalletID>", "<MerchantWalletID>", "30.00", "active", time.Now().Add(time.Hour)))
+				mock.ExpectQuery(merchantWallet).
+					WithArgs("<MerchantWalletID>", "<MerchantID>", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("<MerchantWalletID>"))
+				mock.ExpectExec(`UPDATE "holds" SET "captured_amount"=\$1,"status"=\$2,"updated_at"=NOW\(\) WHERE "holds"\."id" = \$3`).
+					WithArgs("20.00", "captured", "<HoldID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery(lockTwoWallets).
+					WithArgs("<MerchantWalletID>", "<WalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "balance", "currency"}).
+						AddRow("<MerchantWalletID>", "0.00", "THB").
+						AddRow("<WalletID>", "100.00", "THB"))
+				mock.ExpectQuery(sumActiveHolds).
+					WithArgs("<WalletID>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("0.00"))
+				mock.ExpectQuery(`INSERT INTO "transactions"`).
+					WithArgs("TRN000000000000000001", "<WalletID>", "<MerchantWalletID>", "20.00", "transfer", nil, nil, nil, "0", nil, nil, nil, nil, nil, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(nil, nil))
+				mock.ExpectQuery(`INSERT INTO "journal_entries"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("<JournalEntryID>", nil))
+				mock.ExpectQuery(`INSERT INTO "postings"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, nil).AddRow(2, nil))
+				mock.ExpectExec(`UPDATE "wallets"`).
+					WithArgs("20.00", "<WalletID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`UPDATE "wallets"`).
+					WithArgs("20.00", "<MerchantWalletID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectBalancesAfter(mock)
+				mock.ExpectExec(`UPDATE "holds" SET "transaction_id"=\$1 WHERE "holds"\."id" = \$2`).
+					WithArgs(sqlmock.AnyArg(), "<HoldID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			amount:  &partial,
+			wantErr: false,
+		},
+		{
+			name: "GivenExpiredHold_WhenCapture_ThenHoldNotActive",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockHoldQuery).
+					WithArgs("<HoldID>", 1).
+					WillReturnRows(sqlmock.NewRows(holdColumns).
+						AddRow("<HoldID>", "<WalletID>", "<MerchantWalletID>", "30.00", "active", time.Now().Add(-time.Minute)))
+				mock.ExpectQuery(merchantWallet).
+					WithArgs("<MerchantWalletID>", "<MerchantID>", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("<MerchantWalletID>"))
+				mock.ExpectRollback()
+			},
+			wantErr:     true,
+			expectedErr: "hold is not active",
+		},
+		{
+			name: "GivenHoldForAnotherMerchant_WhenCapture_ThenNotFound",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockHoldQuery).
+					WithArgs("<HoldID>", 1).
+					WillReturnRows(sqlmock.NewRows(holdColumns).
+						AddRow("<HoldID>", "<WalletID>", "<MerchantWalletID>", "30.00", "active", time.Now().Add(time.Hour)))
+				mock.ExpectQuery(merchantWallet).
+					WithArgs("<MerchantWalletID>", "<MerchantID>", 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+				mock.ExpectRollback()
+			},
+			wantErr:     true,
+			expectedErr: gorm.ErrRecordNotFound.Error(),
+		},
+		{
+			name: "GivenActiveHold_WhenCaptureExceedsHold_ThenError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockHoldQuery).
+					WithArgs("<HoldID>", 1).
+					WillReturnRows(sqlmock.NewRows(holdColumns).
+						AddRow("<HoldID>", "<WalletID>", "<MerchantWalletID>", "10.00", "active", time.Now().Add(time.Hour)))
+				mock.ExpectQuery(merchantWallet).
+					WithArgs("<MerchantWalletID>", "<MerchantID>", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("<MerchantWalletID>"))
+				mock.ExpectRollback()
+			},
+			amount:      &partial,
+			wantErr:     true,
+			expectedErr: "capture exceeds held amount",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+			txRecord, err := suite.holdRepo.Capture("<MerchantID>", "<HoldID>", tc.amount)
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(txRecord)
+			} else {
+				suite.NoError(err)
+				suite.Equal(*tc.amount, txRecord.Amount)
+			}
+			suite.sqlMock.ExpectationsWereMet()
+		})
+	}
+}
+
+func (suite *HoldRepositoryTestSuite) TestVoid() {
+	testCases := []struct {
+		name        string
+		mock        func(sqlmock.Sqlmock)
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenActiveHold_WhenVoid_ThenVoided",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockHoldQuery).
+					WithArgs("<HoldID>", 1).
+					WillReturnRows(sqlmock.NewRows(holdColumns).
+						AddRow("<HoldID>", "<WalletID>", "<MerchantWalletID>", "30.00", "active", time.Now().Add(time.Hour)))
+				mock.ExpectQuery(merchantWallet).
+					WithArgs("<MerchantWalletID>", "<MerchantID>", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("<MerchantWalletID>"))
+				mock.ExpectExec(`UPDATE "holds" SET "status"=\$1,"updated_at"=NOW\(\) WHERE "holds"\."id" = \$2`).
+					WithArgs("voided", "<HoldID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "GivenCapturedHold_WhenVoid_ThenHoldNotActive",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockHoldQuery).
+					WithArgs("<HoldID>", 1).
+					WillReturnRows(sqlmock.NewRows(holdColumns).
+						AddRow("<HoldID>", "<WalletID>", "<MerchantWalletID>", "30.00", "captured", time.Now().Add(time.Hour)))
+				mock.ExpectQuery(merchantWallet).
+					WithArgs("<MerchantWalletID>", "<MerchantID>", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("<MerchantWalletID>"))
+				mock.ExpectRollback()
+			},
+			wantErr:     true,
+			expectedErr: "hold is not active",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+			hold, err := suite.holdRepo.Void("<MerchantID>", "<HoldID>")
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(hold)
+			} else {
+				suite.NoError(err)
+				suite.Equal("voided", hold.Status)
+			}
+			suite.sqlMock.ExpectationsWereMet()
+		})
+	}
+}
+
+func (suite *HoldRepositoryTestSuite) TestReleaseExpired() {
+	testCases := []struct {
+		name        string
+		mock        func(sqlmock.Sqlmock)
+		wantCount   int64
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenExpiredHolds_WhenReleaseExpired_ThenReturnCount",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "holds" SET "status"=\$1,"updated_at"=NOW\(\) WHERE status = \$2 AND expires_at <= NOW\(\)`).
+					WithArgs("expired", "active").
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectCommit()
+			},
+			wantCount: 3,
+		},
+		{
+			name: "GivenDatabaseError_WhenReleaseExpired_ThenError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "holds"`).
+					WillReturnError(errors.New("update failed"))
+				mock.ExpectRollback()
+			},
+			wantErr:     true,
+			expectedErr: "update failed",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+			count, err := suite.holdRepo.ReleaseExpired()
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+			} else {
+				suite.NoError(err)
+				suite.Equal(tc.wantCount, count)
+			}
+			suite.sqlMock.ExpectationsWereMet()
+		})
+	}
+}
+
+func (suite *HoldRepositoryTestSuite) TestSumActiveByWallets() {
+	suite.sqlMock.ExpectQuery(`SELECT wallet_id, COALESCE\(SUM\(amount\), 0\) AS held FROM "holds" WHERE wallet_id IN \(\$1,\$2\) AND status = \$3 AND expires_at > NOW\(\) GROUP BY "wallet_id"`).
+		WithArgs("<WalletID1>", "<WalletID2>", "active").
+		WillReturnRows(sqlmock.NewRows([]string{"wallet_id", "held"}).AddRow("<WalletID1>", "12.50"))
+
+	held, err := suite.holdRepo.SumActiveByWallets([]string{"<WalletID1>", "<WalletID2>"})
+	suite.NoError(err)
+	suite.Equal(money.MustParse("12.50"), held["<WalletID1>"])
+	suite.Equal(money.Amount(0), held["<WalletID2>"])
+}
@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"log"
+
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IdempotencyKey is a client-supplied key for a money movement. Fingerprint
+// identifies the request the key was first used with.
+type IdempotencyKey struct {
+	Key         string
+	Fingerprint string
+}
+
+// claimIdempotencyKey reserves the key inside the caller's DB transaction. A
+// concurrent request with the same key blocks on the insert until the first
+// one commits or rolls back. When the key was already used it returns the
+// original transaction, or consts.ErrIdempotencyKeyReused if the request differs.
+func claimIdempotencyKey(tx *gorm.DB, userId string, key *IdempotencyKey) (*entity.Transaction, error) {
+	record := entity.IdempotencyKey{UserID: userId, Key: key.Key, Fingerprint: key.Fingerprint}
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&record)
+	if result.Error != nil {
+		log.Printf("Claim idempotency key error: %v", result.Error)
+		return nil, result.Error
+	}
+	if result.RowsAffected == 1 {
+		return nil, nil
+	}
+
+	var existing entity.IdempotencyKey
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(&entity.IdempotencyKey{UserID: userId, Key: key.Key}).
+		First(&existing).Error; err != nil {
+		log.Printf("Failed to lock idempotency key: %v", err)
+		return nil, err
+	}
+
+	if existing.Fingerprint != key.Fingerprint || existing.TransactionID == nil {
+		log.Printf("Idempotency key %s reused with a different request", key.Key)
+		return nil, consts.ErrIdempotencyKeyReused
+	}
+
+	var original entity.Transaction
+	if err := tx.Where(&entity.Transaction{ID: *existing.TransactionID}).First(&original).Error; err != nil {
+		log.Printf("Failed to load idempotent transaction: %v", err)
+		return nil, err
+	}
+
+	var fees []entity.Transaction
+	if err := tx.Where(&entity.Transaction{ParentTransactionID: &original.ID}).Find(&fees).Error; err != nil {
+		log.Printf("Failed to load idempotent transaction fee: %v", err)
+		return nil, err
+	}
+	if len(fees) > 0 {
+		original.Fee = &fees[0]
+	}
+	return &original, nil
+}
+
+func completeIdempotencyKey(tx *gorm.DB, userId string, key *IdempotencyKey, transactionId string) error {
+	if err := tx.Model(&entity.IdempotencyKey{}).
+		Where(&entity.IdempotencyKey{UserID: userId, Key: key.Key}).
+		UpdateColumn("transaction_id", transactionId).Error; err != nil {
+		log.Printf("Complete idempotency key error: %v", err)
+		return err
+	}
+	return nil
+}
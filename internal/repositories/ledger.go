@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"log"
+
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	ledgerAccountWallet   = "wallet"
+	ledgerAccountTreasury = "treasury"
+	ledgerAccountFees     = "fees"
+	ledgerAccountSuspense = "suspense"
+	ledgerAccountFx       = "fx"
+	ledgerAccountInterest = "interest"
+
+	postingDebit  = "debit"
+	postingCredit = "credit"
+)
+
+// ledgerLine is one side of a journal entry. WalletID addresses a wallet
+// account, otherwise SystemAccount names the system account in Currency.
+type ledgerLine struct {
+	WalletID      string
+	SystemAccount string
+	Currency      money.Currency
+	Direction     string
+	Amount        money.Amount
+}
+
+func debitWallet(walletId string, currency money.Currency, amount money.Amount) ledgerLine {
+	return ledgerLine{WalletID: walletId, Currency: currency, Direction: postingDebit, Amount: amount}
+}
+
+func creditWallet(walletId string, currency money.Currency, amount money.Amount) ledgerLine {
+	return ledgerLine{WalletID: walletId, Currency: currency, Direction: postingCredit, Amount: amount}
+}
+
+func debitSystem(code string, currency money.Currency, amount money.Amount) ledgerLine {
+	return ledgerLine{SystemAccount: code, Currency: currency, Direction: postingDebit, Amount: amount}
+}
+
+func creditSystem(code string, currency money.Currency, amount money.Amount) ledgerLine {
+	return ledgerLine{SystemAccount: code, Currency: currency, Direction: postingCredit, Amount: amount}
+}
+
+// postJournalEntry records a balanced journal entry for the transaction,
+// applies its wallet lines to the cached wallets.balance projection and
+// stamps the resulting balances on the transaction row and record. It runs
+// inside the caller's DB transaction, after the affected wallets are locked.
+func postJournalEntry(tx *gorm.DB, record *entity.Transaction, lines []ledgerLine) error {
+	transactionId := record.ID
+	totals := map[money.Currency]money.Amount{}
+	for _, line := range lines {
+		if line.Amount <= 0 {
+			return consts.ErrInvalidAmount
+		}
+		if line.Direction == postingDebit {
+			totals[line.Currency] += line.Amount
+		} else {
+			totals[line.Currency] -= line.Amount
+		}
+	}
+	for currency, total := range totals {
+		if total != 0 {
+			log.Printf("Unbalanced journal entry for %s: %s %s", transactionId, total, currency)
+			return consts.ErrUnbalancedJournalEntry
+		}
+	}
+
+	postings := make([]entity.Posting, 0, len(lines))
+	for _, line := range lines {
+		accountId := line.WalletID
+		if accountId == "" {
+			var account entity.LedgerAccount
+			if err := tx.Where(&entity.LedgerAccount{Code: line.SystemAccount, Currency: line.Currency}).
+				First(&account).Error; err != nil {
+				log.Printf("Failed to find %s %s ledger account: %v", line.SystemAccount, line.Currency, err)
+				return err
+			}
+			accountId = account.ID
+		}
+
+		postings = append(postings, entity.Posting{
+			AccountID: accountId,
+			Direction: line.Direction,
+			Amount:    line.Amount,
+			Currency:  line.Currency,
+		})
+	}
+
+	entry := entity.JournalEntry{TransactionID: &transactionId}
+	if err := tx.Create(&entry).Error; err != nil {
+		log.Printf("Create journal entry error: %v", err)
+		return err
+	}
+
+	for i := range postings {
+		postings[i].JournalEntryID = entry.ID
+	}
+	if err := tx.Create(&postings).Error; err != nil {
+		log.Printf("Create postings error: %v", err)
+		return err
+	}
+
+	for _, line := range lines {
+		if line.WalletID == "" {
+			continue
+		}
+
+		expr := gorm.Expr("balance + ?", line.Amount)
+		if line.Direction == postingDebit {
+			expr = gorm.Expr("balance - ?", line.Amount)
+		}
+
+		if err := tx.Model(&entity.Wallet{}).
+			Where(&entity.Wallet{ID: line.WalletID}).
+			UpdateColumn("balance", expr).Error; err != nil {
+			log.Printf("Update wallet %s balance error: %v", line.WalletID, err)
+			return err
+		}
+	}
+
+	if err := tx.Model(record).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "from_balance_after"}, {Name: "to_balance_after"}}}).
+		UpdateColumns(map[string]interface{}{
+			"from_balance_after": gorm.Expr(`(SELECT balance FROM wallets WHERE wallets.id = transactions."from")`),
+			"to_balance_after":   gorm.Expr(`(SELECT balance FROM wallets WHERE wallets.id = transactions."to")`),
+		}).Error; err != nil {
+		log.Printf("Record balances after %s error: %v", transactionId, err)
+		return err
+	}
+
+	return nil
+}
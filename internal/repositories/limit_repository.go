@@ -0,0 +1,163 @@
+package repositories
+
+import (
+	"log"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// debitTypes are the transaction types counted towards limits.
+var debitTypes = []string{"transfer", "withdraw"}
+
+// LimitCheck is called with the debited wallet locked, before any money
+// moves. Returning an error rejects the debit.
+type LimitCheck func(wallet entity.Wallet, usage LimitUsage) error
+
+// LimitUsage reads the past debits a limit is measured against, as positive
+// totals even though withdrawals are stored negative. The first
+// per-user read locks the user row, so concurrent debits from the user's
+// other wallets are evaluated one after another.
+type LimitUsage interface {
+	WalletDebited(since time.Time) (money.Amount, error)
+	UserDebited(since time.Time) (money.Amount, error)
+	UserTransfers(since time.Time) (int64, *time.Time, error)
+}
+
+//go:generate mockgen -source=./limit_repository.go -destination=./mocks/mock_limit_repository.go -package=mock_repositories
+type LimitRepository interface {
+	ListForUser(userId string) ([]entity.TransactionLimit, error)
+	Replace(userId *string, limits []entity.TransactionLimit) error
+}
+
+type limitRepository struct {
+	db *gorm.DB
+}
+
+func NewLimitRepository(db *gorm.DB) LimitRepository {
+	return &limitRepository{db: db}
+}
+
+// ListForUser returns the global limits together with the user's overrides.
+func (r *limitRepository) ListForUser(userId string) ([]entity.TransactionLimit, error) {
+	var limits []entity.TransactionLimit
+	if err := r.db.Where("user_id IS NULL OR user_id = ?", userId).Find(&limits).Error; err != nil {
+		log.Printf("ListForUser limits error: %v", err)
+		return nil, err
+	}
+	return limits, nil
+}
+
+// Replace swaps the global limits, or the overrides of userId when set, for
+// the given set. An empty set removes them.
+func (r *limitRepository) Replace(userId *string, limits []entity.TransactionLimit) error {
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		scope := tx.Where("user_id IS NULL")
+		if userId != nil {
+			var user entity.User
+			if err := tx.Where(&entity.User{ID: *userId}).First(&user).Error; err != nil {
+				return err
+			}
+			scope = tx.Where("user_id = ?", *userId)
+		}
+
+		if err := scope.Delete(&entity.TransactionLimit{}).Error; err != nil {
+			return err
+		}
+		if len(limits) == 0 {
+			return nil
+		}
+
+		for i := range limits {
+			limits[i].UserID = userId
+		}
+		return tx.Create(&limits).Error
+	}); err != nil {
+		log.Printf("Replace limits error: %v", err)
+		return err
+	}
+	return nil
+}
+
+type limitUsage struct {
+	tx         *gorm.DB
+	wallet     entity.Wallet
+	userLocked bool
+}
+
+func newLimitUsage(tx *gorm.DB, wallet entity.Wallet) *limitUsage {
+	return &limitUsage{tx: tx, wallet: wallet}
+}
+
+func (u *limitUsage) WalletDebited(since time.Time) (money.Amount, error) {
+	var debited money.Amount
+	if err := u.tx.Model(&entity.Transaction{}).
+		Select("COALESCE(SUM(ABS(amount)), 0)").
+		Where(`"from" = ? AND type IN ? AND created_at >= ?`, u.wallet.ID, debitTypes, since).
+		Scan(&debited).Error; err != nil {
+		log.Printf("Sum wallet debits error: %v", err)
+		return 0, err
+	}
+	return debited, nil
+}
+
+// UserDebited sums debits from all of the user's wallets in the wallet's
+// currency.
+func (u *limitUsage) UserDebited(since time.Time) (money.Amount, error) {
+	if err := u.lockUser(); err != nil {
+		return 0, err
+	}
+
+	var debited money.Amount
+	if err := u.tx.Model(&entity.Transaction{}).
+		Select("COALESCE(SUM(ABS(transactions.amount)), 0)").
+		Joins(`JOIN wallets ON wallets.id = transactions."from"`).
+		Where("wallets.user_id = ? AND wallets.currency = ? AND transactions.type IN ? AND transactions.created_at >= ?",
+			u.wallet.UserID, u.wallet.Currency, debitTypes, since).
+		Scan(&debited).Error; err != nil {
+		log.Printf("Sum user debits error: %v", err)
+		return 0, err
+	}
+	return debited, nil
+}
+
+// UserTransfers counts transfers from any of the user's wallets and returns
+// when the oldest of them was made.
+func (u *limitUsage) UserTransfers(since time.Time) (int64, *time.Time, error) {
+	if err := u.lockUser(); err != nil {
+		return 0, nil, err
+	}
+
+	var row struct {
+		Count  int64
+		Oldest *time.Time
+	}
+	if err := u.tx.Model(&entity.Transaction{}).
+		Select("COUNT(*) AS count, MIN(transactions.created_at) AS oldest").
+		Joins(`JOIN wallets ON wallets.id = transactions."from"`).
+		Where("wallets.user_id = ? AND transactions.type = ? AND transactions.created_at >= ?", u.wallet.UserID, "transfer", since).
+		Scan(&row).Error; err != nil {
+		log.Printf("Count user transfers error: %v", err)
+		return 0, nil, err
+	}
+	return row.Count, row.Oldest, nil
+}
+
+func (u *limitUsage) lockUser() error {
+	if u.userLocked {
+		return nil
+	}
+
+	var user entity.User
+	if err := u.tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(&entity.User{ID: u.wallet.UserID}).
+		First(&user).Error; err != nil {
+		log.Printf("Failed to lock user: %v", err)
+		return err
+	}
+	u.userLocked = true
+	return nil
+}
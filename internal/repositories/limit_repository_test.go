@@ -0,0 +1,124 @@
+package repositories_test
+
+import (
+	"errors"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"gorm.io/gorm"
+)
+
+func (suite *LimitRepositoryTestSuite) TestListForUser() {
+	testCases := []struct {
+		name        string
+		mock        func(sqlmock.Sqlmock)
+		expectedLen int
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenGlobalAndUserLimits_WhenListForUser_ThenBothReturned",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "transaction_limits" WHERE user_id IS NULL OR user_id = \$1`).
+					WithArgs("<UserID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "currency", "daily_wallet_max"}).
+						AddRow("<GlobalLimitID>", nil, "THB", "1000.00").
+						AddRow("<UserLimitID>", "<UserID>", "THB", "5000.00"))
+			},
+			expectedLen: 2,
+		},
+		{
+			name: "GivenDatabaseError_WhenListForUser_ThenError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "transaction_limits"`).
+					WillReturnError(errors.New("db error"))
+			},
+			wantErr:     true,
+			expectedErr: "db error",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+			limits, err := suite.limitRepo.ListForUser("<UserID>")
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+			} else {
+				suite.NoError(err)
+				suite.Len(limits, tc.expectedLen)
+			}
+			suite.NoError(suite.sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (suite *LimitRepositoryTestSuite) TestReplace() {
+	userId := "<UserID>"
+	dailyMax := money.MustParse("1000")
+
+	testCases := []struct {
+		name        string
+		userId      *string
+		limits      []entity.TransactionLimit
+		mock        func(sqlmock.Sqlmock)
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:   "GivenGlobalLimits_WhenReplace_ThenGlobalRowsSwapped",
+			limits: []entity.TransactionLimit{{Currency: "THB", DailyWalletMax: &dailyMax}},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "transaction_limits" WHERE user_id IS NULL`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(`INSERT INTO "transaction_limits"`).
+					WithArgs(nil, "THB", nil, "1000.00", nil, nil, nil, nil, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow("<LimitID>", nil, nil))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:   "GivenUserWithoutLimits_WhenReplaceWithEmptySet_ThenOverridesRemoved",
+			userId: &userId,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "users" WHERE "users"\."id" = \$1 ORDER BY "users"\."id" LIMIT \$2`).
+					WithArgs("<UserID>", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("<UserID>"))
+				mock.ExpectExec(`DELETE FROM "transaction_limits" WHERE user_id = \$1`).
+					WithArgs("<UserID>").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:   "GivenUnknownUser_WhenReplace_ThenNotFound",
+			userId: &userId,
+			limits: []entity.TransactionLimit{{Currency: "THB", DailyWalletMax: &dailyMax}},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "users" WHERE "users"\."id" = \$1 ORDER BY "users"\."id" LIMIT \$2`).
+					WithArgs("<UserID>", 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+				mock.ExpectRollback()
+			},
+			wantErr:     true,
+			expectedErr: gorm.ErrRecordNotFound.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+			err := suite.limitRepo.Replace(tc.userId, tc.limits)
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+			} else {
+				suite.NoError(err)
+			}
+			suite.NoError(suite.sqlMock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,118 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./deposit_import_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./deposit_import_repository.go -destination=./mocks/mock_deposit_import_repository.go -package=mock_repositories
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	repositories "github.com/slilp/go-wallet/internal/repositories"
+	entity "github.com/slilp/go-wallet/internal/repositories/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDepositImportRepository is a mock of DepositImportRepository interface.
+type MockDepositImportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDepositImportRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockDepositImportRepositoryMockRecorder is the mock recorder for MockDepositImportRepository.
+type MockDepositImportRepositoryMockRecorder struct {
+	mock *MockDepositImportRepository
+}
+
+// NewMockDepositImportRepository creates a new mock instance.
+func NewMockDepositImportRepository(ctrl *gomock.Controller) *MockDepositImportRepository {
+	mock := &MockDepositImportRepository{ctrl: ctrl}
+	mock.recorder = &MockDepositImportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDepositImportRepository) EXPECT() *MockDepositImportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockDepositImportRepository) Create(depositImport entity.DepositImport, rows []entity.DepositImportRow) (*entity.DepositImport, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", depositImport, rows)
+	ret0, _ := ret[0].(*entity.DepositImport)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDepositImportRepositoryMockRecorder) Create(depositImport, rows any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDepositImportRepository)(nil).Create), depositImport, rows)
+}
+
+// FindByID mocks base method.
+func (m *MockDepositImportRepository) FindByID(importId string) (*entity.DepositImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", importId)
+	ret0, _ := ret[0].(*entity.DepositImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockDepositImportRepositoryMockRecorder) FindByID(importId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockDepositImportRepository)(nil).FindByID), importId)
+}
+
+// FindUsedReferences mocks base method.
+func (m *MockDepositImportRepository) FindUsedReferences(references []string) ([]repositories.DepositReference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUsedReferences", references)
+	ret0, _ := ret[0].([]repositories.DepositReference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUsedReferences indicates an expected call of FindUsedReferences.
+func (mr *MockDepositImportRepositoryMockRecorder) FindUsedReferences(references any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUsedReferences", reflect.TypeOf((*MockDepositImportRepository)(nil).FindUsedReferences), references)
+}
+
+// ListRows mocks base method.
+func (m *MockDepositImportRepository) ListRows(importId string, status *string) ([]entity.DepositImportRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRows", importId, status)
+	ret0, _ := ret[0].([]entity.DepositImportRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRows indicates an expected call of ListRows.
+func (mr *MockDepositImportRepositoryMockRecorder) ListRows(importId, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRows", reflect.TypeOf((*MockDepositImportRepository)(nil).ListRows), importId, status)
+}
+
+// RunChunk mocks base method.
+func (m *MockDepositImportRepository) RunChunk(size int, execute func(entity.DepositImportRow) entity.DepositImportRow) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunChunk", size, execute)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunChunk indicates an expected call of RunChunk.
+func (mr *MockDepositImportRepositoryMockRecorder) RunChunk(size, execute any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunChunk", reflect.TypeOf((*MockDepositImportRepository)(nil).RunChunk), size, execute)
+}
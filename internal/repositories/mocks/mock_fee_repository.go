@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./fee_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./fee_repository.go -destination=./mocks/mock_fee_repository.go -package=mock_repositories
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	entity "github.com/slilp/go-wallet/internal/repositories/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFeeRepository is a mock of FeeRepository interface.
+type MockFeeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeeRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockFeeRepositoryMockRecorder is the mock recorder for MockFeeRepository.
+type MockFeeRepositoryMockRecorder struct {
+	mock *MockFeeRepository
+}
+
+// NewMockFeeRepository creates a new mock instance.
+func NewMockFeeRepository(ctrl *gomock.Controller) *MockFeeRepository {
+	mock := &MockFeeRepository{ctrl: ctrl}
+	mock.recorder = &MockFeeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeeRepository) EXPECT() *MockFeeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindForWallet mocks base method.
+func (m *MockFeeRepository) FindForWallet(userId, walletId, transactionType string) (*entity.Wallet, []entity.FeeRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindForWallet", userId, walletId, transactionType)
+	ret0, _ := ret[0].(*entity.Wallet)
+	ret1, _ := ret[1].([]entity.FeeRule)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindForWallet indicates an expected call of FindForWallet.
+func (mr *MockFeeRepositoryMockRecorder) FindForWallet(userId, walletId, transactionType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindForWallet", reflect.TypeOf((*MockFeeRepository)(nil).FindForWallet), userId, walletId, transactionType)
+}
+
+// Replace mocks base method.
+func (m *MockFeeRepository) Replace(rules []entity.FeeRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replace", rules)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockFeeRepositoryMockRecorder) Replace(rules any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockFeeRepository)(nil).Replace), rules)
+}
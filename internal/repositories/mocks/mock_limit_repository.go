@@ -0,0 +1,142 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./limit_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./limit_repository.go -destination=./mocks/mock_limit_repository.go -package=mock_repositories
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+	time "time"
+
+	money "github.com/slilp/go-wallet/internal/money"
+	entity "github.com/slilp/go-wallet/internal/repositories/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLimitUsage is a mock of LimitUsage interface.
+type MockLimitUsage struct {
+	ctrl     *gomock.Controller
+	recorder *MockLimitUsageMockRecorder
+	isgomock struct{}
+}
+
+// MockLimitUsageMockRecorder is the mock recorder for MockLimitUsage.
+type MockLimitUsageMockRecorder struct {
+	mock *MockLimitUsage
+}
+
+// NewMockLimitUsage creates a new mock instance.
+func NewMockLimitUsage(ctrl *gomock.Controller) *MockLimitUsage {
+	mock := &MockLimitUsage{ctrl: ctrl}
+	mock.recorder = &MockLimitUsageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLimitUsage) EXPECT() *MockLimitUsageMockRecorder {
+	return m.recorder
+}
+
+// UserDebited mocks base method.
+func (m *MockLimitUsage) UserDebited(since time.Time) (money.Amount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserDebited", since)
+	ret0, _ := ret[0].(money.Amount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserDebited indicates an expected call of UserDebited.
+func (mr *MockLimitUsageMockRecorder) UserDebited(since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserDebited", reflect.TypeOf((*MockLimitUsage)(nil).UserDebited), since)
+}
+
+// UserTransfers mocks base method.
+func (m *MockLimitUsage) UserTransfers(since time.Time) (int64, *time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserTransfers", since)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(*time.Time)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UserTransfers indicates an expected call of UserTransfers.
+func (mr *MockLimitUsageMockRecorder) UserTransfers(since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserTransfers", reflect.TypeOf((*MockLimitUsage)(nil).UserTransfers), since)
+}
+
+// WalletDebited mocks base method.
+func (m *MockLimitUsage) WalletDebited(since time.Time) (money.Amount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletDebited", since)
+	ret0, _ := ret[0].(money.Amount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WalletDebited indicates an expected call of WalletDebited.
+func (mr *MockLimitUsageMockRecorder) WalletDebited(since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletDebited", reflect.TypeOf((*MockLimitUsage)(nil).WalletDebited), since)
+}
+
+// MockLimitRepository is a mock of LimitRepository interface.
+type MockLimitRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLimitRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockLimitRepositoryMockRecorder is the mock recorder for MockLimitRepository.
+type MockLimitRepositoryMockRecorder struct {
+	mock *MockLimitRepository
+}
+
+// NewMockLimitRepository creates a new mock instance.
+func NewMockLimitRepository(ctrl *gomock.Controller) *MockLimitRepository {
+	mock := &MockLimitRepository{ctrl: ctrl}
+	mock.recorder = &MockLimitRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLimitRepository) EXPECT() *MockLimitRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ListForUser mocks base method.
+func (m *MockLimitRepository) ListForUser(userId string) ([]entity.TransactionLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListForUser", userId)
+	ret0, _ := ret[0].([]entity.TransactionLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListForUser indicates an expected call of ListForUser.
+func (mr *MockLimitRepositoryMockRecorder) ListForUser(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListForUser", reflect.TypeOf((*MockLimitRepository)(nil).ListForUser), userId)
+}
+
+// Replace mocks base method.
+func (m *MockLimitRepository) Replace(userId *string, limits []entity.TransactionLimit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replace", userId, limits)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockLimitRepositoryMockRecorder) Replace(userId, limits any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockLimitRepository)(nil).Replace), userId, limits)
+}
@@ -0,0 +1,132 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./payment_request_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./payment_request_repository.go -destination=./mocks/mock_payment_request_repository.go -package=mock_repositories
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+
+	repositories "github.com/slilp/go-wallet/internal/repositories"
+	entity "github.com/slilp/go-wallet/internal/repositories/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPaymentRequestRepository is a mock of PaymentRequestRepository interface.
+type MockPaymentRequestRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentRequestRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPaymentRequestRepositoryMockRecorder is the mock recorder for MockPaymentRequestRepository.
+type MockPaymentRequestRepositoryMockRecorder struct {
+	mock *MockPaymentRequestRepository
+}
+
+// NewMockPaymentRequestRepository creates a new mock instance.
+func NewMockPaymentRequestRepository(ctrl *gomock.Controller) *MockPaymentRequestRepository {
+	mock := &MockPaymentRequestRepository{ctrl: ctrl}
+	mock.recorder = &MockPaymentRequestRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentRequestRepository) EXPECT() *MockPaymentRequestRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockPaymentRequestRepository) Accept(userId, requestId, fromWalletId string, check repositories.LimitCheck, fee *repositories.Fee) (*entity.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", userId, requestId, fromWalletId, check, fee)
+	ret0, _ := ret[0].(*entity.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockPaymentRequestRepositoryMockRecorder) Accept(userId, requestId, fromWalletId, check, fee any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockPaymentRequestRepository)(nil).Accept), userId, requestId, fromWalletId, check, fee)
+}
+
+// Create mocks base method.
+func (m *MockPaymentRequestRepository) Create(request entity.PaymentRequest) (*entity.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", request)
+	ret0, _ := ret[0].(*entity.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPaymentRequestRepositoryMockRecorder) Create(request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPaymentRequestRepository)(nil).Create), request)
+}
+
+// Decline mocks base method.
+func (m *MockPaymentRequestRepository) Decline(userId, requestId string) (*entity.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decline", userId, requestId)
+	ret0, _ := ret[0].(*entity.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decline indicates an expected call of Decline.
+func (mr *MockPaymentRequestRepositoryMockRecorder) Decline(userId, requestId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decline", reflect.TypeOf((*MockPaymentRequestRepository)(nil).Decline), userId, requestId)
+}
+
+// ExpirePending mocks base method.
+func (m *MockPaymentRequestRepository) ExpirePending() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpirePending")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpirePending indicates an expected call of ExpirePending.
+func (mr *MockPaymentRequestRepositoryMockRecorder) ExpirePending() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpirePending", reflect.TypeOf((*MockPaymentRequestRepository)(nil).ExpirePending))
+}
+
+// FindPayable mocks base method.
+func (m *MockPaymentRequestRepository) FindPayable(userId, requestId string) (*entity.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPayable", userId, requestId)
+	ret0, _ := ret[0].(*entity.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPayable indicates an expected call of FindPayable.
+func (mr *MockPaymentRequestRepositoryMockRecorder) FindPayable(userId, requestId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPayable", reflect.TypeOf((*MockPaymentRequestRepository)(nil).FindPayable), userId, requestId)
+}
+
+// List mocks base method.
+func (m *MockPaymentRequestRepository) List(userId string, incoming bool) ([]entity.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", userId, incoming)
+	ret0, _ := ret[0].([]entity.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockPaymentRequestRepositoryMockRecorder) List(userId, incoming any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPaymentRequestRepository)(nil).List), userId, incoming)
+}
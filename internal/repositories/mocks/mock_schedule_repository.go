@@ -0,0 +1,133 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./schedule_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./schedule_repository.go -destination=./mocks/mock_schedule_repository.go -package=mock_repositories
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+	time "time"
+
+	money "github.com/slilp/go-wallet/internal/money"
+	entity "github.com/slilp/go-wallet/internal/repositories/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockScheduleRepository is a mock of ScheduleRepository interface.
+type MockScheduleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockScheduleRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockScheduleRepositoryMockRecorder is the mock recorder for MockScheduleRepository.
+type MockScheduleRepositoryMockRecorder struct {
+	mock *MockScheduleRepository
+}
+
+// NewMockScheduleRepository creates a new mock instance.
+func NewMockScheduleRepository(ctrl *gomock.Controller) *MockScheduleRepository {
+	mock := &MockScheduleRepository{ctrl: ctrl}
+	mock.recorder = &MockScheduleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScheduleRepository) EXPECT() *MockScheduleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method.
+func (m *MockScheduleRepository) Cancel(userId, scheduleId string) (*entity.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", userId, scheduleId)
+	ret0, _ := ret[0].(*entity.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockScheduleRepositoryMockRecorder) Cancel(userId, scheduleId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockScheduleRepository)(nil).Cancel), userId, scheduleId)
+}
+
+// Create mocks base method.
+func (m *MockScheduleRepository) Create(userId string, schedule entity.Schedule) (*entity.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userId, schedule)
+	ret0, _ := ret[0].(*entity.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockScheduleRepositoryMockRecorder) Create(userId, schedule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockScheduleRepository)(nil).Create), userId, schedule)
+}
+
+// ListAll mocks base method.
+func (m *MockScheduleRepository) ListAll(userId string) ([]entity.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", userId)
+	ret0, _ := ret[0].([]entity.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockScheduleRepositoryMockRecorder) ListAll(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockScheduleRepository)(nil).ListAll), userId)
+}
+
+// ListRuns mocks base method.
+func (m *MockScheduleRepository) ListRuns(userId, scheduleId string) ([]entity.ScheduleRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRuns", userId, scheduleId)
+	ret0, _ := ret[0].([]entity.ScheduleRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRuns indicates an expected call of ListRuns.
+func (mr *MockScheduleRepositoryMockRecorder) ListRuns(userId, scheduleId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRuns", reflect.TypeOf((*MockScheduleRepository)(nil).ListRuns), userId, scheduleId)
+}
+
+// RunDue mocks base method.
+func (m *MockScheduleRepository) RunDue(limit int, execute func(entity.Schedule) entity.ScheduleRun) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunDue", limit, execute)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunDue indicates an expected call of RunDue.
+func (mr *MockScheduleRepositoryMockRecorder) RunDue(limit, execute any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunDue", reflect.TypeOf((*MockScheduleRepository)(nil).RunDue), limit, execute)
+}
+
+// Update mocks base method.
+func (m *MockScheduleRepository) Update(userId, scheduleId string, amount money.Amount, endAt *time.Time, maxRuns *int) (*entity.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", userId, scheduleId, amount, endAt, maxRuns)
+	ret0, _ := ret[0].(*entity.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockScheduleRepositoryMockRecorder) Update(userId, scheduleId, amount, endAt, maxRuns any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockScheduleRepository)(nil).Update), userId, scheduleId, amount, endAt, maxRuns)
+}
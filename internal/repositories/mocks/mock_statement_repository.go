@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./statement_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./statement_repository.go -destination=./mocks/mock_statement_repository.go -package=mock_repositories
+//
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/slilp/go-wallet/internal/repositories/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStatementRepository is a mock of StatementRepository interface.
+type MockStatementRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatementRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockStatementRepositoryMockRecorder is the mock recorder for MockStatementRepository.
+type MockStatementRepositoryMockRecorder struct {
+	mock *MockStatementRepository
+}
+
+// NewMockStatementRepository creates a new mock instance.
+func NewMockStatementRepository(ctrl *gomock.Controller) *MockStatementRepository {
+	mock := &MockStatementRepository{ctrl: ctrl}
+	mock.recorder = &MockStatementRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatementRepository) EXPECT() *MockStatementRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockStatementRepository) Create(statement entity.Statement) (*entity.Statement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", statement)
+	ret0, _ := ret[0].(*entity.Statement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockStatementRepositoryMockRecorder) Create(statement any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStatementRepository)(nil).Create), statement)
+}
+
+// FindByPeriod mocks base method.
+func (m *MockStatementRepository) FindByPeriod(walletId string, periodStart time.Time) (*entity.Statement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByPeriod", walletId, periodStart)
+	ret0, _ := ret[0].(*entity.Statement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByPeriod indicates an expected call of FindByPeriod.
+func (mr *MockStatementRepositoryMockRecorder) FindByPeriod(walletId, periodStart any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPeriod", reflect.TypeOf((*MockStatementRepository)(nil).FindByPeriod), walletId, periodStart)
+}
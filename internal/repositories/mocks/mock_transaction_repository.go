@@ -11,7 +11,10 @@ package mock_repositories
 
 import (
 	reflect "reflect"
+	time "time"
 
+	money "github.com/slilp/go-wallet/internal/money"
+	repositories "github.com/slilp/go-wallet/internal/repositories"
 	entity "github.com/slilp/go-wallet/internal/repositories/entity"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -40,60 +43,153 @@ func (m *MockTransactionRepository) EXPECT() *MockTransactionRepositoryMockRecor
 	return m.recorder
 }
 
+// BackfillBalanceAfter mocks base method.
+func (m *MockTransactionRepository) BackfillBalanceAfter(afterId string, limit int) (string, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackfillBalanceAfter", afterId, limit)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BackfillBalanceAfter indicates an expected call of BackfillBalanceAfter.
+func (mr *MockTransactionRepositoryMockRecorder) BackfillBalanceAfter(afterId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackfillBalanceAfter", reflect.TypeOf((*MockTransactionRepository)(nil).BackfillBalanceAfter), afterId, limit)
+}
+
+// BalanceAt mocks base method.
+func (m *MockTransactionRepository) BalanceAt(walletId string, at time.Time) (money.Amount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BalanceAt", walletId, at)
+	ret0, _ := ret[0].(money.Amount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BalanceAt indicates an expected call of BalanceAt.
+func (mr *MockTransactionRepositoryMockRecorder) BalanceAt(walletId, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BalanceAt", reflect.TypeOf((*MockTransactionRepository)(nil).BalanceAt), walletId, at)
+}
+
 // CountByWalletId mocks base method.
-func (m *MockTransactionRepository) CountByWalletId(walletId string) (int64, error) {
+func (m *MockTransactionRepository) CountByWalletId(walletId string, filter repositories.TransactionFilter) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountByWalletId", walletId)
+	ret := m.ctrl.Call(m, "CountByWalletId", walletId, filter)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CountByWalletId indicates an expected call of CountByWalletId.
-func (mr *MockTransactionRepositoryMockRecorder) CountByWalletId(walletId any) *gomock.Call {
+func (mr *MockTransactionRepositoryMockRecorder) CountByWalletId(walletId, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByWalletId", reflect.TypeOf((*MockTransactionRepository)(nil).CountByWalletId), walletId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByWalletId", reflect.TypeOf((*MockTransactionRepository)(nil).CountByWalletId), walletId, filter)
 }
 
 // List mocks base method.
-func (m *MockTransactionRepository) List(walletId string, page, limit int) ([]entity.Transaction, error) {
+func (m *MockTransactionRepository) List(walletId string, filter repositories.TransactionFilter, cursor *repositories.TransactionCursor, limit int) ([]entity.Transaction, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", walletId, page, limit)
+	ret := m.ctrl.Call(m, "List", walletId, filter, cursor, limit)
 	ret0, _ := ret[0].([]entity.Transaction)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockTransactionRepositoryMockRecorder) List(walletId, filter, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTransactionRepository)(nil).List), walletId, filter, cursor, limit)
+}
+
+// ListStatementLines mocks base method.
+func (m *MockTransactionRepository) ListStatementLines(walletId string, from, to time.Time) ([]entity.StatementLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStatementLines", walletId, from, to)
+	ret0, _ := ret[0].([]entity.StatementLine)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// List indicates an expected call of List.
-func (mr *MockTransactionRepositoryMockRecorder) List(walletId, page, limit any) *gomock.Call {
+// ListStatementLines indicates an expected call of ListStatementLines.
+func (mr *MockTransactionRepositoryMockRecorder) ListStatementLines(walletId, from, to any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTransactionRepository)(nil).List), walletId, page, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStatementLines", reflect.TypeOf((*MockTransactionRepository)(nil).ListStatementLines), walletId, from, to)
 }
 
-// UpdateBalanceTransaction mocks base method.
-func (m *MockTransactionRepository) UpdateBalanceTransaction(userId, walletId string, amount float64) error {
+// ReverseTransaction mocks base method.
+func (m *MockTransactionRepository) ReverseTransaction(userId, transactionId string, amount *money.Amount, details entity.TransactionDetails, idempotency *repositories.IdempotencyKey) (*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReverseTransaction", userId, transactionId, amount, details, idempotency)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReverseTransaction indicates an expected call of ReverseTransaction.
+func (mr *MockTransactionRepositoryMockRecorder) ReverseTransaction(userId, transactionId, amount, details, idempotency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReverseTransaction", reflect.TypeOf((*MockTransactionRepository)(nil).ReverseTransaction), userId, transactionId, amount, details, idempotency)
+}
+
+// Stream mocks base method.
+func (m *MockTransactionRepository) Stream(walletId string, filter repositories.TransactionFilter, fn func(entity.Transaction) error) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateBalanceTransaction", userId, walletId, amount)
+	ret := m.ctrl.Call(m, "Stream", walletId, filter, fn)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// Stream indicates an expected call of Stream.
+func (mr *MockTransactionRepositoryMockRecorder) Stream(walletId, filter, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stream", reflect.TypeOf((*MockTransactionRepository)(nil).Stream), walletId, filter, fn)
+}
+
+// UpdateBalanceTransaction mocks base method.
+func (m *MockTransactionRepository) UpdateBalanceTransaction(userId, walletId string, amount money.Amount, details entity.TransactionDetails, idempotency *repositories.IdempotencyKey, check repositories.LimitCheck, fee *repositories.Fee) (*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBalanceTransaction", userId, walletId, amount, details, idempotency, check, fee)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // UpdateBalanceTransaction indicates an expected call of UpdateBalanceTransaction.
-func (mr *MockTransactionRepositoryMockRecorder) UpdateBalanceTransaction(userId, walletId, amount any) *gomock.Call {
+func (mr *MockTransactionRepositoryMockRecorder) UpdateBalanceTransaction(userId, walletId, amount, details, idempotency, check, fee any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBalanceTransaction", reflect.TypeOf((*MockTransactionRepository)(nil).UpdateBalanceTransaction), userId, walletId, amount)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBalanceTransaction", reflect.TypeOf((*MockTransactionRepository)(nil).UpdateBalanceTransaction), userId, walletId, amount, details, idempotency, check, fee)
+}
+
+// UpdateBatchTransferTransaction mocks base method.
+func (m *MockTransactionRepository) UpdateBatchTransferTransaction(userId, from string, legs []repositories.BatchTransferLeg, allOrNothing bool) ([]repositories.BatchTransferResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBatchTransferTransaction", userId, from, legs, allOrNothing)
+	ret0, _ := ret[0].([]repositories.BatchTransferResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBatchTransferTransaction indicates an expected call of UpdateBatchTransferTransaction.
+func (mr *MockTransactionRepositoryMockRecorder) UpdateBatchTransferTransaction(userId, from, legs, allOrNothing any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBatchTransferTransaction", reflect.TypeOf((*MockTransactionRepository)(nil).UpdateBatchTransferTransaction), userId, from, legs, allOrNothing)
 }
 
 // UpdateTransferTransaction mocks base method.
-func (m *MockTransactionRepository) UpdateTransferTransaction(userId, from, to string, amount float64) error {
+func (m *MockTransactionRepository) UpdateTransferTransaction(userId, from, to string, amount money.Amount, details entity.TransactionDetails, idempotency *repositories.IdempotencyKey, check repositories.LimitCheck, fee *repositories.Fee) (*entity.Transaction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTransferTransaction", userId, from, to, amount)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "UpdateTransferTransaction", userId, from, to, amount, details, idempotency, check, fee)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // UpdateTransferTransaction indicates an expected call of UpdateTransferTransaction.
-func (mr *MockTransactionRepositoryMockRecorder) UpdateTransferTransaction(userId, from, to, amount any) *gomock.Call {
+func (mr *MockTransactionRepositoryMockRecorder) UpdateTransferTransaction(userId, from, to, amount, details, idempotency, check, fee any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransferTransaction", reflect.TypeOf((*MockTransactionRepository)(nil).UpdateTransferTransaction), userId, from, to, amount)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransferTransaction", reflect.TypeOf((*MockTransactionRepository)(nil).UpdateTransferTransaction), userId, from, to, amount, details, idempotency, check, fee)
 }
@@ -68,3 +68,48 @@ func (mr *MockUserRepositoryMockRecorder) QueryByEmail(email any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryByEmail", reflect.TypeOf((*MockUserRepository)(nil).QueryByEmail), email)
 }
+
+// QueryByHandle mocks base method.
+func (m *MockUserRepository) QueryByHandle(handle string) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryByHandle", handle)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryByHandle indicates an expected call of QueryByHandle.
+func (mr *MockUserRepositoryMockRecorder) QueryByHandle(handle any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryByHandle", reflect.TypeOf((*MockUserRepository)(nil).QueryByHandle), handle)
+}
+
+// QueryById mocks base method.
+func (m *MockUserRepository) QueryById(userId string) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryById", userId)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryById indicates an expected call of QueryById.
+func (mr *MockUserRepositoryMockRecorder) QueryById(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryById", reflect.TypeOf((*MockUserRepository)(nil).QueryById), userId)
+}
+
+// UpdateSettings mocks base method.
+func (m *MockUserRepository) UpdateSettings(userId string, handle, defaultWalletId *string) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSettings", userId, handle, defaultWalletId)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSettings indicates an expected call of UpdateSettings.
+func (mr *MockUserRepositoryMockRecorder) UpdateSettings(userId, handle, defaultWalletId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSettings", reflect.TypeOf((*MockUserRepository)(nil).UpdateSettings), userId, handle, defaultWalletId)
+}
@@ -11,7 +11,9 @@ package mock_repositories
 
 import (
 	reflect "reflect"
+	time "time"
 
+	money "github.com/slilp/go-wallet/internal/money"
 	entity "github.com/slilp/go-wallet/internal/repositories/entity"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -40,32 +42,49 @@ func (m *MockWalletRepository) EXPECT() *MockWalletRepositoryMockRecorder {
 	return m.recorder
 }
 
-// Create mocks base method.
-func (m *MockWalletRepository) Create(req entity.Wallet) error {
+// ChargeInterest mocks base method.
+func (m *MockWalletRepository) ChargeInterest(asOf time.Time) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", req)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ChargeInterest", asOf)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// Create indicates an expected call of Create.
-func (mr *MockWalletRepositoryMockRecorder) Create(req any) *gomock.Call {
+// ChargeInterest indicates an expected call of ChargeInterest.
+func (mr *MockWalletRepositoryMockRecorder) ChargeInterest(asOf any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWalletRepository)(nil).Create), req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChargeInterest", reflect.TypeOf((*MockWalletRepository)(nil).ChargeInterest), asOf)
+}
+
+// Close mocks base method.
+func (m *MockWalletRepository) Close(userId, walletId string, sweepTo *string) (*entity.Wallet, *entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close", userId, walletId, sweepTo)
+	ret0, _ := ret[0].(*entity.Wallet)
+	ret1, _ := ret[1].(*entity.Transaction)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockWalletRepositoryMockRecorder) Close(userId, walletId, sweepTo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockWalletRepository)(nil).Close), userId, walletId, sweepTo)
 }
 
-// Delete mocks base method.
-func (m *MockWalletRepository) Delete(walletId string) error {
+// Create mocks base method.
+func (m *MockWalletRepository) Create(req entity.Wallet) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", walletId)
+	ret := m.ctrl.Call(m, "Create", req)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Delete indicates an expected call of Delete.
-func (mr *MockWalletRepositoryMockRecorder) Delete(walletId any) *gomock.Call {
+// Create indicates an expected call of Create.
+func (mr *MockWalletRepositoryMockRecorder) Create(req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWalletRepository)(nil).Delete), walletId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWalletRepository)(nil).Create), req)
 }
 
 // ListAll mocks base method.
@@ -98,6 +117,51 @@ func (mr *MockWalletRepositoryMockRecorder) QueryByIdAndUser(userId, walletId an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryByIdAndUser", reflect.TypeOf((*MockWalletRepository)(nil).QueryByIdAndUser), userId, walletId)
 }
 
+// QueryByIds mocks base method.
+func (m *MockWalletRepository) QueryByIds(walletIds []string) ([]entity.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryByIds", walletIds)
+	ret0, _ := ret[0].([]entity.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryByIds indicates an expected call of QueryByIds.
+func (mr *MockWalletRepositoryMockRecorder) QueryByIds(walletIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryByIds", reflect.TypeOf((*MockWalletRepository)(nil).QueryByIds), walletIds)
+}
+
+// QueryOldest mocks base method.
+func (m *MockWalletRepository) QueryOldest(userId string) (*entity.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryOldest", userId)
+	ret0, _ := ret[0].(*entity.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryOldest indicates an expected call of QueryOldest.
+func (mr *MockWalletRepositoryMockRecorder) QueryOldest(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryOldest", reflect.TypeOf((*MockWalletRepository)(nil).QueryOldest), userId)
+}
+
+// UpdateCreditLine mocks base method.
+func (m *MockWalletRepository) UpdateCreditLine(walletId string, creditLimit money.Amount, interestRate money.Rate) (*entity.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCreditLine", walletId, creditLimit, interestRate)
+	ret0, _ := ret[0].(*entity.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCreditLine indicates an expected call of UpdateCreditLine.
+func (mr *MockWalletRepositoryMockRecorder) UpdateCreditLine(walletId, creditLimit, interestRate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCreditLine", reflect.TypeOf((*MockWalletRepository)(nil).UpdateCreditLine), walletId, creditLimit, interestRate)
+}
+
 // UpdateInfo mocks base method.
 func (m *MockWalletRepository) UpdateInfo(id, name string, desc *string) error {
 	m.ctrl.T.Helper()
@@ -111,3 +175,18 @@ func (mr *MockWalletRepositoryMockRecorder) UpdateInfo(id, name, desc any) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInfo", reflect.TypeOf((*MockWalletRepository)(nil).UpdateInfo), id, name, desc)
 }
+
+// UpdateStatus mocks base method.
+func (m *MockWalletRepository) UpdateStatus(walletId, status string, reason *string, actor string) (*entity.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", walletId, status, reason, actor)
+	ret0, _ := ret[0].(*entity.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockWalletRepositoryMockRecorder) UpdateStatus(walletId, status, reason, actor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockWalletRepository)(nil).UpdateStatus), walletId, status, reason, actor)
+}
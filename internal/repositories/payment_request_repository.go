@@ -0,0 +1,237 @@
+package repositories
+
+import (
+	"log"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/idgen"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	paymentRequestStatusPending  = "pending"
+	paymentRequestStatusAccepted = "accepted"
+	paymentRequestStatusDeclined = "declined"
+	paymentRequestStatusExpired  = "expired"
+)
+
+//go:generate mockgen -source=./payment_request_repository.go -destination=./mocks/mock_payment_request_repository.go -package=mock_repositories
+type PaymentRequestRepository interface {
+	Create(request entity.PaymentRequest) (*entity.PaymentRequest, error)
+	List(userId string, incoming bool) ([]entity.PaymentRequest, error)
+	FindPayable(userId, requestId string) (*entity.PaymentRequest, error)
+	Accept(userId, requestId, fromWalletId string, check LimitCheck, fee *Fee) (*entity.PaymentRequest, error)
+	Decline(userId, requestId string) (*entity.PaymentRequest, error)
+	ExpirePending() (int64, error)
+}
+
+type paymentRequestRepository struct {
+	db  *gorm.DB
+	ids idgen.Generator
+}
+
+func NewPaymentRequestRepository(db *gorm.DB, ids idgen.Generator) PaymentRequestRepository {
+	return &paymentRequestRepository{db: db, ids: ids}
+}
+
+// Create stores a pending request into one of the requester's wallets.
+func (r *paymentRequestRepository) Create(request entity.PaymentRequest) (*entity.PaymentRequest, error) {
+	var result *entity.PaymentRequest
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		var wallet entity.Wallet
+		if err := tx.Where(&entity.Wallet{ID: request.ToWalletID, UserID: request.RequesterID}).First(&wallet).Error; err != nil {
+			log.Printf("Failed to find payment request wallet: %v", err)
+			return err
+		}
+
+		if wallet.IsClosed() {
+			log.Printf("Payment request wallet %s is closed", wallet.ID)
+			return consts.ErrWalletClosed
+		}
+
+		if !wallet.Currency.Allows(request.Amount) {
+			log.Printf("Payment request amount %s exceeds %s minor units", request.Amount, wallet.Currency)
+			return consts.ErrAmountScaleExceeded
+		}
+
+		request.Status = paymentRequestStatusPending
+		if err := tx.Omit(clause.Associations).Create(&request).Error; err != nil {
+			log.Printf("Create payment request error: %v", err)
+			return err
+		}
+
+		var err error
+		result, err = findPaymentRequest(tx, request.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// List returns the requests addressed to userId when incoming, otherwise the
+// ones userId made, newest first.
+func (r *paymentRequestRepository) List(userId string, incoming bool) ([]entity.PaymentRequest, error) {
+	filter := entity.PaymentRequest{RequesterID: userId}
+	if incoming {
+		filter = entity.PaymentRequest{PayerID: userId}
+	}
+
+	var requests []entity.PaymentRequest
+	if err := r.db.Preload("Requester").Preload("Payer").
+		Where(&filter).
+		Order("created_at DESC").
+		Find(&requests).Error; err != nil {
+		log.Printf("List payment requests error: %v", err)
+		return nil, err
+	}
+	return requests, nil
+}
+
+// FindPayable returns a request addressed to userId that can still be
+// accepted. Requests addressed to someone else are reported as not found.
+func (r *paymentRequestRepository) FindPayable(userId, requestId string) (*entity.PaymentRequest, error) {
+	var request entity.PaymentRequest
+	if err := r.db.Where(&entity.PaymentRequest{ID: requestId, PayerID: userId}).First(&request).Error; err != nil {
+		log.Printf("Find payment request error: %v", err)
+		return nil, err
+	}
+
+	if !isPayable(request) {
+		log.Printf("Payment request %s is %s and expires at %s", request.ID, request.Status, request.ExpiresAt)
+		return nil, consts.ErrPaymentRequestNotPending
+	}
+	return &request, nil
+}
+
+// Accept pays a request addressed to userId from one of their wallets and
+// marks it accepted in the same DB transaction, so a concurrent decline or
+// expiry either wins before any money moves or waits for the payment.
+func (r *paymentRequestRepository) Accept(userId, requestId, fromWalletId string, check LimitCheck, fee *Fee) (*entity.PaymentRequest, error) {
+	var result *entity.PaymentRequest
+	if err := runInTransaction(r.db, func(tx *gorm.DB) error {
+		request, err := lockPayerRequest(tx, userId, requestId)
+		if err != nil {
+			return err
+		}
+
+		if !isPayable(*request) {
+			log.Printf("Payment request %s is %s and expires at %s", request.ID, request.Status, request.ExpiresAt)
+			return consts.ErrPaymentRequestNotPending
+		}
+
+		wallets, err := lockWallets(tx, fromWalletId, request.ToWalletID, fee.walletID())
+		if err != nil {
+			return err
+		}
+		fromWallet, ok := wallets[fromWalletId]
+		if !ok || fromWallet.UserID != userId {
+			log.Printf("Wallet %s not found for user %s", fromWalletId, userId)
+			return gorm.ErrRecordNotFound
+		}
+		toWallet, ok := wallets[request.ToWalletID]
+		if !ok {
+			log.Printf("Payment request wallet %s not found", request.ToWalletID)
+			return gorm.ErrRecordNotFound
+		}
+
+		if check != nil {
+			if err := check(fromWallet, newLimitUsage(tx, fromWallet)); err != nil {
+				return err
+			}
+		}
+
+		txRecord, err := transferFunds(tx, r.ids, fromWallet, toWallet, request.Amount, entity.TransactionDetails{
+			Description: request.Note,
+			Metadata:    entity.Metadata{"paymentRequestId": request.ID},
+		}, fee, wallets[fee.walletID()])
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Model(&entity.PaymentRequest{}).
+			Where(&entity.PaymentRequest{ID: request.ID}).
+			Updates(map[string]interface{}{"status": paymentRequestStatusAccepted, "transaction_id": txRecord.ID, "updated_at": gorm.Expr("NOW()")}).Error; err != nil {
+			log.Printf("Accept payment request error: %v", err)
+			return err
+		}
+
+		result, err = findPaymentRequest(tx, request.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *paymentRequestRepository) Decline(userId, requestId string) (*entity.PaymentRequest, error) {
+	var result *entity.PaymentRequest
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		request, err := lockPayerRequest(tx, userId, requestId)
+		if err != nil {
+			return err
+		}
+
+		if !isPayable(*request) {
+			log.Printf("Payment request %s is %s and expires at %s", request.ID, request.Status, request.ExpiresAt)
+			return consts.ErrPaymentRequestNotPending
+		}
+
+		if err := tx.Model(&entity.PaymentRequest{}).
+			Where(&entity.PaymentRequest{ID: request.ID}).
+			Updates(map[string]interface{}{"status": paymentRequestStatusDeclined, "updated_at": gorm.Expr("NOW()")}).Error; err != nil {
+			log.Printf("Decline payment request error: %v", err)
+			return err
+		}
+
+		result, err = findPaymentRequest(tx, request.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ExpirePending marks pending requests past their expiry as expired. It is a
+// single UPDATE, so running it from several replicas at once is harmless.
+func (r *paymentRequestRepository) ExpirePending() (int64, error) {
+	result := r.db.Model(&entity.PaymentRequest{}).
+		Where("status = ? AND expires_at <= NOW()", paymentRequestStatusPending).
+		Updates(map[string]interface{}{"status": paymentRequestStatusExpired, "updated_at": gorm.Expr("NOW()")})
+	if result.Error != nil {
+		log.Printf("ExpirePending error: %v", result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+func isPayable(request entity.PaymentRequest) bool {
+	return request.Status == paymentRequestStatusPending && request.ExpiresAt.After(time.Now())
+}
+
+// lockPayerRequest locks a request addressed to userId. Requests addressed
+// to someone else are reported as not found.
+func lockPayerRequest(tx *gorm.DB, userId, requestId string) (*entity.PaymentRequest, error) {
+	var request entity.PaymentRequest
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(&entity.PaymentRequest{ID: requestId, PayerID: userId}).
+		First(&request).Error; err != nil {
+		log.Printf("Failed to lock payment request: %v", err)
+		return nil, err
+	}
+	return &request, nil
+}
+
+func findPaymentRequest(tx *gorm.DB, requestId string) (*entity.PaymentRequest, error) {
+	var request entity.PaymentRequest
+	if err := tx.Preload("Requester").Preload("Payer").
+		Where(&entity.PaymentRequest{ID: requestId}).
+		First(&request).Error; err != nil {
+		log.Printf("Find payment request error: %v", err)
+		return nil, err
+	}
+	return &request, nil
+}
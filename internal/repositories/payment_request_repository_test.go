@@ -0,0 +1,347 @@
+package repositories_test
+
+import (
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+)
+
+const (
+	findPaymentRequestQuery = `SELECT \* FROM "payment_requests" WHERE "payment_requests"\."id" = \$1 ORDER BY "payment_requests"\."id" LIMIT \$2`
+	lockPaymentRequestQuery = `SELECT \* FROM "payment_requests" WHERE "payment_requests"\."id" = \$1 AND "payment_requests"\."payer_id" = \$2 ORDER BY "payment_requests"\."id" LIMIT \$3 FOR UPDATE`
+	preloadUserQuery        = `SELECT \* FROM "users" WHERE "users"\."id" = \$1`
+)
+
+var paymentRequestColumns = []string{"id", "requester_id", "payer_id", "to_wallet_id", "amount", "status", "expires_at"}
+
+func expectPaymentRequestReload(mock sqlmock.Sqlmock, status string) {
+	mock.ExpectQuery(findPaymentRequestQuery).
+		WithArgs("<RequestID>", 1).
+		WillReturnRows(sqlmock.NewRows(paymentRequestColumns).
+			AddRow("<RequestID>", "<RequesterID>", "<PayerID>", "<ToWalletID>", "25.00", status, time.Now().Add(time.Hour)))
+	mock.ExpectQuery(preloadUserQuery).
+		WithArgs("<PayerID>").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow("<PayerID>", "payer@example.com"))
+	mock.ExpectQuery(preloadUserQuery).
+		WithArgs("<RequesterID>").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow("<RequesterID>", "requester@example.com"))
+}
+
+func (suite *PaymentRequestRepositoryTestSuite) TestCreate() {
+	testCases := []struct {
+		name        string
+		amount      money.Amount
+		mock        func(sqlmock.Sqlmock)
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:   "GivenRequesterWallet_WhenCreate_ThenRequestPending",
+			amount: money.MustParse("25"),
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(merchantWallet).
+					WithArgs("<ToWalletID>", "<RequesterID>", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "currency"}).AddRow("<ToWalletID>", "THB"))
+				mock.ExpectQuery(`INSERT INTO "payment_requests"`).
+					WithArgs("<RequesterID>", "<PayerID>", "<ToWalletID>", "25.00", nil, "pending", nil, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow("<RequestID>", time.Now(), time.Now()))
+				expectPaymentRequestReload(mock, "pending")
+				mock.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name:   "GivenFractionalYen_WhenCreate_ThenScaleExceeded",
+			amount: money.MustParse("25.50"),
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(merchantWallet).
+					WithArgs("<ToWalletID>", "<RequesterID>", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "currency"}).AddRow("<ToWalletID>", "JPY"))
+				mock.ExpectRollback()
+			},
+			wantErr:     true,
+			expectedErr: "amount exceeds supported decimal places",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+			request, err := suite.paymentRequestRepo.Create(entity.PaymentRequest{
+				RequesterID: "<RequesterID>",
+				PayerID:     "<PayerID>",
+				ToWalletID:  "<ToWalletID>",
+				Amount:      tc.amount,
+				ExpiresAt:   time.Now().Add(time.Hour),
+			})
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(request)
+			} else {
+				suite.NoError(err)
+				suite.Equal("<RequestID>", request.ID)
+				suite.Equal("pending", request.Status)
+				suite.Equal("payer@example.com", request.Payer.Email)
+				suite.Equal("requester@example.com", request.Requester.Email)
+			}
+			suite.sqlMock.ExpectationsWereMet()
+		})
+	}
+}
+
+func (suite *PaymentRequestRepositoryTestSuite) TestList() {
+	testCases := []struct {
+		name     string
+		incoming bool
+		query    string
+	}{
+		{
+			name:     "GivenIncoming_WhenList_ThenFilterByPayer",
+			incoming: true,
+			query:    `SELECT \* FROM "payment_requests" WHERE "payment_requests"\."payer_id" = \$1 ORDER BY created_at DESC`,
+		},
+		{
+			name:     "GivenOutgoing_WhenList_ThenFilterByRequester",
+			incoming: false,
+			query:    `SELECT \* FROM "payment_requests" WHERE "payment_requests"\."requester_id" = \$1 ORDER BY created_at DESC`,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.sqlMock.ExpectQuery(tc.query).
+				WithArgs("<UserID>").
+				WillReturnRows(sqlmock.NewRows(paymentRequestColumns))
+			requests, err := suite.paymentRequestRepo.List("<UserID>", tc.incoming)
+			suite.NoError(err)
+			suite.Empty(requests)
+			suite.sqlMock.ExpectationsWereMet()
+		})
+	}
+}
+
+func (suite *PaymentRequestRepositoryTestSuite) TestFindPayable() {
+	testCases := []struct {
+		name        string
+		status      string
+		expiresAt   time.Time
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:      "GivenPendingRequest_WhenFindPayable_ThenReturnRequest",
+			status:    "pending",
+			expiresAt: time.Now().Add(time.Hour),
+			wantErr:   false,
+		},
+		{
+			name:        "GivenExpiredRequest_WhenFindPayable_ThenNotPending",
+			status:      "pending",
+			expiresAt:   time.Now().Add(-time.Minute),
+			wantErr:     true,
+			expectedErr: "payment request is not pending",
+		},
+		{
+			name:        "GivenDeclinedRequest_WhenFindPayable_ThenNotPending",
+			status:      "declined",
+			expiresAt:   time.Now().Add(time.Hour),
+			wantErr:     true,
+			expectedErr: "payment request is not pending",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.sqlMock.ExpectQuery(`SELECT \* FROM "payment_requests" WHERE "payment_requests"\."id" = \$1 AND "payment_requests"\."payer_id" = \$2 ORDER BY "payment_requests"\."id" LIMIT \$3`).
+				WithArgs("<RequestID>", "<PayerID>", 1).
+				WillReturnRows(sqlmock.NewRows(paymentRequestColumns).
+					AddRow("<RequestID>", "<RequesterID>", "<PayerID>", "<ToWalletID>", "25.00", tc.status, tc.expiresAt))
+			request, err := suite.paymentRequestRepo.FindPayable("<PayerID>", "<RequestID>")
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(request)
+			} else {
+				suite.NoError(err)
+				suite.Equal("<ToWalletID>", request.ToWalletID)
+			}
+			suite.sqlMock.ExpectationsWereMet()
+		})
+	}
+}
+
+func (suite *PaymentRequestRepositoryTestSuite) TestAccept() {
+	testCases := []struct {
+		name        string
+		mock        func(sqlmock.Sqlmock)
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenPendingRequest_WhenAccept_ThenPaidAndAcceptedInOneTransaction",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockPaymentRequestQuery).
+					WithArgs("<RequestID>", "<PayerID>", 1).
+					WillReturnRows(sqlmock.NewRows(append(paymentRequestColumns, "note")).
+						AddRow("<RequestID>", "<RequesterID>", "<PayerID>", "<ToWalletID>", "25.00", "pending", time.Now().Add(time.Hour), "Dinner"))
+				mock.ExpectQuery(lockTwoWallets).
+					WithArgs("<FromWalletID>", "<ToWalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency"}).
+						AddRow("<FromWalletID>", "<PayerID>", "100.00", "THB").
+						AddRow("<ToWalletID>", "<RequesterID>", "0.00", "THB"))
+				mock.ExpectQuery(sumActiveHolds).
+					WithArgs("<FromWalletID>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("0.00"))
+				mock.ExpectQuery(`INSERT INTO "transactions"`).
+					WithArgs("TRN000000000000000001", "<FromWalletID>", "<ToWalletID>", "25.00", "transfer", nil, nil, nil, "0", nil, nil, nil, "Dinner", nil, `{"paymentRequestId":"\u003cRequestID\u003e"}`).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(nil, nil))
+				mock.ExpectQuery(`INSERT INTO "journal_entries"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("<JournalEntryID>", nil))
+				mock.ExpectQuery(`INSERT INTO "postings"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, nil).AddRow(2, nil))
+				mock.ExpectExec(`UPDATE "wallets"`).
+					WithArgs("25.00", "<FromWalletID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`UPDATE "wallets"`).
+					WithArgs("25.00", "<ToWalletID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectBalancesAfter(mock)
+				mock.ExpectExec(`UPDATE "payment_requests" SET "status"=\$1,"transaction_id"=\$2,"updated_at"=NOW\(\) WHERE "payment_requests"\."id" = \$3`).
+					WithArgs("accepted", "TRN000000000000000001", "<RequestID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectPaymentRequestReload(mock, "accepted")
+				mock.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "GivenDeclinedRequest_WhenAccept_ThenNotPendingBeforeMovingFunds",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockPaymentRequestQuery).
+					WithArgs("<RequestID>", "<PayerID>", 1).
+					WillReturnRows(sqlmock.NewRows(paymentRequestColumns).
+						AddRow("<RequestID>", "<RequesterID>", "<PayerID>", "<ToWalletID>", "25.00", "declined", time.Now().Add(time.Hour)))
+				mock.ExpectRollback()
+			},
+			wantErr:     true,
+			expectedErr: "payment request is not pending",
+		},
+		{
+			name: "GivenRequestPastExpiry_WhenAccept_ThenNotPendingBeforeMovingFunds",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockPaymentRequestQuery).
+					WithArgs("<RequestID>", "<PayerID>", 1).
+					WillReturnRows(sqlmock.NewRows(paymentRequestColumns).
+						AddRow("<RequestID>", "<RequesterID>", "<PayerID>", "<ToWalletID>", "25.00", "pending", time.Now().Add(-time.Minute)))
+				mock.ExpectRollback()
+			},
+			wantErr:     true,
+			expectedErr: "payment request is not pending",
+		},
+		{
+			name: "GivenWalletOfAnotherUser_WhenAccept_ThenNotFound",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockPaymentRequestQuery).
+					WithArgs("<RequestID>", "<PayerID>", 1).
+					WillReturnRows(sqlmock.NewRows(paymentRequestColumns).
+						AddRow("<RequestID>", "<RequesterID>", "<PayerID>", "<ToWalletID>", "25.00", "pending", time.Now().Add(time.Hour)))
+				mock.ExpectQuery(lockTwoWallets).
+					WithArgs("<FromWalletID>", "<ToWalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency"}).
+						AddRow("<FromWalletID>", "<OtherUserID>", "100.00", "THB").
+						AddRow("<ToWalletID>", "<RequesterID>", "0.00", "THB"))
+				mock.ExpectRollback()
+			},
+			wantErr:     true,
+			expectedErr: "record not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+			request, err := suite.paymentRequestRepo.Accept("<PayerID>", "<RequestID>", "<FromWalletID>", nil, nil)
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(request)
+			} else {
+				suite.NoError(err)
+				suite.Equal("accepted", request.Status)
+			}
+			suite.NoError(suite.sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (suite *PaymentRequestRepositoryTestSuite) TestDecline() {
+	testCases := []struct {
+		name        string
+		mock        func(sqlmock.Sqlmock)
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenPendingRequest_WhenDecline_ThenDeclined",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockPaymentRequestQuery).
+					WithArgs("<RequestID>", "<PayerID>", 1).
+					WillReturnRows(sqlmock.NewRows(paymentRequestColumns).
+						AddRow("<RequestID>", "<RequesterID>", "<PayerID>", "<ToWalletID>", "25.00", "pending", time.Now().Add(time.Hour)))
+				mock.ExpectExec(`UPDATE "payment_requests" SET "status"=\$1,"updated_at"=NOW\(\) WHERE "payment_requests"\."id" = \$2`).
+					WithArgs("declined", "<RequestID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectPaymentRequestReload(mock, "declined")
+				mock.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "GivenExpiredRequest_WhenDecline_ThenNotPending",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockPaymentRequestQuery).
+					WithArgs("<RequestID>", "<PayerID>", 1).
+					WillReturnRows(sqlmock.NewRows(paymentRequestColumns).
+						AddRow("<RequestID>", "<RequesterID>", "<PayerID>", "<ToWalletID>", "25.00", "pending", time.Now().Add(-time.Minute)))
+				mock.ExpectRollback()
+			},
+			wantErr:     true,
+			expectedErr: "payment request is not pending",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+			request, err := suite.paymentRequestRepo.Decline("<PayerID>", "<RequestID>")
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(request)
+			} else {
+				suite.NoError(err)
+				suite.Equal("declined", request.Status)
+			}
+			suite.sqlMock.ExpectationsWereMet()
+		})
+	}
+}
+
+func (suite *PaymentRequestRepositoryTestSuite) TestExpirePending() {
+	suite.sqlMock.ExpectBegin()
+	suite.sqlMock.ExpectExec(`UPDATE "payment_requests" SET "status"=\$1,"updated_at"=NOW\(\) WHERE status = \$2 AND expires_at <= NOW\(\)`).
+		WithArgs("expired", "pending").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	suite.sqlMock.ExpectCommit()
+	expired, err := suite.paymentRequestRepo.ExpirePending()
+	suite.NoError(err)
+	suite.Equal(int64(3), expired)
+	suite.NoError(suite.sqlMock.ExpectationsWereMet())
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/slilp/go-wallet/internal/idgen"
 	"github.com/slilp/go-wallet/internal/repositories"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/driver/postgres"
@@ -35,10 +36,59 @@ type WalletRepositoryTestSuite struct {
 
 type TransactionRepositoryTestSuite struct {
 	suite.Suite
+	db              *gorm.DB
 	sqlMock         sqlmock.Sqlmock
 	transactionRepo repositories.TransactionRepository
 }
 
+type ExchangeRateRepositoryTestSuite struct {
+	suite.Suite
+	sqlMock          sqlmock.Sqlmock
+	exchangeRateRepo repositories.ExchangeRateRepository
+}
+
+type HoldRepositoryTestSuite struct {
+	suite.Suite
+	sqlMock  sqlmock.Sqlmock
+	holdRepo repositories.HoldRepository
+}
+
+type ScheduleRepositoryTestSuite struct {
+	suite.Suite
+	sqlMock      sqlmock.Sqlmock
+	scheduleRepo repositories.ScheduleRepository
+}
+
+type LimitRepositoryTestSuite struct {
+	suite.Suite
+	sqlMock   sqlmock.Sqlmock
+	limitRepo repositories.LimitRepository
+}
+
+type PaymentRequestRepositoryTestSuite struct {
+	suite.Suite
+	sqlMock            sqlmock.Sqlmock
+	paymentRequestRepo repositories.PaymentRequestRepository
+}
+
+type StatementRepositoryTestSuite struct {
+	suite.Suite
+	sqlMock       sqlmock.Sqlmock
+	statementRepo repositories.StatementRepository
+}
+
+type FeeRepositoryTestSuite struct {
+	suite.Suite
+	sqlMock sqlmock.Sqlmock
+	feeRepo repositories.FeeRepository
+}
+
+type DepositImportRepositoryTestSuite struct {
+	suite.Suite
+	sqlMock           sqlmock.Sqlmock
+	depositImportRepo repositories.DepositImportRepository
+}
+
 func (suite *UserRepositoryTestSuite) SetupTest() {
 	sqlMock, db := setUpMockDb()
 	suite.sqlMock = sqlMock
@@ -48,13 +98,68 @@ func (suite *UserRepositoryTestSuite) SetupTest() {
 func (suite *WalletRepositoryTestSuite) SetupTest() {
 	sqlMock, db := setUpMockDb()
 	suite.sqlMock = sqlMock
-	suite.walletRepo = repositories.NewWalletRepository(db)
+	suite.walletRepo = repositories.NewWalletRepository(db, idgen.NewSequential("TRN"))
 }
 
 func (suite *TransactionRepositoryTestSuite) SetupTest() {
+	sqlMock, db := setUpMockDb()
+	suite.db = db
+	suite.sqlMock = sqlMock
+	suite.transactionRepo = repositories.NewTransactionRepository(db, idgen.NewSequential("TRN"))
+}
+
+// SetupSubTest restarts transaction IDs for every table case so expected
+// IDs do not depend on case order.
+func (suite *TransactionRepositoryTestSuite) SetupSubTest() {
+	suite.transactionRepo = repositories.NewTransactionRepository(suite.db, idgen.NewSequential("TRN"))
+}
+
+func (suite *ExchangeRateRepositoryTestSuite) SetupTest() {
+	sqlMock, db := setUpMockDb()
+	suite.sqlMock = sqlMock
+	suite.exchangeRateRepo = repositories.NewExchangeRateRepository(db)
+}
+
+func (suite *HoldRepositoryTestSuite) SetupTest() {
+	sqlMock, db := setUpMockDb()
+	suite.sqlMock = sqlMock
+	suite.holdRepo = repositories.NewHoldRepository(db, idgen.NewSequential("TRN"))
+}
+
+func (suite *ScheduleRepositoryTestSuite) SetupTest() {
+	sqlMock, db := setUpMockDb()
+	suite.sqlMock = sqlMock
+	suite.scheduleRepo = repositories.NewScheduleRepository(db)
+}
+
+func (suite *LimitRepositoryTestSuite) SetupTest() {
+	sqlMock, db := setUpMockDb()
+	suite.sqlMock = sqlMock
+	suite.limitRepo = repositories.NewLimitRepository(db)
+}
+
+func (suite *FeeRepositoryTestSuite) SetupTest() {
+	sqlMock, db := setUpMockDb()
+	suite.sqlMock = sqlMock
+	suite.feeRepo = repositories.NewFeeRepository(db)
+}
+
+func (suite *PaymentRequestRepositoryTestSuite) SetupTest() {
+	sqlMock, db := setUpMockDb()
+	suite.sqlMock = sqlMock
+	suite.paymentRequestRepo = repositories.NewPaymentRequestRepository(db, idgen.NewSequential("TRN"))
+}
+
+func (suite *StatementRepositoryTestSuite) SetupTest() {
+	sqlMock, db := setUpMockDb()
+	suite.sqlMock = sqlMock
+	suite.statementRepo = repositories.NewStatementRepository(db)
+}
+
+func (suite *DepositImportRepositoryTestSuite) SetupTest() {
 	sqlMock, db := setUpMockDb()
 	suite.sqlMock = sqlMock
-	suite.transactionRepo = repositories.NewTransactionRepository(db)
+	suite.depositImportRepo = repositories.NewDepositImportRepository(db)
 }
 
 func TestRepositoryTestSuite(t *testing.T) {
@@ -62,4 +167,12 @@ func TestRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(UserRepositoryTestSuite))
 	suite.Run(t, new(WalletRepositoryTestSuite))
 	suite.Run(t, new(TransactionRepositoryTestSuite))
+	suite.Run(t, new(ExchangeRateRepositoryTestSuite))
+	suite.Run(t, new(HoldRepositoryTestSuite))
+	suite.Run(t, new(ScheduleRepositoryTestSuite))
+	suite.Run(t, new(LimitRepositoryTestSuite))
+	suite.Run(t, new(FeeRepositoryTestSuite))
+	suite.Run(t, new(PaymentRequestRepositoryTestSuite))
+	suite.Run(t, new(StatementRepositoryTestSuite))
+	suite.Run(t, new(DepositImportRepositoryTestSuite))
 }
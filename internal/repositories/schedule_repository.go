@@ -0,0 +1,259 @@
+package repositories
+
+import (
+	"log"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	scheduleStatusActive    = "active"
+	scheduleStatusCompleted = "completed"
+	scheduleStatusCancelled = "cancelled"
+)
+
+//go:generate mockgen -source=./schedule_repository.go -destination=./mocks/mock_schedule_repository.go -package=mock_repositories
+type ScheduleRepository interface {
+	Create(userId string, schedule entity.Schedule) (*entity.Schedule, error)
+	ListAll(userId string) ([]entity.Schedule, error)
+	Update(userId, scheduleId string, amount money.Amount, endAt *time.Time, maxRuns *int) (*entity.Schedule, error)
+	Cancel(userId, scheduleId string) (*entity.Schedule, error)
+	ListRuns(userId, scheduleId string) ([]entity.ScheduleRun, error)
+	RunDue(limit int, execute func(schedule entity.Schedule) entity.ScheduleRun) (int, error)
+}
+
+type scheduleRepository struct {
+	db *gorm.DB
+}
+
+func NewScheduleRepository(db *gorm.DB) ScheduleRepository {
+	return &scheduleRepository{db: db}
+}
+
+func (r *scheduleRepository) Create(userId string, schedule entity.Schedule) (*entity.Schedule, error) {
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		var wallet entity.Wallet
+		if err := tx.Where(&entity.Wallet{ID: schedule.FromWalletID, UserID: userId}).First(&wallet).Error; err != nil {
+			log.Printf("Failed to find schedule wallet: %v", err)
+			return err
+		}
+
+		if !wallet.Currency.Allows(schedule.Amount) {
+			log.Printf("Schedule amount %s exceeds %s minor units", schedule.Amount, wallet.Currency)
+			return consts.ErrAmountScaleExceeded
+		}
+
+		var toWallet entity.Wallet
+		if err := tx.Where(&entity.Wallet{ID: schedule.ToWalletID}).First(&toWallet).Error; err != nil {
+			log.Printf("Failed to find schedule destination wallet: %v", err)
+			return err
+		}
+
+		if wallet.IsClosed() || toWallet.IsClosed() {
+			log.Printf("Schedule between %s and %s touches a closed wallet", wallet.ID, toWallet.ID)
+			return consts.ErrWalletClosed
+		}
+
+		schedule.UserID = userId
+		schedule.Status = scheduleStatusActive
+		schedule.NextRunAt = &schedule.StartAt
+		if err := tx.Create(&schedule).Error; err != nil {
+			log.Printf("Create schedule error: %v", err)
+			return err
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func (r *scheduleRepository) ListAll(userId string) ([]entity.Schedule, error) {
+	var schedules []entity.Schedule
+	if err := r.db.Where(&entity.Schedule{UserID: userId}).Order("created_at DESC").Find(&schedules).Error; err != nil {
+		log.Printf("ListAll schedules error: %v", err)
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// Update replaces the amount and end conditions of an active schedule. If the
+// new conditions leave no further runs the schedule is completed.
+func (r *scheduleRepository) Update(userId, scheduleId string, amount money.Amount, endAt *time.Time, maxRuns *int) (*entity.Schedule, error) {
+	var result *entity.Schedule
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		schedule, err := lockActiveSchedule(tx, userId, scheduleId)
+		if err != nil {
+			return err
+		}
+
+		var wallet entity.Wallet
+		if err := tx.Where(&entity.Wallet{ID: schedule.FromWalletID}).First(&wallet).Error; err != nil {
+			log.Printf("Failed to find schedule wallet: %v", err)
+			return err
+		}
+		if !wallet.Currency.Allows(amount) {
+			log.Printf("Schedule amount %s exceeds %s minor units", amount, wallet.Currency)
+			return consts.ErrAmountScaleExceeded
+		}
+
+		schedule.Amount = amount
+		schedule.EndAt = endAt
+		schedule.MaxRuns = maxRuns
+		advanceSchedule(schedule)
+
+		if err := tx.Model(&entity.Schedule{}).
+			Where(&entity.Schedule{ID: schedule.ID}).
+			Updates(map[string]interface{}{
+				"amount":      schedule.Amount,
+				"end_at":      schedule.EndAt,
+				"max_runs":    schedule.MaxRuns,
+				"next_run_at": schedule.NextRunAt,
+				"status":      schedule.Status,
+				"updated_at":  gorm.Expr("NOW()"),
+			}).Error; err != nil {
+			log.Printf("Update schedule error: %v", err)
+			return err
+		}
+
+		result = schedule
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *scheduleRepository) Cancel(userId, scheduleId string) (*entity.Schedule, error) {
+	var result *entity.Schedule
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		schedule, err := lockActiveSchedule(tx, userId, scheduleId)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Model(&entity.Schedule{}).
+			Where(&entity.Schedule{ID: schedule.ID}).
+			Updates(map[string]interface{}{"next_run_at": nil, "status": scheduleStatusCancelled, "updated_at": gorm.Expr("NOW()")}).Error; err != nil {
+			log.Printf("Cancel schedule error: %v", err)
+			return err
+		}
+
+		schedule.Status = scheduleStatusCancelled
+		schedule.NextRunAt = nil
+		result = schedule
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *scheduleRepository) ListRuns(userId, scheduleId string) ([]entity.ScheduleRun, error) {
+	var schedule entity.Schedule
+	if err := r.db.Where(&entity.Schedule{ID: scheduleId, UserID: userId}).First(&schedule).Error; err != nil {
+		log.Printf("ListRuns schedule error: %v", err)
+		return nil, err
+	}
+
+	var runs []entity.ScheduleRun
+	if err := r.db.Where(&entity.ScheduleRun{ScheduleID: schedule.ID}).Order("run_at DESC").Find(&runs).Error; err != nil {
+		log.Printf("ListRuns error: %v", err)
+		return nil, err
+	}
+	return runs, nil
+}
+
+// RunDue claims due schedules one at a time and hands each to execute. The
+// row is locked with SKIP LOCKED, so replicas running the executor never
+// claim the same schedule, and the run is recorded in the same transaction
+// that advances it. It stops after limit schedules or when none are due.
+func (r *scheduleRepository) RunDue(limit int, execute func(schedule entity.Schedule) entity.ScheduleRun) (int, error) {
+	processed := 0
+	for processed < limit {
+		claimed := false
+		if err := r.db.Transaction(func(tx *gorm.DB) error {
+			var schedules []entity.Schedule
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+				Where("status = ? AND next_run_at <= NOW()", scheduleStatusActive).
+				Order("next_run_at").
+				Limit(1).
+				Find(&schedules).Error; err != nil {
+				log.Printf("Claim due schedule error: %v", err)
+				return err
+			}
+			if len(schedules) == 0 {
+				return nil
+			}
+			claimed = true
+			schedule := &schedules[0]
+
+			run := execute(*schedule)
+			run.ScheduleID = schedule.ID
+			run.RunAt = *schedule.NextRunAt
+			if err := tx.Create(&run).Error; err != nil {
+				log.Printf("Record schedule run error: %v", err)
+				return err
+			}
+
+			schedule.RunCount++
+			advanceSchedule(schedule)
+
+			if err := tx.Model(&entity.Schedule{}).
+				Where(&entity.Schedule{ID: schedule.ID}).
+				Updates(map[string]interface{}{
+					"next_run_at": schedule.NextRunAt,
+					"run_count":   schedule.RunCount,
+					"status":      schedule.Status,
+					"updated_at":  gorm.Expr("NOW()"),
+				}).Error; err != nil {
+				log.Printf("Advance schedule error: %v", err)
+				return err
+			}
+			return nil
+		}); err != nil {
+			return processed, err
+		}
+
+		if !claimed {
+			break
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+// lockActiveSchedule locks a schedule owned by userId and rejects it unless
+// it is still active.
+func lockActiveSchedule(tx *gorm.DB, userId, scheduleId string) (*entity.Schedule, error) {
+	var schedule entity.Schedule
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(&entity.Schedule{ID: scheduleId, UserID: userId}).
+		First(&schedule).Error; err != nil {
+		log.Printf("Failed to lock schedule: %v", err)
+		return nil, err
+	}
+
+	if schedule.Status != scheduleStatusActive {
+		log.Printf("Schedule %s is %s", schedule.ID, schedule.Status)
+		return nil, consts.ErrScheduleNotActive
+	}
+	return &schedule, nil
+}
+
+// advanceSchedule points NextRunAt at the run after RunCount runs, completing
+// the schedule when there is none.
+func advanceSchedule(schedule *entity.Schedule) {
+	next, ok := schedule.Occurrence(schedule.RunCount)
+	if !ok {
+		schedule.NextRunAt = nil
+		schedule.Status = scheduleStatusCompleted
+		return
+	}
+	schedule.NextRunAt = &next
+}
@@ -0,0 +1,201 @@
+package repositories_test
+
+import (
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+)
+
+const (
+	lockScheduleQuery = `SELECT \* FROM "schedules" WHERE "schedules"\."id" = \$1 AND "schedules"\."user_id" = \$2 ORDER BY "schedules"\."id" LIMIT \$3 FOR UPDATE`
+	claimDueSchedule  = `SELECT \* FROM "schedules" WHERE status = \$1 AND next_run_at <= NOW\(\) ORDER BY next_run_at LIMIT \$2 FOR UPDATE SKIP LOCKED`
+)
+
+var scheduleColumns = []string{"id", "user_id", "from_wallet_id", "to_wallet_id", "amount", "frequency", "start_at", "max_runs", "run_count", "next_run_at", "status"}
+
+func (suite *ScheduleRepositoryTestSuite) TestCreate() {
+	startAt := time.Date(2030, 1, 31, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		amount      money.Amount
+		mock        func(sqlmock.Sqlmock)
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:   "GivenOwnedWallet_WhenCreate_ThenScheduleActive",
+			amount: money.MustParse("25"),
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE "wallets"\."id" = \$1 AND "wallets"\."user_id" = \$2 ORDER BY "wallets"\."id" LIMIT \$3`).
+					WithArgs("<FromWalletID>", "<UserID>", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "currency"}).AddRow("<FromWalletID>", "THB"))
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE "wallets"\."id" = \$1 ORDER BY "wallets"\."id" LIMIT \$2`).
+					WithArgs("<ToWalletID>", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "currency"}).AddRow("<ToWalletID>", "THB"))
+				mock.ExpectQuery(`INSERT INTO "schedules"`).
+					WithArgs("<UserID>", "<FromWalletID>", "<ToWalletID>", "25.00", "monthly", startAt, nil, nil, 0, startAt, "active").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow("<ScheduleID>", nil, nil))
+				mock.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name:   "GivenJPYWallet_WhenAmountHasFraction_ThenScaleExceeded",
+			amount: money.MustParse("25.50"),
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE "wallets"\."id" = \$1 AND "wallets"\."user_id" = \$2 ORDER BY "wallets"\."id" LIMIT \$3`).
+					WithArgs("<FromWalletID>", "<UserID>", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "currency"}).AddRow("<FromWalletID>", "JPY"))
+				mock.ExpectRollback()
+			},
+			wantErr:     true,
+			expectedErr: "amount exceeds supported decimal places",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+			schedule, err := suite.scheduleRepo.Create("<UserID>", entity.Schedule{
+				FromWalletID: "<FromWalletID>",
+				ToWalletID:   "<ToWalletID>",
+				Amount:       tc.amount,
+				Frequency:    "monthly",
+				StartAt:      startAt,
+			})
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(schedule)
+			} else {
+				suite.NoError(err)
+				suite.Equal("<ScheduleID>", schedule.ID)
+				suite.Equal("active", schedule.Status)
+				suite.Equal(startAt, *schedule.NextRunAt)
+			}
+			suite.sqlMock.ExpectationsWereMet()
+		})
+	}
+}
+
+func (suite *ScheduleRepositoryTestSuite) TestUpdate() {
+	startAt := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	maxRuns := 2
+
+	suite.sqlMock.ExpectBegin()
+	suite.sqlMock.ExpectQuery(lockScheduleQuery).
+		WithArgs("<ScheduleID>", "<UserID>", 1).
+		WillReturnRows(sqlmock.NewRows(scheduleColumns).
+			AddRow("<ScheduleID>", "<UserID>", "<FromWalletID>", "<ToWalletID>", "25.00", "daily", startAt, nil, 2, startAt.AddDate(0, 0, 2), "active"))
+	suite.sqlMock.ExpectQuery(`SELECT \* FROM "wallets" WHERE "wallets"\."id" = \$1 ORDER BY "wallets"\."id" LIMIT \$2`).
+		WithArgs("<FromWalletID>", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "currency"}).AddRow("<FromWalletID>", "THB"))
+	suite.sqlMock.ExpectExec(`UPDATE "schedules" SET "amount"=\$1,"end_at"=\$2,"max_runs"=\$3,"next_run_at"=\$4,"status"=\$5,"updated_at"=NOW\(\) WHERE "schedules"\."id" = \$6`).
+		WithArgs("30.00", nil, 2, nil, "completed", "<ScheduleID>").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.sqlMock.ExpectCommit()
+
+	schedule, err := suite.scheduleRepo.Update("<UserID>", "<ScheduleID>", money.MustParse("30"), nil, &maxRuns)
+
+	suite.NoError(err)
+	suite.Equal("completed", schedule.Status)
+	suite.Nil(schedule.NextRunAt)
+	suite.NoError(suite.sqlMock.ExpectationsWereMet())
+}
+
+func (suite *ScheduleRepositoryTestSuite) TestCancel() {
+	startAt := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		status      string
+		mock        func(sqlmock.Sqlmock)
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenActiveSchedule_WhenCancel_ThenCancelled",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockScheduleQuery).
+					WithArgs("<ScheduleID>", "<UserID>", 1).
+					WillReturnRows(sqlmock.NewRows(scheduleColumns).
+						AddRow("<ScheduleID>", "<UserID>", "<FromWalletID>", "<ToWalletID>", "25.00", "weekly", startAt, nil, 0, startAt, "active"))
+				mock.ExpectExec(`UPDATE "schedules" SET "next_run_at"=\$1,"status"=\$2,"updated_at"=NOW\(\) WHERE "schedules"\."id" = \$3`).
+					WithArgs(nil, "cancelled", "<ScheduleID>").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "GivenCompletedSchedule_WhenCancel_ThenScheduleNotActive",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockScheduleQuery).
+					WithArgs("<ScheduleID>", "<UserID>", 1).
+					WillReturnRows(sqlmock.NewRows(scheduleColumns).
+						AddRow("<ScheduleID>", "<UserID>", "<FromWalletID>", "<ToWalletID>", "25.00", "once", startAt, nil, 1, nil, "completed"))
+				mock.ExpectRollback()
+			},
+			wantErr:     true,
+			expectedErr: "schedule is not active",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+			schedule, err := suite.scheduleRepo.Cancel("<UserID>", "<ScheduleID>")
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(schedule)
+			} else {
+				suite.NoError(err)
+				suite.Equal("cancelled", schedule.Status)
+				suite.Nil(schedule.NextRunAt)
+			}
+			suite.sqlMock.ExpectationsWereMet()
+		})
+	}
+}
+
+func (suite *ScheduleRepositoryTestSuite) TestRunDue() {
+	runAt := time.Date(2030, 1, 31, 9, 0, 0, 0, time.UTC)
+	nextRunAt := time.Date(2030, 2, 28, 9, 0, 0, 0, time.UTC)
+	transactionId := "<TransactionID>"
+
+	suite.sqlMock.ExpectBegin()
+	suite.sqlMock.ExpectQuery(claimDueSchedule).
+		WithArgs("active", 1).
+		WillReturnRows(sqlmock.NewRows(scheduleColumns).
+			AddRow("<ScheduleID>", "<UserID>", "<FromWalletID>", "<ToWalletID>", "25.00", "monthly", runAt, nil, 0, runAt, "active"))
+	suite.sqlMock.ExpectQuery(`INSERT INTO "schedule_runs"`).
+		WithArgs("<ScheduleID>", runAt, "succeeded", transactionId, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("<RunID>", nil))
+	suite.sqlMock.ExpectExec(`UPDATE "schedules" SET "next_run_at"=\$1,"run_count"=\$2,"status"=\$3,"updated_at"=NOW\(\) WHERE "schedules"\."id" = \$4`).
+		WithArgs(nextRunAt, 1, "active", "<ScheduleID>").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.sqlMock.ExpectCommit()
+	suite.sqlMock.ExpectBegin()
+	suite.sqlMock.ExpectQuery(claimDueSchedule).
+		WithArgs("active", 1).
+		WillReturnRows(sqlmock.NewRows(scheduleColumns))
+	suite.sqlMock.ExpectCommit()
+
+	var executed []entity.Schedule
+	processed, err := suite.scheduleRepo.RunDue(10, func(schedule entity.Schedule) entity.ScheduleRun {
+		executed = append(executed, schedule)
+		return entity.ScheduleRun{Status: "succeeded", TransactionID: &transactionId}
+	})
+
+	suite.NoError(err)
+	suite.Equal(1, processed)
+	suite.Len(executed, 1)
+	suite.Equal("<UserID>", executed[0].UserID)
+	suite.NoError(suite.sqlMock.ExpectationsWereMet())
+}
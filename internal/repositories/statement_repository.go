@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"log"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockgen -source=./statement_repository.go -destination=./mocks/mock_statement_repository.go -package=mock_repositories
+type StatementRepository interface {
+	FindByPeriod(walletId string, periodStart time.Time) (*entity.Statement, error)
+	Create(statement entity.Statement) (*entity.Statement, error)
+}
+
+type statementRepository struct {
+	db *gorm.DB
+}
+
+func NewStatementRepository(db *gorm.DB) StatementRepository {
+	return &statementRepository{db: db}
+}
+
+func (r *statementRepository) FindByPeriod(walletId string, periodStart time.Time) (*entity.Statement, error) {
+	var statement entity.Statement
+	if err := r.db.Where(&entity.Statement{WalletID: walletId, PeriodStart: periodStart}).
+		First(&statement).Error; err != nil {
+		return nil, err
+	}
+	return &statement, nil
+}
+
+// Create stores a closed period's statement. If another request stored the
+// same period first, that statement is kept and returned instead, so every
+// caller sees the same snapshot.
+func (r *statementRepository) Create(statement entity.Statement) (*entity.Statement, error) {
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&statement).Error; err != nil {
+		log.Printf("Create statement error: %v", err)
+		return nil, err
+	}
+
+	stored, err := r.FindByPeriod(statement.WalletID, statement.PeriodStart)
+	if err != nil {
+		log.Printf("Reload statement error: %v", err)
+		return nil, err
+	}
+	return stored, nil
+}
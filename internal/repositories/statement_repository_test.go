@@ -0,0 +1,131 @@
+package repositories_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"gorm.io/gorm"
+)
+
+func (suite *StatementRepositoryTestSuite) TestFindByPeriod() {
+	march := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		mock        func(sqlmock.Sqlmock)
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "GivenStoredStatement_WhenFindByPeriod_ThenReturnStatement",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "statements" WHERE "statements"\."wallet_id" = \$1 AND "statements"\."period_start" = \$2 ORDER BY "statements"\."id" LIMIT \$3`).
+					WithArgs("<WalletID>", march, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "wallet_id", "period_start", "closing_balance", "lines"}).
+						AddRow("<StatementID>", "<WalletID>", march, "12.50", `[{"type":"deposit","amount":"12.50","balanceAfter":"12.50","createdAt":"2024-03-02T00:00:00Z"}]`))
+			},
+		},
+		{
+			name: "GivenNoStatement_WhenFindByPeriod_ThenNotFound",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "statements"`).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+			wantErr:     true,
+			expectedErr: gorm.ErrRecordNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+			statement, err := suite.statementRepo.FindByPeriod("<WalletID>", march)
+			if tc.wantErr {
+				suite.ErrorIs(err, tc.expectedErr)
+				suite.Nil(statement)
+			} else {
+				suite.NoError(err)
+				suite.Equal(money.MustParse("12.50"), statement.ClosingBalance)
+				suite.Len(statement.Lines, 1)
+				suite.Equal(money.MustParse("12.50"), statement.Lines[0].BalanceAfter)
+			}
+			suite.NoError(suite.sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (suite *StatementRepositoryTestSuite) TestCreate() {
+	march := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	statement := entity.Statement{
+		WalletID:       "<WalletID>",
+		PeriodStart:    march,
+		PeriodEnd:      march.AddDate(0, 1, 0),
+		Currency:       "THB",
+		ClosingBalance: money.MustParse("12.50"),
+		Lines:          entity.StatementLines{},
+	}
+
+	testCases := []struct {
+		name        string
+		mock        func(sqlmock.Sqlmock)
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenNewPeriod_WhenCreate_ThenReturnStoredStatement",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "statements" .* ON CONFLICT DO NOTHING RETURNING "id"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("<StatementID>"))
+				mock.ExpectCommit()
+				mock.ExpectQuery(`SELECT \* FROM "statements" WHERE "statements"\."wallet_id" = \$1 AND "statements"\."period_start" = \$2`).
+					WithArgs("<WalletID>", march, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "wallet_id", "period_start", "closing_balance", "lines"}).
+						AddRow("<StatementID>", "<WalletID>", march, "12.50", `[]`))
+			},
+		},
+		{
+			name: "GivenPeriodStoredConcurrently_WhenCreate_ThenReturnExistingStatement",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "statements" .* ON CONFLICT DO NOTHING RETURNING "id"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectCommit()
+				mock.ExpectQuery(`SELECT \* FROM "statements"`).
+					WithArgs("<WalletID>", march, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "wallet_id", "period_start", "closing_balance", "lines"}).
+						AddRow("<StatementID>", "<WalletID>", march, "12.50", `[]`))
+			},
+		},
+		{
+			name: "GivenInsertFails_WhenCreate_ThenError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "statements"`).
+					WillReturnError(errors.New("insert error"))
+				mock.ExpectRollback()
+			},
+			wantErr:     true,
+			expectedErr: "insert error",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+			stored, err := suite.statementRepo.Create(statement)
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(stored)
+			} else {
+				suite.NoError(err)
+				suite.Equal("<StatementID>", stored.ID)
+				suite.Equal(money.MustParse("12.50"), stored.ClosingBalance)
+			}
+			suite.NoError(suite.sqlMock.ExpectationsWereMet())
+		})
+	}
+}
@@ -1,13 +1,16 @@
 package repositories
 
 import (
-	"fmt"
+	"errors"
 	"log"
-	"math/rand"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/aarondl/null/v9"
 	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/idgen"
+	"github.com/slilp/go-wallet/internal/money"
 	"github.com/slilp/go-wallet/internal/repositories/entity"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -15,142 +18,727 @@ import (
 
 //go:generate mockgen -source=./transaction_repository.go -destination=./mocks/mock_transaction_repository.go -package=mock_repositories
 type TransactionRepository interface {
-	UpdateBalanceTransaction(userId, walletId string, amount float64) error
-	UpdateTransferTransaction(userId, from, to string, amount float64) error
-	List(walletId string, page, limit int) ([]entity.Transaction, error)
-	CountByWalletId(walletId string) (int64, error)
+	UpdateBalanceTransaction(userId, walletId string, amount money.Amount, details entity.TransactionDetails, idempotency *IdempotencyKey, check LimitCheck, fee *Fee) (*entity.Transaction, error)
+	UpdateTransferTransaction(userId, from, to string, amount money.Amount, details entity.TransactionDetails, idempotency *IdempotencyKey, check LimitCheck, fee *Fee) (*entity.Transaction, error)
+	UpdateBatchTransferTransaction(userId, from string, legs []BatchTransferLeg, allOrNothing bool) ([]BatchTransferResult, error)
+	ReverseTransaction(userId, transactionId string, amount *money.Amount, details entity.TransactionDetails, idempotency *IdempotencyKey) (*entity.Transaction, error)
+	List(walletId string, filter TransactionFilter, cursor *TransactionCursor, limit int) ([]entity.Transaction, bool, error)
+	Stream(walletId string, filter TransactionFilter, fn func(entity.Transaction) error) error
+	CountByWalletId(walletId string, filter TransactionFilter) (int64, error)
+	BalanceAt(walletId string, at time.Time) (money.Amount, error)
+	ListStatementLines(walletId string, from, to time.Time) ([]entity.StatementLine, error)
+	BackfillBalanceAfter(afterId string, limit int) (string, int, error)
+}
+
+const (
+	TransactionDirectionIn  = "in"
+	TransactionDirectionOut = "out"
+)
+
+const (
+	TransactionSortNewest   = "newest"
+	TransactionSortOldest   = "oldest"
+	TransactionSortLargest  = "largest"
+	TransactionSortSmallest = "smallest"
+)
+
+// TransactionFilter narrows List, Stream and CountByWalletId. Nil fields
+// match everything. CreatedFrom is inclusive and CreatedTo exclusive; amount
+// bounds are inclusive and, like the amount sorts, compare the absolute
+// amount since withdrawals are stored negative. Sort only affects List and
+// Stream and defaults to newest first.
+type TransactionFilter struct {
+	Reference            *string
+	Type                 *string
+	Direction            *string
+	CreatedFrom          *time.Time
+	CreatedTo            *time.Time
+	MinAmount            *money.Amount
+	MaxAmount            *money.Amount
+	CounterpartyWalletID *string
+	Memo                 *string
+	Sort                 string
+}
+
+func (f TransactionFilter) apply(db *gorm.DB, walletId string) *gorm.DB {
+	direction := null.StringFromPtr(f.Direction).String
+	switch {
+	case direction == TransactionDirectionIn:
+		db = db.Where(`"to" = ?`, walletId)
+		if f.CounterpartyWalletID != nil {
+			db = db.Where(`"from" = ?`, *f.CounterpartyWalletID)
+		}
+	case direction == TransactionDirectionOut:
+		db = db.Where(`"from" = ?`, walletId)
+		if f.CounterpartyWalletID != nil {
+			db = db.Where(`"to" = ?`, *f.CounterpartyWalletID)
+		}
+	case f.CounterpartyWalletID != nil:
+		db = db.Where(`("from" = ? AND "to" = ?) OR ("from" = ? AND "to" = ?)`,
+			walletId, *f.CounterpartyWalletID, *f.CounterpartyWalletID, walletId)
+	default:
+		db = db.Where(`"from" = ? OR "to" = ?`, walletId, walletId)
+	}
+
+	if f.Reference != nil {
+		db = db.Where(&entity.Transaction{Details: entity.TransactionDetails{Reference: f.Reference}})
+	}
+	if f.Type != nil {
+		db = db.Where(&entity.Transaction{Type: *f.Type})
+	}
+	if f.CreatedFrom != nil {
+		db = db.Where("created_at >= ?", *f.CreatedFrom)
+	}
+	if f.CreatedTo != nil {
+		db = db.Where("created_at < ?", *f.CreatedTo)
+	}
+	if f.MinAmount != nil {
+		db = db.Where("ABS(amount) >= ?", *f.MinAmount)
+	}
+	if f.MaxAmount != nil {
+		db = db.Where("ABS(amount) <= ?", *f.MaxAmount)
+	}
+	if f.Memo != nil {
+		db = db.Where("description ILIKE ?", "%"+escapeLike(*f.Memo)+"%")
+	}
+	return db
+}
+
+// TransactionCursor is the position of a transaction in a sorted listing.
+// List returns the page after it, or the page before it when Before is set.
+// Amount is the absolute amount and is only compared for the amount sorts.
+type TransactionCursor struct {
+	CreatedAt time.Time
+	ID        string
+	Amount    money.Amount
+	Before    bool
+}
+
+// sortKey returns the columns a listing is ordered by, most significant
+// first, and whether they descend. The trailing id makes the order total so
+// keyset pages never skip or repeat rows.
+func (f TransactionFilter) sortKey() ([]string, bool) {
+	switch f.Sort {
+	case TransactionSortOldest:
+		return []string{"created_at", "id"}, false
+	case TransactionSortLargest:
+		return []string{"ABS(amount)", "created_at", "id"}, true
+	case TransactionSortSmallest:
+		return []string{"ABS(amount)", "created_at", "id"}, false
+	default:
+		return []string{"created_at", "id"}, true
+	}
+}
+
+func (f TransactionFilter) paginate(db *gorm.DB, cursor *TransactionCursor) *gorm.DB {
+	columns, descending := f.sortKey()
+	if cursor != nil && cursor.Before {
+		descending = !descending
+	}
+
+	direction, comparison := " ASC", ">"
+	if descending {
+		direction, comparison = " DESC", "<"
+	}
+
+	if cursor != nil {
+		values := []interface{}{cursor.CreatedAt, cursor.ID}
+		if len(columns) == 3 {
+			values = append([]interface{}{cursor.Amount}, values...)
+		}
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", ")
+		db = db.Where("("+strings.Join(columns, ", ")+") "+comparison+" ("+placeholders+")", values...)
+	}
+
+	order := make([]string, len(columns))
+	for i, column := range columns {
+		order[i] = column + direction
+	}
+	return db.Order(strings.Join(order, ", "))
+}
+
+// escapeLike makes user input match literally inside a LIKE pattern.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// BatchTransferLeg is one destination of a batch transfer, with the limit
+// check and fee resolved for it.
+type BatchTransferLeg struct {
+	To      string
+	Amount  money.Amount
+	Details entity.TransactionDetails
+	Check   LimitCheck
+	Fee     *Fee
+}
+
+// BatchTransferResult is the outcome of one leg: the transfer it created, or
+// why it was skipped in best-effort mode.
+type BatchTransferResult struct {
+	Transaction *entity.Transaction
+	Err         error
 }
 
 type transactionRepository struct {
-	db *gorm.DB
+	db  *gorm.DB
+	ids idgen.Generator
 }
 
-func NewTransactionRepository(db *gorm.DB) TransactionRepository {
-	return &transactionRepository{db: db}
+func NewTransactionRepository(db *gorm.DB, ids idgen.Generator) TransactionRepository {
+	return &transactionRepository{db: db, ids: ids}
 }
 
-func (r *transactionRepository) UpdateTransferTransaction(userId, from, to string, amount float64) error {
-	if err := r.db.Transaction(func(tx *gorm.DB) error {
+func (r *transactionRepository) UpdateTransferTransaction(userId, from, to string, amount money.Amount, details entity.TransactionDetails, idempotency *IdempotencyKey, check LimitCheck, fee *Fee) (*entity.Transaction, error) {
+	var result *entity.Transaction
+	if err := runInTransaction(r.db, func(tx *gorm.DB) error {
+		if idempotency != nil {
+			original, err := claimIdempotencyKey(tx, userId, idempotency)
+			if err != nil {
+				return err
+			}
+			if original != nil {
+				result = original
+				return nil
+			}
+		}
 
-		var fromWallet entity.Wallet
-		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
-			Where(&entity.Wallet{ID: from, UserID: userId}).
-			First(&fromWallet).Error; err != nil {
-			log.Printf("Failed to lock (from) wallet: %v", err)
+		wallets, err := lockWallets(tx, from, to, fee.walletID())
+		if err != nil {
 			return err
 		}
+		fromWallet, ok := wallets[from]
+		if !ok || fromWallet.UserID != userId {
+			log.Printf("Wallet %s not found for user %s", from, userId)
+			return gorm.ErrRecordNotFound
+		}
+		toWallet, ok := wallets[to]
+		if !ok {
+			log.Printf("Wallet %s not found", to)
+			return gorm.ErrRecordNotFound
+		}
 
-		if fromWallet.Balance < amount {
-			log.Printf("Insufficient balance: wallet %s has %.2f, attempted %.2f", from, fromWallet.Balance, amount)
-			return consts.ErrInsufficientBalance
+		if check != nil {
+			if err := check(fromWallet, newLimitUsage(tx, fromWallet)); err != nil {
+				return err
+			}
 		}
 
-		var toWallet entity.Wallet
-		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
-			Where(&entity.Wallet{ID: to}).
-			First(&toWallet).Error; err != nil {
-			log.Printf("Failed to lock (to) wallet: %v", err)
+		txRecord, err := transferFunds(tx, r.ids, fromWallet, toWallet, amount, details, fee, wallets[fee.walletID()])
+		if err != nil {
 			return err
 		}
 
-		if err := tx.Model(&entity.Wallet{}).
-			Where(&entity.Wallet{ID: from}).
-			UpdateColumn("balance", gorm.Expr("balance - ?", amount)).Error; err != nil {
-			log.Printf("UpdateTransferBalance (from) error: %v", err)
-			return err
+		if idempotency != nil {
+			if err := completeIdempotencyKey(tx, userId, idempotency, txRecord.ID); err != nil {
+				return err
+			}
 		}
 
-		if err := tx.Model(&entity.Wallet{}).
-			Where(&entity.Wallet{ID: to}).
-			UpdateColumn("balance", gorm.Expr("balance + ?", amount)).Error; err != nil {
-			log.Printf("UpdateTransferBalance (to) error: %v", err)
+		result = txRecord
+		return nil
+	}); err != nil {
+		log.Printf("UpdateTransferBalance transaction error: %v", err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateBatchTransferTransaction pays every leg from one wallet in a single
+// DB transaction, locking the source and all destinations once up front. With
+// allOrNothing the total, fees included, is checked against the available
+// balance first and the first failing leg aborts the batch with a
+// *consts.BatchLegError. Otherwise each leg runs in a savepoint and failures
+// are reported in its result while the other legs commit.
+func (r *transactionRepository) UpdateBatchTransferTransaction(userId, from string, legs []BatchTransferLeg, allOrNothing bool) ([]BatchTransferResult, error) {
+	var results []BatchTransferResult
+	if err := runInTransaction(r.db, func(tx *gorm.DB) error {
+		lockIds := []string{from}
+		for _, leg := range legs {
+			lockIds = append(lockIds, leg.To, leg.Fee.walletID())
+		}
+		wallets, err := lockWallets(tx, lockIds...)
+		if err != nil {
 			return err
 		}
+		fromWallet, ok := wallets[from]
+		if !ok || fromWallet.UserID != userId {
+			log.Printf("Wallet %s not found for user %s", from, userId)
+			return gorm.ErrRecordNotFound
+		}
 
-		txRecord := entity.Transaction{
-			ID:     generateTransactionId(),
-			From:   null.StringFrom(from).Ptr(),
-			To:     null.StringFrom(to).Ptr(),
-			Amount: amount,
-			Type:   "transfer",
+		if allOrNothing {
+			var total money.Amount
+			for _, leg := range legs {
+				total += leg.Amount + leg.Fee.amount()
+			}
+			available, err := availableBalance(tx, fromWallet)
+			if err != nil {
+				return err
+			}
+			if available < total {
+				log.Printf("Insufficient balance: wallet %s has %s available, batch needs %s", from, available, total)
+				return consts.ErrInsufficientBalance
+			}
 		}
-		if err := tx.Create(&txRecord).Error; err != nil {
-			log.Printf("Create transfer transaction error: %v", err)
-			return err
+
+		legResults := make([]BatchTransferResult, len(legs))
+		for i, leg := range legs {
+			var txRecord *entity.Transaction
+			pay := func(tx *gorm.DB) error {
+				toWallet, ok := wallets[leg.To]
+				if !ok {
+					log.Printf("Wallet %s not found", leg.To)
+					return gorm.ErrRecordNotFound
+				}
+				if leg.Check != nil {
+					if err := leg.Check(fromWallet, newLimitUsage(tx, fromWallet)); err != nil {
+						return err
+					}
+				}
+				var err error
+				txRecord, err = transferFunds(tx, r.ids, fromWallet, toWallet, leg.Amount, leg.Details, leg.Fee, wallets[leg.Fee.walletID()])
+				return err
+			}
+
+			if allOrNothing {
+				if err := pay(tx); err != nil {
+					return &consts.BatchLegError{Index: i, Err: err}
+				}
+			} else if err := tx.Transaction(pay); err != nil {
+				if isRetryableTransactionError(err) {
+					return err
+				}
+				log.Printf("Batch leg %d from wallet %s failed: %v", i, from, err)
+				legResults[i].Err = err
+				continue
+			}
+
+			// The locked row is not re-read, so keep its balance in step for
+			// the next leg's available-balance check.
+			fromWallet.Balance -= leg.Amount + leg.Fee.amount()
+			legResults[i].Transaction = txRecord
 		}
+
+		results = legResults
 		return nil
 	}); err != nil {
-		log.Printf("UpdateTransferBalance transaction error: %v", err)
-		return err
+		log.Printf("Batch transfer transaction error: %v", err)
+		return nil, err
 	}
-	return nil
+	return results, nil
 }
 
-func (r *transactionRepository) UpdateBalanceTransaction(userId, walletId string, amount float64) error {
-	if err := r.db.Transaction(func(tx *gorm.DB) error {
-		var lockWallet entity.Wallet
-		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
-			Where(&entity.Wallet{ID: walletId, UserID: userId}).
-			First(&lockWallet).Error; err != nil {
-			log.Printf("Failed to lock wallet: %v", err)
+// transferFunds moves amount out of fromWallet into toWallet, converting
+// between currencies when they differ, records details on the transfer and
+// charges fee into feeWallet when set. Both wallets, and the fee wallet, must
+// already be locked with lockWallets. Transfers and hold captures share it so both
+// enforce the same available-balance and FX rules.
+func transferFunds(tx *gorm.DB, ids idgen.Generator, fromWallet, toWallet entity.Wallet, amount money.Amount, details entity.TransactionDetails, fee *Fee, feeWallet entity.Wallet) (*entity.Transaction, error) {
+	if err := ensureCanDebit(fromWallet); err != nil {
+		return nil, err
+	}
+	if err := ensureCanCredit(toWallet); err != nil {
+		return nil, err
+	}
+
+	if !fromWallet.Currency.Allows(amount) {
+		log.Printf("Amount %s exceeds %s minor units", amount, fromWallet.Currency)
+		return nil, consts.ErrAmountScaleExceeded
+	}
+
+	available, err := availableBalance(tx, fromWallet)
+	if err != nil {
+		return nil, err
+	}
+	if available < amount+fee.amount() {
+		log.Printf("Insufficient balance: wallet %s has %s available, attempted %s plus %s fee", fromWallet.ID, available, amount, fee.amount())
+		return nil, consts.ErrInsufficientBalance
+	}
+
+	creditAmount := amount
+	var appliedRate *money.Rate
+	if fromWallet.Currency != toWallet.Currency {
+		var exchangeRate entity.ExchangeRate
+		if err := tx.Clauses(clause.Locking{Strength: "SHARE"}).
+			Where(&entity.ExchangeRate{BaseCurrency: fromWallet.Currency, QuoteCurrency: toWallet.Currency}).
+			First(&exchangeRate).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				log.Printf("No exchange rate from %s to %s", fromWallet.Currency, toWallet.Currency)
+				return nil, &consts.CurrencyMismatchError{FromCurrency: fromWallet.Currency.String(), ToCurrency: toWallet.Currency.String()}
+			}
+			log.Printf("Failed to load exchange rate: %v", err)
+			return nil, err
+		}
+
+		rate := exchangeRate.EffectiveRate()
+		converted, err := amount.Convert(rate, toWallet.Currency)
+		if err != nil {
+			return nil, err
+		}
+		if converted <= 0 {
+			log.Printf("Converted amount of %s %s is below one %s minor unit", amount, fromWallet.Currency, toWallet.Currency)
+			return nil, consts.ErrConvertedAmountTooSmall
+		}
+
+		creditAmount = converted
+		appliedRate = &rate
+	}
+
+	txRecord := entity.Transaction{
+		ID:      ids.NewID(),
+		From:    null.StringFrom(fromWallet.ID).Ptr(),
+		To:      null.StringFrom(toWallet.ID).Ptr(),
+		Amount:  amount,
+		Type:    "transfer",
+		Details: details,
+	}
+	if appliedRate != nil {
+		txRecord.CreditedAmount = &creditAmount
+		txRecord.ExchangeRate = appliedRate
+	}
+	if err := tx.Create(&txRecord).Error; err != nil {
+		log.Printf("Create transfer transaction error: %v", err)
+		return nil, err
+	}
+
+	lines := []ledgerLine{
+		debitWallet(fromWallet.ID, fromWallet.Currency, amount),
+		creditWallet(toWallet.ID, toWallet.Currency, creditAmount),
+	}
+	if appliedRate != nil {
+		lines = []ledgerLine{
+			debitWallet(fromWallet.ID, fromWallet.Currency, amount),
+			creditSystem(ledgerAccountFx, fromWallet.Currency, amount),
+			debitSystem(ledgerAccountFx, toWallet.Currency, creditAmount),
+			creditWallet(toWallet.ID, toWallet.Currency, creditAmount),
+		}
+	}
+	if err := postJournalEntry(tx, &txRecord, lines); err != nil {
+		return nil, err
+	}
+
+	if fee != nil {
+		if err := chargeFee(tx, ids, fromWallet, feeWallet, &txRecord, fee); err != nil {
+			return nil, err
+		}
+	}
+
+	return &txRecord, nil
+}
+
+func (r *transactionRepository) UpdateBalanceTransaction(userId, walletId string, amount money.Amount, details entity.TransactionDetails, idempotency *IdempotencyKey, check LimitCheck, fee *Fee) (*entity.Transaction, error) {
+	var result *entity.Transaction
+	if err := runInTransaction(r.db, func(tx *gorm.DB) error {
+		if idempotency != nil {
+			original, err := claimIdempotencyKey(tx, userId, idempotency)
+			if err != nil {
+				return err
+			}
+			if original != nil {
+				result = original
+				return nil
+			}
+		}
+
+		wallets, err := lockWallets(tx, walletId, fee.walletID())
+		if err != nil {
+			return err
+		}
+		lockWallet, ok := wallets[walletId]
+		if !ok || lockWallet.UserID != userId {
+			log.Printf("Wallet %s not found for user %s", walletId, userId)
+			return gorm.ErrRecordNotFound
+		}
+
+		if amount < 0 {
+			err = ensureCanDebit(lockWallet)
+		} else {
+			err = ensureCanCredit(lockWallet)
+		}
+		if err != nil {
 			return err
 		}
 
+		if !lockWallet.Currency.Allows(amount) {
+			log.Printf("Amount %s exceeds %s minor units", amount, lockWallet.Currency)
+			return consts.ErrAmountScaleExceeded
+		}
+
+		if check != nil {
+			if err := check(lockWallet, newLimitUsage(tx, lockWallet)); err != nil {
+				return err
+			}
+		}
+
 		txRecord := entity.Transaction{
-			ID:     generateTransactionId(),
-			To:     null.StringFrom(walletId).Ptr(),
-			Amount: amount,
-			Type:   "deposit",
+			ID:      r.ids.NewID(),
+			To:      null.StringFrom(walletId).Ptr(),
+			Amount:  amount,
+			Type:    "deposit",
+			Details: details,
+		}
+		lines := []ledgerLine{
+			debitSystem(ledgerAccountTreasury, lockWallet.Currency, amount),
+			creditWallet(walletId, lockWallet.Currency, amount),
 		}
 
 		if amount < 0 {
-			if lockWallet.Balance < -amount {
-				log.Printf("Insufficient balance: wallet %s has %.2f, attempted %.2f", walletId, lockWallet.Balance, amount)
+			available, err := availableBalance(tx, lockWallet)
+			if err != nil {
+				return err
+			}
+			if available < amount.Neg()+fee.amount() {
+				log.Printf("Insufficient balance: wallet %s has %s available, attempted %s plus %s fee", walletId, available, amount, fee.amount())
 				return consts.ErrInsufficientBalance
 			}
 
 			txRecord.To = nil
 			txRecord.From = null.StringFrom(walletId).Ptr()
 			txRecord.Type = "withdraw"
+			lines = []ledgerLine{
+				debitWallet(walletId, lockWallet.Currency, amount.Neg()),
+				creditSystem(ledgerAccountTreasury, lockWallet.Currency, amount.Neg()),
+			}
 		}
 
-		if err := tx.Model(&entity.Wallet{}).
-			Where(&entity.Wallet{ID: walletId}).
-			UpdateColumn("balance", gorm.Expr("balance + ?", amount)).Error; err != nil {
-			log.Printf("UpdateBalance error: %v", err)
+		if err := tx.Create(&txRecord).Error; err != nil {
+			log.Printf("Create %s transaction error: %v", txRecord.Type, err)
 			return err
 		}
 
+		if err := postJournalEntry(tx, &txRecord, lines); err != nil {
+			return err
+		}
+
+		if fee != nil && amount < 0 {
+			if err := chargeFee(tx, r.ids, lockWallet, wallets[fee.WalletID], &txRecord, fee); err != nil {
+				return err
+			}
+		}
+
+		if idempotency != nil {
+			if err := completeIdempotencyKey(tx, userId, idempotency, txRecord.ID); err != nil {
+				return err
+			}
+		}
+
+		result = &txRecord
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ReverseTransaction refunds a deposit or transfer, in full when amount is nil.
+// The refund is taken from the wallet the original credited, which must belong
+// to userId, and amount is expressed in the original (debited) currency.
+func (r *transactionRepository) ReverseTransaction(userId, transactionId string, amount *money.Amount, details entity.TransactionDetails, idempotency *IdempotencyKey) (*entity.Transaction, error) {
+	var result *entity.Transaction
+	if err := runInTransaction(r.db, func(tx *gorm.DB) error {
+		if idempotency != nil {
+			original, err := claimIdempotencyKey(tx, userId, idempotency)
+			if err != nil {
+				return err
+			}
+			if original != nil {
+				result = original
+				return nil
+			}
+		}
+
+		var original entity.Transaction
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(&entity.Transaction{ID: transactionId}).
+			First(&original).Error; err != nil {
+			log.Printf("Failed to lock original transaction: %v", err)
+			return err
+		}
+
+		if (original.Type != "deposit" && original.Type != "transfer") || original.To == nil {
+			log.Printf("Transaction %s of type %s cannot be reversed", original.ID, original.Type)
+			return consts.ErrTransactionNotReversible
+		}
+
+		wallets, err := lockWallets(tx, *original.To, null.StringFromPtr(original.From).String)
+		if err != nil {
+			return err
+		}
+		payerWallet, ok := wallets[*original.To]
+		if !ok || payerWallet.UserID != userId {
+			log.Printf("Wallet %s not found for user %s", *original.To, userId)
+			return gorm.ErrRecordNotFound
+		}
+		if err := ensureCanDebit(payerWallet); err != nil {
+			return err
+		}
+
+		remaining := original.Amount - original.RefundedAmount
+		refund := remaining
+		if amount != nil {
+			refund = *amount
+		}
+		if refund <= 0 || refund > remaining {
+			log.Printf("Refund %s exceeds remaining %s of transaction %s", refund, remaining, original.ID)
+			return consts.ErrRefundExceedsOriginal
+		}
+
+		// Prorate cumulatively so partial refunds of a converted transfer add up
+		// to exactly the credited amount once the transfer is fully refunded.
+		debit := refund
+		if original.CreditedAmount != nil {
+			refundedBefore, err := original.CreditedAmount.Prorate(original.RefundedAmount, original.Amount, payerWallet.Currency)
+			if err != nil {
+				return err
+			}
+			refundedAfter, err := original.CreditedAmount.Prorate(original.RefundedAmount+refund, original.Amount, payerWallet.Currency)
+			if err != nil {
+				return err
+			}
+			debit = refundedAfter - refundedBefore
+			if debit <= 0 {
+				log.Printf("Refund of %s converts to less than one %s minor unit", refund, payerWallet.Currency)
+				return consts.ErrConvertedAmountTooSmall
+			}
+		}
+
+		available, err := availableBalance(tx, payerWallet)
+		if err != nil {
+			return err
+		}
+		if available < debit {
+			log.Printf("Insufficient balance: wallet %s has %s available, refund needs %s", payerWallet.ID, available, debit)
+			return consts.ErrInsufficientBalance
+		}
+
+		txRecord := entity.Transaction{
+			ID:                    r.ids.NewID(),
+			From:                  original.To,
+			To:                    original.From,
+			Amount:                debit,
+			Type:                  "reversal",
+			OriginalTransactionID: &original.ID,
+			Details:               details,
+		}
+		lines := []ledgerLine{
+			debitWallet(payerWallet.ID, payerWallet.Currency, debit),
+			creditSystem(ledgerAccountTreasury, payerWallet.Currency, debit),
+		}
+
+		if original.From != nil {
+			payeeWallet, ok := wallets[*original.From]
+			if !ok {
+				log.Printf("Wallet %s not found", *original.From)
+				return gorm.ErrRecordNotFound
+			}
+			if err := ensureCanCredit(payeeWallet); err != nil {
+				return err
+			}
+
+			if !payeeWallet.Currency.Allows(refund) {
+				log.Printf("Refund %s exceeds %s minor units", refund, payeeWallet.Currency)
+				return consts.ErrAmountScaleExceeded
+			}
+
+			lines = []ledgerLine{
+				debitWallet(payerWallet.ID, payerWallet.Currency, debit),
+				creditWallet(payeeWallet.ID, payeeWallet.Currency, refund),
+			}
+			if original.CreditedAmount != nil {
+				txRecord.CreditedAmount = &refund
+				txRecord.ExchangeRate = original.ExchangeRate
+				lines = []ledgerLine{
+					debitWallet(payerWallet.ID, payerWallet.Currency, debit),
+					creditSystem(ledgerAccountFx, payerWallet.Currency, debit),
+					debitSystem(ledgerAccountFx, payeeWallet.Currency, refund),
+					creditWallet(payeeWallet.ID, payeeWallet.Currency, refund),
+				}
+			}
+		} else if !payerWallet.Currency.Allows(refund) {
+			log.Printf("Refund %s exceeds %s minor units", refund, payerWallet.Currency)
+			return consts.ErrAmountScaleExceeded
+		}
+
 		if err := tx.Create(&txRecord).Error; err != nil {
-			log.Printf("Create %s transaction error: %v", txRecord.Type, err)
+			log.Printf("Create reversal transaction error: %v", err)
+			return err
+		}
+
+		if err := tx.Model(&entity.Transaction{}).
+			Where(&entity.Transaction{ID: original.ID}).
+			UpdateColumn("refunded_amount", gorm.Expr("refunded_amount + ?", refund)).Error; err != nil {
+			log.Printf("Update refunded amount error: %v", err)
+			return err
+		}
+
+		if err := postJournalEntry(tx, &txRecord, lines); err != nil {
 			return err
 		}
+
+		if idempotency != nil {
+			if err := completeIdempotencyKey(tx, userId, idempotency, txRecord.ID); err != nil {
+				return err
+			}
+		}
+
+		result = &txRecord
 		return nil
 	}); err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+	return result, nil
 }
 
-func (r *transactionRepository) List(walletId string, page, limit int) ([]entity.Transaction, error) {
+// List returns up to limit transactions after the cursor, or from the start
+// when it is nil, in filter.Sort order. The bool reports whether more
+// transactions follow in the direction of travel.
+func (r *transactionRepository) List(walletId string, filter TransactionFilter, cursor *TransactionCursor, limit int) ([]entity.Transaction, bool, error) {
 	var transactions []entity.Transaction
-	offset := (page - 1) * limit
 
-	if err := r.db.Where(`"from" = ? OR "to" = ?`, walletId, walletId).
-		Offset(offset).Limit(limit).
-		Order("created_at DESC").
+	if err := filter.paginate(filter.apply(r.db, walletId), cursor).
+		Limit(limit + 1).
 		Find(&transactions).Error; err != nil {
 		log.Printf("List transactions error: %v", err)
-		return nil, err
+		return nil, false, err
+	}
+
+	hasMore := len(transactions) > limit
+	if hasMore {
+		transactions = transactions[:limit]
+	}
+	if cursor != nil && cursor.Before {
+		slices.Reverse(transactions)
 	}
+	return transactions, hasMore, nil
+}
+
+// Stream calls fn for every transaction matching filter, in sort order,
+// reading one row at a time so a long history never has to fit in memory.
+// It stops at the first error fn returns.
+func (r *transactionRepository) Stream(walletId string, filter TransactionFilter, fn func(entity.Transaction) error) error {
+	rows, err := filter.paginate(filter.apply(r.db.Model(&entity.Transaction{}), walletId), nil).Rows()
+	if err != nil {
+		log.Printf("Stream transactions error: %v", err)
+		return err
+	}
+	defer rows.Close()
 
-	return transactions, nil
+	for rows.Next() {
+		var tx entity.Transaction
+		if err := r.db.ScanRows(rows, &tx); err != nil {
+			log.Printf("Scan transaction error: %v", err)
+			return err
+		}
+		if err := fn(tx); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
 }
 
-func (r *transactionRepository) CountByWalletId(walletId string) (int64, error) {
+func (r *transactionRepository) CountByWalletId(walletId string, filter TransactionFilter) (int64, error) {
 	var count int64
-	if err := r.db.Model(&entity.Transaction{}).
-		Where(`"from" = ? OR "to" = ?`, walletId, walletId).
+	if err := filter.apply(r.db.Model(&entity.Transaction{}), walletId).
 		Count(&count).Error; err != nil {
 		log.Printf("Count transactions error: %v", err)
 		return 0, err
@@ -158,15 +746,84 @@ func (r *transactionRepository) CountByWalletId(walletId string) (int64, error)
 	return count, nil
 }
 
-func generateTransactionId() string {
-	unixTime := time.Now().Unix()
-
-	timePart := fmt.Sprintf("%010d", unixTime)
+// BalanceAt sums the wallet's ledger postings made before at, which is the
+// wallet balance at that instant.
+func (r *transactionRepository) BalanceAt(walletId string, at time.Time) (money.Amount, error) {
+	var balance money.Amount
+	if err := r.db.Model(&entity.Posting{}).
+		Select("COALESCE(SUM(CASE WHEN direction = ? THEN amount ELSE -amount END), 0)", postingCredit).
+		Where("account_id = ? AND created_at < ?", walletId, at).
+		Scan(&balance).Error; err != nil {
+		log.Printf("Balance at %s error: %v", at, err)
+		return 0, err
+	}
+	return balance, nil
+}
 
-	rand.Seed(time.Now().UnixNano())
-	randomPart := fmt.Sprintf("%07d", rand.Intn(10000000))
+// ListStatementLines returns one line per journal entry that touched the
+// wallet in [from, to), oldest first, with its signed effect on the balance.
+// BalanceAfter is left for the caller to fill in from the opening balance.
+func (r *transactionRepository) ListStatementLines(walletId string, from, to time.Time) ([]entity.StatementLine, error) {
+	var lines []entity.StatementLine
+	if err := r.db.Table("postings").
+		Select(`journal_entries.transaction_id,
+			COALESCE(transactions.type, 'adjustment') AS type,
+			COALESCE(transactions.description, journal_entries.description) AS description,
+			transactions.reference,
+			CASE WHEN transactions."from" = ? THEN transactions."to" ELSE transactions."from" END AS counterparty_wallet_id,
+			SUM(CASE WHEN postings.direction = ? THEN postings.amount ELSE -postings.amount END) AS amount,
+			MIN(postings.created_at) AS created_at`, walletId, postingCredit).
+		Joins("JOIN journal_entries ON journal_entries.id = postings.journal_entry_id").
+		Joins("LEFT JOIN transactions ON transactions.id = journal_entries.transaction_id").
+		Where("postings.account_id = ? AND postings.created_at >= ? AND postings.created_at < ?", walletId, from, to).
+		Group("postings.journal_entry_id, journal_entries.transaction_id, journal_entries.description, transactions.id").
+		Order("MIN(postings.created_at), MIN(postings.id)").
+		Scan(&lines).Error; err != nil {
+		log.Printf("List statement lines error: %v", err)
+		return nil, err
+	}
+	return lines, nil
+}
 
-	transactionID := fmt.Sprintf("TRN%s%s", timePart, randomPart)
+// BackfillBalanceAfter fills in missing balances for up to limit transactions
+// with ids after afterId, replaying each wallet's ledger postings. It returns
+// the last id looked at and how many were looked at, so callers can page
+// until that count drops below limit. Transactions written before the ledger
+// have no postings and keep NULL balances.
+func (r *transactionRepository) BackfillBalanceAfter(afterId string, limit int) (string, int, error) {
+	var ids []string
+	if err := r.db.Model(&entity.Transaction{}).
+		Where("id > ?", afterId).
+		Where(`(from_balance_after IS NULL AND "from" IS NOT NULL) OR (to_balance_after IS NULL AND "to" IS NOT NULL)`).
+		Order("id").
+		Limit(limit).
+		Pluck("id", &ids).Error; err != nil {
+		log.Printf("Find transactions to backfill error: %v", err)
+		return afterId, 0, err
+	}
+	if len(ids) == 0 {
+		return afterId, 0, nil
+	}
 
-	return transactionID
+	if err := r.db.Exec(`WITH batch AS (
+			SELECT "from", "to" FROM transactions WHERE id IN ?
+		), running AS (
+			SELECT journal_entries.transaction_id, postings.account_id,
+				SUM(CASE WHEN postings.direction = ? THEN postings.amount ELSE -postings.amount END)
+					OVER (PARTITION BY postings.account_id ORDER BY postings.id) AS balance,
+				ROW_NUMBER() OVER (PARTITION BY journal_entries.transaction_id, postings.account_id ORDER BY postings.id DESC) AS latest
+			FROM postings
+			JOIN journal_entries ON journal_entries.id = postings.journal_entry_id
+			WHERE postings.account_id IN (SELECT "from" FROM batch UNION SELECT "to" FROM batch)
+		)
+		UPDATE transactions SET
+			from_balance_after = COALESCE(from_balance_after, (SELECT balance FROM running
+				WHERE running.transaction_id = transactions.id AND running.account_id = transactions."from" AND running.latest = 1)),
+			to_balance_after = COALESCE(to_balance_after, (SELECT balance FROM running
+				WHERE running.transaction_id = transactions.id AND running.account_id = transactions."to" AND running.latest = 1))
+		WHERE transactions.id IN ?`, ids, postingCredit, ids).Error; err != nil {
+		log.Printf("Backfill balances after error: %v", err)
+		return afterId, 0, err
+	}
+	return ids[len(ids)-1], len(ids), nil
 }
@@ -2,8 +2,15 @@ package repositories_test
 
 import (
 	"errors"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aarondl/null/v9"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
 )
 
 func (suite *TransactionRepositoryTestSuite) TestUpdateBalanceTransaction() {
@@ -11,47 +18,232 @@ func (suite *TransactionRepositoryTestSuite) TestUpdateBalanceTransaction() {
 		name        string
 		mock        func(sqlmock.Sqlmock)
 		walletId    string
-		amount      float64
+		amount      money.Amount
+		details     entity.TransactionDetails
+		check       repositories.LimitCheck
+		fee         *repositories.Fee
 		wantErr     bool
 		expectedErr string
 	}{
 		{
-			name: "GivenPositiveAmount_WhenUpdateBalanceSuccess_ThenSuccess",
+			name: "GivenDetails_WhenUpdateBalanceSuccess_ThenDetailsPersisted",
 			mock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE "wallets"\."id" = \$1 AND "wallets"\."user_id" = \$2 ORDER BY "wallets"\."id" LIMIT \$3 FOR UPDATE`).
-					WithArgs("<WalletID>", "<UserID>", 1).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "balance"}).AddRow("<WalletID>", 100.0))
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1\) ORDER BY id FOR UPDATE`).
+					WithArgs("<WalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency"}).AddRow("<WalletID>", "<UserID>", 100.0, "THB"))
+				mock.ExpectQuery(`INSERT INTO "transactions" \("id","from","to","amount","type","credited_amount","exchange_rate","original_transaction_id","refunded_amount","from_balance_after","to_balance_after","parent_transaction_id","description","reference","metadata"\)`).
+					WithArgs("TRN000000000000000001", nil, "<WalletID>", "100.00", "deposit", nil, nil, nil, "0", nil, nil, nil, "Top up", "INV-1001", `{"orderId":"A-1"}`).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(nil, nil))
+				mock.ExpectQuery(`SELECT \* FROM "ledger_accounts" WHERE "ledger_accounts"\."code" = \$1 AND "ledger_accounts"\."currency" = \$2 ORDER BY "ledger_accounts"\."id" LIMIT \$3`).
+					WithArgs("treasury", "THB", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "code", "currency"}).AddRow("<TreasuryAccountID>", "treasury", "THB"))
+				mock.ExpectQuery(`INSERT INTO "journal_entries"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("<JournalEntryID>", nil))
+				mock.ExpectQuery(`INSERT INTO "postings"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, nil).AddRow(2, nil))
 				mock.ExpectExec(`UPDATE "wallets"`).
 					WithArgs(sqlmock.AnyArg(), "<WalletID>").
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectBalancesAfter(mock)
+				mock.ExpectCommit()
+			},
+			walletId: "<WalletID>",
+			amount:   money.MustParse("100"),
+			details: entity.TransactionDetails{
+				Description: null.StringFrom("Top up").Ptr(),
+				Reference:   null.StringFrom("INV-1001").Ptr(),
+				Metadata:    entity.Metadata{"orderId": "A-1"},
+			},
+			wantErr:     false,
+			expectedErr: "",
+		},
+		{
+			name: "GivenCreditBlockedWallet_WhenDeposit_ThenWalletBlockedError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1\) ORDER BY id FOR UPDATE`).
+					WithArgs("<WalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency", "status"}).AddRow("<WalletID>", "<UserID>", 100.0, "THB", "credit_blocked"))
+				mock.ExpectRollback()
+			},
+			walletId:    "<WalletID>",
+			amount:      money.MustParse("100"),
+			wantErr:     true,
+			expectedErr: "wallet <WalletID> is credit_blocked",
+		},
+		{
+			name: "GivenCreditBlockedWallet_WhenWithdraw_ThenNotBlocked",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1\) ORDER BY id FOR UPDATE`).
+					WithArgs("<WalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency", "status"}).AddRow("<WalletID>", "<UserID>", 100.0, "THB", "credit_blocked"))
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\) FROM "holds"`).
+					WithArgs("<WalletID>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("0.00"))
 				mock.ExpectQuery(`INSERT INTO "transactions"`).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "from_wallet_id", "to_wallet_id", "amount", "type", "created_at"}).
-						AddRow("<TransactionID>", nil, "<WalletID>", 100.0, "deposit", nil))
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(nil, nil))
+				mock.ExpectQuery(`SELECT \* FROM "ledger_accounts"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "code", "currency"}).AddRow("<TreasuryAccountID>", "treasury", "THB"))
+				mock.ExpectQuery(`INSERT INTO "journal_entries"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("<JournalEntryID>", nil))
+				mock.ExpectQuery(`INSERT INTO "postings"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, nil).AddRow(2, nil))
+				mock.ExpectExec(`UPDATE "wallets"`).
+					WithArgs(sqlmock.AnyArg(), "<WalletID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectBalancesAfter(mock)
 				mock.ExpectCommit()
 			},
 			walletId:    "<WalletID>",
-			amount:      100.0,
+			amount:      money.MustParse("-50"),
 			wantErr:     false,
 			expectedErr: "",
 		},
 		{
-			name: "GivenNegativeAmount_WhenUpdateBalanceSuccess_ThenSuccess",
+			name: "GivenEarlierWithdrawals_WhenWithdrawOverDailyCap_ThenLimitExceeded",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1\) ORDER BY id FOR UPDATE`).
+					WithArgs("<WalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency"}).AddRow("<WalletID>", "<UserID>", 5000.0, "THB"))
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(ABS\(amount\)\), 0\) FROM "transactions" WHERE "from" = \$1 AND type IN \(\$2,\$3\) AND created_at >= \$4`).
+					WithArgs("<WalletID>", "transfer", "withdraw", sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("1000.00"))
+				mock.ExpectRollback()
+			},
+			walletId: "<WalletID>",
+			amount:   money.MustParse("-1000"),
+			check: func(wallet entity.Wallet, usage repositories.LimitUsage) error {
+				debited, err := usage.WalletDebited(time.Now())
+				if err != nil {
+					return err
+				}
+				if debited+money.MustParse("1000") > money.MustParse("1000") {
+					return &consts.LimitExceededError{Limit: consts.LimitDailyWallet}
+				}
+				return nil
+			},
+			wantErr:     true,
+			expectedErr: "transaction limit exceeded: daily_wallet_max",
+		},
+		{
+			name: "GivenFrozenWallet_WhenWithdraw_ThenWalletBlockedError",
 			mock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE "wallets"\."id" = \$1 AND "wallets"\."user_id" = \$2 ORDER BY "wallets"\."id" LIMIT \$3 FOR UPDATE`).
-					WithArgs("<WalletID>", "<UserID>", 1).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "balance"}).AddRow("<WalletID>", 100.0))
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1\) ORDER BY id FOR UPDATE`).
+					WithArgs("<WalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency", "status"}).AddRow("<WalletID>", "<UserID>", 100.0, "THB", "frozen"))
+				mock.ExpectRollback()
+			},
+			walletId:    "<WalletID>",
+			amount:      money.MustParse("-50"),
+			wantErr:     true,
+			expectedErr: "wallet <WalletID> is frozen",
+		},
+		{
+			name: "GivenCreditLine_WhenWithdrawBeyondBalance_ThenBalanceGoesNegative",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1\) ORDER BY id FOR UPDATE`).
+					WithArgs("<WalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency", "credit_limit"}).AddRow("<WalletID>", "<UserID>", 20.0, "THB", 100.0))
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\) FROM "holds"`).
+					WithArgs("<WalletID>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("0.00"))
+				mock.ExpectQuery(`INSERT INTO "transactions"`).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(nil, nil))
+				mock.ExpectQuery(`SELECT \* FROM "ledger_accounts"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "code", "currency"}).AddRow("<TreasuryAccountID>", "treasury", "THB"))
+				mock.ExpectQuery(`INSERT INTO "journal_entries"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("<JournalEntryID>", nil))
+				mock.ExpectQuery(`INSERT INTO "postings"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, nil).AddRow(2, nil))
+				mock.ExpectExec(`UPDATE "wallets"`).
+					WithArgs(sqlmock.AnyArg(), "<WalletID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectBalancesAfter(mock)
+				mock.ExpectCommit()
+			},
+			walletId:    "<WalletID>",
+			amount:      money.MustParse("-70"),
+			wantErr:     false,
+			expectedErr: "",
+		},
+		{
+			name: "GivenCreditLine_WhenWithdrawBeyondCreditLimit_ThenInsufficientBalance",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1\) ORDER BY id FOR UPDATE`).
+					WithArgs("<WalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency", "credit_limit"}).AddRow("<WalletID>", "<UserID>", 20.0, "THB", 100.0))
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\) FROM "holds"`).
+					WithArgs("<WalletID>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("0.00"))
+				mock.ExpectRollback()
+			},
+			walletId:    "<WalletID>",
+			amount:      money.MustParse("-120.01"),
+			wantErr:     true,
+			expectedErr: "insufficient balance",
+		},
+		{
+			name: "GivenPositiveAmount_WhenUpdateBalanceSuccess_ThenSuccess",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1\) ORDER BY id FOR UPDATE`).
+					WithArgs("<WalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency"}).AddRow("<WalletID>", "<UserID>", 100.0, "THB"))
+				mock.ExpectQuery(`INSERT INTO "transactions"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "from_wallet_id", "to_wallet_id", "amount", "type", "created_at"}).
+						AddRow("<TransactionID>", nil, "<WalletID>", 100.0, "deposit", nil))
+				mock.ExpectQuery(`SELECT \* FROM "ledger_accounts" WHERE "ledger_accounts"\."code" = \$1 AND "ledger_accounts"\."currency" = \$2 ORDER BY "ledger_accounts"\."id" LIMIT \$3`).
+					WithArgs("treasury", "THB", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "code", "currency"}).AddRow("<TreasuryAccountID>", "treasury", "THB"))
+				mock.ExpectQuery(`INSERT INTO "journal_entries"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("<JournalEntryID>", nil))
+				mock.ExpectQuery(`INSERT INTO "postings"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, nil).AddRow(2, nil))
 				mock.ExpectExec(`UPDATE "wallets"`).
 					WithArgs(sqlmock.AnyArg(), "<WalletID>").
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectBalancesAfter(mock)
+				mock.ExpectCommit()
+			},
+			walletId:    "<WalletID>",
+			amount:      money.MustParse("100"),
+			wantErr:     false,
+			expectedErr: "",
+		},
+		{
+			name: "GivenNegativeAmount_WhenUpdateBalanceSuccess_ThenSuccess",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1\) ORDER BY id FOR UPDATE`).
+					WithArgs("<WalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency"}).AddRow("<WalletID>", "<UserID>", 100.0, "THB"))
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\) FROM "holds" WHERE wallet_id = \$1 AND status = \$2 AND expires_at > NOW\(\)`).
+					WithArgs("<WalletID>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("0.00"))
 				mock.ExpectQuery(`INSERT INTO "transactions"`).
 					WillReturnRows(sqlmock.NewRows([]string{"id", "from_wallet_id", "to_wallet_id", "amount", "type", "created_at"}).
 						AddRow("<TransactionID>", "<WalletID>", nil, -50.0, "withdraw", nil))
+				mock.ExpectQuery(`SELECT \* FROM "ledger_accounts" WHERE "ledger_accounts"\."code" = \$1 AND "ledger_accounts"\."currency" = \$2 ORDER BY "ledger_accounts"\."id" LIMIT \$3`).
+					WithArgs("treasury", "THB", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "code", "currency"}).AddRow("<TreasuryAccountID>", "treasury", "THB"))
+				mock.ExpectQuery(`INSERT INTO "journal_entries"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("<JournalEntryID>", nil))
+				mock.ExpectQuery(`INSERT INTO "postings"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, nil).AddRow(2, nil))
+				mock.ExpectExec(`UPDATE "wallets"`).
+					WithArgs(sqlmock.AnyArg(), "<WalletID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectBalancesAfter(mock)
 				mock.ExpectCommit()
 			},
 			walletId:    "<WalletID>",
-			amount:      -50.0,
+			amount:      money.MustParse("-50"),
 			wantErr:     false,
 			expectedErr: "",
 		},
@@ -59,13 +251,114 @@ func (suite *TransactionRepositoryTestSuite) TestUpdateBalanceTransaction() {
 			name: "GivenOverAmount_WhenInsufficientBalance_ThenError",
 			mock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE "wallets"\."id" = \$1 AND "wallets"\."user_id" = \$2 ORDER BY "wallets"\."id" LIMIT \$3 FOR UPDATE`).
-					WithArgs("<WalletID>", "<UserID>", 1).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "balance"}).AddRow("<WalletID>", 100.0))
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1\) ORDER BY id FOR UPDATE`).
+					WithArgs("<WalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency"}).AddRow("<WalletID>", "<UserID>", 100.0, "THB"))
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\) FROM "holds" WHERE wallet_id = \$1 AND status = \$2 AND expires_at > NOW\(\)`).
+					WithArgs("<WalletID>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("0.00"))
+				mock.ExpectRollback()
+			},
+			walletId:    "<WalletID>",
+			amount:      money.MustParse("-101"),
+			wantErr:     true,
+			expectedErr: "insufficient balance",
+		},
+		{
+			name: "GivenWithdrawWithFee_WhenUpdateBalanceSuccess_ThenFeeCreditedToFeeWallet",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1,\$2\) ORDER BY id FOR UPDATE`).
+					WithArgs("<FeeWalletID>", "<WalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency"}).
+						AddRow("<FeeWalletID>", "<SystemUserID>", 0.0, "THB").
+						AddRow("<WalletID>", "<UserID>", 100.0, "THB"))
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\) FROM "holds" WHERE wallet_id = \$1 AND status = \$2 AND expires_at > NOW\(\)`).
+					WithArgs("<WalletID>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("0.00"))
+				mock.ExpectQuery(`INSERT INTO "transactions"`).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(nil, nil))
+				mock.ExpectQuery(`SELECT \* FROM "ledger_accounts" WHERE "ledger_accounts"\."code" = \$1 AND "ledger_accounts"\."currency" = \$2 ORDER BY "ledger_accounts"\."id" LIMIT \$3`).
+					WithArgs("treasury", "THB", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "code", "currency"}).AddRow("<TreasuryAccountID>", "treasury", "THB"))
+				mock.ExpectQuery(`INSERT INTO "journal_entries"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("<JournalEntryID>", nil))
+				mock.ExpectQuery(`INSERT INTO "postings"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, nil).AddRow(2, nil))
+				mock.ExpectExec(`UPDATE "wallets"`).
+					WithArgs(sqlmock.AnyArg(), "<WalletID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectBalancesAfter(mock)
+				mock.ExpectQuery(`INSERT INTO "transactions"`).
+					WithArgs("TRN000000000000000002", "<WalletID>", "<FeeWalletID>", "1.50", "fee", nil, nil, nil, "0", nil, nil, "TRN000000000000000001", nil, nil, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(nil, nil))
+				mock.ExpectQuery(`INSERT INTO "journal_entries"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("<FeeJournalEntryID>", nil))
+				mock.ExpectQuery(`INSERT INTO "postings"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(3, nil).AddRow(4, nil))
+				mock.ExpectExec(`UPDATE "wallets"`).
+					WithArgs("1.50", "<WalletID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`UPDATE "wallets"`).
+					WithArgs("1.50", "<FeeWalletID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectBalancesAfter(mock)
+				mock.ExpectCommit()
+			},
+			walletId:    "<WalletID>",
+			amount:      money.MustParse("-50"),
+			fee:         &repositories.Fee{Amount: money.MustParse("1.50"), WalletID: "<FeeWalletID>"},
+			wantErr:     false,
+			expectedErr: "",
+		},
+		{
+			name: "GivenCreditBlockedFeeWallet_WhenWithdrawWithFee_ThenWalletBlocked",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1,\$2\) ORDER BY id FOR UPDATE`).
+					WithArgs("<FeeWalletID>", "<WalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency", "status"}).
+						AddRow("<FeeWalletID>", "<SystemUserID>", 0.0, "THB", "credit_blocked").
+						AddRow("<WalletID>", "<UserID>", 100.0, "THB", "active"))
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\) FROM "holds" WHERE wallet_id = \$1 AND status = \$2 AND expires_at > NOW\(\)`).
+					WithArgs("<WalletID>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("0.00"))
+				mock.ExpectQuery(`INSERT INTO "transactions"`).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(nil, nil))
+				mock.ExpectQuery(`SELECT \* FROM "ledger_accounts" WHERE "ledger_accounts"\."code" = \$1 AND "ledger_accounts"\."currency" = \$2 ORDER BY "ledger_accounts"\."id" LIMIT \$3`).
+					WithArgs("treasury", "THB", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "code", "currency"}).AddRow("<TreasuryAccountID>", "treasury", "THB"))
+				mock.ExpectQuery(`INSERT INTO "journal_entries"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("<JournalEntryID>", nil))
+				mock.ExpectQuery(`INSERT INTO "postings"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, nil).AddRow(2, nil))
+				mock.ExpectExec(`UPDATE "wallets"`).
+					WithArgs(sqlmock.AnyArg(), "<WalletID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectBalancesAfter(mock)
+				mock.ExpectRollback()
+			},
+			walletId:    "<WalletID>",
+			amount:      money.MustParse("-50"),
+			fee:         &repositories.Fee{Amount: money.MustParse("1.50"), WalletID: "<FeeWalletID>"},
+			wantErr:     true,
+			expectedErr: "wallet <FeeWalletID> is credit_blocked",
+		},
+		{
+			name: "GivenFeeBeyondAvailableBalance_WhenWithdraw_ThenInsufficientBalance",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1,\$2\) ORDER BY id FOR UPDATE`).
+					WithArgs("<FeeWalletID>", "<WalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency"}).AddRow("<WalletID>", "<UserID>", 100.0, "THB"))
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\) FROM "holds" WHERE wallet_id = \$1 AND status = \$2 AND expires_at > NOW\(\)`).
+					WithArgs("<WalletID>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("0.00"))
 				mock.ExpectRollback()
 			},
 			walletId:    "<WalletID>",
-			amount:      -101,
+			amount:      money.MustParse("-100"),
+			fee:         &repositories.Fee{Amount: money.MustParse("0.01"), WalletID: "<FeeWalletID>"},
 			wantErr:     true,
 			expectedErr: "insufficient balance",
 		},
@@ -73,16 +366,25 @@ func (suite *TransactionRepositoryTestSuite) TestUpdateBalanceTransaction() {
 			name: "GivenAmount_WhenUpdateBalanceFail_ThenError",
 			mock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE "wallets"\."id" = \$1 AND "wallets"\."user_id" = \$2 ORDER BY "wallets"\."id" LIMIT \$3 FOR UPDATE`).
-					WithArgs("<WalletID>", "<UserID>", 1).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "balance"}).AddRow("<WalletID>", 100.0))
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1\) ORDER BY id FOR UPDATE`).
+					WithArgs("<WalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency"}).AddRow("<WalletID>", "<UserID>", 100.0, "THB"))
+				mock.ExpectQuery(`INSERT INTO "transactions"`).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(nil))
+				mock.ExpectQuery(`SELECT \* FROM "ledger_accounts" WHERE "ledger_accounts"\."code" = \$1 AND "ledger_accounts"\."currency" = \$2 ORDER BY "ledger_accounts"\."id" LIMIT \$3`).
+					WithArgs("treasury", "THB", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "code", "currency"}).AddRow("<TreasuryAccountID>", "treasury", "THB"))
+				mock.ExpectQuery(`INSERT INTO "journal_entries"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("<JournalEntryID>", nil))
+				mock.ExpectQuery(`INSERT INTO "postings"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, nil).AddRow(2, nil))
 				mock.ExpectExec(`UPDATE "wallets"`).
 					WithArgs(sqlmock.AnyArg(), "<WalletID>").
 					WillReturnError(errors.New("update balance failed"))
 				mock.ExpectRollback()
 			},
 			walletId:    "<WalletID>",
-			amount:      10.0,
+			amount:      money.MustParse("10"),
 			wantErr:     true,
 			expectedErr: "update balance failed",
 		},
@@ -90,18 +392,15 @@ func (suite *TransactionRepositoryTestSuite) TestUpdateBalanceTransaction() {
 			name: "GivenAmount_WhenCreateTransactionFail_ThenError",
 			mock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE "wallets"\."id" = \$1 AND "wallets"\."user_id" = \$2 ORDER BY "wallets"\."id" LIMIT \$3 FOR UPDATE`).
-					WithArgs("<WalletID>", "<UserID>", 1).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "balance"}).AddRow("<WalletID>", 100.0))
-				mock.ExpectExec(`UPDATE "wallets"`).
-					WithArgs(sqlmock.AnyArg(), "<WalletID>").
-					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1\) ORDER BY id FOR UPDATE`).
+					WithArgs("<WalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency"}).AddRow("<WalletID>", "<UserID>", 100.0, "THB"))
 				mock.ExpectQuery(`INSERT INTO "transactions"`).
 					WillReturnError(errors.New("create transaction failed"))
 				mock.ExpectRollback()
 			},
 			walletId:    "<WalletID>",
-			amount:      10.0,
+			amount:      money.MustParse("10"),
 			wantErr:     true,
 			expectedErr: "create transaction failed",
 		},
@@ -110,12 +409,13 @@ func (suite *TransactionRepositoryTestSuite) TestUpdateBalanceTransaction() {
 	for _, tc := range testCases {
 		suite.Run(tc.name, func() {
 			tc.mock(suite.sqlMock)
-			err := suite.transactionRepo.UpdateBalanceTransaction(
-				"<UserID>", tc.walletId, tc.amount)
+			txRecord, err := suite.transactionRepo.UpdateBalanceTransaction("<UserID>", tc.walletId, tc.amount, tc.details, nil, tc.check, tc.fee)
 			if tc.wantErr {
 				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(txRecord)
 			} else {
 				suite.NoError(err)
+				suite.Equal(tc.amount, txRecord.Amount)
 			}
 			suite.sqlMock.ExpectationsWereMet()
 		})
@@ -128,234 +428,1095 @@ func (suite *TransactionRepositoryTestSuite) TestUpdateTransferTransaction() {
 		mock        func(sqlmock.Sqlmock)
 		from        string
 		to          string
-		amount      float64
+		amount      money.Amount
+		idempotency *repositories.IdempotencyKey
+		check       repositories.LimitCheck
+		fee         *repositories.Fee
 		wantErr     bool
 		expectedErr string
+		wantBalance []money.Amount
 	}{
 		{
 			name: "GivenWallets_WhenUpdateTransferSuccess_ThenSuccess",
 			mock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE "wallets"\."id" = \$1 AND "wallets"\."user_id" = \$2 ORDER BY "wallets"\."id" LIMIT \$3 FOR UPDATE`).
-					WithArgs("<FromWalletID>", "<UserID>", 1).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "balance"}).AddRow("<FromWalletID>", 100.0))
-				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE "wallets"\."id" = \$1 ORDER BY "wallets"\."id" LIMIT \$2 FOR UPDATE`).
-					WithArgs("<ToWalletID>", 1).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "balance"}).AddRow("<ToWalletID>", 100.0))
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1,\$2\) ORDER BY id FOR UPDATE`).
+					WithArgs("<FromWalletID>", "<ToWalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance"}).AddRow("<FromWalletID>", "<UserID>", 100.0).AddRow("<ToWalletID>", "<OtherUserID>", 100.0))
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\) FROM "holds" WHERE wallet_id = \$1 AND status = \$2 AND expires_at > NOW\(\)`).
+					WithArgs("<FromWalletID>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("0.00"))
+				mock.ExpectQuery(`INSERT INTO "transactions"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "from_wallet_id", "to_wallet_id", "amount", "type", "created_at"}).
+						AddRow("<TransactionID>", "<FromWalletID>", "<ToWalletID>", 50.0, "transfer", nil))
+				mock.ExpectQuery(`INSERT INTO "journal_entries"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("<JournalEntryID>", nil))
+				mock.ExpectQuery(`INSERT INTO "postings"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, nil).AddRow(2, nil))
 				mock.ExpectExec(`UPDATE "wallets"`).
 					WithArgs(sqlmock.AnyArg(), "<FromWalletID>").
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectExec(`UPDATE "wallets"`).
 					WithArgs(sqlmock.AnyArg(), "<ToWalletID>").
 					WillReturnResult(sqlmock.NewResult(1, 1))
-				mock.ExpectQuery(`INSERT INTO "transactions"`).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "from_wallet_id", "to_wallet_id", "amount", "type", "created_at"}).
-						AddRow("<TransactionID>", "<FromWalletID>", "<ToWalletID>", 50.0, "transfer", nil))
+				expectBalancesAfter(mock)
 				mock.ExpectCommit()
 			},
 			from:        "<FromWalletID>",
 			to:          "<ToWalletID>",
-			amount:      50.0,
+			amount:      money.MustParse("50"),
 			wantErr:     false,
 			expectedErr: "",
 		},
 		{
-			name: "GivenWallets_WhenInsufficientBalance_ThenError",
+			name: "GivenClosedDestinationWallet_WhenUpdateTransfer_ThenWalletClosedError",
 			mock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE "wallets"\."id" = \$1 AND "wallets"\."user_id" = \$2 ORDER BY "wallets"\."id" LIMIT \$3 FOR UPDATE`).
-					WithArgs("<FromWalletID>", "<UserID>", 1).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "balance"}).AddRow("<FromWalletID>", 10.0))
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1,\$2\) ORDER BY id FOR UPDATE`).
+					WithArgs("<FromWalletID>", "<ToWalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "closed_at"}).AddRow("<FromWalletID>", "<UserID>", 100.0, nil).AddRow("<ToWalletID>", "<OtherUserID>", 0.0, time.Now()))
 				mock.ExpectRollback()
 			},
 			from:        "<FromWalletID>",
 			to:          "<ToWalletID>",
-			amount:      50.0,
+			amount:      money.MustParse("50"),
 			wantErr:     true,
-			expectedErr: "insufficient balance",
+			expectedErr: "wallet is closed",
 		},
 		{
-			name: "GivenWallets_WhenUpdateTransferFromFail_ThenError",
+			name: "GivenDebitBlockedSourceWallet_WhenUpdateTransfer_ThenWalletBlockedError",
 			mock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE "wallets"\."id" = \$1 AND "wallets"\."user_id" = \$2 ORDER BY "wallets"\."id" LIMIT \$3 FOR UPDATE`).
-					WithArgs("<FromWalletID>", "<UserID>", 1).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "balance"}).AddRow("<FromWalletID>", 100.0))
-				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE "wallets"\."id" = \$1 ORDER BY "wallets"\."id" LIMIT \$2 FOR UPDATE`).
-					WithArgs("<ToWalletID>", 1).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "balance"}).AddRow("<ToWalletID>", 100.0))
-				mock.ExpectExec(`UPDATE "wallets"`).
-					WithArgs(sqlmock.AnyArg(), "<FromWalletID>").
-					WillReturnError(errors.New("from update failed"))
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1,\$2\) ORDER BY id FOR UPDATE`).
+					WithArgs("<FromWalletID>", "<ToWalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "status"}).AddRow("<FromWalletID>", "<UserID>", 100.0, "debit_blocked").AddRow("<ToWalletID>", "<OtherUserID>", 0.0, "active"))
 				mock.ExpectRollback()
 			},
 			from:        "<FromWalletID>",
 			to:          "<ToWalletID>",
-			amount:      50.0,
+			amount:      money.MustParse("50"),
 			wantErr:     true,
-			expectedErr: "from update failed",
+			expectedErr: "wallet <FromWalletID> is debit_blocked",
 		},
 		{
-			name: "GivenWallets_WhenUpdateTransferToFail_ThenError",
+			name: "GivenFrozenDestinationWallet_WhenUpdateTransfer_ThenWalletBlockedError",
 			mock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE "wallets"\."id" = \$1 AND "wallets"\."user_id" = \$2 ORDER BY "wallets"\."id" LIMIT \$3 FOR UPDATE`).
-					WithArgs("<FromWalletID>", "<UserID>", 1).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "balance"}).AddRow("<FromWalletID>", 100.0))
-				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE "wallets"\."id" = \$1 ORDER BY "wallets"\."id" LIMIT \$2 FOR UPDATE`).
-					WithArgs("<ToWalletID>", 1).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "balance"}).AddRow("<ToWalletID>", 100.0))
-				mock.ExpectExec(`UPDATE "wallets"`).
-					WithArgs(sqlmock.AnyArg(), "<FromWalletID>").
-					WillReturnResult(sqlmock.NewResult(1, 1))
-				mock.ExpectExec(`UPDATE "wallets"`).
-					WithArgs(sqlmock.AnyArg(), "<ToWalletID>").
-					WillReturnError(errors.New("to update failed"))
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1,\$2\) ORDER BY id FOR UPDATE`).
+					WithArgs("<FromWalletID>", "<ToWalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "status"}).AddRow("<FromWalletID>", "<UserID>", 100.0, "active").AddRow("<ToWalletID>", "<OtherUserID>", 0.0, "frozen"))
 				mock.ExpectRollback()
 			},
 			from:        "<FromWalletID>",
 			to:          "<ToWalletID>",
-			amount:      50.0,
+			amount:      money.MustParse("50"),
 			wantErr:     true,
-			expectedErr: "to update failed",
+			expectedErr: "wallet <ToWalletID> is frozen",
 		},
 		{
-			name: "GivenWallets_WhenCreateTransactionFail_ThenError",
+			name: "GivenLimitCheckRejects_WhenUpdateTransfer_ThenRollbackBeforeMovingFunds",
 			mock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE "wallets"\."id" = \$1 AND "wallets"\."user_id" = \$2 ORDER BY "wallets"\."id" LIMIT \$3 FOR UPDATE`).
-					WithArgs("<FromWalletID>", "<UserID>", 1).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "balance"}).AddRow("<FromWalletID>", 100.0))
-				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE "wallets"\."id" = \$1 ORDER BY "wallets"\."id" LIMIT \$2 FOR UPDATE`).
-					WithArgs("<ToWalletID>", 1).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "balance"}).AddRow("<ToWalletID>", 100.0))
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1,\$2\) ORDER BY id FOR UPDATE`).
+					WithArgs("<FromWalletID>", "<ToWalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance"}).AddRow("<FromWalletID>", "<UserID>", 100.0).AddRow("<ToWalletID>", "<UserID>", 100.0))
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(ABS\(amount\)\), 0\) FROM "transactions" WHERE "from" = \$1 AND type IN \(\$2,\$3\) AND created_at >= \$4`).
+					WithArgs("<FromWalletID>", "transfer", "withdraw", sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("80.00"))
+				mock.ExpectRollback()
+			},
+			from:   "<FromWalletID>",
+			to:     "<ToWalletID>",
+			amount: money.MustParse("50"),
+			check: func(wallet entity.Wallet, usage repositories.LimitUsage) error {
+				debited, err := usage.WalletDebited(time.Now())
+				if err != nil {
+					return err
+				}
+				if debited+money.MustParse("50") > money.MustParse("100") {
+					return &consts.LimitExceededError{Limit: consts.LimitDailyWallet}
+				}
+				return nil
+			},
+			wantErr:     true,
+			expectedErr: "transaction limit exceeded: daily_wallet_max",
+		},
+		{
+			name: "GivenNewIdempotencyKey_WhenUpdateTransferSuccess_ThenKeyCompleted",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "idempotency_keys" .* ON CONFLICT DO NOTHING`).
+					WithArgs("<UserID>", "<IdempotencyKey>", "<Fingerprint>", nil).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(nil))
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1,\$2\) ORDER BY id FOR UPDATE`).
+					WithArgs("<FromWalletID>", "<ToWalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance"}).AddRow("<FromWalletID>", "<UserID>", 100.0).AddRow("<ToWalletID>", "<OtherUserID>", 100.0))
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\) FROM "holds" WHERE wallet_id = \$1 AND status = \$2 AND expires_at > NOW\(\)`).
+					WithArgs("<FromWalletID>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("0.00"))
+				mock.ExpectQuery(`INSERT INTO "transactions"`).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(nil, nil))
+				mock.ExpectQuery(`INSERT INTO "journal_entries"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("<JournalEntryID>", nil))
+				mock.ExpectQuery(`INSERT INTO "postings"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, nil).AddRow(2, nil))
 				mock.ExpectExec(`UPDATE "wallets"`).
 					WithArgs(sqlmock.AnyArg(), "<FromWalletID>").
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectExec(`UPDATE "wallets"`).
 					WithArgs(sqlmock.AnyArg(), "<ToWalletID>").
 					WillReturnResult(sqlmock.NewResult(1, 1))
-				mock.ExpectQuery(`INSERT INTO "transactions"`).
-					WillReturnError(errors.New("create transaction failed"))
-				mock.ExpectRollback()
+				expectBalancesAfter(mock)
+				mock.ExpectExec(`UPDATE "idempotency_keys" SET "transaction_id"=\$1 WHERE "idempotency_keys"\."user_id" = \$2 AND "idempotency_keys"\."key" = \$3`).
+					WithArgs(sqlmock.AnyArg(), "<UserID>", "<IdempotencyKey>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
 			},
 			from:        "<FromWalletID>",
 			to:          "<ToWalletID>",
-			amount:      50.0,
-			wantErr:     true,
-			expectedErr: "create transaction failed",
+			amount:      money.MustParse("50"),
+			idempotency: &repositories.IdempotencyKey{Key: "<IdempotencyKey>", Fingerprint: "<Fingerprint>"},
+			wantErr:     false,
+			expectedErr: "",
 		},
-	}
-
-	for _, tc := range testCases {
-		suite.Run(tc.name, func() {
-			tc.mock(suite.sqlMock)
-			err := suite.transactionRepo.UpdateTransferTransaction("<UserID>", tc.from, tc.to, tc.amount)
-			if tc.wantErr {
-				suite.EqualError(err, tc.expectedErr)
-			} else {
-				suite.NoError(err)
-			}
-			suite.sqlMock.ExpectationsWereMet()
-		})
-	}
-}
-
-func (suite *TransactionRepositoryTestSuite) TestList() {
-	testCases := []struct {
-		name        string
-		mock        func(sqlmock.Sqlmock)
-		walletId    string
-		page        int
-		limit       int
-		wantErr     bool
-		expectedLen int
-	}{
 		{
-			name: "GivenWalletId_WhenListSuccess_ThenReturnTransactions",
+			name: "GivenUsedIdempotencyKey_WhenSameRequest_ThenOriginalTransactionReplayed",
 			mock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "from", "to", "amount", "type", "created_at"}).
-					AddRow("<TransactionID1>", "<WalletID1>", "<ToWalletID1>", 10.0, "transfer", nil).
-					AddRow("<TransactionID2>", "<FromWalletID2>", "<WalletID1>", 20.0, "transfer", nil)
-				mock.ExpectQuery(`SELECT \* FROM "transactions"`).
-					WithArgs("<WalletID1>", "<WalletID1>", 2).
-					WillReturnRows(rows)
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "idempotency_keys" .* ON CONFLICT DO NOTHING`).
+					WithArgs("<UserID>", "<IdempotencyKey>", "<Fingerprint>", nil).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}))
+				mock.ExpectQuery(`SELECT \* FROM "idempotency_keys" WHERE "idempotency_keys"\."user_id" = \$1 AND "idempotency_keys"\."key" = \$2 ORDER BY "idempotency_keys"\."user_id" LIMIT \$3 FOR UPDATE`).
+					WithArgs("<UserID>", "<IdempotencyKey>", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"user_id", "key", "fingerprint", "transaction_id"}).
+						AddRow("<UserID>", "<IdempotencyKey>", "<Fingerprint>", "<TransactionID>"))
+				mock.ExpectQuery(`SELECT \* FROM "transactions" WHERE "transactions"\."id" = \$1 ORDER BY "transactions"\."id" LIMIT \$2`).
+					WithArgs("<TransactionID>", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "from", "to", "amount", "type"}).
+						AddRow("<TransactionID>", "<FromWalletID>", "<ToWalletID>", "50.00", "transfer"))
+				mock.ExpectQuery(`SELECT \* FROM "transactions" WHERE "transactions"\."parent_transaction_id" = \$1`).
+					WithArgs("<TransactionID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "from", "to", "amount", "type", "parent_transaction_id"}).
+						AddRow("<FeeTransactionID>", "<FromWalletID>", "<FeeWalletID>", "1.00", "fee", "<TransactionID>"))
+				mock.ExpectCommit()
 			},
-			walletId:    "<WalletID1>",
-			page:        1,
-			limit:       2,
+			from:        "<FromWalletID>",
+			to:          "<ToWalletID>",
+			amount:      money.MustParse("50"),
+			idempotency: &repositories.IdempotencyKey{Key: "<IdempotencyKey>", Fingerprint: "<Fingerprint>"},
 			wantErr:     false,
-			expectedLen: 2,
+			expectedErr: "",
 		},
 		{
-			name: "GivenWalletId_WhenListFail_ThenError",
+			name: "GivenUsedIdempotencyKey_WhenDifferentRequest_ThenKeyReusedError",
 			mock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT \* FROM "transactions"`).
-					WithArgs("<WalletID2>", "<WalletID2>", 2).
-					WillReturnError(errors.New("query error"))
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "idempotency_keys" .* ON CONFLICT DO NOTHING`).
+					WithArgs("<UserID>", "<IdempotencyKey>", "<OtherFingerprint>", nil).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}))
+				mock.ExpectQuery(`SELECT \* FROM "idempotency_keys"`).
+					WithArgs("<UserID>", "<IdempotencyKey>", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"user_id", "key", "fingerprint", "transaction_id"}).
+						AddRow("<UserID>", "<IdempotencyKey>", "<Fingerprint>", "<TransactionID>"))
+				mock.ExpectRollback()
 			},
-			walletId:    "<WalletID2>",
-			page:        1,
-			limit:       2,
+			from:        "<FromWalletID>",
+			to:          "<ToWalletID>",
+			amount:      money.MustParse("50"),
+			idempotency: &repositories.IdempotencyKey{Key: "<IdempotencyKey>", Fingerprint: "<OtherFingerprint>"},
 			wantErr:     true,
-			expectedLen: 0,
+			expectedErr: "idempotency key reused with a different request",
 		},
-	}
-
-	for _, tc := range testCases {
-		suite.Run(tc.name, func() {
-			tc.mock(suite.sqlMock)
-			transactions, err := suite.transactionRepo.List(tc.walletId, tc.page, tc.limit)
-			if tc.wantErr {
-				suite.Error(err)
-				suite.Len(transactions, 0)
-			} else {
-				suite.NoError(err)
-				suite.Len(transactions, tc.expectedLen)
-			}
-			suite.sqlMock.ExpectationsWereMet()
-		})
-	}
-}
-
-func (suite *TransactionRepositoryTestSuite) TestCountByWalletId() {
-	testCases := []struct {
-		name        string
-		mock        func(sqlmock.Sqlmock)
-		walletId    string
-		wantCount   int64
-		wantErr     bool
-		expectedErr string
-	}{
 		{
-			name: "GivenWalletId_WhenCountSuccess_ThenReturnCount",
+			name: "GivenWallets_WhenInsufficientBalance_ThenError",
 			mock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT count\(\*\) FROM "transactions" WHERE "from" = \$1 OR "to" = \$2`).
-					WithArgs("<WalletID>", "<WalletID>").
-					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1,\$2\) ORDER BY id FOR UPDATE`).
+					WithArgs("<FromWalletID>", "<ToWalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance"}).AddRow("<FromWalletID>", "<UserID>", 10.0).AddRow("<ToWalletID>", "<OtherUserID>", 10.0))
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\) FROM "holds" WHERE wallet_id = \$1 AND status = \$2 AND expires_at > NOW\(\)`).
+					WithArgs("<FromWalletID>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("0.00"))
+				mock.ExpectRollback()
 			},
-			walletId:  "<WalletID>",
-			wantCount: int64(5),
-			wantErr:   false,
+			from:        "<FromWalletID>",
+			to:          "<ToWalletID>",
+			amount:      money.MustParse("50"),
+			wantErr:     true,
+			expectedErr: "insufficient balance",
 		},
 		{
-			name: "GivenWalletId_WhenCountFail_ThenError",
+			name: "GivenWalletsInDifferentCurrencies_WhenNoExchangeRate_ThenCurrencyMismatchError",
 			mock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT count\(\*\) FROM "transactions" WHERE "from" = \$1 OR "to" = \$2`).
-					WithArgs("<WalletID>", "<WalletID>").
-					WillReturnError(errors.New("count error"))
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1,\$2\) ORDER BY id FOR UPDATE`).
+					WithArgs("<FromWalletID>", "<ToWalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency"}).AddRow("<FromWalletID>", "<UserID>", 100.0, "THB").AddRow("<ToWalletID>", "<OtherUserID>", 100.0, "USD"))
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\) FROM "holds" WHERE wallet_id = \$1 AND status = \$2 AND expires_at > NOW\(\)`).
+					WithArgs("<FromWalletID>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("0.00"))
+				mock.ExpectQuery(`SELECT \* FROM "exchange_rates" WHERE "exchange_rates"\."base_currency" = \$1 AND "exchange_rates"\."quote_currency" = \$2 ORDER BY "exchange_rates"\."base_currency" LIMIT \$3 FOR SHARE`).
+					WithArgs("THB", "USD", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"base_currency", "quote_currency", "rate", "spread"}))
+				mock.ExpectRollback()
 			},
-			walletId:    "<WalletID>",
-			wantCount:   int64(0),
+			from:        "<FromWalletID>",
+			to:          "<ToWalletID>",
+			amount:      money.MustParse("50"),
 			wantErr:     true,
-			expectedErr: "count error",
+			expectedErr: "currency mismatch: cannot transfer from THB to USD",
 		},
-	}
-
-	for _, tc := range testCases {
+		{
+			name: "GivenWalletsInDifferentCurrencies_WhenExchangeRateExists_ThenConvertedAmountCredited",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1,\$2\) ORDER BY id FOR UPDATE`).
+					WithArgs("<FromWalletID>", "<ToWalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency"}).AddRow("<FromWalletID>", "<UserID>", 100.0, "USD").AddRow("<ToWalletID>", "<OtherUserID>", 0.0, "THB"))
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\) FROM "holds" WHERE wallet_id = \$1 AND status = \$2 AND expires_at > NOW\(\)`).
+					WithArgs("<FromWalletID>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("0.00"))
+				mock.ExpectQuery(`SELECT \* FROM "exchange_rates" WHERE "exchange_rates"\."base_currency" = \$1 AND "exchange_rates"\."quote_currency" = \$2 ORDER BY "exchange_rates"\."base_currency" LIMIT \$3 FOR SHARE`).
+					WithArgs("USD", "THB", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"base_currency", "quote_currency", "rate", "spread"}).AddRow("USD", "THB", "35.00000000", "0.01000000"))
+				mock.ExpectQuery(`INSERT INTO "transactions" \("id","from","to","amount","type","credited_amount","exchange_rate","original_transaction_id","refunded_amount","from_balance_after","to_balance_after","parent_transaction_id","description","reference","metadata"\)`).
+					WithArgs(sqlmock.AnyArg(), "<FromWalletID>", "<ToWalletID>", "10.00", "transfer", "346.50", "34.65000000", nil, "0", nil, nil, nil, nil, nil, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(nil, nil))
+				mock.ExpectQuery(`SELECT \* FROM "ledger_accounts" WHERE "ledger_accounts"\."code" = \$1 AND "ledger_accounts"\."currency" = \$2 ORDER BY "ledger_accounts"\."id" LIMIT \$3`).
+					WithArgs("fx", "USD", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "code", "currency"}).AddRow("<FxUSDAccountID>", "fx", "USD"))
+				mock.ExpectQuery(`SELECT \* FROM "ledger_accounts" WHERE "ledger_accounts"\."code" = \$1 AND "ledger_accounts"\."currency" = \$2 ORDER BY "ledger_accounts"\."id" LIMIT \$3`).
+					WithArgs("fx", "THB", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "code", "currency"}).AddRow("<FxTHBAccountID>", "fx", "THB"))
+				mock.ExpectQuery(`INSERT INTO "journal_entries"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("<JournalEntryID>", nil))
+				mock.ExpectQuery(`INSERT INTO "postings"`).
+					WithArgs(
+						"<JournalEntryID>", "<FromWalletID>", "debit", "10.00", "USD",
+						"<JournalEntryID>", "<FxUSDAccountID>", "credit", "10.00", "USD",
+						"<JournalEntryID>", "<FxTHBAccountID>", "debit", "346.50", "THB",
+						"<JournalEntryID>", "<ToWalletID>", "credit", "346.50", "THB",
+					).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, nil).AddRow(2, nil).AddRow(3, nil).AddRow(4, nil))
+				mock.ExpectExec(`UPDATE "wallets" SET "balance"=balance - \$1 WHERE "wallets"\."id" = \$2`).
+					WithArgs("10.00", "<FromWalletID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`UPDATE "wallets" SET "balance"=balance \+ \$1 WHERE "wallets"\."id" = \$2`).
+					WithArgs("346.50", "<ToWalletID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery(`UPDATE "transactions" SET "from_balance_after"=.*,"to_balance_after"=.* WHERE "id" = \$1 RETURNING "from_balance_after","to_balance_after"`).
+					WithArgs(sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"from_balance_after", "to_balance_after"}).AddRow("90.00", "346.50"))
+				mock.ExpectCommit()
+			},
+			from:        "<FromWalletID>",
+			to:          "<ToWalletID>",
+			amount:      money.MustParse("10"),
+			wantBalance: []money.Amount{money.MustParse("90"), money.MustParse("346.50")},
+			wantErr:     false,
+			expectedErr: "",
+		},
+		{
+			name: "GivenFractionalAmountOnJPYWallet_WhenUpdateTransfer_ThenScaleError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1,\$2\) ORDER BY id FOR UPDATE`).
+					WithArgs("<FromWalletID>", "<ToWalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency"}).AddRow("<FromWalletID>", "<UserID>", 100.0, "JPY").AddRow("<ToWalletID>", "<OtherUserID>", 100.0, "JPY"))
+				mock.ExpectRollback()
+			},
+			from:        "<FromWalletID>",
+			to:          "<ToWalletID>",
+			amount:      money.MustParse("0.5"),
+			wantErr:     true,
+			expectedErr: "amount exceeds supported decimal places",
+		},
+		{
+			name: "GivenWalletsInDescendingIdOrder_WhenUpdateTransfer_ThenLockedInIdOrder",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1,\$2\) ORDER BY id FOR UPDATE`).
+					WithArgs("<WalletA>", "<WalletB>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance"}).AddRow("<WalletA>", "<OtherUserID>", 0.0).AddRow("<WalletB>", "<UserID>", 10.0))
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\) FROM "holds" WHERE wallet_id = \$1 AND status = \$2 AND expires_at > NOW\(\)`).
+					WithArgs("<WalletB>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("0.00"))
+				mock.ExpectRollback()
+			},
+			from:        "<WalletB>",
+			to:          "<WalletA>",
+			amount:      money.MustParse("50"),
+			wantErr:     true,
+			expectedErr: "insufficient balance",
+		},
+		{
+			name: "GivenFromWalletOfAnotherUser_WhenUpdateTransfer_ThenNotFound",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1,\$2\) ORDER BY id FOR UPDATE`).
+					WithArgs("<FromWalletID>", "<ToWalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance"}).AddRow("<FromWalletID>", "<OtherUserID>", 100.0).AddRow("<ToWalletID>", "<OtherUserID>", 100.0))
+				mock.ExpectRollback()
+			},
+			from:        "<FromWalletID>",
+			to:          "<ToWalletID>",
+			amount:      money.MustParse("50"),
+			wantErr:     true,
+			expectedErr: "record not found",
+		},
+		{
+			name: "GivenDeadlock_WhenUpdateTransfer_ThenRetriedAndSucceeds",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1,\$2\) ORDER BY id FOR UPDATE`).
+					WithArgs("<FromWalletID>", "<ToWalletID>").
+					WillReturnError(&pgconn.PgError{Code: "40P01", Message: "deadlock detected"})
+				mock.ExpectRollback()
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1,\$2\) ORDER BY id FOR UPDATE`).
+					WithArgs("<FromWalletID>", "<ToWalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance"}).AddRow("<FromWalletID>", "<UserID>", 100.0).AddRow("<ToWalletID>", "<OtherUserID>", 100.0))
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\) FROM "holds" WHERE wallet_id = \$1 AND status = \$2 AND expires_at > NOW\(\)`).
+					WithArgs("<FromWalletID>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("0.00"))
+				mock.ExpectQuery(`INSERT INTO "transactions"`).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(nil, nil))
+				mock.ExpectQuery(`INSERT INTO "journal_entries"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("<JournalEntryID>", nil))
+				mock.ExpectQuery(`INSERT INTO "postings"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, nil).AddRow(2, nil))
+				mock.ExpectExec(`UPDATE "wallets"`).
+					WithArgs(sqlmock.AnyArg(), "<FromWalletID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`UPDATE "wallets"`).
+					WithArgs(sqlmock.AnyArg(), "<ToWalletID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectBalancesAfter(mock)
+				mock.ExpectCommit()
+			},
+			from:        "<FromWalletID>",
+			to:          "<ToWalletID>",
+			amount:      money.MustParse("50"),
+			wantErr:     false,
+			expectedErr: "",
+		},
+		{
+			name: "GivenPersistentSerializationFailure_WhenUpdateTransfer_ThenGiveUpAfterMaxAttempts",
+			mock: func(mock sqlmock.Sqlmock) {
+				for i := 0; i < 5; i++ {
+					mock.ExpectBegin()
+					mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1,\$2\) ORDER BY id FOR UPDATE`).
+						WithArgs("<FromWalletID>", "<ToWalletID>").
+						WillReturnError(&pgconn.PgError{Severity: "ERROR", Code: "40001", Message: "could not serialize access"})
+					mock.ExpectRollback()
+				}
+			},
+			from:        "<FromWalletID>",
+			to:          "<ToWalletID>",
+			amount:      money.MustParse("50"),
+			wantErr:     true,
+			expectedErr: "ERROR: could not serialize access (SQLSTATE 40001)",
+		},
+		{
+			name: "GivenWallets_WhenUpdateTransferFromFail_ThenError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1,\$2\) ORDER BY id FOR UPDATE`).
+					WithArgs("<FromWalletID>", "<ToWalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance"}).AddRow("<FromWalletID>", "<UserID>", 100.0).AddRow("<ToWalletID>", "<OtherUserID>", 100.0))
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\) FROM "holds" WHERE wallet_id = \$1 AND status = \$2 AND expires_at > NOW\(\)`).
+					WithArgs("<FromWalletID>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("0.00"))
+				mock.ExpectQuery(`INSERT INTO "transactions"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "from_wallet_id", "to_wallet_id", "amount", "type", "created_at"}).
+						AddRow("<TransactionID>", "<FromWalletID>", "<ToWalletID>", 50.0, "transfer", nil))
+				mock.ExpectQuery(`INSERT INTO "journal_entries"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("<JournalEntryID>", nil))
+				mock.ExpectQuery(`INSERT INTO "postings"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, nil).AddRow(2, nil))
+				mock.ExpectExec(`UPDATE "wallets"`).
+					WithArgs(sqlmock.AnyArg(), "<FromWalletID>").
+					WillReturnError(errors.New("from update failed"))
+				mock.ExpectRollback()
+			},
+			from:        "<FromWalletID>",
+			to:          "<ToWalletID>",
+			amount:      money.MustParse("50"),
+			wantErr:     true,
+			expectedErr: "from update failed",
+		},
+		{
+			name: "GivenWallets_WhenUpdateTransferToFail_ThenError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1,\$2\) ORDER BY id FOR UPDATE`).
+					WithArgs("<FromWalletID>", "<ToWalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance"}).AddRow("<FromWalletID>", "<UserID>", 100.0).AddRow("<ToWalletID>", "<OtherUserID>", 100.0))
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\) FROM "holds" WHERE wallet_id = \$1 AND status = \$2 AND expires_at > NOW\(\)`).
+					WithArgs("<FromWalletID>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("0.00"))
+				mock.ExpectQuery(`INSERT INTO "transactions"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "from_wallet_id", "to_wallet_id", "amount", "type", "created_at"}).
+						AddRow("<TransactionID>", "<FromWalletID>", "<ToWalletID>", 50.0, "transfer", nil))
+				mock.ExpectQuery(`INSERT INTO "journal_entries"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("<JournalEntryID>", nil))
+				mock.ExpectQuery(`INSERT INTO "postings"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, nil).AddRow(2, nil))
+				mock.ExpectExec(`UPDATE "wallets"`).
+					WithArgs(sqlmock.AnyArg(), "<FromWalletID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`UPDATE "wallets"`).
+					WithArgs(sqlmock.AnyArg(), "<ToWalletID>").
+					WillReturnError(errors.New("to update failed"))
+				mock.ExpectRollback()
+			},
+			from:        "<FromWalletID>",
+			to:          "<ToWalletID>",
+			amount:      money.MustParse("50"),
+			wantErr:     true,
+			expectedErr: "to update failed",
+		},
+		{
+			name: "GivenWallets_WhenCreateTransactionFail_ThenError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1,\$2\) ORDER BY id FOR UPDATE`).
+					WithArgs("<FromWalletID>", "<ToWalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance"}).AddRow("<FromWalletID>", "<UserID>", 100.0).AddRow("<ToWalletID>", "<OtherUserID>", 100.0))
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\) FROM "holds" WHERE wallet_id = \$1 AND status = \$2 AND expires_at > NOW\(\)`).
+					WithArgs("<FromWalletID>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("0.00"))
+				mock.ExpectQuery(`INSERT INTO "transactions"`).
+					WillReturnError(errors.New("create transaction failed"))
+				mock.ExpectRollback()
+			},
+			from:        "<FromWalletID>",
+			to:          "<ToWalletID>",
+			amount:      money.MustParse("50"),
+			wantErr:     true,
+			expectedErr: "create transaction failed",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+			txRecord, err := suite.transactionRepo.UpdateTransferTransaction("<UserID>", tc.from, tc.to, tc.amount, entity.TransactionDetails{}, tc.idempotency, tc.check, tc.fee)
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(txRecord)
+			} else {
+				suite.NoError(err)
+				suite.Equal(tc.amount, txRecord.Amount)
+				if tc.wantBalance != nil {
+					suite.Equal(tc.wantBalance[0], *txRecord.FromBalanceAfter)
+					suite.Equal(tc.wantBalance[1], *txRecord.ToBalanceAfter)
+				}
+			}
+			suite.sqlMock.ExpectationsWereMet()
+		})
+	}
+}
+
+func (suite *TransactionRepositoryTestSuite) TestUpdateBatchTransferTransaction() {
+	lockBatchWallets := `SELECT \* FROM "wallets" WHERE id IN \(\$1,\$2,\$3\) ORDER BY id FOR UPDATE`
+	expectHeld := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\) FROM "holds" WHERE wallet_id = \$1 AND status = \$2 AND expires_at > NOW\(\)`).
+			WithArgs("<FromWalletID>", "active").
+			WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("0.00"))
+	}
+	expectLeg := func(mock sqlmock.Sqlmock, id, to, amount string) {
+		expectHeld(mock)
+		mock.ExpectQuery(`INSERT INTO "transactions"`).
+			WithArgs(id, "<FromWalletID>", to, amount, "transfer", nil, nil, nil, "0", nil, nil, nil, nil, nil, nil).
+			WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(nil, nil))
+		mock.ExpectQuery(`INSERT INTO "journal_entries"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("<JournalEntryID>", nil))
+		mock.ExpectQuery(`INSERT INTO "postings"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, nil).AddRow(2, nil))
+		mock.ExpectExec(`UPDATE "wallets"`).
+			WithArgs(amount, "<FromWalletID>").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`UPDATE "wallets"`).
+			WithArgs(amount, to).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		expectBalancesAfter(mock)
+	}
+	legs := []repositories.BatchTransferLeg{
+		{To: "<ToWalletID1>", Amount: money.MustParse("80")},
+		{To: "<ToWalletID2>", Amount: money.MustParse("50")},
+	}
+
+	testCases := []struct {
+		name         string
+		mock         func(sqlmock.Sqlmock)
+		legs         []repositories.BatchTransferLeg
+		allOrNothing bool
+		wantIds      []string
+		wantLegErrs  []error
+		wantErr      bool
+		expectedErr  string
+	}{
+		{
+			name: "GivenEnoughBalance_WhenAllOrNothingBatch_ThenEveryLegCommitted",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockBatchWallets).
+					WithArgs("<FromWalletID>", "<ToWalletID1>", "<ToWalletID2>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency"}).
+						AddRow("<FromWalletID>", "<UserID>", 200.0, "THB").
+						AddRow("<ToWalletID1>", "<OtherUserID>", 0.0, "THB").
+						AddRow("<ToWalletID2>", "<OtherUserID>", 0.0, "THB"))
+				expectHeld(mock)
+				expectLeg(mock, "TRN000000000000000001", "<ToWalletID1>", "80.00")
+				expectLeg(mock, "TRN000000000000000002", "<ToWalletID2>", "50.00")
+				mock.ExpectCommit()
+			},
+			legs:         legs,
+			allOrNothing: true,
+			wantIds:      []string{"TRN000000000000000001", "TRN000000000000000002"},
+			wantLegErrs:  []error{nil, nil},
+		},
+		{
+			name: "GivenTotalAboveBalance_WhenAllOrNothingBatch_ThenInsufficientBalance",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockBatchWallets).
+					WithArgs("<FromWalletID>", "<ToWalletID1>", "<ToWalletID2>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency"}).
+						AddRow("<FromWalletID>", "<UserID>", 100.0, "THB").
+						AddRow("<ToWalletID1>", "<OtherUserID>", 0.0, "THB").
+						AddRow("<ToWalletID2>", "<OtherUserID>", 0.0, "THB"))
+				expectHeld(mock)
+				mock.ExpectRollback()
+			},
+			legs:         legs,
+			allOrNothing: true,
+			wantErr:      true,
+			expectedErr:  "insufficient balance",
+		},
+		{
+			name: "GivenMissingDestination_WhenAllOrNothingBatch_ThenBatchRolledBack",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockBatchWallets).
+					WithArgs("<FromWalletID>", "<ToWalletID1>", "<ToWalletID2>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency"}).
+						AddRow("<FromWalletID>", "<UserID>", 200.0, "THB").
+						AddRow("<ToWalletID1>", "<OtherUserID>", 0.0, "THB"))
+				expectHeld(mock)
+				expectLeg(mock, "TRN000000000000000001", "<ToWalletID1>", "80.00")
+				mock.ExpectRollback()
+			},
+			legs:         legs,
+			allOrNothing: true,
+			wantErr:      true,
+			expectedErr:  "batch leg 1: record not found",
+		},
+		{
+			name: "GivenBalanceForOneLeg_WhenBestEffortBatch_ThenSecondLegSkipped",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockBatchWallets).
+					WithArgs("<FromWalletID>", "<ToWalletID1>", "<ToWalletID2>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency"}).
+						AddRow("<FromWalletID>", "<UserID>", 100.0, "THB").
+						AddRow("<ToWalletID1>", "<OtherUserID>", 0.0, "THB").
+						AddRow("<ToWalletID2>", "<OtherUserID>", 0.0, "THB"))
+				mock.ExpectExec(`SAVEPOINT`).WillReturnResult(sqlmock.NewResult(0, 0))
+				expectLeg(mock, "TRN000000000000000001", "<ToWalletID1>", "80.00")
+				mock.ExpectExec(`SAVEPOINT`).WillReturnResult(sqlmock.NewResult(0, 0))
+				expectHeld(mock)
+				mock.ExpectExec(`ROLLBACK TO SAVEPOINT`).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			legs:        legs,
+			wantIds:     []string{"TRN000000000000000001", ""},
+			wantLegErrs: []error{nil, consts.ErrInsufficientBalance},
+		},
+		{
+			name: "GivenSourceOwnedByAnotherUser_WhenBatch_ThenNotFound",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockBatchWallets).
+					WithArgs("<FromWalletID>", "<ToWalletID1>", "<ToWalletID2>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency"}).
+						AddRow("<FromWalletID>", "<OtherUserID>", 200.0, "THB").
+						AddRow("<ToWalletID1>", "<OtherUserID>", 0.0, "THB").
+						AddRow("<ToWalletID2>", "<OtherUserID>", 0.0, "THB"))
+				mock.ExpectRollback()
+			},
+			legs:        legs,
+			wantErr:     true,
+			expectedErr: "record not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+			results, err := suite.transactionRepo.UpdateBatchTransferTransaction("<UserID>", "<FromWalletID>", tc.legs, tc.allOrNothing)
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(results)
+			} else {
+				suite.NoError(err)
+				suite.Len(results, len(tc.wantIds))
+				for i, id := range tc.wantIds {
+					suite.ErrorIs(results[i].Err, tc.wantLegErrs[i])
+					if id == "" {
+						suite.Nil(results[i].Transaction)
+					} else {
+						suite.Equal(id, results[i].Transaction.ID)
+					}
+				}
+			}
+			suite.NoError(suite.sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (suite *TransactionRepositoryTestSuite) TestReverseTransaction() {
+	lockOriginal := `SELECT \* FROM "transactions" WHERE "transactions"\."id" = \$1 ORDER BY "transactions"\."id" LIMIT \$2 FOR UPDATE`
+	lockPayer := `SELECT \* FROM "wallets" WHERE id IN \(\$1\) ORDER BY id FOR UPDATE`
+	lockPayerAndPayee := `SELECT \* FROM "wallets" WHERE id IN \(\$1,\$2\) ORDER BY id FOR UPDATE`
+	originalColumns := []string{"id", "from", "to", "amount", "type", "credited_amount", "refunded_amount"}
+
+	partial := money.MustParse("4")
+
+	testCases := []struct {
+		name        string
+		mock        func(sqlmock.Sqlmock)
+		amount      *money.Amount
+		wantAmount  money.Amount
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenTransfer_WhenFullReversal_ThenRemainingAmountReturnedToSender",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockOriginal).
+					WithArgs("<TransactionID>", 1).
+					WillReturnRows(sqlmock.NewRows(originalColumns).
+						AddRow("<TransactionID>", "<FromWalletID>", "<ToWalletID>", "50.00", "transfer", nil, "10.00"))
+				mock.ExpectQuery(lockPayerAndPayee).
+					WithArgs("<FromWalletID>", "<ToWalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency"}).
+						AddRow("<FromWalletID>", "<OtherUserID>", "0.00", "THB").
+						AddRow("<ToWalletID>", "<UserID>", "100.00", "THB"))
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\) FROM "holds" WHERE wallet_id = \$1 AND status = \$2 AND expires_at > NOW\(\)`).
+					WithArgs("<ToWalletID>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("0.00"))
+				mock.ExpectQuery(`INSERT INTO "transactions"`).
+					WithArgs(sqlmock.AnyArg(), "<ToWalletID>", "<FromWalletID>", "40.00", "reversal", nil, nil, "<TransactionID>", "0", nil, nil, nil, nil, nil, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(nil, nil))
+				mock.ExpectExec(`UPDATE "transactions" SET "refunded_amount"=refunded_amount \+ \$1 WHERE "transactions"\."id" = \$2`).
+					WithArgs("40.00", "<TransactionID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery(`INSERT INTO "journal_entries"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("<JournalEntryID>", nil))
+				mock.ExpectQuery(`INSERT INTO "postings"`).
+					WithArgs(
+						"<JournalEntryID>", "<ToWalletID>", "debit", "40.00", "THB",
+						"<JournalEntryID>", "<FromWalletID>", "credit", "40.00", "THB",
+					).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, nil).AddRow(2, nil))
+				mock.ExpectExec(`UPDATE "wallets" SET "balance"=balance - \$1 WHERE "wallets"\."id" = \$2`).
+					WithArgs("40.00", "<ToWalletID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`UPDATE "wallets" SET "balance"=balance \+ \$1 WHERE "wallets"\."id" = \$2`).
+					WithArgs("40.00", "<FromWalletID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectBalancesAfter(mock)
+				mock.ExpectCommit()
+			},
+			wantAmount: money.MustParse("40"),
+		},
+		{
+			name: "GivenConvertedTransfer_WhenPartialRefund_ThenPayerDebitedProratedCreditedAmount",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockOriginal).
+					WithArgs("<TransactionID>", 1).
+					WillReturnRows(sqlmock.NewRows(originalColumns).
+						AddRow("<TransactionID>", "<FromWalletID>", "<ToWalletID>", "10.00", "transfer", "346.50", "0.00"))
+				mock.ExpectQuery(lockPayerAndPayee).
+					WithArgs("<FromWalletID>", "<ToWalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency"}).
+						AddRow("<FromWalletID>", "<OtherUserID>", "0.00", "USD").
+						AddRow("<ToWalletID>", "<UserID>", "346.50", "THB"))
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\) FROM "holds" WHERE wallet_id = \$1 AND status = \$2 AND expires_at > NOW\(\)`).
+					WithArgs("<ToWalletID>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("0.00"))
+				mock.ExpectQuery(`INSERT INTO "transactions"`).
+					WithArgs(sqlmock.AnyArg(), "<ToWalletID>", "<FromWalletID>", "138.60", "reversal", "4.00", nil, "<TransactionID>", "0", nil, nil, nil, nil, nil, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(nil, nil))
+				mock.ExpectExec(`UPDATE "transactions"`).
+					WithArgs("4.00", "<TransactionID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery(`SELECT \* FROM "ledger_accounts"`).
+					WithArgs("fx", "THB", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "code", "currency"}).AddRow("<FxTHBAccountID>", "fx", "THB"))
+				mock.ExpectQuery(`SELECT \* FROM "ledger_accounts"`).
+					WithArgs("fx", "USD", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "code", "currency"}).AddRow("<FxUSDAccountID>", "fx", "USD"))
+				mock.ExpectQuery(`INSERT INTO "journal_entries"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("<JournalEntryID>", nil))
+				mock.ExpectQuery(`INSERT INTO "postings"`).
+					WithArgs(
+						"<JournalEntryID>", "<ToWalletID>", "debit", "138.60", "THB",
+						"<JournalEntryID>", "<FxTHBAccountID>", "credit", "138.60", "THB",
+						"<JournalEntryID>", "<FxUSDAccountID>", "debit", "4.00", "USD",
+						"<JournalEntryID>", "<FromWalletID>", "credit", "4.00", "USD",
+					).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, nil).AddRow(2, nil).AddRow(3, nil).AddRow(4, nil))
+				mock.ExpectExec(`UPDATE "wallets"`).
+					WithArgs("138.60", "<ToWalletID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`UPDATE "wallets"`).
+					WithArgs("4.00", "<FromWalletID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectBalancesAfter(mock)
+				mock.ExpectCommit()
+			},
+			amount:     &partial,
+			wantAmount: money.MustParse("138.60"),
+		},
+		{
+			name: "GivenPartiallyRefundedTransfer_WhenRefundExceedsRemaining_ThenError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockOriginal).
+					WithArgs("<TransactionID>", 1).
+					WillReturnRows(sqlmock.NewRows(originalColumns).
+						AddRow("<TransactionID>", "<FromWalletID>", "<ToWalletID>", "5.00", "transfer", nil, "2.00"))
+				mock.ExpectQuery(lockPayerAndPayee).
+					WithArgs("<FromWalletID>", "<ToWalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency"}).
+						AddRow("<FromWalletID>", "<OtherUserID>", "0.00", "THB").
+						AddRow("<ToWalletID>", "<UserID>", "100.00", "THB"))
+				mock.ExpectRollback()
+			},
+			amount:      &partial,
+			wantErr:     true,
+			expectedErr: "refund exceeds the remaining refundable amount",
+		},
+		{
+			name: "GivenDeposit_WhenPayerBalanceTooLow_ThenInsufficientBalance",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockOriginal).
+					WithArgs("<TransactionID>", 1).
+					WillReturnRows(sqlmock.NewRows(originalColumns).
+						AddRow("<TransactionID>", nil, "<ToWalletID>", "50.00", "deposit", nil, "0.00"))
+				mock.ExpectQuery(lockPayer).
+					WithArgs("<ToWalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency"}).AddRow("<ToWalletID>", "<UserID>", "20.00", "THB"))
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\) FROM "holds" WHERE wallet_id = \$1 AND status = \$2 AND expires_at > NOW\(\)`).
+					WithArgs("<ToWalletID>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("0.00"))
+				mock.ExpectRollback()
+			},
+			wantErr:     true,
+			expectedErr: "insufficient balance",
+		},
+		{
+			name: "GivenWithdrawal_WhenReverse_ThenNotReversible",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockOriginal).
+					WithArgs("<TransactionID>", 1).
+					WillReturnRows(sqlmock.NewRows(originalColumns).
+						AddRow("<TransactionID>", "<FromWalletID>", nil, "50.00", "withdraw", nil, "0.00"))
+				mock.ExpectRollback()
+			},
+			wantErr:     true,
+			expectedErr: "transaction cannot be reversed",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+			txRecord, err := suite.transactionRepo.ReverseTransaction("<UserID>", "<TransactionID>", tc.amount, entity.TransactionDetails{}, nil)
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(txRecord)
+			} else {
+				suite.NoError(err)
+				suite.Equal(tc.wantAmount, txRecord.Amount)
+				suite.Equal("<TransactionID>", *txRecord.OriginalTransactionID)
+			}
+			suite.sqlMock.ExpectationsWereMet()
+		})
+	}
+}
+
+func (suite *TransactionRepositoryTestSuite) TestList() {
+	minAmount, maxAmount, withdrawalMin := money.MustParse("10"), money.MustParse("500"), money.MustParse("100")
+	cursorTime := time.Date(2024, 3, 15, 9, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		mock        func(sqlmock.Sqlmock)
+		walletId    string
+		filter      repositories.TransactionFilter
+		cursor      *repositories.TransactionCursor
+		limit       int
+		wantErr     bool
+		wantIds     []string
+		wantHasMore bool
+	}{
+		{
+			name: "GivenReference_WhenListSuccess_ThenFilterByReference",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "from", "to", "amount", "type", "reference", "metadata", "created_at"}).
+					AddRow("<TransactionID1>", nil, "<WalletID1>", 10.0, "deposit", "INV-1001", []byte(`{"orderId":"A-1"}`), nil)
+				mock.ExpectQuery(`SELECT \* FROM "transactions" WHERE \("from" = \$1 OR "to" = \$2\) AND "transactions"\."reference" = \$3 ORDER BY created_at DESC, id DESC LIMIT \$4`).
+					WithArgs("<WalletID1>", "<WalletID1>", "INV-1001", 3).
+					WillReturnRows(rows)
+			},
+			walletId:    "<WalletID1>",
+			filter:      repositories.TransactionFilter{Reference: null.StringFrom("INV-1001").Ptr()},
+			limit:       2,
+			wantErr:     false,
+			wantIds:     []string{"<TransactionID1>"},
+			wantHasMore: false,
+		},
+		{
+			name: "GivenOutgoingToCounterpartyByAmount_WhenListSuccess_ThenFilteredAndSortedByAmount",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "from", "to", "amount", "type", "description", "created_at"}).
+					AddRow("<TransactionID1>", "<WalletID1>", "<CounterpartyWalletID>", 450.0, "transfer", "March rent", nil)
+				mock.ExpectQuery(`SELECT \* FROM "transactions" WHERE "from" = \$1 AND "to" = \$2 AND "transactions"\."type" = \$3 AND created_at >= \$4 AND created_at < \$5 AND ABS\(amount\) >= \$6 AND ABS\(amount\) <= \$7 AND description ILIKE \$8 ORDER BY ABS\(amount\) DESC, created_at DESC, id DESC LIMIT \$9`).
+					WithArgs("<WalletID1>", "<CounterpartyWalletID>", "transfer", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), "10.00", "500.00", `%50\%\_off%`, 3).
+					WillReturnRows(rows)
+			},
+			walletId: "<WalletID1>",
+			filter: repositories.TransactionFilter{
+				Type:                 null.StringFrom("transfer").Ptr(),
+				Direction:            null.StringFrom(repositories.TransactionDirectionOut).Ptr(),
+				CreatedFrom:          null.TimeFrom(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)).Ptr(),
+				CreatedTo:            null.TimeFrom(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)).Ptr(),
+				MinAmount:            &minAmount,
+				MaxAmount:            &maxAmount,
+				CounterpartyWalletID: null.StringFrom("<CounterpartyWalletID>").Ptr(),
+				Memo:                 null.StringFrom("50%_off").Ptr(),
+				Sort:                 repositories.TransactionSortLargest,
+			},
+			limit:       2,
+			wantErr:     false,
+			wantIds:     []string{"<TransactionID1>"},
+			wantHasMore: false,
+		},
+		{
+			name: "GivenLargeWithdrawal_WhenListByAmountRange_ThenAbsoluteAmountCompared",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "from", "to", "amount", "type", "created_at"}).
+					AddRow("<TransactionID1>", "<WalletID1>", nil, -500.0, "withdraw", nil)
+				mock.ExpectQuery(`SELECT \* FROM "transactions" WHERE "from" = \$1 AND ABS\(amount\) >= \$2 ORDER BY ABS\(amount\) ASC, created_at ASC, id ASC LIMIT \$3`).
+					WithArgs("<WalletID1>", "100.00", 3).
+					WillReturnRows(rows)
+			},
+			walletId: "<WalletID1>",
+			filter: repositories.TransactionFilter{
+				Direction: null.StringFrom(repositories.TransactionDirectionOut).Ptr(),
+				MinAmount: &withdrawalMin,
+				Sort:      repositories.TransactionSortSmallest,
+			},
+			limit:       2,
+			wantErr:     false,
+			wantIds:     []string{"<TransactionID1>"},
+			wantHasMore: false,
+		},
+		{
+			name: "GivenCounterpartyInEitherDirection_WhenListOldestFirst_ThenBothDirectionsMatched",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "from", "to", "amount", "type", "created_at"}).
+					AddRow("<TransactionID1>", "<WalletID1>", "<CounterpartyWalletID>", 10.0, "transfer", nil).
+					AddRow("<TransactionID2>", "<CounterpartyWalletID>", "<WalletID1>", 20.0, "transfer", nil)
+				mock.ExpectQuery(`SELECT \* FROM "transactions" WHERE \(\("from" = \$1 AND "to" = \$2\) OR \("from" = \$3 AND "to" = \$4\)\) AND "transactions"\."reference" = \$5 ORDER BY created_at ASC, id ASC LIMIT \$6`).
+					WithArgs("<WalletID1>", "<CounterpartyWalletID>", "<CounterpartyWalletID>", "<WalletID1>", "INV-1001", 3).
+					WillReturnRows(rows)
+			},
+			walletId: "<WalletID1>",
+			filter: repositories.TransactionFilter{
+				Reference:            null.StringFrom("INV-1001").Ptr(),
+				CounterpartyWalletID: null.StringFrom("<CounterpartyWalletID>").Ptr(),
+				Sort:                 repositories.TransactionSortOldest,
+			},
+			limit:       2,
+			wantErr:     false,
+			wantIds:     []string{"<TransactionID1>", "<TransactionID2>"},
+			wantHasMore: false,
+		},
+		{
+			name: "GivenMoreRowsThanLimit_WhenListFirstPage_ThenPageTrimmedAndHasMore",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "from", "to", "amount", "type", "created_at"}).
+					AddRow("<TransactionID3>", "<WalletID1>", "<ToWalletID1>", 10.0, "transfer", nil).
+					AddRow("<TransactionID2>", "<FromWalletID2>", "<WalletID1>", 20.0, "transfer", nil).
+					AddRow("<TransactionID1>", "<FromWalletID2>", "<WalletID1>", 30.0, "transfer", nil)
+				mock.ExpectQuery(`SELECT \* FROM "transactions" WHERE "from" = \$1 OR "to" = \$2 ORDER BY created_at DESC, id DESC LIMIT \$3`).
+					WithArgs("<WalletID1>", "<WalletID1>", 3).
+					WillReturnRows(rows)
+			},
+			walletId:    "<WalletID1>",
+			limit:       2,
+			wantErr:     false,
+			wantIds:     []string{"<TransactionID3>", "<TransactionID2>"},
+			wantHasMore: true,
+		},
+		{
+			name: "GivenNextCursor_WhenList_ThenRowsAfterCursor",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "from", "to", "amount", "type", "created_at"}).
+					AddRow("<TransactionID1>", "<FromWalletID2>", "<WalletID1>", 30.0, "transfer", nil)
+				mock.ExpectQuery(`SELECT \* FROM "transactions" WHERE \("from" = \$1 OR "to" = \$2\) AND \(created_at, id\) < \(\$3, \$4\) ORDER BY created_at DESC, id DESC LIMIT \$5`).
+					WithArgs("<WalletID1>", "<WalletID1>", cursorTime, "<TransactionID2>", 3).
+					WillReturnRows(rows)
+			},
+			walletId:    "<WalletID1>",
+			cursor:      &repositories.TransactionCursor{CreatedAt: cursorTime, ID: "<TransactionID2>"},
+			limit:       2,
+			wantErr:     false,
+			wantIds:     []string{"<TransactionID1>"},
+			wantHasMore: false,
+		},
+		{
+			name: "GivenPrevCursor_WhenList_ThenRowsBeforeCursorInDisplayOrder",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "from", "to", "amount", "type", "created_at"}).
+					AddRow("<TransactionID3>", "<WalletID1>", "<ToWalletID1>", 10.0, "transfer", nil).
+					AddRow("<TransactionID4>", "<WalletID1>", "<ToWalletID1>", 10.0, "transfer", nil).
+					AddRow("<TransactionID5>", "<WalletID1>", "<ToWalletID1>", 10.0, "transfer", nil)
+				mock.ExpectQuery(`SELECT \* FROM "transactions" WHERE \("from" = \$1 OR "to" = \$2\) AND \(created_at, id\) > \(\$3, \$4\) ORDER BY created_at ASC, id ASC LIMIT \$5`).
+					WithArgs("<WalletID1>", "<WalletID1>", cursorTime, "<TransactionID2>", 3).
+					WillReturnRows(rows)
+			},
+			walletId:    "<WalletID1>",
+			cursor:      &repositories.TransactionCursor{CreatedAt: cursorTime, ID: "<TransactionID2>", Before: true},
+			limit:       2,
+			wantErr:     false,
+			wantIds:     []string{"<TransactionID4>", "<TransactionID3>"},
+			wantHasMore: true,
+		},
+		{
+			name: "GivenAmountSortCursor_WhenList_ThenAmountComparedFirst",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "transactions" WHERE \("from" = \$1 OR "to" = \$2\) AND \(ABS\(amount\), created_at, id\) > \(\$3, \$4, \$5\) ORDER BY ABS\(amount\) ASC, created_at ASC, id ASC LIMIT \$6`).
+					WithArgs("<WalletID1>", "<WalletID1>", "25.00", cursorTime, "<TransactionID2>", 3).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			walletId:    "<WalletID1>",
+			filter:      repositories.TransactionFilter{Sort: repositories.TransactionSortSmallest},
+			cursor:      &repositories.TransactionCursor{CreatedAt: cursorTime, ID: "<TransactionID2>", Amount: money.MustParse("25")},
+			limit:       2,
+			wantErr:     false,
+			wantIds:     []string{},
+			wantHasMore: false,
+		},
+		{
+			name: "GivenWalletId_WhenListFail_ThenError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "transactions"`).
+					WithArgs("<WalletID2>", "<WalletID2>", 3).
+					WillReturnError(errors.New("query error"))
+			},
+			walletId: "<WalletID2>",
+			limit:    2,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+			transactions, hasMore, err := suite.transactionRepo.List(tc.walletId, tc.filter, tc.cursor, tc.limit)
+			if tc.wantErr {
+				suite.Error(err)
+				suite.Len(transactions, 0)
+			} else {
+				suite.NoError(err)
+				ids := []string{}
+				for _, tx := range transactions {
+					ids = append(ids, tx.ID)
+				}
+				suite.Equal(tc.wantIds, ids)
+				suite.Equal(tc.wantHasMore, hasMore)
+			}
+			suite.sqlMock.ExpectationsWereMet()
+		})
+	}
+}
+
+func (suite *TransactionRepositoryTestSuite) TestCountByWalletId() {
+	testCases := []struct {
+		name        string
+		mock        func(sqlmock.Sqlmock)
+		walletId    string
+		filter      repositories.TransactionFilter
+		wantCount   int64
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenWalletId_WhenCountSuccess_ThenReturnCount",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "transactions" WHERE "from" = \$1 OR "to" = \$2`).
+					WithArgs("<WalletID>", "<WalletID>").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+			},
+			walletId:  "<WalletID>",
+			wantCount: int64(5),
+			wantErr:   false,
+		},
+		{
+			name: "GivenReference_WhenCountSuccess_ThenFilterByReference",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "transactions" WHERE \("from" = \$1 OR "to" = \$2\) AND "transactions"\."reference" = \$3`).
+					WithArgs("<WalletID>", "<WalletID>", "INV-1001").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			},
+			walletId:  "<WalletID>",
+			filter:    repositories.TransactionFilter{Reference: null.StringFrom("INV-1001").Ptr()},
+			wantCount: int64(1),
+			wantErr:   false,
+		},
+		{
+			name: "GivenIncomingDeposits_WhenCountSuccess_ThenFilterByDirectionAndType",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "transactions" WHERE "to" = \$1 AND "transactions"\."type" = \$2`).
+					WithArgs("<WalletID>", "deposit").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+			},
+			walletId: "<WalletID>",
+			filter: repositories.TransactionFilter{
+				Direction: null.StringFrom(repositories.TransactionDirectionIn).Ptr(),
+				Type:      null.StringFrom("deposit").Ptr(),
+			},
+			wantCount: int64(3),
+			wantErr:   false,
+		},
+		{
+			name: "GivenWalletId_WhenCountFail_ThenError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "transactions" WHERE "from" = \$1 OR "to" = \$2`).
+					WithArgs("<WalletID>", "<WalletID>").
+					WillReturnError(errors.New("count error"))
+			},
+			walletId:    "<WalletID>",
+			wantCount:   int64(0),
+			wantErr:     true,
+			expectedErr: "count error",
+		},
+	}
+
+	for _, tc := range testCases {
 		suite.Run(tc.name, func() {
 			tc.mock(suite.sqlMock)
-			count, err := suite.transactionRepo.CountByWalletId(tc.walletId)
+			count, err := suite.transactionRepo.CountByWalletId(tc.walletId, tc.filter)
 			if tc.wantErr {
 				suite.EqualError(err, tc.expectedErr)
 				suite.Equal(int64(0), count)
@@ -367,3 +1528,235 @@ func (suite *TransactionRepositoryTestSuite) TestCountByWalletId() {
 		})
 	}
 }
+
+func (suite *TransactionRepositoryTestSuite) TestBalanceAt() {
+	at := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		mock        func(sqlmock.Sqlmock)
+		wantBalance money.Amount
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenPostingsBeforeDate_WhenBalanceAt_ThenReturnNetCredits",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(CASE WHEN direction = \$1 THEN amount ELSE -amount END\), 0\) FROM "postings" WHERE account_id = \$2 AND created_at < \$3`).
+					WithArgs("credit", "<WalletID>", at).
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("42.50"))
+			},
+			wantBalance: money.MustParse("42.50"),
+		},
+		{
+			name: "GivenDatabaseError_WhenBalanceAt_ThenError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT COALESCE`).
+					WillReturnError(errors.New("db error"))
+			},
+			wantErr:     true,
+			expectedErr: "db error",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+			balance, err := suite.transactionRepo.BalanceAt("<WalletID>", at)
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+			} else {
+				suite.NoError(err)
+				suite.Equal(tc.wantBalance, balance)
+			}
+			suite.NoError(suite.sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (suite *TransactionRepositoryTestSuite) TestListStatementLines() {
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	testCases := []struct {
+		name        string
+		mock        func(sqlmock.Sqlmock)
+		wantLines   []entity.StatementLine
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenPostingsInPeriod_WhenListStatementLines_ThenReturnOneLinePerEntry",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT journal_entries.transaction_id,.* FROM "postings" JOIN journal_entries ON journal_entries.id = postings.journal_entry_id LEFT JOIN transactions ON transactions.id = journal_entries.transaction_id WHERE postings.account_id = \$3 AND postings.created_at >= \$4 AND postings.created_at < \$5 GROUP BY .* ORDER BY MIN\(postings.created_at\), MIN\(postings.id\)`).
+					WithArgs("<WalletID>", "credit", "<WalletID>", from, to).
+					WillReturnRows(sqlmock.NewRows([]string{"transaction_id", "type", "description", "reference", "counterparty_wallet_id", "amount", "created_at"}).
+						AddRow(nil, "adjustment", "Opening balance", nil, nil, "100.00", from).
+						AddRow("TRN1", "transfer", nil, "INV-1", "<OtherWalletID>", "-25.00", from.Add(time.Hour)))
+			},
+			wantLines: []entity.StatementLine{
+				{Type: "adjustment", Description: null.StringFrom("Opening balance").Ptr(), Amount: money.MustParse("100"), CreatedAt: from},
+				{TransactionID: null.StringFrom("TRN1").Ptr(), Type: "transfer", Reference: null.StringFrom("INV-1").Ptr(),
+					CounterpartyWalletID: null.StringFrom("<OtherWalletID>").Ptr(), Amount: money.MustParse("-25"), CreatedAt: from.Add(time.Hour)},
+			},
+		},
+		{
+			name: "GivenDatabaseError_WhenListStatementLines_ThenError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT journal_entries.transaction_id`).
+					WillReturnError(errors.New("db error"))
+			},
+			wantErr:     true,
+			expectedErr: "db error",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+			lines, err := suite.transactionRepo.ListStatementLines("<WalletID>", from, to)
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(lines)
+			} else {
+				suite.NoError(err)
+				suite.Equal(tc.wantLines, lines)
+			}
+			suite.NoError(suite.sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (suite *TransactionRepositoryTestSuite) TestStream() {
+	createdFrom := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	createdTo := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	filter := repositories.TransactionFilter{CreatedFrom: &createdFrom, CreatedTo: &createdTo, Sort: repositories.TransactionSortOldest}
+	query := `SELECT \* FROM "transactions" WHERE \("from" = \$1 OR "to" = \$2\) AND created_at >= \$3 AND created_at < \$4 ORDER BY created_at ASC, id ASC`
+	rows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "from", "to", "amount", "type"}).
+			AddRow("TRN1", nil, "<WalletID>", "100.00", "deposit").
+			AddRow("TRN2", "<WalletID>", nil, "40.00", "withdraw")
+	}
+
+	testCases := []struct {
+		name        string
+		mock        func(sqlmock.Sqlmock)
+		fnErr       error
+		wantIds     []string
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenMatchingRows_WhenStream_ThenEachRowPassedInOrder",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs("<WalletID>", "<WalletID>", createdFrom, createdTo).
+					WillReturnRows(rows())
+			},
+			wantIds: []string{"TRN1", "TRN2"},
+		},
+		{
+			name: "GivenCallbackFails_WhenStream_ThenStopAtFirstError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WillReturnRows(rows())
+			},
+			fnErr:       errors.New("write error"),
+			wantIds:     []string{"TRN1"},
+			wantErr:     true,
+			expectedErr: "write error",
+		},
+		{
+			name: "GivenDatabaseError_WhenStream_ThenError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WillReturnError(errors.New("db error"))
+			},
+			wantErr:     true,
+			expectedErr: "db error",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+			var ids []string
+			err := suite.transactionRepo.Stream("<WalletID>", filter, func(tx entity.Transaction) error {
+				ids = append(ids, tx.ID)
+				return tc.fnErr
+			})
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+			} else {
+				suite.NoError(err)
+			}
+			suite.Equal(tc.wantIds, ids)
+			suite.NoError(suite.sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (suite *TransactionRepositoryTestSuite) TestBackfillBalanceAfter() {
+	testCases := []struct {
+		name        string
+		mock        func(sqlmock.Sqlmock)
+		wantLastId  string
+		wantCount   int
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenMissingBalances_WhenBackfill_ThenReplayPostingsForBatch",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT "id" FROM "transactions" WHERE id > \$1 AND \(\(from_balance_after IS NULL AND "from" IS NOT NULL\) OR \(to_balance_after IS NULL AND "to" IS NOT NULL\)\) ORDER BY id LIMIT \$2`).
+					WithArgs("<AfterID>", 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("<TransactionID1>").AddRow("<TransactionID2>"))
+				mock.ExpectExec(`WITH batch AS .* SUM\(CASE WHEN postings\.direction = \$3 THEN .* UPDATE transactions SET .* WHERE transactions\.id IN \(\$4,\$5\)`).
+					WithArgs("<TransactionID1>", "<TransactionID2>", "credit", "<TransactionID1>", "<TransactionID2>").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+			wantLastId: "<TransactionID2>",
+			wantCount:  2,
+		},
+		{
+			name: "GivenNothingLeft_WhenBackfill_ThenReturnZeroWithoutUpdate",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT "id" FROM "transactions"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			wantLastId: "<AfterID>",
+			wantCount:  0,
+		},
+		{
+			name: "GivenUpdateError_WhenBackfill_ThenError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT "id" FROM "transactions"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("<TransactionID1>"))
+				mock.ExpectExec(`WITH batch AS`).
+					WillReturnError(errors.New("db error"))
+			},
+			wantErr:     true,
+			expectedErr: "db error",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+			lastId, count, err := suite.transactionRepo.BackfillBalanceAfter("<AfterID>", 2)
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+			} else {
+				suite.NoError(err)
+				suite.Equal(tc.wantLastId, lastId)
+				suite.Equal(tc.wantCount, count)
+			}
+			suite.NoError(suite.sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+// expectBalancesAfter expects the wallets' new balances to be stamped on the
+// transaction once its postings are applied.
+func expectBalancesAfter(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(`UPDATE "transactions" SET "from_balance_after"=\(SELECT balance FROM wallets WHERE wallets\.id = transactions\."from"\),"to_balance_after"=\(SELECT balance FROM wallets WHERE wallets\.id = transactions\."to"\) WHERE "id" = \$1 RETURNING "from_balance_after","to_balance_after"`).
+		WillReturnRows(sqlmock.NewRows([]string{"from_balance_after", "to_balance_after"}).AddRow(nil, nil))
+}
@@ -1,8 +1,12 @@
 package repositories
 
 import (
+	"errors"
 	"log"
+	"strings"
 
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/slilp/go-wallet/internal/consts"
 	"github.com/slilp/go-wallet/internal/repositories/entity"
 	"gorm.io/gorm"
 )
@@ -11,6 +15,9 @@ import (
 type UserRepository interface {
 	Create(req entity.User) error
 	QueryByEmail(email string) (*entity.User, error)
+	QueryById(userId string) (*entity.User, error)
+	QueryByHandle(handle string) (*entity.User, error)
+	UpdateSettings(userId string, handle, defaultWalletId *string) (*entity.User, error)
 }
 
 type userRepository struct {
@@ -37,3 +44,70 @@ func (r *userRepository) QueryByEmail(email string) (*entity.User, error) {
 	}
 	return &user, nil
 }
+
+func (r *userRepository) QueryById(userId string) (*entity.User, error) {
+	var user entity.User
+	if err := r.db.Where(&entity.User{ID: userId}).Take(&user).Error; err != nil {
+		log.Printf("Error querying user by id: %v", err)
+		return nil, err
+	}
+	return &user, nil
+}
+
+// QueryByHandle matches handles case-insensitively; they are stored in lower
+// case.
+func (r *userRepository) QueryByHandle(handle string) (*entity.User, error) {
+	handle = strings.ToLower(handle)
+
+	var user entity.User
+	if err := r.db.Where(&entity.User{Handle: &handle}).Take(&user).Error; err != nil {
+		log.Printf("Error querying user by handle: %v", err)
+		return nil, err
+	}
+	return &user, nil
+}
+
+// UpdateSettings sets the non-nil settings. The default wallet must be one
+// of the user's own wallets.
+func (r *userRepository) UpdateSettings(userId string, handle, defaultWalletId *string) (*entity.User, error) {
+	var user entity.User
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{}
+		if handle != nil {
+			updates["handle"] = strings.ToLower(*handle)
+		}
+		if defaultWalletId != nil {
+			var wallet entity.Wallet
+			if err := tx.Where(&entity.Wallet{ID: *defaultWalletId, UserID: userId}).First(&wallet).Error; err != nil {
+				log.Printf("Failed to find default wallet: %v", err)
+				return err
+			}
+			if wallet.IsClosed() {
+				log.Printf("Default wallet %s is closed", wallet.ID)
+				return consts.ErrWalletClosed
+			}
+			updates["default_wallet_id"] = wallet.ID
+		}
+
+		if len(updates) > 0 {
+			updates["updated_at"] = gorm.Expr("NOW()")
+			if err := tx.Model(&entity.User{}).Where(&entity.User{ID: userId}).Updates(updates).Error; err != nil {
+				log.Printf("Update user settings error: %v", err)
+				if isUniqueViolation(err) {
+					return consts.ErrHandleTaken
+				}
+				return err
+			}
+		}
+
+		return tx.Where(&entity.User{ID: userId}).Take(&user).Error
+	}); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation
+}
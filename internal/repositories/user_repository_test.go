@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/slilp/go-wallet/internal/repositories/entity"
 )
 
@@ -123,3 +124,137 @@ func (suite *UserRepositoryTestSuite) TestQueryByEmail() {
 		})
 	}
 }
+
+func (suite *UserRepositoryTestSuite) TestQueryByHandle() {
+	testCases := []struct {
+		name        string
+		mock        func(sqlmock.Sqlmock)
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenMixedCaseHandle_WhenUserFound_ThenMatchedInLowerCase",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "users" WHERE "users"\."handle" = \$1 LIMIT \$2`).
+					WithArgs("friend", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "handle"}).AddRow("<UserID>", "friend"))
+			},
+			wantErr: false,
+		},
+		{
+			name: "GivenHandle_WhenUserNotFound_ThenError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "users" WHERE "users"\."handle" = \$1 LIMIT \$2`).
+					WithArgs("friend", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "handle"}))
+			},
+			wantErr:     true,
+			expectedErr: "record not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+
+			result, err := suite.userRepo.QueryByHandle("Friend")
+
+			if tc.wantErr {
+				suite.Nil(result)
+				suite.EqualError(err, tc.expectedErr)
+			} else {
+				suite.NoError(err)
+				suite.Equal("<UserID>", result.ID)
+			}
+		})
+	}
+}
+
+func (suite *UserRepositoryTestSuite) TestQueryById() {
+	suite.sqlMock.ExpectQuery(`SELECT \* FROM "users" WHERE "users"\."id" = \$1 LIMIT \$2`).
+		WithArgs("<UserID>", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow("<UserID>", "<Email>"))
+
+	result, err := suite.userRepo.QueryById("<UserID>")
+
+	suite.NoError(err)
+	suite.Equal("<Email>", result.Email)
+}
+
+func (suite *UserRepositoryTestSuite) TestUpdateSettings() {
+	handle, walletId := "Friend", "<WalletID>"
+
+	testCases := []struct {
+		name            string
+		handle          *string
+		defaultWalletId *string
+		mock            func(sqlmock.Sqlmock)
+		wantErr         bool
+		expectedErr     string
+	}{
+		{
+			name:            "GivenHandleAndOwnWallet_WhenUpdateSettings_ThenSaved",
+			handle:          &handle,
+			defaultWalletId: &walletId,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE "wallets"\."id" = \$1 AND "wallets"\."user_id" = \$2 ORDER BY "wallets"\."id" LIMIT \$3`).
+					WithArgs("<WalletID>", "<UserID>", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow("<WalletID>", "<UserID>"))
+				mock.ExpectExec(`UPDATE "users" SET "default_wallet_id"=\$1,"handle"=\$2,"updated_at"=NOW\(\) WHERE "users"\."id" = \$3`).
+					WithArgs("<WalletID>", "friend", "<UserID>").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(`SELECT \* FROM "users" WHERE "users"\."id" = \$1 LIMIT \$2`).
+					WithArgs("<UserID>", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "handle", "default_wallet_id"}).AddRow("<UserID>", "friend", "<WalletID>"))
+				mock.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name:            "GivenOtherUsersWallet_WhenUpdateSettings_ThenNotFound",
+			defaultWalletId: &walletId,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE "wallets"\."id" = \$1 AND "wallets"\."user_id" = \$2 ORDER BY "wallets"\."id" LIMIT \$3`).
+					WithArgs("<WalletID>", "<UserID>", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}))
+				mock.ExpectRollback()
+			},
+			wantErr:     true,
+			expectedErr: "record not found",
+		},
+		{
+			name:   "GivenTakenHandle_WhenUpdateSettings_ThenHandleTaken",
+			handle: &handle,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "users" SET "handle"=\$1,"updated_at"=NOW\(\) WHERE "users"\."id" = \$2`).
+					WithArgs("friend", "<UserID>").
+					WillReturnError(&pgconn.PgError{Code: "23505"})
+				mock.ExpectRollback()
+			},
+			wantErr:     true,
+			expectedErr: "handle is already taken",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+
+			result, err := suite.userRepo.UpdateSettings("<UserID>", tc.handle, tc.defaultWalletId)
+
+			if tc.wantErr {
+				suite.Nil(result)
+				suite.EqualError(err, tc.expectedErr)
+			} else {
+				suite.NoError(err)
+				suite.Equal("friend", *result.Handle)
+				suite.Equal("<WalletID>", *result.DefaultWalletID)
+			}
+
+			suite.sqlMock.ExpectationsWereMet()
+		})
+	}
+}
@@ -2,7 +2,12 @@ package repositories
 
 import (
 	"log"
+	"time"
 
+	"github.com/aarondl/null/v9"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/idgen"
+	"github.com/slilp/go-wallet/internal/money"
 	"github.com/slilp/go-wallet/internal/repositories/entity"
 	"gorm.io/gorm"
 )
@@ -11,21 +16,34 @@ import (
 type WalletRepository interface {
 	Create(req entity.Wallet) error
 	UpdateInfo(id, name string, desc *string) error
-	Delete(walletId string) error
+	Close(userId, walletId string, sweepTo *string) (*entity.Wallet, *entity.Transaction, error)
 	ListAll(userId string) ([]entity.Wallet, error)
 	QueryByIdAndUser(userId, walletId string) (*entity.Wallet, error)
+	QueryByIds(walletIds []string) ([]entity.Wallet, error)
+	QueryOldest(userId string) (*entity.Wallet, error)
+	UpdateStatus(walletId, status string, reason *string, actor string) (*entity.Wallet, error)
+	UpdateCreditLine(walletId string, creditLimit money.Amount, interestRate money.Rate) (*entity.Wallet, error)
+	ChargeInterest(asOf time.Time) (int, error)
 }
 
 type walletRepository struct {
-	db *gorm.DB
+	db  *gorm.DB
+	ids idgen.Generator
 }
 
-func NewWalletRepository(db *gorm.DB) WalletRepository {
-	return &walletRepository{db: db}
+func NewWalletRepository(db *gorm.DB, ids idgen.Generator) WalletRepository {
+	return &walletRepository{db: db, ids: ids}
 }
 
 func (r *walletRepository) Create(req entity.Wallet) error {
-	if err := r.db.Create(&req).Error; err != nil {
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&req).Error; err != nil {
+			return err
+		}
+
+		account := entity.LedgerAccount{ID: req.ID, Code: ledgerAccountWallet, Currency: req.Currency}
+		return tx.Create(&account).Error
+	}); err != nil {
 		log.Printf("Create error: %v", err)
 		return err
 	}
@@ -42,13 +60,85 @@ func (r *walletRepository) UpdateInfo(id, name string, desc *string) error {
 	return nil
 }
 
-func (r *walletRepository) Delete(walletId string) error {
-	wallet := entity.Wallet{ID: walletId}
-	if err := r.db.Delete(&wallet).Error; err != nil {
-		log.Printf("Delete error: %v", err)
-		return err
+// Close marks a wallet owned by userId as closed. A remaining balance is
+// first transferred to sweepTo, another of the user's wallets, in the same
+// transaction; without one the wallet must already be empty, and a negative
+// balance drawn on a credit line must be repaid first. Active schedules
+// into or out of the wallet are cancelled and it stops being a default wallet.
+func (r *walletRepository) Close(userId, walletId string, sweepTo *string) (*entity.Wallet, *entity.Transaction, error) {
+	var closed entity.Wallet
+	var sweep *entity.Transaction
+	if err := runInTransaction(r.db, func(tx *gorm.DB) error {
+		sweep = nil
+		var target string
+		if sweepTo != nil {
+			target = *sweepTo
+		}
+
+		wallets, err := lockWallets(tx, walletId, target)
+		if err != nil {
+			return err
+		}
+		wallet, ok := wallets[walletId]
+		if !ok || wallet.UserID != userId {
+			log.Printf("Wallet %s not found for user %s", walletId, userId)
+			return gorm.ErrRecordNotFound
+		}
+
+		available, err := availableBalance(tx, wallet)
+		if err != nil {
+			return err
+		}
+		if held := wallet.Balance + wallet.CreditLimit - available; held != 0 {
+			log.Printf("Wallet %s has %s held", wallet.ID, held)
+			return consts.ErrWalletHasActiveHolds
+		}
+
+		if wallet.Balance != 0 {
+			if sweepTo == nil || wallet.Balance < 0 {
+				log.Printf("Wallet %s still holds %s", wallet.ID, wallet.Balance)
+				return consts.ErrWalletNotEmpty
+			}
+			toWallet, ok := wallets[target]
+			if !ok || toWallet.UserID != userId {
+				log.Printf("Sweep wallet %s not found for user %s", target, userId)
+				return gorm.ErrRecordNotFound
+			}
+
+			description := "Balance swept on wallet close"
+			sweep, err = transferFunds(tx, r.ids, wallet, toWallet, wallet.Balance, entity.TransactionDetails{Description: &description}, nil, entity.Wallet{})
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&entity.Wallet{}).
+			Where(&entity.Wallet{ID: wallet.ID}).
+			Updates(map[string]interface{}{"closed_at": gorm.Expr("NOW()"), "updated_at": gorm.Expr("NOW()")}).Error; err != nil {
+			log.Printf("Close wallet error: %v", err)
+			return err
+		}
+
+		if err := tx.Model(&entity.Schedule{}).
+			Where("status = ? AND (from_wallet_id = ? OR to_wallet_id = ?)", scheduleStatusActive, wallet.ID, wallet.ID).
+			Updates(map[string]interface{}{"next_run_at": nil, "status": scheduleStatusCancelled, "updated_at": gorm.Expr("NOW()")}).Error; err != nil {
+			log.Printf("Cancel schedules of closed wallet error: %v", err)
+			return err
+		}
+
+		if err := tx.Model(&entity.User{}).
+			Where("default_wallet_id = ?", wallet.ID).
+			Update("default_wallet_id", nil).Error; err != nil {
+			log.Printf("Clear default wallet error: %v", err)
+			return err
+		}
+
+		return tx.Where(&entity.Wallet{ID: wallet.ID}).First(&closed).Error
+	}); err != nil {
+		log.Printf("Close wallet transaction error: %v", err)
+		return nil, nil, err
 	}
-	return nil
+	return &closed, sweep, nil
 }
 
 func (r *walletRepository) ListAll(userId string) ([]entity.Wallet, error) {
@@ -68,3 +158,276 @@ func (r *walletRepository) QueryByIdAndUser(userId, walletId string) (*entity.Wa
 	}
 	return &wallet, nil
 }
+
+func (r *walletRepository) QueryByIds(walletIds []string) ([]entity.Wallet, error) {
+	var wallets []entity.Wallet
+	if len(walletIds) == 0 {
+		return wallets, nil
+	}
+	if err := r.db.Where("id IN ?", walletIds).Find(&wallets).Error; err != nil {
+		log.Printf("QueryByIds error: %v", err)
+		return nil, err
+	}
+	return wallets, nil
+}
+
+func (r *walletRepository) QueryOldest(userId string) (*entity.Wallet, error) {
+	var wallet entity.Wallet
+	if err := r.db.Where(&entity.Wallet{UserID: userId}).Where("closed_at IS NULL").Order("created_at, id").First(&wallet).Error; err != nil {
+		log.Printf("QueryOldest error: %v", err)
+		return nil, err
+	}
+	return &wallet, nil
+}
+
+// UpdateStatus sets the operational status of any wallet. The wallet row is
+// locked first, so the change waits for movements already in flight and every
+// later one sees it.
+func (r *walletRepository) UpdateStatus(walletId, status string, reason *string, actor string) (*entity.Wallet, error) {
+	var wallet entity.Wallet
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		wallets, err := lockWallets(tx, walletId)
+		if err != nil {
+			return err
+		}
+		if _, ok := wallets[walletId]; !ok {
+			log.Printf("Wallet %s not found", walletId)
+			return gorm.ErrRecordNotFound
+		}
+
+		if err := tx.Model(&entity.Wallet{}).
+			Where(&entity.Wallet{ID: walletId}).
+			Updates(map[string]interface{}{
+				"status":            status,
+				"status_reason":     reason,
+				"status_changed_by": actor,
+				"status_changed_at": gorm.Expr("NOW()"),
+				"updated_at":        gorm.Expr("NOW()"),
+			}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where(&entity.Wallet{ID: walletId}).First(&wallet).Error
+	}); err != nil {
+		log.Printf("UpdateStatus error: %v", err)
+		return nil, err
+	}
+	return &wallet, nil
+}
+
+// UpdateCreditLine sets how far below zero a wallet may go and the annual
+// interest rate charged on its negative balance. Lowering the limit below
+// what is already drawn only stops further spending.
+func (r *walletRepository) UpdateCreditLine(walletId string, creditLimit money.Amount, interestRate money.Rate) (*entity.Wallet, error) {
+	var wallet entity.Wallet
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		wallets, err := lockWallets(tx, walletId)
+		if err != nil {
+			return err
+		}
+		locked, ok := wallets[walletId]
+		if !ok {
+			log.Printf("Wallet %s not found", walletId)
+			return gorm.ErrRecordNotFound
+		}
+
+		if !locked.Currency.Allows(creditLimit) {
+			log.Printf("Credit limit %s exceeds %s minor units", creditLimit, locked.Currency)
+			return consts.ErrAmountScaleExceeded
+		}
+
+		if err := tx.Model(&entity.Wallet{}).
+			Where(&entity.Wallet{ID: walletId}).
+			Updates(map[string]interface{}{
+				"credit_limit":  creditLimit,
+				"interest_rate": interestRate,
+				"updated_at":    gorm.Expr("NOW()"),
+			}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where(&entity.Wallet{ID: walletId}).First(&wallet).Error
+	}); err != nil {
+		log.Printf("UpdateCreditLine error: %v", err)
+		return nil, err
+	}
+	return &wallet, nil
+}
+
+// ChargeInterest debits interest from every open wallet with a negative
+// balance and an interest rate, at most once per UTC day. Days missed since
+// the wallet was last charged are caught up in the same entry, each on that
+// day's closing balance from the ledger, so wallets repaid since then are
+// still visited. Each wallet is charged in its own DB transaction so one
+// failure does not hold up the rest, and it returns how many wallets were
+// charged.
+func (r *walletRepository) ChargeInterest(asOf time.Time) (int, error) {
+	today := asOf.UTC().Truncate(24 * time.Hour)
+
+	var walletIds []string
+	if err := r.db.Model(&entity.Wallet{}).
+		Where("interest_rate > 0 AND closed_at IS NULL").
+		Where("balance < 0 OR interest_charged_on < ?", today).
+		Order("id").
+		Pluck("id", &walletIds).Error; err != nil {
+		log.Printf("Find wallets owing interest error: %v", err)
+		return 0, err
+	}
+
+	charged := 0
+	for _, walletId := range walletIds {
+		ok, err := r.chargeWalletInterest(walletId, today)
+		if err != nil {
+			log.Printf("Charge interest on wallet %s error: %v", walletId, err)
+			continue
+		}
+		if ok {
+			charged++
+		}
+	}
+	return charged, nil
+}
+
+func (r *walletRepository) chargeWalletInterest(walletId string, today time.Time) (bool, error) {
+	charged := false
+	err := runInTransaction(r.db, func(tx *gorm.DB) error {
+		charged = false
+		wallets, err := lockWallets(tx, walletId)
+		if err != nil {
+			return err
+		}
+		wallet, ok := wallets[walletId]
+		if !ok {
+			return gorm.ErrRecordNotFound
+		}
+
+		// Re-check under the lock: another node may have charged the wallet
+		// since it was selected. A repaid balance still replays missed days.
+		if wallet.InterestRate <= 0 ||
+			(wallet.InterestChargedOn != nil && !wallet.InterestChargedOn.Before(today)) {
+			return nil
+		}
+
+		first := today
+		balances := []money.Amount{}
+		if wallet.InterestChargedOn != nil {
+			first = wallet.InterestChargedOn.UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+			if balances, err = closingBalances(tx, wallet.ID, first, today); err != nil {
+				return err
+			}
+		}
+		balances = append(balances, wallet.Balance)
+
+		// Interest is not posted for missed days until now, so it is added to
+		// what is owed on the days after it, as if charged daily.
+		var interest money.Amount
+		for _, balance := range balances {
+			owed := interest - balance
+			if owed <= 0 {
+				continue
+			}
+			daily, err := owed.Accrue(wallet.InterestRate, 1, wallet.Currency)
+			if err != nil {
+				return err
+			}
+			interest += daily
+		}
+
+		description := "Interest on " + today.Format("2006-01-02")
+		if first.Before(today) {
+			description = "Interest on " + first.Format("2006-01-02") + " to " + today.Format("2006-01-02")
+		}
+
+		if interest > 0 {
+			txRecord := entity.Transaction{
+				ID:      r.ids.NewID(),
+				From:    null.StringFrom(wallet.ID).Ptr(),
+				Amount:  interest,
+				Type:    "interest",
+				Details: entity.TransactionDetails{Description: null.StringFrom(description).Ptr()},
+			}
+			if err := tx.Create(&txRecord).Error; err != nil {
+				log.Printf("Create interest transaction error: %v", err)
+				return err
+			}
+
+			if err := postJournalEntry(tx, &txRecord, []ledgerLine{
+				debitWallet(wallet.ID, wallet.Currency, interest),
+				creditSystem(ledgerAccountInterest, wallet.Currency, interest),
+			}); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&entity.Wallet{}).
+			Where(&entity.Wallet{ID: wallet.ID}).
+			UpdateColumn("interest_charged_on", today).Error; err != nil {
+			log.Printf("Update interest charged date error: %v", err)
+			return err
+		}
+
+		charged = interest > 0
+		return nil
+	})
+	return charged, err
+}
+
+// closingBalances returns the wallet's balance at the end of each UTC day
+// from first up to, but not including, end, replayed from its postings.
+func closingBalances(tx *gorm.DB, walletId string, first, end time.Time) ([]money.Amount, error) {
+	balances := []money.Amount{}
+	if !first.Before(end) {
+		return balances, nil
+	}
+
+	var balance money.Amount
+	if err := tx.Model(&entity.Posting{}).
+		Select("COALESCE(SUM(CASE WHEN direction = ? THEN amount ELSE -amount END), 0)", postingCredit).
+		Where("account_id = ? AND created_at < ?", walletId, first).
+		Scan(&balance).Error; err != nil {
+		log.Printf("Opening balance for interest error: %v", err)
+		return nil, err
+	}
+
+	var days []struct {
+		Day    time.Time
+		Amount money.Amount
+	}
+	if err := tx.Model(&entity.Posting{}).
+		Select("DATE_TRUNC('day', created_at) AS day, SUM(CASE WHEN direction = ? THEN amount ELSE -amount END) AS amount", postingCredit).
+		Where("account_id = ? AND created_at >= ? AND created_at < ?", walletId, first, end).
+		Group("day").
+		Order("day").
+		Scan(&days).Error; err != nil {
+		log.Printf("Daily balances for interest error: %v", err)
+		return nil, err
+	}
+
+	for day := first; day.Before(end); day = day.AddDate(0, 0, 1) {
+		for len(days) > 0 && days[0].Day.Before(day.AddDate(0, 0, 1)) {
+			balance += days[0].Amount
+			days = days[1:]
+		}
+		balances = append(balances, balance)
+	}
+	return balances, nil
+}
+
+// ensureCanDebit and ensureCanCredit enforce the wallet status. They must be
+// called on wallets locked in the current transaction so a concurrent status
+// change cannot slip in between the check and the movement.
+func ensureCanDebit(wallet entity.Wallet) error {
+	if !wallet.CanDebit() {
+		log.Printf("Wallet %s is %s and cannot be debited", wallet.ID, wallet.Status)
+		return &consts.WalletBlockedError{WalletID: wallet.ID, Status: wallet.Status}
+	}
+	return nil
+}
+
+func ensureCanCredit(wallet entity.Wallet) error {
+	if !wallet.CanCredit() {
+		log.Printf("Wallet %s is %s and cannot be credited", wallet.ID, wallet.Status)
+		return &consts.WalletBlockedError{WalletID: wallet.ID, Status: wallet.Status}
+	}
+	return nil
+}
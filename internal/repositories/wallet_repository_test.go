@@ -6,6 +6,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/aarondl/null/v9"
+	"github.com/slilp/go-wallet/internal/money"
 	"github.com/slilp/go-wallet/internal/repositories/entity"
 )
 
@@ -21,14 +22,17 @@ func (suite *WalletRepositoryTestSuite) TestCreate() {
 			name: "GivenNewWallet_WhenCreateSuccess_ThenSuccess",
 			mock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				mock.ExpectQuery(`INSERT INTO "wallets"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectQuery(`INSERT INTO "wallets"`).WillReturnRows(sqlmock.NewRows([]string{"currency", "balance", "created_at", "updated_at"}).AddRow("THB", 0.0, nil, nil))
+				mock.ExpectQuery(`INSERT INTO "ledger_accounts" \("code","currency","id"\)`).
+					WithArgs("wallet", "THB", "<ID>").
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(nil))
 				mock.ExpectCommit()
 			},
 			input: entity.Wallet{
 				ID:        "<ID>",
 				UserID:    "<UserID>",
 				Name:      "<Name>",
-				Balance:   100,
+				Balance:   money.MustParse("100"),
 				CreatedAt: time.Now(),
 				UpdatedAt: time.Now(),
 			},
@@ -47,7 +51,7 @@ func (suite *WalletRepositoryTestSuite) TestCreate() {
 				ID:        "<ID>",
 				UserID:    "<UserID>",
 				Name:      "<Name>",
-				Balance:   100,
+				Balance:   money.MustParse("100"),
 				CreatedAt: time.Now(),
 				UpdatedAt: time.Now(),
 			},
@@ -73,35 +77,168 @@ func (suite *WalletRepositoryTestSuite) TestCreate() {
 	}
 }
 
-func (suite *WalletRepositoryTestSuite) TestDelete() {
+func (suite *WalletRepositoryTestSuite) TestClose() {
+	lockWallet := `SELECT \* FROM "wallets" WHERE id IN \(\$1\) ORDER BY id FOR UPDATE`
+	reloadWallet := `SELECT \* FROM "wallets" WHERE "wallets"\."id" = \$1 ORDER BY "wallets"\."id" LIMIT \$2`
+	closedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sweepTo := "<SweepWalletID>"
+
+	expectCloseUpdates := func(mock sqlmock.Sqlmock) {
+		mock.ExpectExec(`UPDATE "wallets" SET "closed_at"=NOW\(\),"updated_at"=NOW\(\) WHERE "wallets"\."id" = \$1`).
+			WithArgs("<ID>").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`UPDATE "schedules" SET "next_run_at"=\$1,"status"=\$2,"updated_at"=NOW\(\) WHERE status = \$3 AND \(from_wallet_id = \$4 OR to_wallet_id = \$5\)`).
+			WithArgs(nil, "cancelled", "active", "<ID>", "<ID>").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`UPDATE "users" SET "default_wallet_id"=\$1,"updated_at"=\$2 WHERE default_wallet_id = \$3`).
+			WithArgs(nil, sqlmock.AnyArg(), "<ID>").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(reloadWallet).
+			WithArgs("<ID>", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "closed_at"}).AddRow("<ID>", "<UserID>", 0.0, closedAt))
+	}
+
 	testCases := []struct {
 		name        string
 		mock        func(sqlmock.Sqlmock)
-		walletId    string
+		sweepTo     *string
+		wantSweep   bool
 		wantErr     bool
 		expectedErr string
 	}{
 		{
-			name: "GivenWalletId_WhenDeleteSuccess_ThenSuccess",
+			name: "GivenEmptyWallet_WhenClose_ThenClosedAndSchedulesCancelled",
 			mock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				mock.ExpectExec(`DELETE FROM "wallets"`).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery(lockWallet).
+					WithArgs("<ID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance"}).AddRow("<ID>", "<UserID>", 0.0))
+				mock.ExpectQuery(sumActiveHolds).
+					WithArgs("<ID>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("0.00"))
+				expectCloseUpdates(mock)
 				mock.ExpectCommit()
 			},
-			walletId:    "<ID>",
-			wantErr:     false,
-			expectedErr: "",
+			wantErr: false,
+		},
+		{
+			name: "GivenFundedWalletAndSweepTarget_WhenClose_ThenBalanceSweptAndClosed",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockTwoWallets).
+					WithArgs("<ID>", sweepTo).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance"}).AddRow("<ID>", "<UserID>", 40.0).AddRow(sweepTo, "<UserID>", 10.0))
+				mock.ExpectQuery(sumActiveHolds).
+					WithArgs("<ID>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("0.00"))
+				mock.ExpectQuery(sumActiveHolds).
+					WithArgs("<ID>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("0.00"))
+				mock.ExpectQuery(`INSERT INTO "transactions"`).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(nil, nil))
+				mock.ExpectQuery(`INSERT INTO "journal_entries"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("<JournalEntryID>", nil))
+				mock.ExpectQuery(`INSERT INTO "postings"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, nil).AddRow(2, nil))
+				mock.ExpectExec(`UPDATE "wallets"`).
+					WithArgs(sqlmock.AnyArg(), "<ID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`UPDATE "wallets"`).
+					WithArgs(sqlmock.AnyArg(), sweepTo).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectBalancesAfter(mock)
+				expectCloseUpdates(mock)
+				mock.ExpectCommit()
+			},
+			sweepTo:   &sweepTo,
+			wantSweep: true,
+			wantErr:   false,
 		},
 		{
-			name: "GivenWalletId_WhenDeleteFail_ThenError",
+			name: "GivenFundedWalletWithoutSweepTarget_WhenClose_ThenNotEmptyError",
 			mock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				mock.ExpectExec(`DELETE FROM "wallets"`).WillReturnError(errors.New("delete failed"))
+				mock.ExpectQuery(lockWallet).
+					WithArgs("<ID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance"}).AddRow("<ID>", "<UserID>", 40.0))
+				mock.ExpectQuery(sumActiveHolds).
+					WithArgs("<ID>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("0.00"))
+				mock.ExpectRollback()
+			},
+			wantErr:     true,
+			expectedErr: "wallet balance is not zero",
+		},
+		{
+			name: "GivenWalletWithActiveHold_WhenClose_ThenActiveHoldsError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockWallet).
+					WithArgs("<ID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance"}).AddRow("<ID>", "<UserID>", 40.0))
+				mock.ExpectQuery(sumActiveHolds).
+					WithArgs("<ID>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("15.00"))
+				mock.ExpectRollback()
+			},
+			wantErr:     true,
+			expectedErr: "wallet has active holds",
+		},
+		{
+			name: "GivenWalletUsingCreditLine_WhenCloseWithSweepTarget_ThenNotEmptyError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockTwoWallets).
+					WithArgs("<ID>", sweepTo).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "credit_limit"}).AddRow("<ID>", "<UserID>", -25.0, 100.0).AddRow(sweepTo, "<UserID>", 10.0, 0.0))
+				mock.ExpectQuery(sumActiveHolds).
+					WithArgs("<ID>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("0.00"))
+				mock.ExpectRollback()
+			},
+			sweepTo:     &sweepTo,
+			wantErr:     true,
+			expectedErr: "wallet balance is not zero",
+		},
+		{
+			name: "GivenSweepTargetOfOtherUser_WhenClose_ThenNotFound",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockTwoWallets).
+					WithArgs("<ID>", sweepTo).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance"}).AddRow("<ID>", "<UserID>", 40.0).AddRow(sweepTo, "<OtherUserID>", 10.0))
+				mock.ExpectQuery(sumActiveHolds).
+					WithArgs("<ID>", "active").
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("0.00"))
+				mock.ExpectRollback()
+			},
+			sweepTo:     &sweepTo,
+			wantErr:     true,
+			expectedErr: "record not found",
+		},
+		{
+			name: "GivenAlreadyClosedWallet_WhenClose_ThenWalletClosedError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockWallet).
+					WithArgs("<ID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "closed_at"}).AddRow("<ID>", "<UserID>", 0.0, closedAt))
 				mock.ExpectRollback()
 			},
-			walletId:    "<ID>",
 			wantErr:     true,
-			expectedErr: "delete failed",
+			expectedErr: "wallet is closed",
+		},
+		{
+			name: "GivenWalletOfOtherUser_WhenClose_ThenNotFound",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockWallet).
+					WithArgs("<ID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance"}).AddRow("<ID>", "<OtherUserID>", 0.0))
+				mock.ExpectRollback()
+			},
+			wantErr:     true,
+			expectedErr: "record not found",
 		},
 	}
 
@@ -109,15 +246,26 @@ func (suite *WalletRepositoryTestSuite) TestDelete() {
 		suite.Run(tc.name, func() {
 			tc.mock(suite.sqlMock)
 
-			err := suite.walletRepo.Delete(tc.walletId)
+			wallet, sweep, err := suite.walletRepo.Close("<UserID>", "<ID>", tc.sweepTo)
 
 			if tc.wantErr {
+				suite.Nil(wallet)
+				suite.Nil(sweep)
 				suite.EqualError(err, tc.expectedErr)
 			} else {
 				suite.NoError(err)
+				suite.True(wallet.IsClosed())
+				if tc.wantSweep {
+					suite.Require().NotNil(sweep)
+					suite.Equal(money.MustParse("40"), sweep.Amount)
+					suite.Equal("<ID>", *sweep.From)
+					suite.Equal(sweepTo, *sweep.To)
+				} else {
+					suite.Nil(sweep)
+				}
 			}
 
-			suite.sqlMock.ExpectationsWereMet()
+			suite.NoError(suite.sqlMock.ExpectationsWereMet())
 		})
 	}
 }
@@ -145,7 +293,7 @@ func (suite *WalletRepositoryTestSuite) TestListAll() {
 					ID:      "1",
 					UserID:  userIdArg,
 					Name:    "<Name>",
-					Balance: 100,
+					Balance: money.MustParse("100"),
 				},
 			},
 			wantErr:     false,
@@ -262,7 +410,7 @@ func (suite *WalletRepositoryTestSuite) TestQueryByIdAndUser() {
 				ID:      "<ID>",
 				UserID:  "<UserID>",
 				Name:    "<Name>",
-				Balance: 100,
+				Balance: money.MustParse("100"),
 			},
 			wantErr:     false,
 			expectedErr: "",
@@ -318,3 +466,448 @@ func (suite *WalletRepositoryTestSuite) TestQueryByIdAndUser() {
 		})
 	}
 }
+
+func (suite *WalletRepositoryTestSuite) TestQueryByIds() {
+	testCases := []struct {
+		name        string
+		walletIds   []string
+		mock        func(sqlmock.Sqlmock)
+		wantLen     int
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:      "GivenWalletIds_WhenWalletsFound_ThenSuccess",
+			walletIds: []string{"<WalletID1>", "<WalletID2>"},
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "currency"}).
+					AddRow("<WalletID1>", "<UserID>", "THB").
+					AddRow("<WalletID2>", "<UserID>", "USD")
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1,\$2\)`).WithArgs("<WalletID1>", "<WalletID2>").WillReturnRows(rows)
+			},
+			wantLen: 2,
+		},
+		{
+			name:      "GivenNoWalletIds_WhenQuery_ThenSkipQuery",
+			walletIds: []string{},
+			mock:      func(mock sqlmock.Sqlmock) {},
+			wantLen:   0,
+		},
+		{
+			name:      "GivenWalletIds_WhenQueryFails_ThenError",
+			walletIds: []string{"<WalletID1>"},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE id IN \(\$1\)`).WillReturnError(errors.New("query failed"))
+			},
+			wantErr:     true,
+			expectedErr: "query failed",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+
+			result, err := suite.walletRepo.QueryByIds(tc.walletIds)
+
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(result)
+			} else {
+				suite.NoError(err)
+				suite.Len(result, tc.wantLen)
+			}
+			suite.NoError(suite.sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (suite *WalletRepositoryTestSuite) TestQueryOldest() {
+	testCases := []struct {
+		name        string
+		mock        func(sqlmock.Sqlmock)
+		want        string
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenUserWithWallets_WhenQueryOldest_ThenReturnFirstCreated",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE "wallets"\."user_id" = \$1 AND closed_at IS NULL ORDER BY created_at, id,"wallets"\."id" LIMIT \$2`).
+					WithArgs("<UserID>", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow("<ID>", "<UserID>"))
+			},
+			want:    "<ID>",
+			wantErr: false,
+		},
+		{
+			name: "GivenUserWithoutWallets_WhenQueryOldest_ThenError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE "wallets"\."user_id" = \$1 AND closed_at IS NULL ORDER BY created_at, id,"wallets"\."id" LIMIT \$2`).
+					WithArgs("<UserID>", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}))
+			},
+			wantErr:     true,
+			expectedErr: "record not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+
+			result, err := suite.walletRepo.QueryOldest("<UserID>")
+
+			if tc.wantErr {
+				suite.Nil(result)
+				suite.EqualError(err, tc.expectedErr)
+			} else {
+				suite.NoError(err)
+				suite.Equal(tc.want, result.ID)
+			}
+
+			suite.sqlMock.ExpectationsWereMet()
+		})
+	}
+}
+
+func (suite *WalletRepositoryTestSuite) TestUpdateStatus() {
+	lockWallet := `SELECT \* FROM "wallets" WHERE id IN \(\$1\) ORDER BY id FOR UPDATE`
+	reason := "Suspected account takeover"
+	changedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		mock        func(sqlmock.Sqlmock)
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenOpenWallet_WhenUpdateStatus_ThenStatusRecorded",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockWallet).
+					WithArgs("<ID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status"}).AddRow("<ID>", "<UserID>", "active"))
+				mock.ExpectExec(`UPDATE "wallets" SET "status"=\$1,"status_changed_at"=NOW\(\),"status_changed_by"=\$2,"status_reason"=\$3,"updated_at"=NOW\(\) WHERE "wallets"\."id" = \$4`).
+					WithArgs("frozen", "ops@example.com", reason, "<ID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE "wallets"\."id" = \$1 ORDER BY "wallets"\."id" LIMIT \$2`).
+					WithArgs("<ID>", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status", "status_reason", "status_changed_by", "status_changed_at"}).
+						AddRow("<ID>", "<UserID>", "frozen", reason, "ops@example.com", changedAt))
+				mock.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "GivenClosedWallet_WhenUpdateStatus_ThenWalletClosedError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockWallet).
+					WithArgs("<ID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "closed_at"}).AddRow("<ID>", "<UserID>", changedAt))
+				mock.ExpectRollback()
+			},
+			wantErr:     true,
+			expectedErr: "wallet is closed",
+		},
+		{
+			name: "GivenUnknownWallet_WhenUpdateStatus_ThenNotFound",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockWallet).
+					WithArgs("<ID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}))
+				mock.ExpectRollback()
+			},
+			wantErr:     true,
+			expectedErr: "record not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+
+			wallet, err := suite.walletRepo.UpdateStatus("<ID>", "frozen", &reason, "ops@example.com")
+
+			if tc.wantErr {
+				suite.Nil(wallet)
+				suite.EqualError(err, tc.expectedErr)
+			} else {
+				suite.NoError(err)
+				suite.Equal("frozen", wallet.Status)
+				suite.Equal(reason, *wallet.StatusReason)
+				suite.Equal("ops@example.com", *wallet.StatusChangedBy)
+				suite.False(wallet.CanDebit())
+				suite.False(wallet.CanCredit())
+			}
+
+			suite.NoError(suite.sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (suite *WalletRepositoryTestSuite) TestUpdateCreditLine() {
+	lockWallet := `SELECT \* FROM "wallets" WHERE id IN \(\$1\) ORDER BY id FOR UPDATE`
+
+	testCases := []struct {
+		name        string
+		mock        func(sqlmock.Sqlmock)
+		creditLimit money.Amount
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenOpenWallet_WhenUpdateCreditLine_ThenLimitAndRateRecorded",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockWallet).
+					WithArgs("<ID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency"}).AddRow("<ID>", "<UserID>", -20.0, "THB"))
+				mock.ExpectExec(`UPDATE "wallets" SET "credit_limit"=\$1,"interest_rate"=\$2,"updated_at"=NOW\(\) WHERE "wallets"\."id" = \$3`).
+					WithArgs("500.00", "0.18000000", "<ID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery(`SELECT \* FROM "wallets" WHERE "wallets"\."id" = \$1 ORDER BY "wallets"\."id" LIMIT \$2`).
+					WithArgs("<ID>", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency", "credit_limit", "interest_rate"}).
+						AddRow("<ID>", "<UserID>", -20.0, "THB", 500.0, 0.18))
+				mock.ExpectCommit()
+			},
+			creditLimit: money.MustParse("500"),
+			wantErr:     false,
+		},
+		{
+			name: "GivenLimitFinerThanCurrency_WhenUpdateCreditLine_ThenScaleExceeded",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockWallet).
+					WithArgs("<ID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency"}).AddRow("<ID>", "<UserID>", 0.0, "JPY"))
+				mock.ExpectRollback()
+			},
+			creditLimit: money.MustParse("500.50"),
+			wantErr:     true,
+			expectedErr: "amount exceeds supported decimal places",
+		},
+		{
+			name: "GivenUnknownWallet_WhenUpdateCreditLine_ThenNotFound",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockWallet).
+					WithArgs("<ID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}))
+				mock.ExpectRollback()
+			},
+			creditLimit: money.MustParse("500"),
+			wantErr:     true,
+			expectedErr: "record not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+
+			wallet, err := suite.walletRepo.UpdateCreditLine("<ID>", tc.creditLimit, money.MustParseRate("0.18"))
+
+			if tc.wantErr {
+				suite.Nil(wallet)
+				suite.EqualError(err, tc.expectedErr)
+			} else {
+				suite.NoError(err)
+				suite.Equal(money.MustParse("500"), wallet.CreditLimit)
+				suite.Equal(money.MustParse("20"), wallet.CreditUsed())
+			}
+
+			suite.NoError(suite.sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (suite *WalletRepositoryTestSuite) TestChargeInterest() {
+	lockWallet := `SELECT \* FROM "wallets" WHERE id IN \(\$1\) ORDER BY id FOR UPDATE`
+	owingWallets := `SELECT "id" FROM "wallets" WHERE \(interest_rate > 0 AND closed_at IS NULL\) AND \(balance < 0 OR interest_charged_on < \$1\) ORDER BY id`
+	markCharged := `UPDATE "wallets" SET "interest_charged_on"=\$1 WHERE "wallets"\."id" = \$2`
+	asOf := time.Date(2024, 3, 10, 15, 30, 0, 0, time.UTC)
+	today := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name            string
+		mock            func(sqlmock.Sqlmock)
+		expectedCharged int
+	}{
+		{
+			name: "GivenWalletOwingInterest_WhenChargeInterest_ThenDailyInterestDebited",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(owingWallets).
+					WithArgs(today).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("<ID>"))
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockWallet).
+					WithArgs("<ID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency", "credit_limit", "interest_rate"}).
+						AddRow("<ID>", "<UserID>", -36500.0, "THB", 50000.0, 0.1))
+				mock.ExpectQuery(`INSERT INTO "transactions"`).
+					WithArgs("TRN000000000000000001", "<ID>", nil, "10.00", "interest", nil, nil, nil, "0", nil, nil, nil, "Interest on 2024-03-10", nil, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(nil, nil))
+				mock.ExpectQuery(`SELECT \* FROM "ledger_accounts" WHERE "ledger_accounts"\."code" = \$1 AND "ledger_accounts"\."currency" = \$2 ORDER BY "ledger_accounts"\."id" LIMIT \$3`).
+					WithArgs("interest", "THB", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "code", "currency"}).AddRow("<InterestAccountID>", "interest", "THB"))
+				mock.ExpectQuery(`INSERT INTO "journal_entries"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("<JournalEntryID>", nil))
+				mock.ExpectQuery(`INSERT INTO "postings"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, nil).AddRow(2, nil))
+				mock.ExpectExec(`UPDATE "wallets"`).
+					WithArgs(sqlmock.AnyArg(), "<ID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectBalancesAfter(mock)
+				mock.ExpectExec(markCharged).
+					WithArgs(today, "<ID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			expectedCharged: 1,
+		},
+		{
+			name: "GivenWalletLastChargedDaysAgo_WhenChargeInterest_ThenMissedDaysChargedOnLedgerBalances",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(owingWallets).
+					WithArgs(today).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("<ID>"))
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockWallet).
+					WithArgs("<ID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency", "credit_limit", "interest_rate", "interest_charged_on"}).
+						AddRow("<ID>", "<UserID>", -36500.0, "THB", 50000.0, 0.1, today.AddDate(0, 0, -3)))
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(CASE WHEN direction = \$1 THEN amount ELSE -amount END\), 0\) FROM "postings" WHERE account_id = \$2 AND created_at < \$3`).
+					WithArgs("credit", "<ID>", today.AddDate(0, 0, -2)).
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("-36500.00"))
+				// Repaid in full on the second missed day, then borrowed again today.
+				mock.ExpectQuery(`SELECT DATE_TRUNC\('day', created_at\) AS day, SUM\(CASE WHEN direction = \$1 THEN amount ELSE -amount END\) AS amount FROM "postings" WHERE account_id = \$2 AND created_at >= \$3 AND created_at < \$4 GROUP BY "day" ORDER BY day`).
+					WithArgs("credit", "<ID>", today.AddDate(0, 0, -2), today).
+					WillReturnRows(sqlmock.NewRows([]string{"day", "amount"}).AddRow(today.AddDate(0, 0, -1), "36500.00"))
+				mock.ExpectQuery(`INSERT INTO "transactions"`).
+					WithArgs(sqlmock.AnyArg(), "<ID>", nil, "20.00", "interest", nil, nil, nil, "0", nil, nil, nil, "Interest on 2024-03-08 to 2024-03-10", nil, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(nil, nil))
+				mock.ExpectQuery(`SELECT \* FROM "ledger_accounts"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "code", "currency"}).AddRow("<InterestAccountID>", "interest", "THB"))
+				mock.ExpectQuery(`INSERT INTO "journal_entries"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("<JournalEntryID>", nil))
+				mock.ExpectQuery(`INSERT INTO "postings"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, nil).AddRow(2, nil))
+				mock.ExpectExec(`UPDATE "wallets"`).
+					WithArgs(sqlmock.AnyArg(), "<ID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectBalancesAfter(mock)
+				mock.ExpectExec(markCharged).
+					WithArgs(today, "<ID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			expectedCharged: 1,
+		},
+		{
+			name: "GivenOverdrawnWalletRepaidBeforeMissedRun_WhenChargeInterest_ThenMissedDayCharged",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(owingWallets).
+					WithArgs(today).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("<ID>"))
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockWallet).
+					WithArgs("<ID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency", "credit_limit", "interest_rate", "interest_charged_on"}).
+						AddRow("<ID>", "<UserID>", 100.0, "THB", 50000.0, 0.1, today.AddDate(0, 0, -2)))
+				// Still overdrawn at the end of the missed day, repaid today.
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(CASE WHEN direction = \$1 THEN amount ELSE -amount END\), 0\) FROM "postings" WHERE account_id = \$2 AND created_at < \$3`).
+					WithArgs("credit", "<ID>", today.AddDate(0, 0, -1)).
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("-36500.00"))
+				mock.ExpectQuery(`SELECT DATE_TRUNC\('day', created_at\) AS day, SUM\(CASE WHEN direction = \$1 THEN amount ELSE -amount END\) AS amount FROM "postings" WHERE account_id = \$2 AND created_at >= \$3 AND created_at < \$4 GROUP BY "day" ORDER BY day`).
+					WithArgs("credit", "<ID>", today.AddDate(0, 0, -1), today).
+					WillReturnRows(sqlmock.NewRows([]string{"day", "amount"}))
+				mock.ExpectQuery(`INSERT INTO "transactions"`).
+					WithArgs(sqlmock.AnyArg(), "<ID>", nil, "10.00", "interest", nil, nil, nil, "0", nil, nil, nil, "Interest on 2024-03-09 to 2024-03-10", nil, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(nil, nil))
+				mock.ExpectQuery(`SELECT \* FROM "ledger_accounts"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "code", "currency"}).AddRow("<InterestAccountID>", "interest", "THB"))
+				mock.ExpectQuery(`INSERT INTO "journal_entries"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("<JournalEntryID>", nil))
+				mock.ExpectQuery(`INSERT INTO "postings"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, nil).AddRow(2, nil))
+				mock.ExpectExec(`UPDATE "wallets"`).
+					WithArgs(sqlmock.AnyArg(), "<ID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectBalancesAfter(mock)
+				mock.ExpectExec(markCharged).
+					WithArgs(today, "<ID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			expectedCharged: 1,
+		},
+		{
+			name: "GivenWalletRepaidSinceSelected_WhenChargeInterest_ThenOnlyDateRecorded",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(owingWallets).
+					WithArgs(today).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("<ID>"))
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockWallet).
+					WithArgs("<ID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency", "credit_limit", "interest_rate"}).
+						AddRow("<ID>", "<UserID>", 5.0, "THB", 50000.0, 0.1))
+				mock.ExpectExec(markCharged).
+					WithArgs(today, "<ID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			expectedCharged: 0,
+		},
+		{
+			name: "GivenWalletAlreadyChargedToday_WhenChargeInterest_ThenNothingCharged",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(owingWallets).
+					WithArgs(today).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("<ID>"))
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockWallet).
+					WithArgs("<ID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency", "credit_limit", "interest_rate", "interest_charged_on"}).
+						AddRow("<ID>", "<UserID>", -36500.0, "THB", 50000.0, 0.1, today))
+				mock.ExpectCommit()
+			},
+			expectedCharged: 0,
+		},
+		{
+			name: "GivenInterestRoundsToZero_WhenChargeInterest_ThenOnlyDateRecorded",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(owingWallets).
+					WithArgs(today).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("<ID>"))
+				mock.ExpectBegin()
+				mock.ExpectQuery(lockWallet).
+					WithArgs("<ID>").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency", "credit_limit", "interest_rate"}).
+						AddRow("<ID>", "<UserID>", -1.0, "THB", 50000.0, 0.1))
+				mock.ExpectExec(markCharged).
+					WithArgs(today, "<ID>").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			expectedCharged: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock(suite.sqlMock)
+
+			charged, err := suite.walletRepo.ChargeInterest(asOf)
+
+			suite.NoError(err)
+			suite.Equal(tc.expectedCharged, charged)
+			suite.NoError(suite.sqlMock.ExpectationsWereMet())
+		})
+	}
+}
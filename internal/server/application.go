@@ -3,11 +3,14 @@ package server
 import (
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/golang-migrate/migrate/v4"
 	postgres2 "github.com/golang-migrate/migrate/v4/database/postgres"
 	"github.com/slilp/go-wallet/internal/config"
+	"github.com/slilp/go-wallet/internal/idgen"
+	"github.com/slilp/go-wallet/internal/jobs"
 	"github.com/slilp/go-wallet/internal/repositories"
 	"github.com/slilp/go-wallet/internal/services/commands"
 	"github.com/slilp/go-wallet/internal/services/queries"
@@ -21,18 +24,35 @@ type Application struct {
 	Queries  Queries
 	Commands Commands
 	Utils    Utils
+	Jobs     []jobs.Job
 }
 
 type Queries struct {
-	ListWalletsService      queries.ListWalletsService
-	ListTransactionsService queries.ListTransactionsService
-	LoginService            queries.LoginService
+	ListWalletsService         queries.ListWalletsService
+	ListTransactionsService    queries.ListTransactionsService
+	ExportTransactionsService  queries.ExportTransactionsService
+	LoginService               queries.LoginService
+	ListExchangeRatesService   queries.ListExchangeRatesService
+	ListSchedulesService       queries.ListSchedulesService
+	ListPaymentRequestsService queries.ListPaymentRequestsService
+	FindRecipientService       queries.FindRecipientService
+	GetUserSettingsService     queries.GetUserSettingsService
+	GetDepositImportService    queries.GetDepositImportService
 }
 
 type Commands struct {
-	RegisterService    commands.RegisterService
-	WalletService      commands.WalletService
-	TransactionService commands.TransactionService
+	RegisterService       commands.RegisterService
+	WalletService         commands.WalletService
+	TransactionService    commands.TransactionService
+	ExchangeRateService   commands.ExchangeRateService
+	HoldService           commands.HoldService
+	ScheduleService       commands.ScheduleService
+	LimitService          commands.LimitService
+	FeeService            commands.FeeService
+	PaymentRequestService commands.PaymentRequestService
+	UserSettingsService   commands.UserSettingsService
+	StatementService      commands.StatementService
+	DepositImportService  commands.DepositImportService
 }
 
 type Utils struct {
@@ -41,38 +61,81 @@ type Utils struct {
 
 func NewApplicationServer() *Application {
 
-	db, err := initDatabase()
+	db := openDatabase()
+
+	transactionIds, err := idgen.NewGenerator("TRN", config.Config.NodeID)
 	if err != nil {
 		log.Panic(err)
 	}
 
-	if err := initMigrations(db); err != nil {
-		if err == migrate.ErrNoChange {
-			log.Println("No new migrations to apply.")
-		} else {
-			log.Panic("Error applying migrations:", err)
-		}
-	}
-
 	userRepo := repositories.NewUserRepository(db)
-	walletRepo := repositories.NewWalletRepository(db)
-	transactionRepo := repositories.NewTransactionRepository(db)
+	walletRepo := repositories.NewWalletRepository(db, transactionIds)
+	transactionRepo := repositories.NewTransactionRepository(db, transactionIds)
+	exchangeRateRepo := repositories.NewExchangeRateRepository(db)
+	holdRepo := repositories.NewHoldRepository(db, transactionIds)
+	scheduleRepo := repositories.NewScheduleRepository(db)
+	limitRepo := repositories.NewLimitRepository(db)
+	feeRepo := repositories.NewFeeRepository(db)
+	paymentRequestRepo := repositories.NewPaymentRequestRepository(db, transactionIds)
+	statementRepo := repositories.NewStatementRepository(db)
+	depositImportRepo := repositories.NewDepositImportRepository(db)
+
+	transactionService := commands.NewTransactionService(transactionRepo, limitRepo, feeRepo)
 
 	return &Application{
 		Queries: Queries{
-			ListWalletsService:      queries.NewListWalletsService(walletRepo),
-			ListTransactionsService: queries.NewListTransactionsService(walletRepo, transactionRepo),
-			LoginService:            queries.NewLoginService(userRepo),
+			ListWalletsService:         queries.NewListWalletsService(walletRepo, holdRepo),
+			ListTransactionsService:    queries.NewListTransactionsService(walletRepo, transactionRepo),
+			ExportTransactionsService:  queries.NewExportTransactionsService(walletRepo, transactionRepo),
+			LoginService:               queries.NewLoginService(userRepo),
+			ListExchangeRatesService:   queries.NewListExchangeRatesService(exchangeRateRepo),
+			ListSchedulesService:       queries.NewListSchedulesService(scheduleRepo),
+			ListPaymentRequestsService: queries.NewListPaymentRequestsService(paymentRequestRepo),
+			FindRecipientService:       queries.NewFindRecipientService(userRepo, walletRepo),
+			GetUserSettingsService:     queries.NewGetUserSettingsService(userRepo),
+			GetDepositImportService:    queries.NewGetDepositImportService(depositImportRepo),
 		},
 		Commands: Commands{
-			RegisterService:    commands.NewRegisterService(userRepo),
-			WalletService:      commands.NewWalletService(walletRepo),
-			TransactionService: commands.NewTransactionService(transactionRepo),
+			RegisterService:       commands.NewRegisterService(userRepo),
+			WalletService:         commands.NewWalletService(walletRepo),
+			TransactionService:    transactionService,
+			ExchangeRateService:   commands.NewExchangeRateService(exchangeRateRepo),
+			HoldService:           commands.NewHoldService(holdRepo),
+			ScheduleService:       commands.NewScheduleService(scheduleRepo),
+			LimitService:          commands.NewLimitService(limitRepo),
+			FeeService:            commands.NewFeeService(feeRepo),
+			PaymentRequestService: commands.NewPaymentRequestService(paymentRequestRepo, userRepo, limitRepo, feeRepo),
+			UserSettingsService:   commands.NewUserSettingsService(userRepo),
+			StatementService:      commands.NewStatementService(walletRepo, transactionRepo, statementRepo),
+			DepositImportService:  commands.NewDepositImportService(walletRepo, depositImportRepo),
 		},
 		Utils: Utils{
 			Validate: validator.New(),
 		},
+		Jobs: []jobs.Job{
+			jobs.NewHoldExpiryJob(holdRepo, time.Minute),
+			jobs.NewScheduledTransferJob(scheduleRepo, transactionService, time.Minute),
+			jobs.NewPaymentRequestExpiryJob(paymentRequestRepo, time.Minute),
+			jobs.NewCreditInterestJob(walletRepo, time.Hour),
+			jobs.NewDepositImportJob(depositImportRepo, transactionService, 10*time.Second),
+		},
+	}
+}
+
+func openDatabase() *gorm.DB {
+	db, err := initDatabase()
+	if err != nil {
+		log.Panic(err)
+	}
+
+	if err := initMigrations(db); err != nil {
+		if err == migrate.ErrNoChange {
+			log.Println("No new migrations to apply.")
+		} else {
+			log.Panic("Error applying migrations:", err)
+		}
 	}
+	return db
 }
 
 func initDatabase() (*gorm.DB, error) {
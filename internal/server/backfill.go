@@ -0,0 +1,40 @@
+package server
+
+import (
+	"log"
+
+	"github.com/slilp/go-wallet/internal/config"
+	"github.com/slilp/go-wallet/internal/idgen"
+	"github.com/slilp/go-wallet/internal/repositories"
+)
+
+const backfillBatchSize = 1000
+
+// BackfillBalanceAfter fills in from_balance_after and to_balance_after on
+// transactions written before balances were recorded. It is safe to rerun.
+func BackfillBalanceAfter() {
+	db := openDatabase()
+
+	transactionIds, err := idgen.NewGenerator("TRN", config.Config.NodeID)
+	if err != nil {
+		log.Panic(err)
+	}
+	transactionRepo := repositories.NewTransactionRepository(db, transactionIds)
+
+	lastId, total := "", 0
+	for {
+		next, count, err := transactionRepo.BackfillBalanceAfter(lastId, backfillBatchSize)
+		if err != nil {
+			log.Panic("Error backfilling balances:", err)
+		}
+		total += count
+		lastId = next
+		if count > 0 {
+			log.Printf("Backfilled balances up to %s (%d transactions)", lastId, total)
+		}
+		if count < backfillBatchSize {
+			break
+		}
+	}
+	log.Printf("Balance backfill done, %d transactions checked", total)
+}
@@ -11,12 +11,29 @@ import (
 
 type CommandsTestSuite struct {
 	suite.Suite
-	registerService     commands.RegisterService
-	walletService       commands.WalletService
-	transactionService  commands.TransactionService
-	mockWalletRepo      *mock_repositories.MockWalletRepository
-	mockUserRepo        *mock_repositories.MockUserRepository
-	mockTransactionRepo *mock_repositories.MockTransactionRepository
+	registerService        commands.RegisterService
+	walletService          commands.WalletService
+	transactionService     commands.TransactionService
+	exchangeRateService    commands.ExchangeRateService
+	holdService            commands.HoldService
+	scheduleService        commands.ScheduleService
+	limitService           commands.LimitService
+	feeService             commands.FeeService
+	paymentRequestService  commands.PaymentRequestService
+	userSettingsService    commands.UserSettingsService
+	statementService       commands.StatementService
+	depositImportService   commands.DepositImportService
+	mockWalletRepo         *mock_repositories.MockWalletRepository
+	mockUserRepo           *mock_repositories.MockUserRepository
+	mockTransactionRepo    *mock_repositories.MockTransactionRepository
+	mockExchangeRateRepo   *mock_repositories.MockExchangeRateRepository
+	mockHoldRepo           *mock_repositories.MockHoldRepository
+	mockScheduleRepo       *mock_repositories.MockScheduleRepository
+	mockLimitRepo          *mock_repositories.MockLimitRepository
+	mockFeeRepo            *mock_repositories.MockFeeRepository
+	mockPaymentRequestRepo *mock_repositories.MockPaymentRequestRepository
+	mockStatementRepo      *mock_repositories.MockStatementRepository
+	mockDepositImportRepo  *mock_repositories.MockDepositImportRepository
 }
 
 func (suite *CommandsTestSuite) SetupTest() {
@@ -25,13 +42,38 @@ func (suite *CommandsTestSuite) SetupTest() {
 	mockUserRepo := mock_repositories.NewMockUserRepository(ctrl)
 	mockWalletRepo := mock_repositories.NewMockWalletRepository(ctrl)
 	mockTransactionRepo := mock_repositories.NewMockTransactionRepository(ctrl)
+	mockExchangeRateRepo := mock_repositories.NewMockExchangeRateRepository(ctrl)
 	suite.mockUserRepo = mockUserRepo
 	suite.mockWalletRepo = mockWalletRepo
 	suite.mockTransactionRepo = mockTransactionRepo
+	mockHoldRepo := mock_repositories.NewMockHoldRepository(ctrl)
+	suite.mockExchangeRateRepo = mockExchangeRateRepo
+	mockScheduleRepo := mock_repositories.NewMockScheduleRepository(ctrl)
+	suite.mockHoldRepo = mockHoldRepo
+	suite.mockScheduleRepo = mockScheduleRepo
+	mockLimitRepo := mock_repositories.NewMockLimitRepository(ctrl)
+	suite.mockLimitRepo = mockLimitRepo
+	mockFeeRepo := mock_repositories.NewMockFeeRepository(ctrl)
+	suite.mockFeeRepo = mockFeeRepo
+	mockPaymentRequestRepo := mock_repositories.NewMockPaymentRequestRepository(ctrl)
+	suite.mockPaymentRequestRepo = mockPaymentRequestRepo
+	mockStatementRepo := mock_repositories.NewMockStatementRepository(ctrl)
+	suite.mockStatementRepo = mockStatementRepo
+	mockDepositImportRepo := mock_repositories.NewMockDepositImportRepository(ctrl)
+	suite.mockDepositImportRepo = mockDepositImportRepo
 
 	suite.registerService = commands.NewRegisterService(mockUserRepo)
 	suite.walletService = commands.NewWalletService(mockWalletRepo)
-	suite.transactionService = commands.NewTransactionService(mockTransactionRepo)
+	suite.transactionService = commands.NewTransactionService(mockTransactionRepo, mockLimitRepo, mockFeeRepo)
+	suite.exchangeRateService = commands.NewExchangeRateService(mockExchangeRateRepo)
+	suite.holdService = commands.NewHoldService(mockHoldRepo)
+	suite.scheduleService = commands.NewScheduleService(mockScheduleRepo)
+	suite.limitService = commands.NewLimitService(mockLimitRepo)
+	suite.feeService = commands.NewFeeService(mockFeeRepo)
+	suite.paymentRequestService = commands.NewPaymentRequestService(mockPaymentRequestRepo, mockUserRepo, mockLimitRepo, mockFeeRepo)
+	suite.userSettingsService = commands.NewUserSettingsService(mockUserRepo)
+	suite.statementService = commands.NewStatementService(mockWalletRepo, mockTransactionRepo, mockStatementRepo)
+	suite.depositImportService = commands.NewDepositImportService(mockWalletRepo, mockDepositImportRepo)
 }
 
 func TestCommandsTestSuite(t *testing.T) {
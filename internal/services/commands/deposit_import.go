@@ -0,0 +1,238 @@
+package commands
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+)
+
+// maxDepositImportRows caps one file so that validating it stays a handful
+// of queries.
+const maxDepositImportRows = 10000
+
+const maxDepositImportReference = 100
+
+//go:generate mockgen -source=./deposit_import.go -destination=./mocks/mock_deposit_import_service.go -package=mock_commands
+type DepositImportService interface {
+	HandleUpload(filename string, content []byte) (*api_gen.DepositImportResponseData, bool, error)
+}
+
+type depositImportService struct {
+	walletRepo        repositories.WalletRepository
+	depositImportRepo repositories.DepositImportRepository
+}
+
+func NewDepositImportService(walletRepo repositories.WalletRepository, depositImportRepo repositories.DepositImportRepository) DepositImportService {
+	return &depositImportService{walletRepo: walletRepo, depositImportRepo: depositImportRepo}
+}
+
+type depositImportLine struct {
+	rowNumber int
+	walletId  string
+	amount    string
+	reference string
+}
+
+// HandleUpload validates every row of a CSV file with wallet_id, amount and
+// reference columns and queues it for the deposit import job. The returned
+// flag is false when the same file was uploaded before, in which case the
+// earlier import is returned and nothing new is queued.
+func (r *depositImportService) HandleUpload(filename string, content []byte) (*api_gen.DepositImportResponseData, bool, error) {
+	lines, err := parseDepositImport(content)
+	if err != nil {
+		return nil, false, err
+	}
+
+	rows, err := r.validate(lines)
+	if err != nil {
+		return nil, false, err
+	}
+
+	hash := sha256.Sum256(content)
+	depositImport, created, err := r.depositImportRepo.Create(entity.DepositImport{
+		Filename: filename,
+		FileHash: hex.EncodeToString(hash[:]),
+	}, rows)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return depositImportResponse(depositImport), created, nil
+}
+
+func parseDepositImport(content []byte) ([]depositImportLine, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, consts.ErrImportFileEmpty
+	}
+	if err != nil {
+		return nil, consts.ErrImportFileMalformed
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	walletColumn, hasWallet := columns["wallet_id"]
+	amountColumn, hasAmount := columns["amount"]
+	referenceColumn, hasReference := columns["reference"]
+	if !hasWallet || !hasAmount || !hasReference {
+		return nil, consts.ErrImportMissingColumns
+	}
+
+	lines := []depositImportLine{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, consts.ErrImportFileMalformed
+		}
+		if len(lines) == maxDepositImportRows {
+			return nil, consts.ErrImportTooManyRows
+		}
+
+		field := func(i int) string {
+			if i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		rowNumber, _ := reader.FieldPos(0)
+		lines = append(lines, depositImportLine{
+			rowNumber: rowNumber,
+			walletId:  field(walletColumn),
+			amount:    field(amountColumn),
+			reference: field(referenceColumn),
+		})
+	}
+	if len(lines) == 0 {
+		return nil, consts.ErrImportFileEmpty
+	}
+	return lines, nil
+}
+
+// validate turns each line into a row, setting ErrorMessage on the first
+// problem found with it.
+func (r *depositImportService) validate(lines []depositImportLine) ([]entity.DepositImportRow, error) {
+	walletIds := []string{}
+	references := []string{}
+	seenWallet := map[string]bool{}
+	seenReference := map[string]bool{}
+	for _, line := range lines {
+		if _, err := uuid.Parse(line.walletId); err == nil && !seenWallet[line.walletId] {
+			seenWallet[line.walletId] = true
+			walletIds = append(walletIds, line.walletId)
+		}
+		if line.reference != "" && !seenReference[line.reference] {
+			seenReference[line.reference] = true
+			references = append(references, line.reference)
+		}
+	}
+
+	wallets := map[string]entity.Wallet{}
+	if len(walletIds) > 0 {
+		found, err := r.walletRepo.QueryByIds(walletIds)
+		if err != nil {
+			return nil, err
+		}
+		for _, wallet := range found {
+			wallets[wallet.ID] = wallet
+		}
+	}
+
+	used := map[repositories.DepositReference]bool{}
+	if len(references) > 0 {
+		found, err := r.depositImportRepo.FindUsedReferences(references)
+		if err != nil {
+			return nil, err
+		}
+		for _, reference := range found {
+			used[reference] = true
+		}
+	}
+
+	rows := make([]entity.DepositImportRow, 0, len(lines))
+	firstRow := map[string]int{}
+	for _, line := range lines {
+		row := entity.DepositImportRow{
+			RowNumber: line.rowNumber,
+			WalletID:  line.walletId,
+			Reference: line.reference,
+		}
+
+		wallet, walletFound := wallets[line.walletId]
+		amount, amountErr := money.Parse(line.amount)
+		if amountErr == nil {
+			row.Amount = &amount
+		}
+		if walletFound {
+			row.UserID = &wallet.UserID
+		}
+		duplicateOf, duplicate := firstRow[line.reference]
+		if line.reference != "" && !duplicate {
+			firstRow[line.reference] = line.rowNumber
+		}
+
+		problem := ""
+		switch {
+		case !walletFound:
+			problem = "unknown wallet"
+		case wallet.IsClosed():
+			problem = "wallet is closed"
+		case errors.Is(amountErr, consts.ErrAmountScaleExceeded):
+			problem = "amount exceeds wallet currency precision"
+		case amountErr != nil:
+			problem = "invalid amount"
+		case amount <= 0:
+			problem = "amount must be positive"
+		case !wallet.Currency.Allows(amount):
+			problem = "amount exceeds wallet currency precision"
+		case line.reference == "":
+			problem = "reference is required"
+		case len(line.reference) > maxDepositImportReference:
+			problem = fmt.Sprintf("reference exceeds %d characters", maxDepositImportReference)
+		case duplicate:
+			problem = fmt.Sprintf("duplicate reference, first used on row %d", duplicateOf)
+		case used[repositories.DepositReference{WalletID: wallet.ID, Reference: line.reference}]:
+			problem = "reference already deposited to this wallet"
+		}
+		if problem != "" {
+			row.ErrorMessage = &problem
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func depositImportResponse(depositImport *entity.DepositImport) *api_gen.DepositImportResponseData {
+	return &api_gen.DepositImportResponseData{
+		Id:            depositImport.ID,
+		Filename:      depositImport.Filename,
+		Status:        api_gen.DepositImportResponseDataStatus(depositImport.Status),
+		TotalRows:     depositImport.TotalRows,
+		InvalidRows:   depositImport.InvalidRows,
+		CompletedRows: depositImport.CompletedRows,
+		FailedRows:    depositImport.FailedRows,
+		CreatedAt:     depositImport.CreatedAt,
+		UpdatedAt:     depositImport.UpdatedAt,
+		CompletedAt:   depositImport.CompletedAt,
+	}
+}
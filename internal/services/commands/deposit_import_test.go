@@ -0,0 +1,182 @@
+package commands_test
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"go.uber.org/mock/gomock"
+)
+
+func (suite *CommandsTestSuite) TestDepositImportService_HandleUpload() {
+	walletId := "7b0c3f0e-5d8a-4a8e-9c1f-1f2d3c4b5a60"
+	closedWalletId := "2c9e6b1a-0f4d-4c3b-8e7a-6d5c4b3a2f10"
+	jpyWalletId := "9a8b7c6d-5e4f-4a3b-9c2d-1e0f9a8b7c6d"
+	closedAt := time.Now()
+	wallets := []entity.Wallet{
+		{ID: walletId, UserID: "<UserID>", Currency: "THB"},
+		{ID: closedWalletId, UserID: "<UserID>", Currency: "THB", ClosedAt: &closedAt},
+		{ID: jpyWalletId, UserID: "<UserID>", Currency: "JPY"},
+	}
+
+	testCases := []struct {
+		name        string
+		content     string
+		mock        func()
+		wantErr     bool
+		expectedErr error
+		wantCreated bool
+		wantRows    []string
+	}{
+		{
+			name:    "GivenValidFile_WhenUpload_ThenQueueImport",
+			content: "\xef\xbb\xbfWallet_ID,Amount,Reference\n" + walletId + ",100.50,INV-1\n" + walletId + ", 20 ,INV-2\n",
+			mock: func() {
+				suite.mockWalletRepo.EXPECT().QueryByIds([]string{walletId}).Return(wallets[:1], nil)
+				suite.mockDepositImportRepo.EXPECT().FindUsedReferences([]string{"INV-1", "INV-2"}).Return(nil, nil)
+			},
+			wantCreated: true,
+			wantRows:    []string{"", ""},
+		},
+		{
+			name: "GivenInvalidRows_WhenUpload_ThenReportEveryProblem",
+			content: "reference,wallet_id,amount\n" +
+				"A1,not-a-uuid,10\n" +
+				"A2," + closedWalletId + ",10\n" +
+				"A3," + walletId + ",ten\n" +
+				"A4," + walletId + ",-5\n" +
+				"A5," + jpyWalletId + ",1.50\n" +
+				"A6," + walletId + ",1.005\n" +
+				"," + walletId + ",10\n" +
+				strings.Repeat("R", 101) + "," + walletId + ",10\n" +
+				"A9," + walletId + ",10\n" +
+				"A9," + walletId + ",10\n" +
+				"USED," + walletId + ",10\n",
+			mock: func() {
+				suite.mockWalletRepo.EXPECT().QueryByIds(gomock.Any()).Return(wallets, nil)
+				suite.mockDepositImportRepo.EXPECT().FindUsedReferences(gomock.Any()).Return([]repositories.DepositReference{
+					{WalletID: walletId, Reference: "USED"},
+				}, nil)
+			},
+			wantCreated: true,
+			wantRows: []string{
+				"unknown wallet",
+				"wallet is closed",
+				"invalid amount",
+				"amount must be positive",
+				"amount exceeds wallet currency precision",
+				"amount exceeds wallet currency precision",
+				"reference is required",
+				"reference exceeds 100 characters",
+				"",
+				"duplicate reference, first used on row 10",
+				"reference already deposited to this wallet",
+			},
+		},
+		{
+			name:    "GivenSameFileAgain_WhenUpload_ThenReturnExistingImport",
+			content: "wallet_id,amount,reference\n" + walletId + ",100,INV-1\n",
+			mock: func() {
+				suite.mockWalletRepo.EXPECT().QueryByIds([]string{walletId}).Return(wallets[:1], nil)
+				suite.mockDepositImportRepo.EXPECT().FindUsedReferences([]string{"INV-1"}).Return(nil, nil)
+			},
+			wantCreated: false,
+			wantRows:    []string{""},
+		},
+		{
+			name:        "GivenHeaderOnly_WhenUpload_ThenError",
+			content:     "wallet_id,amount,reference\n",
+			mock:        func() {},
+			wantErr:     true,
+			expectedErr: consts.ErrImportFileEmpty,
+		},
+		{
+			name:        "GivenMissingColumn_WhenUpload_ThenError",
+			content:     "wallet_id,amount\n" + walletId + ",100\n",
+			mock:        func() {},
+			wantErr:     true,
+			expectedErr: consts.ErrImportMissingColumns,
+		},
+		{
+			name:        "GivenMalformedCsv_WhenUpload_ThenError",
+			content:     "wallet_id,amount,reference\n\"" + walletId + ",100,INV-1\n",
+			mock:        func() {},
+			wantErr:     true,
+			expectedErr: consts.ErrImportFileMalformed,
+		},
+		{
+			name:        "GivenTooManyRows_WhenUpload_ThenError",
+			content:     "wallet_id,amount,reference\n" + strings.Repeat(walletId+",1,R\n", 10001),
+			mock:        func() {},
+			wantErr:     true,
+			expectedErr: consts.ErrImportTooManyRows,
+		},
+		{
+			name:    "GivenWalletLookupFails_WhenUpload_ThenError",
+			content: "wallet_id,amount,reference\n" + walletId + ",100,INV-1\n",
+			mock: func() {
+				suite.mockWalletRepo.EXPECT().QueryByIds(gomock.Any()).Return(nil, errors.New("db error"))
+			},
+			wantErr:     true,
+			expectedErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			var stored []entity.DepositImportRow
+			if !tc.wantErr {
+				suite.mockDepositImportRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(depositImport entity.DepositImport, rows []entity.DepositImportRow) (*entity.DepositImport, bool, error) {
+						suite.Equal("deposits.csv", depositImport.Filename)
+						suite.Len(depositImport.FileHash, 64)
+						stored = rows
+						depositImport.ID = "<ImportID>"
+						depositImport.Status = "pending"
+						return &depositImport, tc.wantCreated, nil
+					})
+			}
+
+			result, created, err := suite.depositImportService.HandleUpload("deposits.csv", []byte(tc.content))
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr.Error())
+				suite.Nil(result)
+			} else {
+				suite.NoError(err)
+				suite.Equal("<ImportID>", result.Id)
+				suite.Equal(tc.wantCreated, created)
+				suite.Len(stored, len(tc.wantRows))
+				for i, want := range tc.wantRows {
+					suite.Equal(i+2, stored[i].RowNumber)
+					if want == "" {
+						suite.Nil(stored[i].ErrorMessage)
+						suite.Equal("<UserID>", *stored[i].UserID)
+					} else {
+						suite.Equal(want, *stored[i].ErrorMessage)
+					}
+				}
+			}
+		})
+	}
+}
+
+func (suite *CommandsTestSuite) TestDepositImportService_HandleUpload_ParsesAmount() {
+	walletId := "7b0c3f0e-5d8a-4a8e-9c1f-1f2d3c4b5a60"
+	suite.mockWalletRepo.EXPECT().QueryByIds(gomock.Any()).Return([]entity.Wallet{{ID: walletId, UserID: "<UserID>", Currency: "THB"}}, nil)
+	suite.mockDepositImportRepo.EXPECT().FindUsedReferences(gomock.Any()).Return(nil, nil)
+	suite.mockDepositImportRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(depositImport entity.DepositImport, rows []entity.DepositImportRow) (*entity.DepositImport, bool, error) {
+			suite.Equal(money.MustParse("100.50"), *rows[0].Amount)
+			suite.Equal(walletId, rows[0].WalletID)
+			suite.Equal("INV-1", rows[0].Reference)
+			return &depositImport, true, nil
+		})
+
+	_, _, err := suite.depositImportService.HandleUpload("deposits.csv", []byte("wallet_id,amount,reference\n"+walletId+",100.50,INV-1\n"))
+	suite.NoError(err)
+}
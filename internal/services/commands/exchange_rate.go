@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+)
+
+//go:generate mockgen -source=./exchange_rate.go -destination=./mocks/mock_exchange_rate_service.go -package=mock_commands
+type ExchangeRateService interface {
+	HandleLoadRates(req api_gen.LoadExchangeRatesRequest) error
+}
+
+type exchangeRateService struct {
+	exchangeRateRepo repositories.ExchangeRateRepository
+}
+
+func NewExchangeRateService(exchangeRateRepo repositories.ExchangeRateRepository) ExchangeRateService {
+	return &exchangeRateService{exchangeRateRepo: exchangeRateRepo}
+}
+
+func (r *exchangeRateService) HandleLoadRates(req api_gen.LoadExchangeRatesRequest) error {
+	rates := []entity.ExchangeRate{}
+	for _, item := range req.Rates {
+		base, ok := money.ParseCurrency(item.BaseCurrency)
+		if !ok {
+			return consts.ErrUnsupportedCurrency
+		}
+		quote, ok := money.ParseCurrency(item.QuoteCurrency)
+		if !ok {
+			return consts.ErrUnsupportedCurrency
+		}
+
+		var spread money.Rate
+		if item.Spread != nil {
+			spread = *item.Spread
+		}
+
+		if base == quote || item.Rate <= 0 || spread < 0 || spread >= money.MustParseRate("1") {
+			return consts.ErrInvalidExchangeRate
+		}
+
+		rates = append(rates, entity.ExchangeRate{
+			BaseCurrency:  base,
+			QuoteCurrency: quote,
+			Rate:          item.Rate,
+			Spread:        spread,
+		})
+	}
+
+	return r.exchangeRateRepo.Upsert(rates)
+}
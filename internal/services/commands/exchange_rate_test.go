@@ -0,0 +1,101 @@
+package commands_test
+
+import (
+	"errors"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"go.uber.org/mock/gomock"
+)
+
+func (suite *CommandsTestSuite) TestExchangeRateService_HandleLoadRates() {
+	spread := money.MustParseRate("0.005")
+	fullSpread := money.MustParseRate("1")
+
+	testCases := []struct {
+		name        string
+		req         api_gen.LoadExchangeRatesRequest
+		mock        func()
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenValidRates_WhenUpsertSuccess_ThenSuccess",
+			req: api_gen.LoadExchangeRatesRequest{
+				Rates: []api_gen.ExchangeRateRequest{
+					{BaseCurrency: "usd", QuoteCurrency: "THB", Rate: money.MustParseRate("35.5"), Spread: &spread},
+					{BaseCurrency: "THB", QuoteCurrency: "USD", Rate: money.MustParseRate("0.028")},
+				},
+			},
+			mock: func() {
+				suite.mockExchangeRateRepo.EXPECT().
+					Upsert([]entity.ExchangeRate{
+						{BaseCurrency: "USD", QuoteCurrency: "THB", Rate: money.MustParseRate("35.5"), Spread: spread},
+						{BaseCurrency: "THB", QuoteCurrency: "USD", Rate: money.MustParseRate("0.028")},
+					}).
+					Return(nil)
+			},
+			wantErr:     false,
+			expectedErr: "",
+		},
+		{
+			name: "GivenUnsupportedCurrency_WhenLoadRates_ThenError",
+			req: api_gen.LoadExchangeRatesRequest{
+				Rates: []api_gen.ExchangeRateRequest{
+					{BaseCurrency: "CHF", QuoteCurrency: "THB", Rate: money.MustParseRate("40")},
+				},
+			},
+			mock:        func() {},
+			wantErr:     true,
+			expectedErr: "unsupported currency",
+		},
+		{
+			name: "GivenSameCurrencyPair_WhenLoadRates_ThenError",
+			req: api_gen.LoadExchangeRatesRequest{
+				Rates: []api_gen.ExchangeRateRequest{
+					{BaseCurrency: "THB", QuoteCurrency: "THB", Rate: money.MustParseRate("1")},
+				},
+			},
+			mock:        func() {},
+			wantErr:     true,
+			expectedErr: "invalid exchange rate",
+		},
+		{
+			name: "GivenFullSpread_WhenLoadRates_ThenError",
+			req: api_gen.LoadExchangeRatesRequest{
+				Rates: []api_gen.ExchangeRateRequest{
+					{BaseCurrency: "USD", QuoteCurrency: "THB", Rate: money.MustParseRate("35"), Spread: &fullSpread},
+				},
+			},
+			mock:        func() {},
+			wantErr:     true,
+			expectedErr: "invalid exchange rate",
+		},
+		{
+			name: "GivenValidRates_WhenUpsertFails_ThenError",
+			req: api_gen.LoadExchangeRatesRequest{
+				Rates: []api_gen.ExchangeRateRequest{
+					{BaseCurrency: "USD", QuoteCurrency: "THB", Rate: money.MustParseRate("35.5")},
+				},
+			},
+			mock: func() {
+				suite.mockExchangeRateRepo.EXPECT().Upsert(gomock.Any()).Return(errors.New("upsert error"))
+			},
+			wantErr:     true,
+			expectedErr: "upsert error",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			err := suite.exchangeRateService.HandleLoadRates(tc.req)
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+			} else {
+				suite.NoError(err)
+			}
+		})
+	}
+}
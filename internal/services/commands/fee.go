@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+)
+
+//go:generate mockgen -source=./fee.go -destination=./mocks/mock_fee_service.go -package=mock_commands
+type FeeService interface {
+	HandleLoadRules(req api_gen.LoadFeeRulesRequest) error
+	HandleQuote(userId string, req api_gen.FeeQuoteRequest) (*api_gen.FeeQuoteResponseData, error)
+}
+
+type feeService struct {
+	feeRepo repositories.FeeRepository
+}
+
+func NewFeeService(feeRepo repositories.FeeRepository) FeeService {
+	return &feeService{feeRepo: feeRepo}
+}
+
+func (r *feeService) HandleLoadRules(req api_gen.LoadFeeRulesRequest) error {
+	type tierKey struct {
+		transactionType string
+		currency        money.Currency
+		minAmount       money.Amount
+	}
+
+	rules := []entity.FeeRule{}
+	seen := map[tierKey]bool{}
+	for _, item := range req.Rules {
+		currency, ok := money.ParseCurrency(item.Currency)
+		if !ok {
+			return consts.ErrUnsupportedCurrency
+		}
+
+		rule := entity.FeeRule{
+			TransactionType: string(item.TransactionType),
+			Currency:        currency,
+			MinFee:          item.MinFee,
+			MaxFee:          item.MaxFee,
+			FeeWalletID:     item.FeeWalletId,
+		}
+		if item.MinAmount != nil {
+			rule.MinAmount = *item.MinAmount
+		}
+		if item.FlatFee != nil {
+			rule.FlatFee = *item.FlatFee
+		}
+		if item.Rate != nil {
+			rule.Rate = *item.Rate
+		}
+
+		key := tierKey{rule.TransactionType, rule.Currency, rule.MinAmount}
+		if seen[key] || rule.Rate >= money.MustParseRate("1") ||
+			(rule.MinFee != nil && rule.MaxFee != nil && *rule.MinFee > *rule.MaxFee) {
+			return consts.ErrInvalidFeeRule
+		}
+		for _, amount := range []*money.Amount{&rule.MinAmount, &rule.FlatFee, rule.MinFee, rule.MaxFee} {
+			if amount != nil && !currency.Allows(*amount) {
+				return consts.ErrAmountScaleExceeded
+			}
+		}
+		seen[key] = true
+
+		rules = append(rules, rule)
+	}
+
+	return r.feeRepo.Replace(rules)
+}
+
+// HandleQuote computes the fee the transfer or withdrawal would be charged
+// now, without moving money.
+func (r *feeService) HandleQuote(userId string, req api_gen.FeeQuoteRequest) (*api_gen.FeeQuoteResponseData, error) {
+	wallet, fee, err := quoteFee(r.feeRepo, userId, req.WalletId, string(req.TransactionType), req.Amount)
+	if err != nil {
+		return nil, err
+	}
+	if !wallet.Currency.Allows(req.Amount) {
+		return nil, consts.ErrAmountScaleExceeded
+	}
+
+	var amountFee money.Amount
+	if fee != nil {
+		amountFee = fee.Amount
+	}
+	return &api_gen.FeeQuoteResponseData{
+		TransactionType: string(req.TransactionType),
+		WalletId:        wallet.ID,
+		Currency:        wallet.Currency.String(),
+		Amount:          req.Amount,
+		Fee:             amountFee,
+		Total:           req.Amount + amountFee,
+	}, nil
+}
@@ -0,0 +1,213 @@
+package commands_test
+
+import (
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"github.com/slilp/go-wallet/internal/services/commands"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func ratePtr(s string) *money.Rate {
+	rate := money.MustParseRate(s)
+	return &rate
+}
+
+func (suite *CommandsTestSuite) TestFeeService_HandleLoadRules() {
+	testCases := []struct {
+		name        string
+		req         api_gen.LoadFeeRulesRequest
+		mock        func()
+		expectedErr error
+	}{
+		{
+			name: "GivenTieredRules_WhenLoad_ThenReplaced",
+			req: api_gen.LoadFeeRulesRequest{Rules: []api_gen.FeeRuleRequest{
+				{TransactionType: api_gen.FeeRuleRequestTransactionTypeTransfer, Currency: "thb", FlatFee: amountPtr("5"), FeeWalletId: "<FeeWalletID>"},
+				{TransactionType: api_gen.FeeRuleRequestTransactionTypeTransfer, Currency: "THB", MinAmount: amountPtr("1000"), Rate: ratePtr("0.01"), MaxFee: amountPtr("50"), FeeWalletId: "<FeeWalletID>"},
+			}},
+			mock: func() {
+				suite.mockFeeRepo.EXPECT().Replace([]entity.FeeRule{
+					{TransactionType: "transfer", Currency: "THB", FlatFee: money.MustParse("5"), FeeWalletID: "<FeeWalletID>"},
+					{TransactionType: "transfer", Currency: "THB", MinAmount: money.MustParse("1000"), Rate: money.MustParseRate("0.01"), MaxFee: amountPtr("50"), FeeWalletID: "<FeeWalletID>"},
+				}).Return(nil)
+			},
+		},
+		{
+			name: "GivenDuplicateTier_WhenLoad_ThenInvalidFeeRule",
+			req: api_gen.LoadFeeRulesRequest{Rules: []api_gen.FeeRuleRequest{
+				{TransactionType: api_gen.FeeRuleRequestTransactionTypeWithdraw, Currency: "THB", FlatFee: amountPtr("5"), FeeWalletId: "<FeeWalletID>"},
+				{TransactionType: api_gen.FeeRuleRequestTransactionTypeWithdraw, Currency: "THB", FlatFee: amountPtr("10"), FeeWalletId: "<FeeWalletID>"},
+			}},
+			mock:        func() {},
+			expectedErr: consts.ErrInvalidFeeRule,
+		},
+		{
+			name: "GivenMinFeeAboveMaxFee_WhenLoad_ThenInvalidFeeRule",
+			req: api_gen.LoadFeeRulesRequest{Rules: []api_gen.FeeRuleRequest{
+				{TransactionType: api_gen.FeeRuleRequestTransactionTypeWithdraw, Currency: "THB", MinFee: amountPtr("10"), MaxFee: amountPtr("5"), FeeWalletId: "<FeeWalletID>"},
+			}},
+			mock:        func() {},
+			expectedErr: consts.ErrInvalidFeeRule,
+		},
+		{
+			name: "GivenRateOfOneOrMore_WhenLoad_ThenInvalidFeeRule",
+			req: api_gen.LoadFeeRulesRequest{Rules: []api_gen.FeeRuleRequest{
+				{TransactionType: api_gen.FeeRuleRequestTransactionTypeWithdraw, Currency: "THB", Rate: ratePtr("1"), FeeWalletId: "<FeeWalletID>"},
+			}},
+			mock:        func() {},
+			expectedErr: consts.ErrInvalidFeeRule,
+		},
+		{
+			name: "GivenFractionalFeeForJPY_WhenLoad_ThenScaleError",
+			req: api_gen.LoadFeeRulesRequest{Rules: []api_gen.FeeRuleRequest{
+				{TransactionType: api_gen.FeeRuleRequestTransactionTypeWithdraw, Currency: "JPY", FlatFee: amountPtr("0.5"), FeeWalletId: "<FeeWalletID>"},
+			}},
+			mock:        func() {},
+			expectedErr: consts.ErrAmountScaleExceeded,
+		},
+		{
+			name: "GivenUnsupportedCurrency_WhenLoad_ThenError",
+			req: api_gen.LoadFeeRulesRequest{Rules: []api_gen.FeeRuleRequest{
+				{TransactionType: api_gen.FeeRuleRequestTransactionTypeWithdraw, Currency: "XYZ", FeeWalletId: "<FeeWalletID>"},
+			}},
+			mock:        func() {},
+			expectedErr: consts.ErrUnsupportedCurrency,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			err := suite.feeService.HandleLoadRules(tc.req)
+			if tc.expectedErr != nil {
+				suite.ErrorIs(err, tc.expectedErr)
+			} else {
+				suite.NoError(err)
+			}
+		})
+	}
+}
+
+func (suite *CommandsTestSuite) TestFeeService_HandleQuote() {
+	thbWallet := &entity.Wallet{ID: "<WalletID>", Currency: "THB"}
+	tiers := []entity.FeeRule{
+		{MinAmount: money.MustParse("1000"), Rate: money.MustParseRate("0.01"), MaxFee: amountPtr("50"), FeeWalletID: "<FeeWalletID>"},
+		{FlatFee: money.MustParse("5"), FeeWalletID: "<FeeWalletID>"},
+		{MinAmount: money.MustParse("100"), FlatFee: money.MustParse("2"), Rate: money.MustParseRate("0.005"), MinFee: amountPtr("3"), FeeWalletID: "<FeeWalletID>"},
+	}
+
+	testCases := []struct {
+		name        string
+		wallet      *entity.Wallet
+		rules       []entity.FeeRule
+		amount      money.Amount
+		findErr     error
+		expectedFee money.Amount
+		expectedErr error
+	}{
+		{
+			name:        "GivenNoRules_WhenQuote_ThenNoFee",
+			wallet:      thbWallet,
+			amount:      money.MustParse("500"),
+			expectedFee: 0,
+		},
+		{
+			name:        "GivenAmountInFirstTier_WhenQuote_ThenFlatFee",
+			wallet:      thbWallet,
+			rules:       tiers,
+			amount:      money.MustParse("99.99"),
+			expectedFee: money.MustParse("5"),
+		},
+		{
+			name:        "GivenAmountInMiddleTier_WhenQuote_ThenMinFeeApplies",
+			wallet:      thbWallet,
+			rules:       tiers,
+			amount:      money.MustParse("100"),
+			expectedFee: money.MustParse("3"),
+		},
+		{
+			name:        "GivenAmountInMiddleTier_WhenQuote_ThenFlatPlusPercentage",
+			wallet:      thbWallet,
+			rules:       tiers,
+			amount:      money.MustParse("999.99"),
+			expectedFee: money.MustParse("6.99"),
+		},
+		{
+			name:        "GivenAmountInTopTier_WhenQuote_ThenPercentage",
+			wallet:      thbWallet,
+			rules:       tiers,
+			amount:      money.MustParse("1234.56"),
+			expectedFee: money.MustParse("12.34"),
+		},
+		{
+			name:        "GivenLargeAmount_WhenQuote_ThenMaxFeeApplies",
+			wallet:      thbWallet,
+			rules:       tiers,
+			amount:      money.MustParse("10000"),
+			expectedFee: money.MustParse("50"),
+		},
+		{
+			name:        "GivenJPYWallet_WhenQuote_ThenPercentageRoundedToWholeYen",
+			wallet:      &entity.Wallet{ID: "<WalletID>", Currency: "JPY"},
+			rules:       []entity.FeeRule{{Rate: money.MustParseRate("0.015"), FeeWalletID: "<FeeWalletID>"}},
+			amount:      money.MustParse("1999"),
+			expectedFee: money.MustParse("29"),
+		},
+		{
+			name:        "GivenFractionalAmountOnJPYWallet_WhenQuote_ThenScaleError",
+			wallet:      &entity.Wallet{ID: "<WalletID>", Currency: "JPY"},
+			amount:      money.MustParse("10.5"),
+			expectedErr: consts.ErrAmountScaleExceeded,
+		},
+		{
+			name:        "GivenWalletOfAnotherUser_WhenQuote_ThenNotFound",
+			amount:      money.MustParse("100"),
+			findErr:     gorm.ErrRecordNotFound,
+			expectedErr: gorm.ErrRecordNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.mockFeeRepo.EXPECT().FindForWallet("<UserID>", "<WalletID>", "withdraw").Return(tc.wallet, tc.rules, tc.findErr)
+
+			result, err := suite.feeService.HandleQuote("<UserID>", api_gen.FeeQuoteRequest{
+				TransactionType: api_gen.FeeQuoteRequestTransactionTypeWithdraw,
+				WalletId:        "<WalletID>",
+				Amount:          tc.amount,
+			})
+			if tc.expectedErr != nil {
+				suite.ErrorIs(err, tc.expectedErr)
+				suite.Nil(result)
+				return
+			}
+			suite.NoError(err)
+			suite.Equal(tc.expectedFee, result.Fee)
+			suite.Equal(tc.amount+tc.expectedFee, result.Total)
+			suite.Equal(tc.wallet.Currency.String(), result.Currency)
+		})
+	}
+}
+
+func (suite *CommandsTestSuite) TestTransactionService_TransferChargesQuotedFee() {
+	suite.mockLimitRepo.EXPECT().ListForUser("<UserID>").Return(nil, nil)
+	suite.mockFeeRepo.EXPECT().FindForWallet("<UserID>", "<FromWalletID>", "transfer").
+		Return(&entity.Wallet{ID: "<FromWalletID>", Currency: "THB"}, []entity.FeeRule{{FlatFee: money.MustParse("5"), FeeWalletID: "<FeeWalletID>"}}, nil)
+	suite.mockTransactionRepo.EXPECT().
+		UpdateTransferTransaction("<UserID>", "<FromWalletID>", "<ToWalletID>", money.MustParse("100"), entity.TransactionDetails{}, nil, gomock.Nil(),
+			&repositories.Fee{Amount: money.MustParse("5"), WalletID: "<FeeWalletID>"}).
+		Return(&entity.Transaction{
+			ID:     "<TransactionID>",
+			Amount: money.MustParse("100"),
+			Type:   "transfer",
+			Fee:    &entity.Transaction{ID: "<FeeTransactionID>", Amount: money.MustParse("5"), Type: "fee"},
+		}, nil)
+
+	result, err := suite.transactionService.HandleTransferBalance("<UserID>", "<FromWalletID>", "<ToWalletID>", money.MustParse("100"), commands.TransactionDetails{}, nil)
+	suite.NoError(err)
+	suite.Equal(money.MustParse("5"), *result.Fee)
+}
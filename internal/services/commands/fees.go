@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+)
+
+// computeFee prices amount with the tier whose MinAmount is the highest not
+// above it. The rate part is rounded down to the currency's minor units before
+// the MinFee and MaxFee caps apply. No matching tier means no fee.
+func computeFee(rules []entity.FeeRule, amount money.Amount, currency money.Currency) (money.Amount, *entity.FeeRule, error) {
+	var tier *entity.FeeRule
+	for i := range rules {
+		if rules[i].MinAmount <= amount && (tier == nil || rules[i].MinAmount > tier.MinAmount) {
+			tier = &rules[i]
+		}
+	}
+	if tier == nil {
+		return 0, nil, nil
+	}
+
+	variable, err := amount.Convert(tier.Rate, currency)
+	if err != nil {
+		return 0, nil, err
+	}
+	fee := tier.FlatFee + variable
+	if tier.MinFee != nil && fee < *tier.MinFee {
+		fee = *tier.MinFee
+	}
+	if tier.MaxFee != nil && fee > *tier.MaxFee {
+		fee = *tier.MaxFee
+	}
+	return fee, tier, nil
+}
+
+// quoteFee computes the fee of debiting amount from the user's wallet by
+// transactionType, before any lock is taken. It returns the wallet and a nil
+// fee when nothing is charged.
+func quoteFee(feeRepo repositories.FeeRepository, userId, walletId, transactionType string, amount money.Amount) (*entity.Wallet, *repositories.Fee, error) {
+	wallet, rules, err := feeRepo.FindForWallet(userId, walletId, transactionType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fee, err := newFee(rules, amount, wallet.Currency)
+	if err != nil {
+		return nil, nil, err
+	}
+	return wallet, fee, nil
+}
+
+// newFee prices amount with rules already loaded for the debited wallet, or
+// returns nil when nothing is charged.
+func newFee(rules []entity.FeeRule, amount money.Amount, currency money.Currency) (*repositories.Fee, error) {
+	amountFee, tier, err := computeFee(rules, amount, currency)
+	if err != nil {
+		return nil, err
+	}
+	if amountFee <= 0 {
+		return nil, nil
+	}
+	return &repositories.Fee{Amount: amountFee, WalletID: tier.FeeWalletID}, nil
+}
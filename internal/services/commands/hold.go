@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+)
+
+// maxHoldDuration bounds how far ahead a hold may expire.
+const maxHoldDuration = 30 * 24 * time.Hour
+
+//go:generate mockgen -source=./hold.go -destination=./mocks/mock_hold_service.go -package=mock_commands
+type HoldService interface {
+	HandleCreate(userId string, req api_gen.CreateHoldRequest) (*api_gen.HoldResponseData, error)
+	HandleCapture(userId, holdId string, amount *money.Amount) (*api_gen.TransactionResponseData, error)
+	HandleVoid(userId, holdId string) (*api_gen.HoldResponseData, error)
+}
+
+type holdService struct {
+	holdRepo repositories.HoldRepository
+}
+
+func NewHoldService(holdRepo repositories.HoldRepository) HoldService {
+	return &holdService{holdRepo: holdRepo}
+}
+
+func (r *holdService) HandleCreate(userId string, req api_gen.CreateHoldRequest) (*api_gen.HoldResponseData, error) {
+	now := time.Now()
+	if !req.ExpiresAt.After(now) || req.ExpiresAt.After(now.Add(maxHoldDuration)) {
+		return nil, consts.ErrInvalidHoldExpiry
+	}
+
+	hold, err := r.holdRepo.Create(userId, entity.Hold{
+		WalletID:   req.WalletId,
+		ToWalletID: req.ToWalletId,
+		Amount:     req.Amount,
+		ExpiresAt:  req.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toHoldResponseData(hold), nil
+}
+
+func (r *holdService) HandleCapture(userId, holdId string, amount *money.Amount) (*api_gen.TransactionResponseData, error) {
+	tx, err := r.holdRepo.Capture(userId, holdId, amount)
+	if err != nil {
+		return nil, err
+	}
+	return toTransactionResponseData(tx), nil
+}
+
+func (r *holdService) HandleVoid(userId, holdId string) (*api_gen.HoldResponseData, error) {
+	hold, err := r.holdRepo.Void(userId, holdId)
+	if err != nil {
+		return nil, err
+	}
+	return toHoldResponseData(hold), nil
+}
+
+func toHoldResponseData(hold *entity.Hold) *api_gen.HoldResponseData {
+	return &api_gen.HoldResponseData{
+		Id:             hold.ID,
+		WalletId:       hold.WalletID,
+		ToWalletId:     hold.ToWalletID,
+		Amount:         hold.Amount,
+		CapturedAmount: hold.CapturedAmount,
+		Status:         api_gen.HoldResponseDataStatus(hold.Status),
+		TransactionId:  hold.TransactionID,
+		ExpiresAt:      hold.ExpiresAt,
+		CreatedAt:      hold.CreatedAt,
+	}
+}
@@ -0,0 +1,160 @@
+package commands_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"go.uber.org/mock/gomock"
+)
+
+func (suite *CommandsTestSuite) TestHoldService_HandleCreate() {
+	expiresAt := time.Now().Add(24 * time.Hour)
+
+	testCases := []struct {
+		name        string
+		req         api_gen.CreateHoldRequest
+		mock        func()
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenValidRequest_WhenCreateSuccess_ThenReturnHold",
+			req:  api_gen.CreateHoldRequest{WalletId: "<WalletID>", ToWalletId: "<ToWalletID>", Amount: money.MustParse("100"), ExpiresAt: expiresAt},
+			mock: func() {
+				suite.mockHoldRepo.EXPECT().
+					Create("<UserID>", entity.Hold{WalletID: "<WalletID>", ToWalletID: "<ToWalletID>", Amount: money.MustParse("100"), ExpiresAt: expiresAt}).
+					Return(&entity.Hold{ID: "<HoldID>", WalletID: "<WalletID>", ToWalletID: "<ToWalletID>", Amount: money.MustParse("100"), Status: "active", ExpiresAt: expiresAt}, nil)
+			},
+			wantErr:     false,
+			expectedErr: "",
+		},
+		{
+			name:        "GivenPastExpiry_WhenCreate_ThenError",
+			req:         api_gen.CreateHoldRequest{WalletId: "<WalletID>", ToWalletId: "<ToWalletID>", Amount: money.MustParse("100"), ExpiresAt: time.Now().Add(-time.Minute)},
+			mock:        func() {},
+			wantErr:     true,
+			expectedErr: "invalid hold expiry",
+		},
+		{
+			name:        "GivenExpiryBeyondLimit_WhenCreate_ThenError",
+			req:         api_gen.CreateHoldRequest{WalletId: "<WalletID>", ToWalletId: "<ToWalletID>", Amount: money.MustParse("100"), ExpiresAt: time.Now().Add(31 * 24 * time.Hour)},
+			mock:        func() {},
+			wantErr:     true,
+			expectedErr: "invalid hold expiry",
+		},
+		{
+			name: "GivenValidRequest_WhenCreateFails_ThenError",
+			req:  api_gen.CreateHoldRequest{WalletId: "<WalletID>", ToWalletId: "<ToWalletID>", Amount: money.MustParse("100"), ExpiresAt: expiresAt},
+			mock: func() {
+				suite.mockHoldRepo.EXPECT().Create("<UserID>", gomock.Any()).Return(nil, errors.New("create error"))
+			},
+			wantErr:     true,
+			expectedErr: "create error",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			result, err := suite.holdService.HandleCreate("<UserID>", tc.req)
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(result)
+			} else {
+				suite.NoError(err)
+				suite.Equal("<HoldID>", result.Id)
+				suite.Equal(api_gen.HoldResponseDataStatus("active"), result.Status)
+			}
+		})
+	}
+}
+
+func (suite *CommandsTestSuite) TestHoldService_HandleCapture() {
+	partial := money.MustParse("40")
+
+	testCases := []struct {
+		name        string
+		amount      *money.Amount
+		mock        func()
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:   "GivenPartialAmount_WhenCaptureSuccess_ThenReturnTransaction",
+			amount: &partial,
+			mock: func() {
+				suite.mockHoldRepo.EXPECT().Capture("<UserID>", "<HoldID>", &partial).
+					Return(&entity.Transaction{ID: "<TransactionID>", Amount: partial, Type: "transfer"}, nil)
+			},
+			wantErr:     false,
+			expectedErr: "",
+		},
+		{
+			name:   "GivenNoAmount_WhenCaptureFails_ThenError",
+			amount: nil,
+			mock: func() {
+				suite.mockHoldRepo.EXPECT().Capture("<UserID>", "<HoldID>", nil).Return(nil, errors.New("capture error"))
+			},
+			wantErr:     true,
+			expectedErr: "capture error",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			result, err := suite.holdService.HandleCapture("<UserID>", "<HoldID>", tc.amount)
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(result)
+			} else {
+				suite.NoError(err)
+				suite.Equal("<TransactionID>", result.Id)
+			}
+		})
+	}
+}
+
+func (suite *CommandsTestSuite) TestHoldService_HandleVoid() {
+	testCases := []struct {
+		name        string
+		mock        func()
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenActiveHold_WhenVoidSuccess_ThenReturnHold",
+			mock: func() {
+				suite.mockHoldRepo.EXPECT().Void("<UserID>", "<HoldID>").
+					Return(&entity.Hold{ID: "<HoldID>", Status: "voided"}, nil)
+			},
+			wantErr:     false,
+			expectedErr: "",
+		},
+		{
+			name: "GivenHold_WhenVoidFails_ThenError",
+			mock: func() {
+				suite.mockHoldRepo.EXPECT().Void("<UserID>", "<HoldID>").Return(nil, errors.New("void error"))
+			},
+			wantErr:     true,
+			expectedErr: "void error",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			result, err := suite.holdService.HandleVoid("<UserID>", "<HoldID>")
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(result)
+			} else {
+				suite.NoError(err)
+				suite.Equal(api_gen.HoldResponseDataStatus("voided"), result.Status)
+			}
+		})
+	}
+}
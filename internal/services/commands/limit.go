@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+)
+
+//go:generate mockgen -source=./limit.go -destination=./mocks/mock_limit_service.go -package=mock_commands
+type LimitService interface {
+	HandleLoadLimits(userId *string, req api_gen.LoadLimitsRequest) error
+}
+
+type limitService struct {
+	limitRepo repositories.LimitRepository
+}
+
+func NewLimitService(limitRepo repositories.LimitRepository) LimitService {
+	return &limitService{limitRepo: limitRepo}
+}
+
+// HandleLoadLimits replaces the global limits, or the overrides of userId
+// when it is set.
+func (r *limitService) HandleLoadLimits(userId *string, req api_gen.LoadLimitsRequest) error {
+	limits := []entity.TransactionLimit{}
+	seen := map[money.Currency]bool{}
+	for _, item := range req.Limits {
+		currency, ok := money.ParseCurrency(item.Currency)
+		if !ok {
+			return consts.ErrUnsupportedCurrency
+		}
+		if seen[currency] || (item.MaxTransfers == nil) != (item.TransferWindowSeconds == nil) {
+			return consts.ErrInvalidLimit
+		}
+		seen[currency] = true
+
+		limits = append(limits, entity.TransactionLimit{
+			Currency:              currency,
+			PerTransactionMax:     item.PerTransactionMax,
+			DailyWalletMax:        item.DailyWalletMax,
+			MonthlyWalletMax:      item.MonthlyWalletMax,
+			DailyUserMax:          item.DailyUserMax,
+			MonthlyUserMax:        item.MonthlyUserMax,
+			MaxTransfers:          item.MaxTransfers,
+			TransferWindowSeconds: item.TransferWindowSeconds,
+		})
+	}
+
+	return r.limitRepo.Replace(userId, limits)
+}
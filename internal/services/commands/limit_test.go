@@ -0,0 +1,229 @@
+package commands_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"github.com/slilp/go-wallet/internal/services/commands"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type fakeLimitUsage struct {
+	walletDebited  money.Amount
+	userDebited    money.Amount
+	transfers      int64
+	oldestTransfer *time.Time
+}
+
+func (u fakeLimitUsage) WalletDebited(time.Time) (money.Amount, error) {
+	return u.walletDebited, nil
+}
+
+func (u fakeLimitUsage) UserDebited(time.Time) (money.Amount, error) {
+	return u.userDebited, nil
+}
+
+func (u fakeLimitUsage) UserTransfers(time.Time) (int64, *time.Time, error) {
+	return u.transfers, u.oldestTransfer, nil
+}
+
+func amountPtr(s string) *money.Amount {
+	amount := money.MustParse(s)
+	return &amount
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func (suite *CommandsTestSuite) TestLimitService_HandleLoadLimits() {
+	userId := "<UserID>"
+
+	testCases := []struct {
+		name        string
+		userId      *string
+		req         api_gen.LoadLimitsRequest
+		mock        func()
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name:   "GivenGlobalLimits_WhenLoad_ThenReplaced",
+			userId: nil,
+			req: api_gen.LoadLimitsRequest{Limits: []api_gen.LimitRequest{
+				{Currency: "THB", DailyWalletMax: amountPtr("1000"), MaxTransfers: intPtr(5), TransferWindowSeconds: intPtr(60)},
+			}},
+			mock: func() {
+				suite.mockLimitRepo.EXPECT().Replace(nil, []entity.TransactionLimit{
+					{Currency: "THB", DailyWalletMax: amountPtr("1000"), MaxTransfers: intPtr(5), TransferWindowSeconds: intPtr(60)},
+				}).Return(nil)
+			},
+		},
+		{
+			name:   "GivenUnknownUser_WhenLoad_ThenNotFound",
+			userId: &userId,
+			req: api_gen.LoadLimitsRequest{Limits: []api_gen.LimitRequest{
+				{Currency: "THB", PerTransactionMax: amountPtr("500")},
+			}},
+			mock: func() {
+				suite.mockLimitRepo.EXPECT().Replace(&userId, gomock.Any()).Return(gorm.ErrRecordNotFound)
+			},
+			wantErr:     true,
+			expectedErr: gorm.ErrRecordNotFound,
+		},
+		{
+			name: "GivenUnsupportedCurrency_WhenLoad_ThenError",
+			req: api_gen.LoadLimitsRequest{Limits: []api_gen.LimitRequest{
+				{Currency: "XYZ", PerTransactionMax: amountPtr("500")},
+			}},
+			mock:        func() {},
+			wantErr:     true,
+			expectedErr: consts.ErrUnsupportedCurrency,
+		},
+		{
+			name: "GivenDuplicateCurrency_WhenLoad_ThenInvalidLimit",
+			req: api_gen.LoadLimitsRequest{Limits: []api_gen.LimitRequest{
+				{Currency: "THB", PerTransactionMax: amountPtr("500")},
+				{Currency: "THB", DailyWalletMax: amountPtr("1000")},
+			}},
+			mock:        func() {},
+			wantErr:     true,
+			expectedErr: consts.ErrInvalidLimit,
+		},
+		{
+			name: "GivenMaxTransfersWithoutWindow_WhenLoad_ThenInvalidLimit",
+			req: api_gen.LoadLimitsRequest{Limits: []api_gen.LimitRequest{
+				{Currency: "THB", MaxTransfers: intPtr(5)},
+			}},
+			mock:        func() {},
+			wantErr:     true,
+			expectedErr: consts.ErrInvalidLimit,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			err := suite.limitService.HandleLoadLimits(tc.userId, tc.req)
+			if tc.wantErr {
+				suite.ErrorIs(err, tc.expectedErr)
+			} else {
+				suite.NoError(err)
+			}
+		})
+	}
+}
+
+func (suite *CommandsTestSuite) TestTransactionService_TransferLimits() {
+	userId := "<UserID>"
+	wallet := entity.Wallet{ID: "<FromWalletID>", UserID: userId, Currency: "THB"}
+	now := time.Now().UTC()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+	nextMonth := time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	oldest := now.Add(-30 * time.Second)
+
+	testCases := []struct {
+		name          string
+		limits        []entity.TransactionLimit
+		usage         fakeLimitUsage
+		amount        money.Amount
+		expectedLimit string
+		resetsAt      *time.Time
+	}{
+		{
+			name:   "GivenAmountUnderAllLimits_WhenTransfer_ThenAllowed",
+			limits: []entity.TransactionLimit{{Currency: "THB", PerTransactionMax: amountPtr("500"), DailyWalletMax: amountPtr("1000")}},
+			usage:  fakeLimitUsage{walletDebited: money.MustParse("400")},
+			amount: money.MustParse("500"),
+		},
+		{
+			name:          "GivenAmountOverPerTransactionMax_WhenTransfer_ThenExceeded",
+			limits:        []entity.TransactionLimit{{Currency: "THB", PerTransactionMax: amountPtr("500")}},
+			amount:        money.MustParse("500.01"),
+			expectedLimit: consts.LimitPerTransaction,
+		},
+		{
+			name:          "GivenDailyWalletUsage_WhenTransferCrossesCap_ThenExceededUntilTomorrow",
+			limits:        []entity.TransactionLimit{{Currency: "THB", DailyWalletMax: amountPtr("1000")}},
+			usage:         fakeLimitUsage{walletDebited: money.MustParse("900")},
+			amount:        money.MustParse("100.01"),
+			expectedLimit: consts.LimitDailyWallet,
+			resetsAt:      &tomorrow,
+		},
+		{
+			name:          "GivenMonthlyUserUsage_WhenTransferCrossesCap_ThenExceededUntilNextMonth",
+			limits:        []entity.TransactionLimit{{Currency: "THB", MonthlyUserMax: amountPtr("5000")}},
+			usage:         fakeLimitUsage{userDebited: money.MustParse("4950")},
+			amount:        money.MustParse("60"),
+			expectedLimit: consts.LimitMonthlyUser,
+			resetsAt:      &nextMonth,
+		},
+		{
+			name:          "GivenTransferCountReached_WhenTransfer_ThenExceededUntilOldestLeavesWindow",
+			limits:        []entity.TransactionLimit{{Currency: "THB", MaxTransfers: intPtr(3), TransferWindowSeconds: intPtr(60)}},
+			usage:         fakeLimitUsage{transfers: 3, oldestTransfer: &oldest},
+			amount:        money.MustParse("1"),
+			expectedLimit: consts.LimitTransferCount,
+			resetsAt:      func() *time.Time { t := oldest.Add(time.Minute); return &t }(),
+		},
+		{
+			name: "GivenUserOverride_WhenTransferOverGlobalCap_ThenUserCapApplies",
+			limits: []entity.TransactionLimit{
+				{UserID: &userId, Currency: "THB", DailyWalletMax: amountPtr("5000")},
+				{Currency: "THB", DailyWalletMax: amountPtr("1000"), PerTransactionMax: amountPtr("2000")},
+			},
+			usage:  fakeLimitUsage{walletDebited: money.MustParse("1500")},
+			amount: money.MustParse("2000"),
+		},
+		{
+			name:   "GivenLimitsInOtherCurrency_WhenTransfer_ThenAllowed",
+			limits: []entity.TransactionLimit{{Currency: "USD", PerTransactionMax: amountPtr("1")}},
+			amount: money.MustParse("100"),
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			var checkErr error
+			suite.mockLimitRepo.EXPECT().ListForUser(userId).Return(tc.limits, nil)
+			suite.mockFeeRepo.EXPECT().FindForWallet(userId, "<FromWalletID>", "transfer").Return(&wallet, nil, nil)
+			suite.mockTransactionRepo.EXPECT().UpdateTransferTransaction(userId, "<FromWalletID>", "<ToWalletID>", tc.amount, entity.TransactionDetails{}, nil, gomock.Not(nil), nil).
+				DoAndReturn(func(_, _, _ string, amount money.Amount, _ entity.TransactionDetails, _ *repositories.IdempotencyKey, check repositories.LimitCheck, _ *repositories.Fee) (*entity.Transaction, error) {
+					if checkErr = check(wallet, tc.usage); checkErr != nil {
+						return nil, checkErr
+					}
+					return &entity.Transaction{ID: "<TransactionID>", Amount: amount, Type: "transfer"}, nil
+				})
+
+			result, err := suite.transactionService.HandleTransferBalance(userId, "<FromWalletID>", "<ToWalletID>", tc.amount, commands.TransactionDetails{}, nil)
+			if tc.expectedLimit == "" {
+				suite.NoError(err)
+				suite.Equal("<TransactionID>", result.Id)
+				return
+			}
+
+			var exceeded *consts.LimitExceededError
+			suite.True(errors.As(err, &exceeded))
+			suite.Equal(tc.expectedLimit, exceeded.Limit)
+			if tc.resetsAt == nil {
+				suite.Nil(exceeded.ResetsAt)
+			} else {
+				suite.Equal(*tc.resetsAt, *exceeded.ResetsAt)
+			}
+		})
+	}
+}
+
+func (suite *CommandsTestSuite) TestTransactionService_DepositSkipsLimitsAndFees() {
+	suite.mockTransactionRepo.EXPECT().UpdateBalanceTransaction("<UserID>", "<WalletID>", money.MustParse("100000"), entity.TransactionDetails{}, nil, nil, nil).
+		Return(&entity.Transaction{ID: "<TransactionID>", Amount: money.MustParse("100000")}, nil)
+
+	_, err := suite.transactionService.HandleDepositWithDrawBalance("<UserID>", "<WalletID>", money.MustParse("100000"), commands.TransactionDetails{}, nil)
+	suite.NoError(err)
+}
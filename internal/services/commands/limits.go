@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+)
+
+// limitPolicy is the effective set of limits for one currency after applying
+// a user's overrides to the global defaults.
+type limitPolicy struct {
+	perTransactionMax *money.Amount
+	dailyWalletMax    *money.Amount
+	monthlyWalletMax  *money.Amount
+	dailyUserMax      *money.Amount
+	monthlyUserMax    *money.Amount
+	maxTransfers      *int
+	transferWindow    time.Duration
+}
+
+// resolveLimitPolicy merges the global and user rows configured for currency.
+func resolveLimitPolicy(limits []entity.TransactionLimit, currency money.Currency) limitPolicy {
+	var policy limitPolicy
+	// Apply the global row first so the user's row wins wherever it is set.
+	for _, global := range []bool{true, false} {
+		for _, limit := range limits {
+			if limit.Currency != currency || (limit.UserID == nil) != global {
+				continue
+			}
+			if limit.PerTransactionMax != nil {
+				policy.perTransactionMax = limit.PerTransactionMax
+			}
+			if limit.DailyWalletMax != nil {
+				policy.dailyWalletMax = limit.DailyWalletMax
+			}
+			if limit.MonthlyWalletMax != nil {
+				policy.monthlyWalletMax = limit.MonthlyWalletMax
+			}
+			if limit.DailyUserMax != nil {
+				policy.dailyUserMax = limit.DailyUserMax
+			}
+			if limit.MonthlyUserMax != nil {
+				policy.monthlyUserMax = limit.MonthlyUserMax
+			}
+			if limit.MaxTransfers != nil && limit.TransferWindowSeconds != nil {
+				policy.maxTransfers = limit.MaxTransfers
+				policy.transferWindow = time.Duration(*limit.TransferWindowSeconds) * time.Second
+			}
+		}
+	}
+	return policy
+}
+
+// check evaluates a debit of amount against the policy. Daily and monthly
+// windows are calendar periods in UTC; the transfer count uses a rolling window.
+func (p limitPolicy) check(amount money.Amount, transfer bool, usage repositories.LimitUsage, now time.Time) error {
+	now = now.UTC()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+	monthEnd := monthStart.AddDate(0, 1, 0)
+
+	if p.perTransactionMax != nil && amount > *p.perTransactionMax {
+		return &consts.LimitExceededError{Limit: consts.LimitPerTransaction}
+	}
+
+	caps := []struct {
+		limit    string
+		max      *money.Amount
+		since    time.Time
+		resetsAt time.Time
+		debited  func(time.Time) (money.Amount, error)
+	}{
+		{consts.LimitDailyWallet, p.dailyWalletMax, dayStart, dayEnd, usage.WalletDebited},
+		{consts.LimitMonthlyWallet, p.monthlyWalletMax, monthStart, monthEnd, usage.WalletDebited},
+		{consts.LimitDailyUser, p.dailyUserMax, dayStart, dayEnd, usage.UserDebited},
+		{consts.LimitMonthlyUser, p.monthlyUserMax, monthStart, monthEnd, usage.UserDebited},
+	}
+	for _, c := range caps {
+		if c.max == nil {
+			continue
+		}
+		debited, err := c.debited(c.since)
+		if err != nil {
+			return err
+		}
+		if debited+amount > *c.max {
+			resetsAt := c.resetsAt
+			return &consts.LimitExceededError{Limit: c.limit, ResetsAt: &resetsAt}
+		}
+	}
+
+	if transfer && p.maxTransfers != nil {
+		count, oldest, err := usage.UserTransfers(now.Add(-p.transferWindow))
+		if err != nil {
+			return err
+		}
+		if count >= int64(*p.maxTransfers) {
+			exceeded := &consts.LimitExceededError{Limit: consts.LimitTransferCount}
+			if oldest != nil {
+				resetsAt := oldest.UTC().Add(p.transferWindow)
+				exceeded.ResetsAt = &resetsAt
+			}
+			return exceeded
+		}
+	}
+	return nil
+}
+
+// newLimitCheck loads the user's limits and returns the check to run once the
+// debited wallet is locked, or nil when no limits are configured.
+func newLimitCheck(limitRepo repositories.LimitRepository, userId string, amount money.Amount, transfer bool) (repositories.LimitCheck, error) {
+	limits, err := limitRepo.ListForUser(userId)
+	if err != nil {
+		return nil, err
+	}
+	if len(limits) == 0 {
+		return nil, nil
+	}
+	return limitCheck(limits, amount, transfer), nil
+}
+
+// limitCheck checks a debit of amount against limits already loaded for the
+// user.
+func limitCheck(limits []entity.TransactionLimit, amount money.Amount, transfer bool) repositories.LimitCheck {
+	return func(wallet entity.Wallet, usage repositories.LimitUsage) error {
+		return resolveLimitPolicy(limits, wallet.Currency).check(amount, transfer, usage, time.Now())
+	}
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./deposit_import.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./deposit_import.go -destination=./mocks/mock_deposit_import_service.go -package=mock_commands
+//
+
+// Package mock_commands is a generated GoMock package.
+package mock_commands
+
+import (
+	reflect "reflect"
+
+	api_gen "github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDepositImportService is a mock of DepositImportService interface.
+type MockDepositImportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDepositImportServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockDepositImportServiceMockRecorder is the mock recorder for MockDepositImportService.
+type MockDepositImportServiceMockRecorder struct {
+	mock *MockDepositImportService
+}
+
+// NewMockDepositImportService creates a new mock instance.
+func NewMockDepositImportService(ctrl *gomock.Controller) *MockDepositImportService {
+	mock := &MockDepositImportService{ctrl: ctrl}
+	mock.recorder = &MockDepositImportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDepositImportService) EXPECT() *MockDepositImportServiceMockRecorder {
+	return m.recorder
+}
+
+// HandleUpload mocks base method.
+func (m *MockDepositImportService) HandleUpload(filename string, content []byte) (*api_gen.DepositImportResponseData, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleUpload", filename, content)
+	ret0, _ := ret[0].(*api_gen.DepositImportResponseData)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// HandleUpload indicates an expected call of HandleUpload.
+func (mr *MockDepositImportServiceMockRecorder) HandleUpload(filename, content any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleUpload", reflect.TypeOf((*MockDepositImportService)(nil).HandleUpload), filename, content)
+}
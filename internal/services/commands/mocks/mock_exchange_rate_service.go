@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./exchange_rate.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./exchange_rate.go -destination=./mocks/mock_exchange_rate_service.go -package=mock_commands
+//
+
+// Package mock_commands is a generated GoMock package.
+package mock_commands
+
+import (
+	reflect "reflect"
+
+	api_gen "github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockExchangeRateService is a mock of ExchangeRateService interface.
+type MockExchangeRateService struct {
+	ctrl     *gomock.Controller
+	recorder *MockExchangeRateServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockExchangeRateServiceMockRecorder is the mock recorder for MockExchangeRateService.
+type MockExchangeRateServiceMockRecorder struct {
+	mock *MockExchangeRateService
+}
+
+// NewMockExchangeRateService creates a new mock instance.
+func NewMockExchangeRateService(ctrl *gomock.Controller) *MockExchangeRateService {
+	mock := &MockExchangeRateService{ctrl: ctrl}
+	mock.recorder = &MockExchangeRateServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExchangeRateService) EXPECT() *MockExchangeRateServiceMockRecorder {
+	return m.recorder
+}
+
+// HandleLoadRates mocks base method.
+func (m *MockExchangeRateService) HandleLoadRates(req api_gen.LoadExchangeRatesRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleLoadRates", req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleLoadRates indicates an expected call of HandleLoadRates.
+func (mr *MockExchangeRateServiceMockRecorder) HandleLoadRates(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleLoadRates", reflect.TypeOf((*MockExchangeRateService)(nil).HandleLoadRates), req)
+}
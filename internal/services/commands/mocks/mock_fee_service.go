@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./fee.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./fee.go -destination=./mocks/mock_fee_service.go -package=mock_commands
+//
+
+// Package mock_commands is a generated GoMock package.
+package mock_commands
+
+import (
+	reflect "reflect"
+
+	api_gen "github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFeeService is a mock of FeeService interface.
+type MockFeeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeeServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockFeeServiceMockRecorder is the mock recorder for MockFeeService.
+type MockFeeServiceMockRecorder struct {
+	mock *MockFeeService
+}
+
+// NewMockFeeService creates a new mock instance.
+func NewMockFeeService(ctrl *gomock.Controller) *MockFeeService {
+	mock := &MockFeeService{ctrl: ctrl}
+	mock.recorder = &MockFeeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeeService) EXPECT() *MockFeeServiceMockRecorder {
+	return m.recorder
+}
+
+// HandleLoadRules mocks base method.
+func (m *MockFeeService) HandleLoadRules(req api_gen.LoadFeeRulesRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleLoadRules", req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleLoadRules indicates an expected call of HandleLoadRules.
+func (mr *MockFeeServiceMockRecorder) HandleLoadRules(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleLoadRules", reflect.TypeOf((*MockFeeService)(nil).HandleLoadRules), req)
+}
+
+// HandleQuote mocks base method.
+func (m *MockFeeService) HandleQuote(userId string, req api_gen.FeeQuoteRequest) (*api_gen.FeeQuoteResponseData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleQuote", userId, req)
+	ret0, _ := ret[0].(*api_gen.FeeQuoteResponseData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleQuote indicates an expected call of HandleQuote.
+func (mr *MockFeeServiceMockRecorder) HandleQuote(userId, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleQuote", reflect.TypeOf((*MockFeeService)(nil).HandleQuote), userId, req)
+}
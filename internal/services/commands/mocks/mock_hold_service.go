@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./hold.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./hold.go -destination=./mocks/mock_hold_service.go -package=mock_commands
+//
+
+// Package mock_commands is a generated GoMock package.
+package mock_commands
+
+import (
+	reflect "reflect"
+
+	api_gen "github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	money "github.com/slilp/go-wallet/internal/money"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHoldService is a mock of HoldService interface.
+type MockHoldService struct {
+	ctrl     *gomock.Controller
+	recorder *MockHoldServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockHoldServiceMockRecorder is the mock recorder for MockHoldService.
+type MockHoldServiceMockRecorder struct {
+	mock *MockHoldService
+}
+
+// NewMockHoldService creates a new mock instance.
+func NewMockHoldService(ctrl *gomock.Controller) *MockHoldService {
+	mock := &MockHoldService{ctrl: ctrl}
+	mock.recorder = &MockHoldServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHoldService) EXPECT() *MockHoldServiceMockRecorder {
+	return m.recorder
+}
+
+// HandleCapture mocks base method.
+func (m *MockHoldService) HandleCapture(userId, holdId string, amount *money.Amount) (*api_gen.TransactionResponseData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleCapture", userId, holdId, amount)
+	ret0, _ := ret[0].(*api_gen.TransactionResponseData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleCapture indicates an expected call of HandleCapture.
+func (mr *MockHoldServiceMockRecorder) HandleCapture(userId, holdId, amount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleCapture", reflect.TypeOf((*MockHoldService)(nil).HandleCapture), userId, holdId, amount)
+}
+
+// HandleCreate mocks base method.
+func (m *MockHoldService) HandleCreate(userId string, req api_gen.CreateHoldRequest) (*api_gen.HoldResponseData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleCreate", userId, req)
+	ret0, _ := ret[0].(*api_gen.HoldResponseData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleCreate indicates an expected call of HandleCreate.
+func (mr *MockHoldServiceMockRecorder) HandleCreate(userId, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleCreate", reflect.TypeOf((*MockHoldService)(nil).HandleCreate), userId, req)
+}
+
+// HandleVoid mocks base method.
+func (m *MockHoldService) HandleVoid(userId, holdId string) (*api_gen.HoldResponseData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleVoid", userId, holdId)
+	ret0, _ := ret[0].(*api_gen.HoldResponseData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleVoid indicates an expected call of HandleVoid.
+func (mr *MockHoldServiceMockRecorder) HandleVoid(userId, holdId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleVoid", reflect.TypeOf((*MockHoldService)(nil).HandleVoid), userId, holdId)
+}
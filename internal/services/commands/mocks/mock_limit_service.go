@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./limit.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./limit.go -destination=./mocks/mock_limit_service.go -package=mock_commands
+//
+
+// Package mock_commands is a generated GoMock package.
+package mock_commands
+
+import (
+	reflect "reflect"
+
+	api_gen "github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLimitService is a mock of LimitService interface.
+type MockLimitService struct {
+	ctrl     *gomock.Controller
+	recorder *MockLimitServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockLimitServiceMockRecorder is the mock recorder for MockLimitService.
+type MockLimitServiceMockRecorder struct {
+	mock *MockLimitService
+}
+
+// NewMockLimitService creates a new mock instance.
+func NewMockLimitService(ctrl *gomock.Controller) *MockLimitService {
+	mock := &MockLimitService{ctrl: ctrl}
+	mock.recorder = &MockLimitServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLimitService) EXPECT() *MockLimitServiceMockRecorder {
+	return m.recorder
+}
+
+// HandleLoadLimits mocks base method.
+func (m *MockLimitService) HandleLoadLimits(userId *string, req api_gen.LoadLimitsRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleLoadLimits", userId, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleLoadLimits indicates an expected call of HandleLoadLimits.
+func (mr *MockLimitServiceMockRecorder) HandleLoadLimits(userId, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleLoadLimits", reflect.TypeOf((*MockLimitService)(nil).HandleLoadLimits), userId, req)
+}
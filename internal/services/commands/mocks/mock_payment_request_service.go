@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./payment_request.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./payment_request.go -destination=./mocks/mock_payment_request_service.go -package=mock_commands
+//
+
+// Package mock_commands is a generated GoMock package.
+package mock_commands
+
+import (
+	reflect "reflect"
+
+	api_gen "github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPaymentRequestService is a mock of PaymentRequestService interface.
+type MockPaymentRequestService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentRequestServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPaymentRequestServiceMockRecorder is the mock recorder for MockPaymentRequestService.
+type MockPaymentRequestServiceMockRecorder struct {
+	mock *MockPaymentRequestService
+}
+
+// NewMockPaymentRequestService creates a new mock instance.
+func NewMockPaymentRequestService(ctrl *gomock.Controller) *MockPaymentRequestService {
+	mock := &MockPaymentRequestService{ctrl: ctrl}
+	mock.recorder = &MockPaymentRequestServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentRequestService) EXPECT() *MockPaymentRequestServiceMockRecorder {
+	return m.recorder
+}
+
+// HandleAccept mocks base method.
+func (m *MockPaymentRequestService) HandleAccept(userId, requestId, fromWalletId string) (*api_gen.PaymentRequestResponseData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleAccept", userId, requestId, fromWalletId)
+	ret0, _ := ret[0].(*api_gen.PaymentRequestResponseData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleAccept indicates an expected call of HandleAccept.
+func (mr *MockPaymentRequestServiceMockRecorder) HandleAccept(userId, requestId, fromWalletId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleAccept", reflect.TypeOf((*MockPaymentRequestService)(nil).HandleAccept), userId, requestId, fromWalletId)
+}
+
+// HandleCreate mocks base method.
+func (m *MockPaymentRequestService) HandleCreate(userId string, req api_gen.CreatePaymentRequestRequest) (*api_gen.PaymentRequestResponseData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleCreate", userId, req)
+	ret0, _ := ret[0].(*api_gen.PaymentRequestResponseData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleCreate indicates an expected call of HandleCreate.
+func (mr *MockPaymentRequestServiceMockRecorder) HandleCreate(userId, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleCreate", reflect.TypeOf((*MockPaymentRequestService)(nil).HandleCreate), userId, req)
+}
+
+// HandleDecline mocks base method.
+func (m *MockPaymentRequestService) HandleDecline(userId, requestId string) (*api_gen.PaymentRequestResponseData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleDecline", userId, requestId)
+	ret0, _ := ret[0].(*api_gen.PaymentRequestResponseData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleDecline indicates an expected call of HandleDecline.
+func (mr *MockPaymentRequestServiceMockRecorder) HandleDecline(userId, requestId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleDecline", reflect.TypeOf((*MockPaymentRequestService)(nil).HandleDecline), userId, requestId)
+}
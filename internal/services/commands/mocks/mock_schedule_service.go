@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./schedule.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./schedule.go -destination=./mocks/mock_schedule_service.go -package=mock_commands
+//
+
+// Package mock_commands is a generated GoMock package.
+package mock_commands
+
+import (
+	reflect "reflect"
+
+	api_gen "github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockScheduleService is a mock of ScheduleService interface.
+type MockScheduleService struct {
+	ctrl     *gomock.Controller
+	recorder *MockScheduleServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockScheduleServiceMockRecorder is the mock recorder for MockScheduleService.
+type MockScheduleServiceMockRecorder struct {
+	mock *MockScheduleService
+}
+
+// NewMockScheduleService creates a new mock instance.
+func NewMockScheduleService(ctrl *gomock.Controller) *MockScheduleService {
+	mock := &MockScheduleService{ctrl: ctrl}
+	mock.recorder = &MockScheduleServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScheduleService) EXPECT() *MockScheduleServiceMockRecorder {
+	return m.recorder
+}
+
+// HandleCancel mocks base method.
+func (m *MockScheduleService) HandleCancel(userId, scheduleId string) (*api_gen.ScheduleResponseData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleCancel", userId, scheduleId)
+	ret0, _ := ret[0].(*api_gen.ScheduleResponseData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleCancel indicates an expected call of HandleCancel.
+func (mr *MockScheduleServiceMockRecorder) HandleCancel(userId, scheduleId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleCancel", reflect.TypeOf((*MockScheduleService)(nil).HandleCancel), userId, scheduleId)
+}
+
+// HandleCreate mocks base method.
+func (m *MockScheduleService) HandleCreate(userId string, req api_gen.CreateScheduleRequest) (*api_gen.ScheduleResponseData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleCreate", userId, req)
+	ret0, _ := ret[0].(*api_gen.ScheduleResponseData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleCreate indicates an expected call of HandleCreate.
+func (mr *MockScheduleServiceMockRecorder) HandleCreate(userId, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleCreate", reflect.TypeOf((*MockScheduleService)(nil).HandleCreate), userId, req)
+}
+
+// HandleUpdate mocks base method.
+func (m *MockScheduleService) HandleUpdate(userId, scheduleId string, req api_gen.UpdateScheduleRequest) (*api_gen.ScheduleResponseData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleUpdate", userId, scheduleId, req)
+	ret0, _ := ret[0].(*api_gen.ScheduleResponseData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleUpdate indicates an expected call of HandleUpdate.
+func (mr *MockScheduleServiceMockRecorder) HandleUpdate(userId, scheduleId, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleUpdate", reflect.TypeOf((*MockScheduleService)(nil).HandleUpdate), userId, scheduleId, req)
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./statement.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./statement.go -destination=./mocks/mock_statement_service.go -package=mock_commands
+//
+
+// Package mock_commands is a generated GoMock package.
+package mock_commands
+
+import (
+	reflect "reflect"
+	time "time"
+
+	api_gen "github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStatementService is a mock of StatementService interface.
+type MockStatementService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatementServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockStatementServiceMockRecorder is the mock recorder for MockStatementService.
+type MockStatementServiceMockRecorder struct {
+	mock *MockStatementService
+}
+
+// NewMockStatementService creates a new mock instance.
+func NewMockStatementService(ctrl *gomock.Controller) *MockStatementService {
+	mock := &MockStatementService{ctrl: ctrl}
+	mock.recorder = &MockStatementServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatementService) EXPECT() *MockStatementServiceMockRecorder {
+	return m.recorder
+}
+
+// HandleGenerate mocks base method.
+func (m *MockStatementService) HandleGenerate(userId, walletId string, month time.Time) (*api_gen.StatementResponseData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleGenerate", userId, walletId, month)
+	ret0, _ := ret[0].(*api_gen.StatementResponseData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleGenerate indicates an expected call of HandleGenerate.
+func (mr *MockStatementServiceMockRecorder) HandleGenerate(userId, walletId, month any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleGenerate", reflect.TypeOf((*MockStatementService)(nil).HandleGenerate), userId, walletId, month)
+}
@@ -12,6 +12,9 @@ package mock_commands
 import (
 	reflect "reflect"
 
+	api_gen "github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	money "github.com/slilp/go-wallet/internal/money"
+	commands "github.com/slilp/go-wallet/internal/services/commands"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -39,30 +42,62 @@ func (m *MockTransactionService) EXPECT() *MockTransactionServiceMockRecorder {
 	return m.recorder
 }
 
+// HandleBatchTransfer mocks base method.
+func (m *MockTransactionService) HandleBatchTransfer(userId, from string, legs []commands.BatchTransferLeg, allOrNothing bool) ([]commands.BatchTransferResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleBatchTransfer", userId, from, legs, allOrNothing)
+	ret0, _ := ret[0].([]commands.BatchTransferResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleBatchTransfer indicates an expected call of HandleBatchTransfer.
+func (mr *MockTransactionServiceMockRecorder) HandleBatchTransfer(userId, from, legs, allOrNothing any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleBatchTransfer", reflect.TypeOf((*MockTransactionService)(nil).HandleBatchTransfer), userId, from, legs, allOrNothing)
+}
+
 // HandleDepositWithDrawBalance mocks base method.
-func (m *MockTransactionService) HandleDepositWithDrawBalance(userId, walletId string, amount float64) error {
+func (m *MockTransactionService) HandleDepositWithDrawBalance(userId, walletId string, amount money.Amount, details commands.TransactionDetails, idempotencyKey *string) (*api_gen.TransactionResponseData, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HandleDepositWithDrawBalance", userId, walletId, amount)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "HandleDepositWithDrawBalance", userId, walletId, amount, details, idempotencyKey)
+	ret0, _ := ret[0].(*api_gen.TransactionResponseData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // HandleDepositWithDrawBalance indicates an expected call of HandleDepositWithDrawBalance.
-func (mr *MockTransactionServiceMockRecorder) HandleDepositWithDrawBalance(userId, walletId, amount any) *gomock.Call {
+func (mr *MockTransactionServiceMockRecorder) HandleDepositWithDrawBalance(userId, walletId, amount, details, idempotencyKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleDepositWithDrawBalance", reflect.TypeOf((*MockTransactionService)(nil).HandleDepositWithDrawBalance), userId, walletId, amount, details, idempotencyKey)
+}
+
+// HandleReverseTransaction mocks base method.
+func (m *MockTransactionService) HandleReverseTransaction(userId, transactionId string, amount *money.Amount, details commands.TransactionDetails, idempotencyKey *string) (*api_gen.TransactionResponseData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleReverseTransaction", userId, transactionId, amount, details, idempotencyKey)
+	ret0, _ := ret[0].(*api_gen.TransactionResponseData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleReverseTransaction indicates an expected call of HandleReverseTransaction.
+func (mr *MockTransactionServiceMockRecorder) HandleReverseTransaction(userId, transactionId, amount, details, idempotencyKey any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleDepositWithDrawBalance", reflect.TypeOf((*MockTransactionService)(nil).HandleDepositWithDrawBalance), userId, walletId, amount)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleReverseTransaction", reflect.TypeOf((*MockTransactionService)(nil).HandleReverseTransaction), userId, transactionId, amount, details, idempotencyKey)
 }
 
 // HandleTransferBalance mocks base method.
-func (m *MockTransactionService) HandleTransferBalance(userId, from, to string, amount float64) error {
+func (m *MockTransactionService) HandleTransferBalance(userId, from, to string, amount money.Amount, details commands.TransactionDetails, idempotencyKey *string) (*api_gen.TransactionResponseData, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HandleTransferBalance", userId, from, to, amount)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "HandleTransferBalance", userId, from, to, amount, details, idempotencyKey)
+	ret0, _ := ret[0].(*api_gen.TransactionResponseData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // HandleTransferBalance indicates an expected call of HandleTransferBalance.
-func (mr *MockTransactionServiceMockRecorder) HandleTransferBalance(userId, from, to, amount any) *gomock.Call {
+func (mr *MockTransactionServiceMockRecorder) HandleTransferBalance(userId, from, to, amount, details, idempotencyKey any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleTransferBalance", reflect.TypeOf((*MockTransactionService)(nil).HandleTransferBalance), userId, from, to, amount)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleTransferBalance", reflect.TypeOf((*MockTransactionService)(nil).HandleTransferBalance), userId, from, to, amount, details, idempotencyKey)
 }
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./user_settings.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./user_settings.go -destination=./mocks/mock_user_settings_service.go -package=mock_commands
+//
+
+// Package mock_commands is a generated GoMock package.
+package mock_commands
+
+import (
+	reflect "reflect"
+
+	api_gen "github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserSettingsService is a mock of UserSettingsService interface.
+type MockUserSettingsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserSettingsServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockUserSettingsServiceMockRecorder is the mock recorder for MockUserSettingsService.
+type MockUserSettingsServiceMockRecorder struct {
+	mock *MockUserSettingsService
+}
+
+// NewMockUserSettingsService creates a new mock instance.
+func NewMockUserSettingsService(ctrl *gomock.Controller) *MockUserSettingsService {
+	mock := &MockUserSettingsService{ctrl: ctrl}
+	mock.recorder = &MockUserSettingsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserSettingsService) EXPECT() *MockUserSettingsServiceMockRecorder {
+	return m.recorder
+}
+
+// HandleUpdate mocks base method.
+func (m *MockUserSettingsService) HandleUpdate(userId string, req api_gen.UpdateUserSettingsRequest) (*api_gen.UserSettingsResponseData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleUpdate", userId, req)
+	ret0, _ := ret[0].(*api_gen.UserSettingsResponseData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleUpdate indicates an expected call of HandleUpdate.
+func (mr *MockUserSettingsServiceMockRecorder) HandleUpdate(userId, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleUpdate", reflect.TypeOf((*MockUserSettingsService)(nil).HandleUpdate), userId, req)
+}
@@ -40,8 +40,23 @@ func (m *MockWalletService) EXPECT() *MockWalletServiceMockRecorder {
 	return m.recorder
 }
 
+// HandleClose mocks base method.
+func (m *MockWalletService) HandleClose(userId, walletId string, sweepTo *string) (*api_gen.CloseWalletResponseData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleClose", userId, walletId, sweepTo)
+	ret0, _ := ret[0].(*api_gen.CloseWalletResponseData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleClose indicates an expected call of HandleClose.
+func (mr *MockWalletServiceMockRecorder) HandleClose(userId, walletId, sweepTo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleClose", reflect.TypeOf((*MockWalletService)(nil).HandleClose), userId, walletId, sweepTo)
+}
+
 // HandleCreate mocks base method.
-func (m *MockWalletService) HandleCreate(userId string, req api_gen.WalletRequest) error {
+func (m *MockWalletService) HandleCreate(userId string, req api_gen.CreateWalletRequest) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "HandleCreate", userId, req)
 	ret0, _ := ret[0].(error)
@@ -54,18 +69,19 @@ func (mr *MockWalletServiceMockRecorder) HandleCreate(userId, req any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleCreate", reflect.TypeOf((*MockWalletService)(nil).HandleCreate), userId, req)
 }
 
-// HandleDelete mocks base method.
-func (m *MockWalletService) HandleDelete(userId, walletId string) error {
+// HandleUpdateCreditLine mocks base method.
+func (m *MockWalletService) HandleUpdateCreditLine(walletId string, req api_gen.UpdateCreditLineRequest) (*api_gen.WalletCreditLineResponseData, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HandleDelete", userId, walletId)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "HandleUpdateCreditLine", walletId, req)
+	ret0, _ := ret[0].(*api_gen.WalletCreditLineResponseData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// HandleDelete indicates an expected call of HandleDelete.
-func (mr *MockWalletServiceMockRecorder) HandleDelete(userId, walletId any) *gomock.Call {
+// HandleUpdateCreditLine indicates an expected call of HandleUpdateCreditLine.
+func (mr *MockWalletServiceMockRecorder) HandleUpdateCreditLine(walletId, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleDelete", reflect.TypeOf((*MockWalletService)(nil).HandleDelete), userId, walletId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleUpdateCreditLine", reflect.TypeOf((*MockWalletService)(nil).HandleUpdateCreditLine), walletId, req)
 }
 
 // HandleUpdateInfo mocks base method.
@@ -81,3 +97,18 @@ func (mr *MockWalletServiceMockRecorder) HandleUpdateInfo(userId, walletId, req
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleUpdateInfo", reflect.TypeOf((*MockWalletService)(nil).HandleUpdateInfo), userId, walletId, req)
 }
+
+// HandleUpdateStatus mocks base method.
+func (m *MockWalletService) HandleUpdateStatus(walletId string, req api_gen.UpdateWalletStatusRequest) (*api_gen.WalletStatusResponseData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleUpdateStatus", walletId, req)
+	ret0, _ := ret[0].(*api_gen.WalletStatusResponseData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleUpdateStatus indicates an expected call of HandleUpdateStatus.
+func (mr *MockWalletServiceMockRecorder) HandleUpdateStatus(walletId, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleUpdateStatus", reflect.TypeOf((*MockWalletService)(nil).HandleUpdateStatus), walletId, req)
+}
@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"errors"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/repositories"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultPaymentRequestDuration applies when a request sets no expiry.
+	defaultPaymentRequestDuration = 7 * 24 * time.Hour
+	// maxPaymentRequestDuration bounds how far ahead a request may expire.
+	maxPaymentRequestDuration = 30 * 24 * time.Hour
+)
+
+//go:generate mockgen -source=./payment_request.go -destination=./mocks/mock_payment_request_service.go -package=mock_commands
+type PaymentRequestService interface {
+	HandleCreate(userId string, req api_gen.CreatePaymentRequestRequest) (*api_gen.PaymentRequestResponseData, error)
+	HandleAccept(userId, requestId, fromWalletId string) (*api_gen.PaymentRequestResponseData, error)
+	HandleDecline(userId, requestId string) (*api_gen.PaymentRequestResponseData, error)
+}
+
+type paymentRequestService struct {
+	paymentRequestRepo repositories.PaymentRequestRepository
+	userRepo           repositories.UserRepository
+	limitRepo          repositories.LimitRepository
+	feeRepo            repositories.FeeRepository
+}
+
+func NewPaymentRequestService(paymentRequestRepo repositories.PaymentRequestRepository, userRepo repositories.UserRepository, limitRepo repositories.LimitRepository, feeRepo repositories.FeeRepository) PaymentRequestService {
+	return &paymentRequestService{paymentRequestRepo: paymentRequestRepo, userRepo: userRepo, limitRepo: limitRepo, feeRepo: feeRepo}
+}
+
+func (r *paymentRequestService) HandleCreate(userId string, req api_gen.CreatePaymentRequestRequest) (*api_gen.PaymentRequestResponseData, error) {
+	now := time.Now()
+	expiresAt := now.Add(defaultPaymentRequestDuration)
+	if req.ExpiresAt != nil {
+		expiresAt = *req.ExpiresAt
+	}
+	if !expiresAt.After(now) || expiresAt.After(now.Add(maxPaymentRequestDuration)) {
+		return nil, consts.ErrInvalidPaymentRequestExpiry
+	}
+
+	payer, err := r.userRepo.QueryByEmail(req.PayerEmail)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, consts.ErrPayerNotFound
+		}
+		return nil, err
+	}
+	if payer.ID == userId {
+		return nil, consts.ErrPaymentRequestToSelf
+	}
+
+	request, err := r.paymentRequestRepo.Create(entity.PaymentRequest{
+		RequesterID: userId,
+		PayerID:     payer.ID,
+		ToWalletID:  req.ToWalletId,
+		Amount:      req.Amount,
+		Note:        req.Note,
+		ExpiresAt:   expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toPaymentRequestResponseData(request), nil
+}
+
+// HandleAccept pays the request with the same limits and fees as a
+// transfer. The payment and the status change commit together, so retrying
+// an accept whose outcome was lost fails as not pending instead of paying
+// twice.
+func (r *paymentRequestService) HandleAccept(userId, requestId, fromWalletId string) (*api_gen.PaymentRequestResponseData, error) {
+	request, err := r.paymentRequestRepo.FindPayable(userId, requestId)
+	if err != nil {
+		return nil, err
+	}
+
+	check, err := newLimitCheck(r.limitRepo, userId, request.Amount, true)
+	if err != nil {
+		return nil, err
+	}
+
+	_, fee, err := quoteFee(r.feeRepo, userId, fromWalletId, "transfer", request.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	accepted, err := r.paymentRequestRepo.Accept(userId, request.ID, fromWalletId, check, fee)
+	if err != nil {
+		return nil, err
+	}
+	return toPaymentRequestResponseData(accepted), nil
+}
+
+func (r *paymentRequestService) HandleDecline(userId, requestId string) (*api_gen.PaymentRequestResponseData, error) {
+	request, err := r.paymentRequestRepo.Decline(userId, requestId)
+	if err != nil {
+		return nil, err
+	}
+	return toPaymentRequestResponseData(request), nil
+}
+
+func toPaymentRequestResponseData(request *entity.PaymentRequest) *api_gen.PaymentRequestResponseData {
+	return &api_gen.PaymentRequestResponseData{
+		Id:             request.ID,
+		RequesterEmail: request.Requester.Email,
+		PayerEmail:     request.Payer.Email,
+		ToWalletId:     request.ToWalletID,
+		Amount:         request.Amount,
+		Note:           request.Note,
+		Status:         api_gen.PaymentRequestResponseDataStatus(request.Status),
+		TransactionId:  request.TransactionID,
+		ExpiresAt:      request.ExpiresAt,
+		CreatedAt:      request.CreatedAt,
+	}
+}
@@ -0,0 +1,233 @@
+package commands_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func (suite *CommandsTestSuite) TestPaymentRequestService_HandleCreate() {
+	expiresAt := time.Now().Add(24 * time.Hour)
+	pastExpiry := time.Now().Add(-time.Minute)
+	farExpiry := time.Now().Add(31 * 24 * time.Hour)
+
+	testCases := []struct {
+		name        string
+		req         api_gen.CreatePaymentRequestRequest
+		mock        func()
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenValidRequest_WhenCreateSuccess_ThenReturnRequest",
+			req:  api_gen.CreatePaymentRequestRequest{PayerEmail: "payer@example.com", ToWalletId: "<ToWalletID>", Amount: money.MustParse("25"), ExpiresAt: &expiresAt},
+			mock: func() {
+				suite.mockUserRepo.EXPECT().QueryByEmail("payer@example.com").Return(&entity.User{ID: "<PayerID>"}, nil)
+				suite.mockPaymentRequestRepo.EXPECT().
+					Create(entity.PaymentRequest{RequesterID: "<UserID>", PayerID: "<PayerID>", ToWalletID: "<ToWalletID>", Amount: money.MustParse("25"), ExpiresAt: expiresAt}).
+					Return(&entity.PaymentRequest{ID: "<RequestID>", Status: "pending", ExpiresAt: expiresAt}, nil)
+			},
+			wantErr:     false,
+			expectedErr: "",
+		},
+		{
+			name: "GivenNoExpiry_WhenCreateSuccess_ThenDefaultExpiryApplied",
+			req:  api_gen.CreatePaymentRequestRequest{PayerEmail: "payer@example.com", ToWalletId: "<ToWalletID>", Amount: money.MustParse("25")},
+			mock: func() {
+				suite.mockUserRepo.EXPECT().QueryByEmail("payer@example.com").Return(&entity.User{ID: "<PayerID>"}, nil)
+				suite.mockPaymentRequestRepo.EXPECT().
+					Create(gomock.Cond(func(request entity.PaymentRequest) bool {
+						return request.ExpiresAt.After(time.Now().Add(6*24*time.Hour)) && request.ExpiresAt.Before(time.Now().Add(8*24*time.Hour))
+					})).
+					Return(&entity.PaymentRequest{ID: "<RequestID>", Status: "pending"}, nil)
+			},
+			wantErr:     false,
+			expectedErr: "",
+		},
+		{
+			name:        "GivenPastExpiry_WhenCreate_ThenError",
+			req:         api_gen.CreatePaymentRequestRequest{PayerEmail: "payer@example.com", ToWalletId: "<ToWalletID>", Amount: money.MustParse("25"), ExpiresAt: &pastExpiry},
+			mock:        func() {},
+			wantErr:     true,
+			expectedErr: "invalid payment request expiry",
+		},
+		{
+			name:        "GivenExpiryBeyondLimit_WhenCreate_ThenError",
+			req:         api_gen.CreatePaymentRequestRequest{PayerEmail: "payer@example.com", ToWalletId: "<ToWalletID>", Amount: money.MustParse("25"), ExpiresAt: &farExpiry},
+			mock:        func() {},
+			wantErr:     true,
+			expectedErr: "invalid payment request expiry",
+		},
+		{
+			name: "GivenUnknownPayer_WhenCreate_ThenError",
+			req:  api_gen.CreatePaymentRequestRequest{PayerEmail: "unknown@example.com", ToWalletId: "<ToWalletID>", Amount: money.MustParse("25")},
+			mock: func() {
+				suite.mockUserRepo.EXPECT().QueryByEmail("unknown@example.com").Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr:     true,
+			expectedErr: consts.ErrPayerNotFound.Error(),
+		},
+		{
+			name: "GivenOwnEmail_WhenCreate_ThenError",
+			req:  api_gen.CreatePaymentRequestRequest{PayerEmail: "me@example.com", ToWalletId: "<ToWalletID>", Amount: money.MustParse("25")},
+			mock: func() {
+				suite.mockUserRepo.EXPECT().QueryByEmail("me@example.com").Return(&entity.User{ID: "<UserID>"}, nil)
+			},
+			wantErr:     true,
+			expectedErr: consts.ErrPaymentRequestToSelf.Error(),
+		},
+		{
+			name: "GivenValidRequest_WhenCreateFails_ThenError",
+			req:  api_gen.CreatePaymentRequestRequest{PayerEmail: "payer@example.com", ToWalletId: "<ToWalletID>", Amount: money.MustParse("25")},
+			mock: func() {
+				suite.mockUserRepo.EXPECT().QueryByEmail("payer@example.com").Return(&entity.User{ID: "<PayerID>"}, nil)
+				suite.mockPaymentRequestRepo.EXPECT().Create(gomock.Any()).Return(nil, errors.New("create error"))
+			},
+			wantErr:     true,
+			expectedErr: "create error",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			result, err := suite.paymentRequestService.HandleCreate("<UserID>", tc.req)
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(result)
+			} else {
+				suite.NoError(err)
+				suite.Equal("<RequestID>", result.Id)
+				suite.Equal(api_gen.PaymentRequestResponseDataStatus("pending"), result.Status)
+			}
+		})
+	}
+}
+
+func (suite *CommandsTestSuite) TestPaymentRequestService_HandleAccept() {
+	note := "Dinner"
+	transactionId := "<TransactionID>"
+	pending := &entity.PaymentRequest{ID: "<RequestID>", ToWalletID: "<ToWalletID>", Amount: money.MustParse("25"), Note: &note, Status: "pending"}
+	fromWallet := &entity.Wallet{ID: "<FromWalletID>", Currency: "THB"}
+	perTransactionMax := money.MustParse("100")
+
+	testCases := []struct {
+		name        string
+		mock        func()
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenPendingRequest_WhenAcceptSuccess_ThenPaidWithTransferFeeAndAccepted",
+			mock: func() {
+				suite.mockPaymentRequestRepo.EXPECT().FindPayable("<UserID>", "<RequestID>").Return(pending, nil)
+				suite.mockLimitRepo.EXPECT().ListForUser("<UserID>").Return(nil, nil)
+				suite.mockFeeRepo.EXPECT().FindForWallet("<UserID>", "<FromWalletID>", "transfer").
+					Return(fromWallet, []entity.FeeRule{{FlatFee: money.MustParse("1"), FeeWalletID: "<FeeWalletID>"}}, nil)
+				suite.mockPaymentRequestRepo.EXPECT().
+					Accept("<UserID>", "<RequestID>", "<FromWalletID>", gomock.Nil(), &repositories.Fee{Amount: money.MustParse("1"), WalletID: "<FeeWalletID>"}).
+					Return(&entity.PaymentRequest{ID: "<RequestID>", Status: "accepted", TransactionID: &transactionId}, nil)
+			},
+			wantErr:     false,
+			expectedErr: "",
+		},
+		{
+			name: "GivenTransferLimits_WhenAccept_ThenLimitCheckPassedToRepository",
+			mock: func() {
+				suite.mockPaymentRequestRepo.EXPECT().FindPayable("<UserID>", "<RequestID>").Return(pending, nil)
+				suite.mockLimitRepo.EXPECT().ListForUser("<UserID>").
+					Return([]entity.TransactionLimit{{Currency: "THB", PerTransactionMax: &perTransactionMax}}, nil)
+				suite.mockFeeRepo.EXPECT().FindForWallet("<UserID>", "<FromWalletID>", "transfer").Return(fromWallet, nil, nil)
+				suite.mockPaymentRequestRepo.EXPECT().
+					Accept("<UserID>", "<RequestID>", "<FromWalletID>", gomock.Not(gomock.Nil()), gomock.Nil()).
+					Return(&entity.PaymentRequest{ID: "<RequestID>", Status: "accepted", TransactionID: &transactionId}, nil)
+			},
+			wantErr:     false,
+			expectedErr: "",
+		},
+		{
+			name: "GivenRequestNotPending_WhenAccept_ThenError",
+			mock: func() {
+				suite.mockPaymentRequestRepo.EXPECT().FindPayable("<UserID>", "<RequestID>").Return(nil, consts.ErrPaymentRequestNotPending)
+			},
+			wantErr:     true,
+			expectedErr: consts.ErrPaymentRequestNotPending.Error(),
+		},
+		{
+			name: "GivenPendingRequest_WhenPaymentFails_ThenError",
+			mock: func() {
+				suite.mockPaymentRequestRepo.EXPECT().FindPayable("<UserID>", "<RequestID>").Return(pending, nil)
+				suite.mockLimitRepo.EXPECT().ListForUser("<UserID>").Return(nil, nil)
+				suite.mockFeeRepo.EXPECT().FindForWallet("<UserID>", "<FromWalletID>", "transfer").Return(fromWallet, nil, nil)
+				suite.mockPaymentRequestRepo.EXPECT().
+					Accept("<UserID>", "<RequestID>", "<FromWalletID>", gomock.Nil(), gomock.Nil()).
+					Return(nil, consts.ErrInsufficientBalance)
+			},
+			wantErr:     true,
+			expectedErr: consts.ErrInsufficientBalance.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			result, err := suite.paymentRequestService.HandleAccept("<UserID>", "<RequestID>", "<FromWalletID>")
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(result)
+			} else {
+				suite.NoError(err)
+				suite.Equal(api_gen.PaymentRequestResponseDataStatus("accepted"), result.Status)
+				suite.Equal(&transactionId, result.TransactionId)
+			}
+		})
+	}
+}
+
+func (suite *CommandsTestSuite) TestPaymentRequestService_HandleDecline() {
+	testCases := []struct {
+		name        string
+		mock        func()
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenPendingRequest_WhenDeclineSuccess_ThenReturnRequest",
+			mock: func() {
+				suite.mockPaymentRequestRepo.EXPECT().Decline("<UserID>", "<RequestID>").
+					Return(&entity.PaymentRequest{ID: "<RequestID>", Status: "declined"}, nil)
+			},
+			wantErr:     false,
+			expectedErr: "",
+		},
+		{
+			name: "GivenRequest_WhenDeclineFails_ThenError",
+			mock: func() {
+				suite.mockPaymentRequestRepo.EXPECT().Decline("<UserID>", "<RequestID>").Return(nil, errors.New("decline error"))
+			},
+			wantErr:     true,
+			expectedErr: "decline error",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			result, err := suite.paymentRequestService.HandleDecline("<UserID>", "<RequestID>")
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(result)
+			} else {
+				suite.NoError(err)
+				suite.Equal(api_gen.PaymentRequestResponseDataStatus("declined"), result.Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/repositories"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+)
+
+//go:generate mockgen -source=./schedule.go -destination=./mocks/mock_schedule_service.go -package=mock_commands
+type ScheduleService interface {
+	HandleCreate(userId string, req api_gen.CreateScheduleRequest) (*api_gen.ScheduleResponseData, error)
+	HandleUpdate(userId, scheduleId string, req api_gen.UpdateScheduleRequest) (*api_gen.ScheduleResponseData, error)
+	HandleCancel(userId, scheduleId string) (*api_gen.ScheduleResponseData, error)
+}
+
+type scheduleService struct {
+	scheduleRepo repositories.ScheduleRepository
+}
+
+func NewScheduleService(scheduleRepo repositories.ScheduleRepository) ScheduleService {
+	return &scheduleService{scheduleRepo: scheduleRepo}
+}
+
+func (r *scheduleService) HandleCreate(userId string, req api_gen.CreateScheduleRequest) (*api_gen.ScheduleResponseData, error) {
+	if !req.StartAt.After(time.Now()) || (req.EndAt != nil && req.EndAt.Before(req.StartAt)) {
+		return nil, consts.ErrInvalidScheduleTime
+	}
+
+	schedule, err := r.scheduleRepo.Create(userId, entity.Schedule{
+		FromWalletID: req.FromWalletId,
+		ToWalletID:   req.ToWalletId,
+		Amount:       req.Amount,
+		Frequency:    string(req.Frequency),
+		StartAt:      req.StartAt,
+		EndAt:        req.EndAt,
+		MaxRuns:      req.MaxRuns,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toScheduleResponseData(schedule), nil
+}
+
+func (r *scheduleService) HandleUpdate(userId, scheduleId string, req api_gen.UpdateScheduleRequest) (*api_gen.ScheduleResponseData, error) {
+	schedule, err := r.scheduleRepo.Update(userId, scheduleId, req.Amount, req.EndAt, req.MaxRuns)
+	if err != nil {
+		return nil, err
+	}
+	return toScheduleResponseData(schedule), nil
+}
+
+func (r *scheduleService) HandleCancel(userId, scheduleId string) (*api_gen.ScheduleResponseData, error) {
+	schedule, err := r.scheduleRepo.Cancel(userId, scheduleId)
+	if err != nil {
+		return nil, err
+	}
+	return toScheduleResponseData(schedule), nil
+}
+
+func toScheduleResponseData(schedule *entity.Schedule) *api_gen.ScheduleResponseData {
+	return &api_gen.ScheduleResponseData{
+		Id:           schedule.ID,
+		FromWalletId: schedule.FromWalletID,
+		ToWalletId:   schedule.ToWalletID,
+		Amount:       schedule.Amount,
+		Frequency:    api_gen.ScheduleResponseDataFrequency(schedule.Frequency),
+		StartAt:      schedule.StartAt,
+		EndAt:        schedule.EndAt,
+		MaxRuns:      schedule.MaxRuns,
+		RunCount:     schedule.RunCount,
+		NextRunAt:    schedule.NextRunAt,
+		Status:       api_gen.ScheduleResponseDataStatus(schedule.Status),
+		CreatedAt:    schedule.CreatedAt,
+	}
+}
@@ -0,0 +1,160 @@
+package commands_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+)
+
+func (suite *CommandsTestSuite) TestScheduleService_HandleCreate() {
+	startAt := time.Now().Add(24 * time.Hour)
+	endAt := startAt.Add(-time.Hour)
+	maxRuns := 12
+
+	testCases := []struct {
+		name        string
+		req         api_gen.CreateScheduleRequest
+		mock        func()
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenMonthlySchedule_WhenCreateSuccess_ThenReturnSchedule",
+			req: api_gen.CreateScheduleRequest{
+				FromWalletId: "<FromWalletID>", ToWalletId: "<ToWalletID>", Amount: money.MustParse("25"),
+				Frequency: api_gen.CreateScheduleRequestFrequencyMonthly, StartAt: startAt, MaxRuns: &maxRuns,
+			},
+			mock: func() {
+				suite.mockScheduleRepo.EXPECT().
+					Create("<UserID>", entity.Schedule{
+						FromWalletID: "<FromWalletID>", ToWalletID: "<ToWalletID>", Amount: money.MustParse("25"),
+						Frequency: "monthly", StartAt: startAt, MaxRuns: &maxRuns,
+					}).
+					Return(&entity.Schedule{ID: "<ScheduleID>", Frequency: "monthly", Status: "active", NextRunAt: &startAt}, nil)
+			},
+			wantErr:     false,
+			expectedErr: "",
+		},
+		{
+			name: "GivenPastStart_WhenCreate_ThenError",
+			req: api_gen.CreateScheduleRequest{
+				FromWalletId: "<FromWalletID>", ToWalletId: "<ToWalletID>", Amount: money.MustParse("25"),
+				Frequency: api_gen.CreateScheduleRequestFrequencyOnce, StartAt: time.Now().Add(-time.Minute),
+			},
+			mock:        func() {},
+			wantErr:     true,
+			expectedErr: "invalid schedule time",
+		},
+		{
+			name: "GivenEndBeforeStart_WhenCreate_ThenError",
+			req: api_gen.CreateScheduleRequest{
+				FromWalletId: "<FromWalletID>", ToWalletId: "<ToWalletID>", Amount: money.MustParse("25"),
+				Frequency: api_gen.CreateScheduleRequestFrequencyDaily, StartAt: startAt, EndAt: &endAt,
+			},
+			mock:        func() {},
+			wantErr:     true,
+			expectedErr: "invalid schedule time",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			result, err := suite.scheduleService.HandleCreate("<UserID>", tc.req)
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(result)
+			} else {
+				suite.NoError(err)
+				suite.Equal("<ScheduleID>", result.Id)
+				suite.Equal(api_gen.ScheduleResponseDataStatusActive, result.Status)
+			}
+		})
+	}
+}
+
+func (suite *CommandsTestSuite) TestScheduleService_HandleUpdate() {
+	testCases := []struct {
+		name        string
+		mock        func()
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenActiveSchedule_WhenUpdateSuccess_ThenReturnSchedule",
+			mock: func() {
+				suite.mockScheduleRepo.EXPECT().Update("<UserID>", "<ScheduleID>", money.MustParse("30"), nil, nil).
+					Return(&entity.Schedule{ID: "<ScheduleID>", Amount: money.MustParse("30"), Status: "active"}, nil)
+			},
+			wantErr:     false,
+			expectedErr: "",
+		},
+		{
+			name: "GivenSchedule_WhenUpdateFails_ThenError",
+			mock: func() {
+				suite.mockScheduleRepo.EXPECT().Update("<UserID>", "<ScheduleID>", money.MustParse("30"), nil, nil).
+					Return(nil, errors.New("update error"))
+			},
+			wantErr:     true,
+			expectedErr: "update error",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			result, err := suite.scheduleService.HandleUpdate("<UserID>", "<ScheduleID>", api_gen.UpdateScheduleRequest{Amount: money.MustParse("30")})
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(result)
+			} else {
+				suite.NoError(err)
+				suite.Equal(money.MustParse("30"), result.Amount)
+			}
+		})
+	}
+}
+
+func (suite *CommandsTestSuite) TestScheduleService_HandleCancel() {
+	testCases := []struct {
+		name        string
+		mock        func()
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenActiveSchedule_WhenCancelSuccess_ThenReturnSchedule",
+			mock: func() {
+				suite.mockScheduleRepo.EXPECT().Cancel("<UserID>", "<ScheduleID>").
+					Return(&entity.Schedule{ID: "<ScheduleID>", Status: "cancelled"}, nil)
+			},
+			wantErr:     false,
+			expectedErr: "",
+		},
+		{
+			name: "GivenSchedule_WhenCancelFails_ThenError",
+			mock: func() {
+				suite.mockScheduleRepo.EXPECT().Cancel("<UserID>", "<ScheduleID>").Return(nil, errors.New("cancel error"))
+			},
+			wantErr:     true,
+			expectedErr: "cancel error",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			result, err := suite.scheduleService.HandleCancel("<UserID>", "<ScheduleID>")
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(result)
+			} else {
+				suite.NoError(err)
+				suite.Equal(api_gen.ScheduleResponseDataStatusCancelled, result.Status)
+			}
+		})
+	}
+}
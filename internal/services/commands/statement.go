@@ -0,0 +1,127 @@
+package commands
+
+import (
+	"errors"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/repositories"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=./statement.go -destination=./mocks/mock_statement_service.go -package=mock_commands
+type StatementService interface {
+	HandleGenerate(userId, walletId string, month time.Time) (*api_gen.StatementResponseData, error)
+}
+
+type statementService struct {
+	walletRepo      repositories.WalletRepository
+	transactionRepo repositories.TransactionRepository
+	statementRepo   repositories.StatementRepository
+}
+
+func NewStatementService(walletRepo repositories.WalletRepository, transactionRepo repositories.TransactionRepository, statementRepo repositories.StatementRepository) StatementService {
+	return &statementService{walletRepo: walletRepo, transactionRepo: transactionRepo, statementRepo: statementRepo}
+}
+
+// HandleGenerate builds the statement for the UTC calendar month containing
+// month. A month that has ended is stored on first request and served from
+// the stored copy afterwards; the current month is rebuilt every time.
+func (r *statementService) HandleGenerate(userId, walletId string, month time.Time) (*api_gen.StatementResponseData, error) {
+	wallet, err := r.walletRepo.QueryByIdAndUser(userId, walletId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	periodStart := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := periodStart.AddDate(0, 1, 0)
+	if !periodStart.Before(now) {
+		return nil, consts.ErrStatementPeriodNotStarted
+	}
+	final := !periodEnd.After(now)
+
+	if final {
+		stored, err := r.statementRepo.FindByPeriod(wallet.ID, periodStart)
+		if err == nil {
+			return statementResponse(stored, true), nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+
+	opening, err := r.transactionRepo.BalanceAt(wallet.ID, periodStart)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := r.transactionRepo.ListStatementLines(wallet.ID, periodStart, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := &entity.Statement{
+		WalletID:       wallet.ID,
+		PeriodStart:    periodStart,
+		PeriodEnd:      periodEnd,
+		Currency:       wallet.Currency,
+		OpeningBalance: opening,
+		ClosingBalance: opening,
+		Lines:          entity.StatementLines{},
+		CreatedAt:      now,
+	}
+	for _, line := range lines {
+		if line.Amount >= 0 {
+			statement.TotalIn += line.Amount
+		} else {
+			statement.TotalOut -= line.Amount
+		}
+		statement.ClosingBalance += line.Amount
+		line.BalanceAfter = statement.ClosingBalance
+		statement.Lines = append(statement.Lines, line)
+	}
+
+	if final {
+		statement, err = r.statementRepo.Create(*statement)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return statementResponse(statement, final), nil
+}
+
+func statementResponse(statement *entity.Statement, final bool) *api_gen.StatementResponseData {
+	response := &api_gen.StatementResponseData{
+		WalletId:       statement.WalletID,
+		Currency:       statement.Currency.String(),
+		PeriodStart:    statement.PeriodStart,
+		PeriodEnd:      statement.PeriodEnd,
+		OpeningBalance: statement.OpeningBalance,
+		TotalIn:        statement.TotalIn,
+		TotalOut:       statement.TotalOut,
+		ClosingBalance: statement.ClosingBalance,
+		Final:          final,
+		GeneratedAt:    statement.CreatedAt,
+		Lines:          []api_gen.StatementLineData{},
+	}
+	if statement.ID != "" {
+		response.StatementId = &statement.ID
+	}
+	for _, line := range statement.Lines {
+		response.Lines = append(response.Lines, api_gen.StatementLineData{
+			TransactionId:        line.TransactionID,
+			Type:                 line.Type,
+			Description:          line.Description,
+			Reference:            line.Reference,
+			CounterpartyWalletId: line.CounterpartyWalletID,
+			Amount:               line.Amount,
+			BalanceAfter:         line.BalanceAfter,
+			CreatedAt:            line.CreatedAt,
+		})
+	}
+	return response
+}
@@ -0,0 +1,136 @@
+package commands_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func (suite *CommandsTestSuite) TestStatementService_HandleGenerate() {
+	wallet := &entity.Wallet{ID: "<WalletID>", UserID: "<UserID>", Currency: "THB"}
+	closedStart := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	closedEnd := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Now().UTC()
+	openStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	openEnd := openStart.AddDate(0, 1, 0)
+
+	transactionId := "<TransactionID>"
+	lines := []entity.StatementLine{
+		{TransactionID: &transactionId, Type: "deposit", Amount: money.MustParse("100"), CreatedAt: closedStart.Add(time.Hour)},
+		{Type: "adjustment", Amount: money.MustParse("-30.50"), CreatedAt: closedStart.Add(2 * time.Hour)},
+	}
+
+	testCases := []struct {
+		name            string
+		month           time.Time
+		mock            func()
+		wantErr         bool
+		expectedErr     error
+		wantFinal       bool
+		wantStatementId bool
+		wantClosing     money.Amount
+		wantBalances    []money.Amount
+	}{
+		{
+			name:  "GivenClosedMonthAlreadyStored_WhenGenerate_ThenReturnStoredStatement",
+			month: time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC),
+			mock: func() {
+				suite.mockWalletRepo.EXPECT().QueryByIdAndUser("<UserID>", "<WalletID>").Return(wallet, nil)
+				suite.mockStatementRepo.EXPECT().FindByPeriod("<WalletID>", closedStart).Return(&entity.Statement{
+					ID: "<StatementID>", WalletID: "<WalletID>", PeriodStart: closedStart, PeriodEnd: closedEnd,
+					Currency: "THB", OpeningBalance: money.MustParse("10"), ClosingBalance: money.MustParse("20"),
+				}, nil)
+			},
+			wantFinal:       true,
+			wantStatementId: true,
+			wantClosing:     money.MustParse("20"),
+		},
+		{
+			name:  "GivenClosedMonthNotStored_WhenGenerate_ThenBuildAndStoreStatement",
+			month: closedStart,
+			mock: func() {
+				suite.mockWalletRepo.EXPECT().QueryByIdAndUser("<UserID>", "<WalletID>").Return(wallet, nil)
+				suite.mockStatementRepo.EXPECT().FindByPeriod("<WalletID>", closedStart).Return(nil, gorm.ErrRecordNotFound)
+				suite.mockTransactionRepo.EXPECT().BalanceAt("<WalletID>", closedStart).Return(money.MustParse("50"), nil)
+				suite.mockTransactionRepo.EXPECT().ListStatementLines("<WalletID>", closedStart, closedEnd).Return(lines, nil)
+				suite.mockStatementRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(statement entity.Statement) (*entity.Statement, error) {
+					suite.Equal(money.MustParse("100"), statement.TotalIn)
+					suite.Equal(money.MustParse("30.50"), statement.TotalOut)
+					statement.ID = "<StatementID>"
+					return &statement, nil
+				})
+			},
+			wantFinal:       true,
+			wantStatementId: true,
+			wantClosing:     money.MustParse("119.50"),
+			wantBalances:    []money.Amount{money.MustParse("150"), money.MustParse("119.50")},
+		},
+		{
+			name:  "GivenCurrentMonth_WhenGenerate_ThenBuildWithoutStoring",
+			month: now,
+			mock: func() {
+				suite.mockWalletRepo.EXPECT().QueryByIdAndUser("<UserID>", "<WalletID>").Return(wallet, nil)
+				suite.mockTransactionRepo.EXPECT().BalanceAt("<WalletID>", openStart).Return(money.MustParse("5"), nil)
+				suite.mockTransactionRepo.EXPECT().ListStatementLines("<WalletID>", openStart, openEnd).Return(nil, nil)
+			},
+			wantFinal:   false,
+			wantClosing: money.MustParse("5"),
+		},
+		{
+			name:  "GivenFutureMonth_WhenGenerate_ThenError",
+			month: openEnd,
+			mock: func() {
+				suite.mockWalletRepo.EXPECT().QueryByIdAndUser("<UserID>", "<WalletID>").Return(wallet, nil)
+			},
+			wantErr:     true,
+			expectedErr: consts.ErrStatementPeriodNotStarted,
+		},
+		{
+			name:  "GivenUnknownWallet_WhenGenerate_ThenError",
+			month: closedStart,
+			mock: func() {
+				suite.mockWalletRepo.EXPECT().QueryByIdAndUser("<UserID>", "<WalletID>").Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr:     true,
+			expectedErr: gorm.ErrRecordNotFound,
+		},
+		{
+			name:  "GivenStoreFails_WhenGenerate_ThenError",
+			month: closedStart,
+			mock: func() {
+				suite.mockWalletRepo.EXPECT().QueryByIdAndUser("<UserID>", "<WalletID>").Return(wallet, nil)
+				suite.mockStatementRepo.EXPECT().FindByPeriod("<WalletID>", closedStart).Return(nil, gorm.ErrRecordNotFound)
+				suite.mockTransactionRepo.EXPECT().BalanceAt("<WalletID>", closedStart).Return(money.Amount(0), nil)
+				suite.mockTransactionRepo.EXPECT().ListStatementLines("<WalletID>", closedStart, closedEnd).Return(nil, nil)
+				suite.mockStatementRepo.EXPECT().Create(gomock.Any()).Return(nil, errors.New("db error"))
+			},
+			wantErr:     true,
+			expectedErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			result, err := suite.statementService.HandleGenerate("<UserID>", "<WalletID>", tc.month)
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr.Error())
+				suite.Nil(result)
+			} else {
+				suite.NoError(err)
+				suite.Equal(tc.wantFinal, result.Final)
+				suite.Equal(tc.wantStatementId, result.StatementId != nil)
+				suite.Equal(tc.wantClosing, result.ClosingBalance)
+				suite.Len(result.Lines, len(tc.wantBalances))
+				for i, balance := range tc.wantBalances {
+					suite.Equal(balance, result.Lines[i].BalanceAfter)
+				}
+			}
+		})
+	}
+}
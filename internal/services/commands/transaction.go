@@ -1,27 +1,214 @@
 package commands
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/aarondl/null/v9"
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/money"
 	"github.com/slilp/go-wallet/internal/repositories"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
 )
 
 //go:generate mockgen -source=./transaction.go -destination=./mocks/mock_transaction_service.go -package=mock_commands
 type TransactionService interface {
-	HandleTransferBalance(userId, from, to string, amount float64) error
-	HandleDepositWithDrawBalance(userId, walletId string, amount float64) error
+	HandleTransferBalance(userId, from, to string, amount money.Amount, details TransactionDetails, idempotencyKey *string) (*api_gen.TransactionResponseData, error)
+	HandleBatchTransfer(userId, from string, legs []BatchTransferLeg, allOrNothing bool) ([]BatchTransferResult, error)
+	HandleDepositWithDrawBalance(userId, walletId string, amount money.Amount, details TransactionDetails, idempotencyKey *string) (*api_gen.TransactionResponseData, error)
+	HandleReverseTransaction(userId, transactionId string, amount *money.Amount, details TransactionDetails, idempotencyKey *string) (*api_gen.TransactionResponseData, error)
+}
+
+// TransactionDetails is the optional memo, external reference and metadata a
+// caller attaches to a movement.
+type TransactionDetails struct {
+	Description *string
+	Reference   *string
+	Metadata    map[string]interface{}
+}
+
+func (d TransactionDetails) toEntity() entity.TransactionDetails {
+	return entity.TransactionDetails{
+		Description: d.Description,
+		Reference:   d.Reference,
+		Metadata:    d.Metadata,
+	}
+}
+
+// fingerprint renders the details for newIdempotencyKey. Metadata keys are
+// marshalled in sorted order, so equal maps fingerprint the same.
+func (d TransactionDetails) fingerprint() string {
+	metadata, _ := json.Marshal(d.Metadata)
+	return strings.Join([]string{
+		null.StringFromPtr(d.Description).String,
+		null.StringFromPtr(d.Reference).String,
+		string(metadata),
+	}, "\n")
+}
+
+// BatchTransferLeg is one destination of a batch transfer.
+type BatchTransferLeg struct {
+	To      string
+	Amount  money.Amount
+	Details TransactionDetails
+}
+
+// BatchTransferResult is the transaction a leg created, or the error that
+// skipped it in best-effort mode.
+type BatchTransferResult struct {
+	Transaction *api_gen.TransactionResponseData
+	Err         error
 }
 
 type transactionService struct {
 	transactionRepo repositories.TransactionRepository
+	limitRepo       repositories.LimitRepository
+	feeRepo         repositories.FeeRepository
+}
+
+func NewTransactionService(transactionRepo repositories.TransactionRepository, limitRepo repositories.LimitRepository, feeRepo repositories.FeeRepository) TransactionService {
+	return &transactionService{transactionRepo: transactionRepo, limitRepo: limitRepo, feeRepo: feeRepo}
+}
+
+func (r *transactionService) HandleTransferBalance(userId, from, to string, amount money.Amount, details TransactionDetails, idempotencyKey *string) (*api_gen.TransactionResponseData, error) {
+	check, err := newLimitCheck(r.limitRepo, userId, amount, true)
+	if err != nil {
+		return nil, err
+	}
+
+	_, fee, err := quoteFee(r.feeRepo, userId, from, "transfer", amount)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.transactionRepo.UpdateTransferTransaction(userId, from, to, amount, details.toEntity(),
+		newIdempotencyKey(idempotencyKey, "transfer", from, to, amount.String(), details.fingerprint()), check, fee)
+	if err != nil {
+		return nil, err
+	}
+	return toTransactionResponseData(tx), nil
+}
+
+// HandleBatchTransfer loads the user's limits and the source wallet's fee
+// rules once and applies them to every leg, as if each were a transfer.
+func (r *transactionService) HandleBatchTransfer(userId, from string, legs []BatchTransferLeg, allOrNothing bool) ([]BatchTransferResult, error) {
+	limits, err := r.limitRepo.ListForUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, rules, err := r.feeRepo.FindForWallet(userId, from, "transfer")
+	if err != nil {
+		return nil, err
+	}
+
+	batch := make([]repositories.BatchTransferLeg, len(legs))
+	for i, leg := range legs {
+		fee, err := newFee(rules, leg.Amount, wallet.Currency)
+		if err != nil {
+			return nil, err
+		}
+		batch[i] = repositories.BatchTransferLeg{To: leg.To, Amount: leg.Amount, Details: leg.Details.toEntity(), Fee: fee}
+		if len(limits) > 0 {
+			batch[i].Check = limitCheck(limits, leg.Amount, true)
+		}
+	}
+
+	results, err := r.transactionRepo.UpdateBatchTransferTransaction(userId, from, batch, allOrNothing)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]BatchTransferResult, len(results))
+	for i, result := range results {
+		data[i].Err = result.Err
+		if result.Transaction != nil {
+			data[i].Transaction = toTransactionResponseData(result.Transaction)
+		}
+	}
+	return data, nil
 }
 
-func NewTransactionService(transactionRepo repositories.TransactionRepository) TransactionService {
-	return &transactionService{transactionRepo: transactionRepo}
+// HandleDepositWithDrawBalance deposits a positive amount and withdraws a
+// negative one. Only withdrawals are subject to transaction limits and fees.
+func (r *transactionService) HandleDepositWithDrawBalance(userId, walletId string, amount money.Amount, details TransactionDetails, idempotencyKey *string) (*api_gen.TransactionResponseData, error) {
+	var check repositories.LimitCheck
+	var fee *repositories.Fee
+	if amount < 0 {
+		var err error
+		if check, err = newLimitCheck(r.limitRepo, userId, amount.Neg(), false); err != nil {
+			return nil, err
+		}
+		if _, fee, err = quoteFee(r.feeRepo, userId, walletId, "withdraw", amount.Neg()); err != nil {
+			return nil, err
+		}
+	}
+
+	tx, err := r.transactionRepo.UpdateBalanceTransaction(userId, walletId, amount, details.toEntity(),
+		newIdempotencyKey(idempotencyKey, "balance", walletId, amount.String(), details.fingerprint()), check, fee)
+	if err != nil {
+		return nil, err
+	}
+	return toTransactionResponseData(tx), nil
+}
+
+func (r *transactionService) HandleReverseTransaction(userId, transactionId string, amount *money.Amount, details TransactionDetails, idempotencyKey *string) (*api_gen.TransactionResponseData, error) {
+	refund := "full"
+	if amount != nil {
+		refund = amount.String()
+	}
+
+	tx, err := r.transactionRepo.ReverseTransaction(userId, transactionId, amount, details.toEntity(),
+		newIdempotencyKey(idempotencyKey, "reverse", transactionId, refund, details.fingerprint()))
+	if err != nil {
+		return nil, err
+	}
+	return toTransactionResponseData(tx), nil
+}
+
+// newIdempotencyKey fingerprints the operation and its arguments so a key
+// replayed with a different request can be told apart.
+func newIdempotencyKey(key *string, parts ...string) *repositories.IdempotencyKey {
+	if key == nil || *key == "" {
+		return nil
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\n")))
+	return &repositories.IdempotencyKey{Key: *key, Fingerprint: hex.EncodeToString(sum[:])}
 }
 
-func (r *transactionService) HandleTransferBalance(userId, from, to string, amount float64) error {
-	return r.transactionRepo.UpdateTransferTransaction(userId, from, to, amount)
+func toTransactionResponseData(tx *entity.Transaction) *api_gen.TransactionResponseData {
+	var fee *money.Amount
+	if tx.Fee != nil {
+		fee = &tx.Fee.Amount
+	}
+	return &api_gen.TransactionResponseData{
+		Id:                    tx.ID,
+		FromWalletId:          null.StringFromPtr(tx.From).String,
+		ToWalletId:            null.StringFromPtr(tx.To).String,
+		FromBalanceAfter:      tx.FromBalanceAfter,
+		ToBalanceAfter:        tx.ToBalanceAfter,
+		Amount:                tx.Amount,
+		CreditedAmount:        tx.CreditedAmount,
+		ExchangeRate:          tx.ExchangeRate,
+		OriginalTransactionId: tx.OriginalTransactionID,
+		ParentTransactionId:   tx.ParentTransactionID,
+		Fee:                   fee,
+		RefundedAmount:        &tx.RefundedAmount,
+		Description:           tx.Details.Description,
+		Reference:             tx.Details.Reference,
+		Metadata:              metadataPtr(tx.Details.Metadata),
+		Type:                  api_gen.TransactionResponseDataType(tx.Type),
+		CreatedAt:             tx.CreatedAt,
+	}
 }
 
-func (r *transactionService) HandleDepositWithDrawBalance(userId, walletId string, amount float64) error {
-	return r.transactionRepo.UpdateBalanceTransaction(userId, walletId, amount)
+func metadataPtr(m entity.Metadata) *map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	metadata := map[string]interface{}(m)
+	return &metadata
 }
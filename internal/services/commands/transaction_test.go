@@ -2,25 +2,51 @@ package commands_test
 
 import (
 	"errors"
+
+	"github.com/aarondl/null/v9"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"github.com/slilp/go-wallet/internal/services/commands"
+	"go.uber.org/mock/gomock"
 )
 
 func (suite *CommandsTestSuite) TestTransactionService_HandleTransferBalance() {
 	testCases := []struct {
-		name        string
-		from        string
-		to          string
-		amount      float64
-		mock        func()
-		wantErr     bool
-		expectedErr string
+		name           string
+		from           string
+		to             string
+		amount         money.Amount
+		idempotencyKey *string
+		mock           func()
+		wantErr        bool
+		expectedErr    string
 	}{
 		{
 			name:   "GivingValidFromToAmount_WhenUpdateBalanceSuccess_ThenSuccess",
 			from:   "<FromWalletID>",
 			to:     "<ToWalletID>",
-			amount: 100.0,
+			amount: money.MustParse("100"),
+			mock: func() {
+				suite.mockLimitRepo.EXPECT().ListForUser("<UserID>").Return(nil, nil)
+				suite.mockFeeRepo.EXPECT().FindForWallet("<UserID>", "<FromWalletID>", "transfer").Return(&entity.Wallet{ID: "<FromWalletID>", Currency: "THB"}, nil, nil)
+				suite.mockTransactionRepo.EXPECT().UpdateTransferTransaction("<UserID>", "<FromWalletID>", "<ToWalletID>", money.MustParse("100"), entity.TransactionDetails{}, nil, nil, nil).Return(&entity.Transaction{ID: "<TransactionID>", From: null.StringFrom("<FromWalletID>").Ptr(), To: null.StringFrom("<ToWalletID>").Ptr(), Amount: money.MustParse("100"), Type: "transfer"}, nil)
+			},
+			wantErr:     false,
+			expectedErr: "",
+		},
+		{
+			name:           "GivingIdempotencyKey_WhenUpdateBalanceSuccess_ThenKeyFingerprinted",
+			from:           "<FromWalletID>",
+			to:             "<ToWalletID>",
+			amount:         money.MustParse("100"),
+			idempotencyKey: null.StringFrom("<IdempotencyKey>").Ptr(),
 			mock: func() {
-				suite.mockTransactionRepo.EXPECT().UpdateTransferTransaction("<UserID>", "<FromWalletID>", "<ToWalletID>", 100.0).Return(nil)
+				suite.mockLimitRepo.EXPECT().ListForUser("<UserID>").Return(nil, nil)
+				suite.mockFeeRepo.EXPECT().FindForWallet("<UserID>", "<FromWalletID>", "transfer").Return(&entity.Wallet{ID: "<FromWalletID>", Currency: "THB"}, nil, nil)
+				suite.mockTransactionRepo.EXPECT().UpdateTransferTransaction("<UserID>", "<FromWalletID>", "<ToWalletID>", money.MustParse("100"), entity.TransactionDetails{}, gomock.Cond(func(key *repositories.IdempotencyKey) bool {
+					return key.Key == "<IdempotencyKey>" && len(key.Fingerprint) == 64
+				}), nil, nil).Return(&entity.Transaction{ID: "<TransactionID>", Amount: money.MustParse("100"), Type: "transfer"}, nil)
 			},
 			wantErr:     false,
 			expectedErr: "",
@@ -29,9 +55,11 @@ func (suite *CommandsTestSuite) TestTransactionService_HandleTransferBalance() {
 			name:   "GivingValidFromToAmount_WhenUpdateBalanceFails_ThenError",
 			from:   "<FromWalletID>",
 			to:     "<ToWalletID>",
-			amount: 100.0,
+			amount: money.MustParse("100"),
 			mock: func() {
-				suite.mockTransactionRepo.EXPECT().UpdateTransferTransaction("<UserID>", "<FromWalletID>", "<ToWalletID>", 100.0).Return(errors.New("update balance error"))
+				suite.mockLimitRepo.EXPECT().ListForUser("<UserID>").Return(nil, nil)
+				suite.mockFeeRepo.EXPECT().FindForWallet("<UserID>", "<FromWalletID>", "transfer").Return(&entity.Wallet{ID: "<FromWalletID>", Currency: "THB"}, nil, nil)
+				suite.mockTransactionRepo.EXPECT().UpdateTransferTransaction("<UserID>", "<FromWalletID>", "<ToWalletID>", money.MustParse("100"), entity.TransactionDetails{}, nil, nil, nil).Return(nil, errors.New("update balance error"))
 			},
 			wantErr:     true,
 			expectedErr: "update balance error",
@@ -41,32 +69,161 @@ func (suite *CommandsTestSuite) TestTransactionService_HandleTransferBalance() {
 	for _, tc := range testCases {
 		suite.Run(tc.name, func() {
 			tc.mock()
-			err := suite.transactionService.HandleTransferBalance("<UserID>", tc.from, tc.to, tc.amount)
+			result, err := suite.transactionService.HandleTransferBalance("<UserID>", tc.from, tc.to, tc.amount, commands.TransactionDetails{}, tc.idempotencyKey)
 			if tc.wantErr {
 				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(result)
 			} else {
 				suite.NoError(err)
+				suite.Equal("<TransactionID>", result.Id)
 			}
 		})
 	}
 }
 
+func (suite *CommandsTestSuite) TestTransactionService_HandleBatchTransfer() {
+	reference := "PAY-2026-10"
+	legs := []commands.BatchTransferLeg{
+		{To: "<ToWalletID1>", Amount: money.MustParse("100"), Details: commands.TransactionDetails{Reference: &reference}},
+		{To: "<ToWalletID2>", Amount: money.MustParse("50")},
+	}
+	testCases := []struct {
+		name         string
+		allOrNothing bool
+		mock         func()
+		wantErr      bool
+		expectedErr  string
+		wantIds      []string
+	}{
+		{
+			name:         "GivingNoLimitsOrFees_WhenBatchSuccess_ThenLegsPassedWithoutCheckOrFee",
+			allOrNothing: true,
+			mock: func() {
+				suite.mockLimitRepo.EXPECT().ListForUser("<UserID>").Return(nil, nil)
+				suite.mockFeeRepo.EXPECT().FindForWallet("<UserID>", "<FromWalletID>", "transfer").Return(&entity.Wallet{ID: "<FromWalletID>", Currency: "THB"}, nil, nil)
+				suite.mockTransactionRepo.EXPECT().UpdateBatchTransferTransaction("<UserID>", "<FromWalletID>", []repositories.BatchTransferLeg{
+					{To: "<ToWalletID1>", Amount: money.MustParse("100"), Details: entity.TransactionDetails{Reference: &reference}},
+					{To: "<ToWalletID2>", Amount: money.MustParse("50")},
+				}, true).Return([]repositories.BatchTransferResult{
+					{Transaction: &entity.Transaction{ID: "<TransactionID1>", Amount: money.MustParse("100"), Type: "transfer"}},
+					{Transaction: &entity.Transaction{ID: "<TransactionID2>", Amount: money.MustParse("50"), Type: "transfer"}},
+				}, nil)
+			},
+			wantIds: []string{"<TransactionID1>", "<TransactionID2>"},
+		},
+		{
+			name:         "GivingLimitsAndFeeRule_WhenBatchSuccess_ThenEveryLegPricedAndChecked",
+			allOrNothing: false,
+			mock: func() {
+				suite.mockLimitRepo.EXPECT().ListForUser("<UserID>").Return([]entity.TransactionLimit{{Currency: "THB", PerTransactionMax: amountPtr("500")}}, nil)
+				suite.mockFeeRepo.EXPECT().FindForWallet("<UserID>", "<FromWalletID>", "transfer").Return(&entity.Wallet{ID: "<FromWalletID>", Currency: "THB"},
+					[]entity.FeeRule{{FlatFee: money.MustParse("1"), FeeWalletID: "<FeeWalletID>"}}, nil)
+				suite.mockTransactionRepo.EXPECT().UpdateBatchTransferTransaction("<UserID>", "<FromWalletID>", gomock.Any(), false).
+					DoAndReturn(func(_, _ string, got []repositories.BatchTransferLeg, _ bool) ([]repositories.BatchTransferResult, error) {
+						suite.Len(got, 2)
+						for _, leg := range got {
+							suite.NotNil(leg.Check)
+							suite.Equal(&repositories.Fee{Amount: money.MustParse("1"), WalletID: "<FeeWalletID>"}, leg.Fee)
+						}
+						return []repositories.BatchTransferResult{
+							{Transaction: &entity.Transaction{ID: "<TransactionID1>", Amount: money.MustParse("100"), Type: "transfer"}},
+							{Err: errors.New("leg failed")},
+						}, nil
+					})
+			},
+			wantIds: []string{"<TransactionID1>", ""},
+		},
+		{
+			name:         "GivingSourceWalletNotFound_WhenBatchTransfer_ThenError",
+			allOrNothing: true,
+			mock: func() {
+				suite.mockLimitRepo.EXPECT().ListForUser("<UserID>").Return(nil, nil)
+				suite.mockFeeRepo.EXPECT().FindForWallet("<UserID>", "<FromWalletID>", "transfer").Return(nil, nil, errors.New("record not found"))
+			},
+			wantErr:     true,
+			expectedErr: "record not found",
+		},
+		{
+			name:         "GivingValidLegs_WhenBatchTransferFails_ThenError",
+			allOrNothing: true,
+			mock: func() {
+				suite.mockLimitRepo.EXPECT().ListForUser("<UserID>").Return(nil, nil)
+				suite.mockFeeRepo.EXPECT().FindForWallet("<UserID>", "<FromWalletID>", "transfer").Return(&entity.Wallet{ID: "<FromWalletID>", Currency: "THB"}, nil, nil)
+				suite.mockTransactionRepo.EXPECT().UpdateBatchTransferTransaction("<UserID>", "<FromWalletID>", gomock.Any(), true).Return(nil, errors.New("batch error"))
+			},
+			wantErr:     true,
+			expectedErr: "batch error",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			results, err := suite.transactionService.HandleBatchTransfer("<UserID>", "<FromWalletID>", legs, tc.allOrNothing)
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(results)
+			} else {
+				suite.NoError(err)
+				suite.Len(results, len(tc.wantIds))
+				for i, id := range tc.wantIds {
+					if id == "" {
+						suite.Error(results[i].Err)
+						suite.Nil(results[i].Transaction)
+					} else {
+						suite.NoError(results[i].Err)
+						suite.Equal(id, results[i].Transaction.Id)
+					}
+				}
+			}
+		})
+	}
+}
+
+func (suite *CommandsTestSuite) TestTransactionService_HandleTransferBalance_WithDetails() {
+	description := "Dinner"
+	reference := "INV-1001"
+	details := entity.TransactionDetails{Description: &description, Reference: &reference, Metadata: entity.Metadata{"orderId": "A-1"}}
+
+	var fingerprints []string
+	suite.mockLimitRepo.EXPECT().ListForUser("<UserID>").Return(nil, nil).Times(2)
+	suite.mockFeeRepo.EXPECT().FindForWallet("<UserID>", "<FromWalletID>", "transfer").Return(&entity.Wallet{ID: "<FromWalletID>", Currency: "THB"}, nil, nil).Times(2)
+	suite.mockTransactionRepo.EXPECT().UpdateTransferTransaction("<UserID>", "<FromWalletID>", "<ToWalletID>", money.MustParse("100"), gomock.Any(), gomock.Any(), nil, nil).
+		DoAndReturn(func(_, _, _ string, _ money.Amount, got entity.TransactionDetails, key *repositories.IdempotencyKey, _ repositories.LimitCheck, _ *repositories.Fee) (*entity.Transaction, error) {
+			fingerprints = append(fingerprints, key.Fingerprint)
+			return &entity.Transaction{ID: "<TransactionID>", Amount: money.MustParse("100"), Type: "transfer", Details: got}, nil
+		}).Times(2)
+
+	result, err := suite.transactionService.HandleTransferBalance("<UserID>", "<FromWalletID>", "<ToWalletID>", money.MustParse("100"),
+		commands.TransactionDetails{Description: &description, Reference: &reference, Metadata: map[string]interface{}{"orderId": "A-1"}}, null.StringFrom("<IdempotencyKey>").Ptr())
+	suite.NoError(err)
+	suite.Equal(details.Description, result.Description)
+	suite.Equal(details.Reference, result.Reference)
+	suite.Equal(&map[string]interface{}{"orderId": "A-1"}, result.Metadata)
+
+	_, err = suite.transactionService.HandleTransferBalance("<UserID>", "<FromWalletID>", "<ToWalletID>", money.MustParse("100"),
+		commands.TransactionDetails{Description: &description, Reference: &reference, Metadata: map[string]interface{}{"orderId": "A-2"}}, null.StringFrom("<IdempotencyKey>").Ptr())
+	suite.NoError(err)
+	suite.NotEqual(fingerprints[0], fingerprints[1])
+}
+
 func (suite *CommandsTestSuite) TestWalletService_HandleDepositWithDrawBalance() {
 
 	testCases := []struct {
-		name        string
-		walletId    string
-		amount      float64
-		mock        func()
-		wantErr     bool
-		expectedErr string
+		name           string
+		walletId       string
+		amount         money.Amount
+		idempotencyKey *string
+		mock           func()
+		wantErr        bool
+		expectedErr    string
 	}{
 		{
 			name:     "GivenValidWalletIdAndPositiveAmount_WhenDepositSuccess_ThenSuccess",
 			walletId: "<WalletID>",
-			amount:   100.0,
+			amount:   money.MustParse("100"),
 			mock: func() {
-				suite.mockTransactionRepo.EXPECT().UpdateBalanceTransaction("<UserID>", "<WalletID>", 100.0).Return(nil)
+				suite.mockTransactionRepo.EXPECT().UpdateBalanceTransaction("<UserID>", "<WalletID>", money.MustParse("100"), entity.TransactionDetails{}, nil, nil, nil).Return(&entity.Transaction{ID: "<TransactionID>", Amount: money.MustParse("100")}, nil)
 			},
 			wantErr:     false,
 			expectedErr: "",
@@ -74,9 +231,11 @@ func (suite *CommandsTestSuite) TestWalletService_HandleDepositWithDrawBalance()
 		{
 			name:     "GivenValidWalletIdAndNegativeAmount_WhenWithdrawSuccess_ThenSuccess",
 			walletId: "<WalletID>",
-			amount:   -50.0,
+			amount:   money.MustParse("-50"),
 			mock: func() {
-				suite.mockTransactionRepo.EXPECT().UpdateBalanceTransaction("<UserID>", "<WalletID>", -50.0).Return(nil)
+				suite.mockLimitRepo.EXPECT().ListForUser("<UserID>").Return(nil, nil)
+				suite.mockFeeRepo.EXPECT().FindForWallet("<UserID>", "<WalletID>", "withdraw").Return(&entity.Wallet{ID: "<WalletID>", Currency: "THB"}, nil, nil)
+				suite.mockTransactionRepo.EXPECT().UpdateBalanceTransaction("<UserID>", "<WalletID>", money.MustParse("-50"), entity.TransactionDetails{}, nil, nil, nil).Return(&entity.Transaction{ID: "<TransactionID>", Amount: money.MustParse("-50")}, nil)
 			},
 			wantErr:     false,
 			expectedErr: "",
@@ -84,9 +243,9 @@ func (suite *CommandsTestSuite) TestWalletService_HandleDepositWithDrawBalance()
 		{
 			name:     "GivenValidWalletId_WhenUpdateBalanceFails_ThenError",
 			walletId: "<WalletID>",
-			amount:   10.0,
+			amount:   money.MustParse("10"),
 			mock: func() {
-				suite.mockTransactionRepo.EXPECT().UpdateBalanceTransaction("<UserID>", "<WalletID>", 10.0).Return(errors.New("update balance error"))
+				suite.mockTransactionRepo.EXPECT().UpdateBalanceTransaction("<UserID>", "<WalletID>", money.MustParse("10"), entity.TransactionDetails{}, nil, nil, nil).Return(nil, errors.New("update balance error"))
 			},
 			wantErr:     true,
 			expectedErr: "update balance error",
@@ -96,11 +255,60 @@ func (suite *CommandsTestSuite) TestWalletService_HandleDepositWithDrawBalance()
 	for _, tc := range testCases {
 		suite.Run(tc.name, func() {
 			tc.mock()
-			err := suite.transactionService.HandleDepositWithDrawBalance("<UserID>", tc.walletId, tc.amount)
+			result, err := suite.transactionService.HandleDepositWithDrawBalance("<UserID>", tc.walletId, tc.amount, commands.TransactionDetails{}, tc.idempotencyKey)
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(result)
+			} else {
+				suite.NoError(err)
+				suite.Equal(tc.amount, result.Amount)
+			}
+		})
+	}
+}
+
+func (suite *CommandsTestSuite) TestTransactionService_HandleReverseTransaction() {
+	partial := money.MustParse("20")
+
+	testCases := []struct {
+		name        string
+		amount      *money.Amount
+		mock        func()
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:   "GivenNoAmount_WhenReverseSuccess_ThenReturnReversal",
+			amount: nil,
+			mock: func() {
+				suite.mockTransactionRepo.EXPECT().ReverseTransaction("<UserID>", "<TransactionID>", nil, entity.TransactionDetails{}, nil).
+					Return(&entity.Transaction{ID: "<ReversalID>", Amount: money.MustParse("50"), Type: "reversal", OriginalTransactionID: null.StringFrom("<TransactionID>").Ptr()}, nil)
+			},
+			wantErr:     false,
+			expectedErr: "",
+		},
+		{
+			name:   "GivenPartialAmount_WhenReverseFails_ThenError",
+			amount: &partial,
+			mock: func() {
+				suite.mockTransactionRepo.EXPECT().ReverseTransaction("<UserID>", "<TransactionID>", &partial, entity.TransactionDetails{}, nil).
+					Return(nil, errors.New("reverse error"))
+			},
+			wantErr:     true,
+			expectedErr: "reverse error",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			result, err := suite.transactionService.HandleReverseTransaction("<UserID>", "<TransactionID>", tc.amount, commands.TransactionDetails{}, nil)
 			if tc.wantErr {
 				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(result)
 			} else {
 				suite.NoError(err)
+				suite.Equal("<TransactionID>", *result.OriginalTransactionId)
 			}
 		})
 	}
@@ -0,0 +1,33 @@
+package commands
+
+import (
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/repositories"
+)
+
+//go:generate mockgen -source=./user_settings.go -destination=./mocks/mock_user_settings_service.go -package=mock_commands
+type UserSettingsService interface {
+	HandleUpdate(userId string, req api_gen.UpdateUserSettingsRequest) (*api_gen.UserSettingsResponseData, error)
+}
+
+type userSettingsService struct {
+	userRepo repositories.UserRepository
+}
+
+func NewUserSettingsService(userRepo repositories.UserRepository) UserSettingsService {
+	return &userSettingsService{userRepo: userRepo}
+}
+
+func (r *userSettingsService) HandleUpdate(userId string, req api_gen.UpdateUserSettingsRequest) (*api_gen.UserSettingsResponseData, error) {
+	user, err := r.userRepo.UpdateSettings(userId, req.Handle, req.DefaultWalletId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api_gen.UserSettingsResponseData{
+		Email:           user.Email,
+		DisplayName:     user.DisplayName,
+		Handle:          user.Handle,
+		DefaultWalletId: user.DefaultWalletID,
+	}, nil
+}
@@ -0,0 +1,54 @@
+package commands_test
+
+import (
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+)
+
+func (suite *CommandsTestSuite) TestUserSettingsService_HandleUpdate() {
+	handle, walletId := "Friend", "<WalletID>"
+	stored := "friend"
+
+	testCases := []struct {
+		name        string
+		req         api_gen.UpdateUserSettingsRequest
+		mock        func()
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenHandleAndWallet_WhenUpdateSuccess_ThenReturnSettings",
+			req:  api_gen.UpdateUserSettingsRequest{Handle: &handle, DefaultWalletId: &walletId},
+			mock: func() {
+				suite.mockUserRepo.EXPECT().UpdateSettings("<UserID>", &handle, &walletId).
+					Return(&entity.User{ID: "<UserID>", Email: "<Email>", Handle: &stored, DefaultWalletID: &walletId}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "GivenTakenHandle_WhenUpdate_ThenError",
+			req:  api_gen.UpdateUserSettingsRequest{Handle: &handle},
+			mock: func() {
+				suite.mockUserRepo.EXPECT().UpdateSettings("<UserID>", &handle, nil).Return(nil, consts.ErrHandleTaken)
+			},
+			wantErr:     true,
+			expectedErr: consts.ErrHandleTaken.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			result, err := suite.userSettingsService.HandleUpdate("<UserID>", tc.req)
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(result)
+			} else {
+				suite.NoError(err)
+				suite.Equal(&stored, result.Handle)
+				suite.Equal(&walletId, result.DefaultWalletId)
+			}
+		})
+	}
+}
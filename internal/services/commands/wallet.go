@@ -2,15 +2,19 @@ package commands
 
 import (
 	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/money"
 	"github.com/slilp/go-wallet/internal/repositories"
 	"github.com/slilp/go-wallet/internal/repositories/entity"
 )
 
 //go:generate mockgen -source=./wallet.go -destination=./mocks/mock_wallet_service.go -package=mock_commands
 type WalletService interface {
-	HandleCreate(userId string, req api_gen.WalletRequest) error
-	HandleDelete(userId, walletId string) error
+	HandleCreate(userId string, req api_gen.CreateWalletRequest) error
+	HandleClose(userId, walletId string, sweepTo *string) (*api_gen.CloseWalletResponseData, error)
 	HandleUpdateInfo(userId, walletId string, req api_gen.WalletRequest) error
+	HandleUpdateStatus(walletId string, req api_gen.UpdateWalletStatusRequest) (*api_gen.WalletStatusResponseData, error)
+	HandleUpdateCreditLine(walletId string, req api_gen.UpdateCreditLineRequest) (*api_gen.WalletCreditLineResponseData, error)
 }
 
 type walletService struct {
@@ -21,20 +25,43 @@ func NewWalletService(walletRepo repositories.WalletRepository) WalletService {
 	return &walletService{walletRepo: walletRepo}
 }
 
-func (r *walletService) HandleCreate(userId string, req api_gen.WalletRequest) error {
+func (r *walletService) HandleCreate(userId string, req api_gen.CreateWalletRequest) error {
+	currency, ok := money.ParseCurrency(req.Currency)
+	if !ok {
+		return consts.ErrUnsupportedCurrency
+	}
+
 	return r.walletRepo.Create(entity.Wallet{
 		UserID:      userId,
 		Name:        req.Name,
 		Description: req.Description,
+		Currency:    currency,
 	})
 }
 
-func (r *walletService) HandleDelete(userId, walletId string) error {
-	if _, err := r.walletRepo.QueryByIdAndUser(userId, walletId); err != nil {
-		return err
+func (r *walletService) HandleClose(userId, walletId string, sweepTo *string) (*api_gen.CloseWalletResponseData, error) {
+	wallet, sweep, err := r.walletRepo.Close(userId, walletId, sweepTo)
+	if err != nil {
+		return nil, err
 	}
 
-	return r.walletRepo.Delete(walletId)
+	data := &api_gen.CloseWalletResponseData{
+		Wallet: api_gen.WalletResponseData{
+			Id:               wallet.ID,
+			Name:             wallet.Name,
+			Description:      wallet.Description,
+			Currency:         wallet.Currency.String(),
+			Balance:          wallet.Balance,
+			AvailableBalance: wallet.Balance,
+			UpdatedAt:        wallet.UpdatedAt,
+			Status:           api_gen.WalletResponseDataStatus(wallet.Status),
+			ClosedAt:         wallet.ClosedAt,
+		},
+	}
+	if sweep != nil {
+		data.SweepTransaction = toTransactionResponseData(sweep)
+	}
+	return data, nil
 }
 
 func (r *walletService) HandleUpdateInfo(userId, walletId string, req api_gen.WalletRequest) error {
@@ -44,3 +71,38 @@ func (r *walletService) HandleUpdateInfo(userId, walletId string, req api_gen.Wa
 
 	return r.walletRepo.UpdateInfo(walletId, req.Name, req.Description)
 }
+
+func (r *walletService) HandleUpdateStatus(walletId string, req api_gen.UpdateWalletStatusRequest) (*api_gen.WalletStatusResponseData, error) {
+	wallet, err := r.walletRepo.UpdateStatus(walletId, string(req.Status), req.Reason, req.Actor)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api_gen.WalletStatusResponseData{
+		WalletId:  wallet.ID,
+		Status:    api_gen.WalletStatusResponseDataStatus(wallet.Status),
+		Reason:    wallet.StatusReason,
+		ChangedBy: wallet.StatusChangedBy,
+		ChangedAt: wallet.StatusChangedAt,
+	}, nil
+}
+
+func (r *walletService) HandleUpdateCreditLine(walletId string, req api_gen.UpdateCreditLineRequest) (*api_gen.WalletCreditLineResponseData, error) {
+	var interestRate money.Rate
+	if req.InterestRate != nil {
+		interestRate = *req.InterestRate
+	}
+
+	wallet, err := r.walletRepo.UpdateCreditLine(walletId, req.CreditLimit, interestRate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api_gen.WalletCreditLineResponseData{
+		WalletId:     wallet.ID,
+		Currency:     wallet.Currency.String(),
+		CreditLimit:  wallet.CreditLimit,
+		CreditUsed:   wallet.CreditUsed(),
+		InterestRate: wallet.InterestRate,
+	}, nil
+}
@@ -2,9 +2,12 @@ package commands_test
 
 import (
 	"errors"
+	"time"
 
 	"github.com/aarondl/null/v9"
 	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/money"
 	"github.com/slilp/go-wallet/internal/repositories/entity"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
@@ -15,7 +18,7 @@ func (suite *CommandsTestSuite) TestWalletService_HandleCreate() {
 	testCases := []struct {
 		name        string
 		userId      string
-		req         api_gen.WalletRequest
+		req         api_gen.CreateWalletRequest
 		mock        func()
 		wantErr     bool
 		expectedErr string
@@ -23,9 +26,10 @@ func (suite *CommandsTestSuite) TestWalletService_HandleCreate() {
 		{
 			name:   "GivenValidRequest_WhenCreateSuccess_ThenSucces",
 			userId: "<UserID>",
-			req: api_gen.WalletRequest{
+			req: api_gen.CreateWalletRequest{
 				Name:        "<WalletName>",
 				Description: nil,
+				Currency:    "usd",
 			},
 			mock: func() {
 				suite.mockWalletRepo.EXPECT().
@@ -33,6 +37,7 @@ func (suite *CommandsTestSuite) TestWalletService_HandleCreate() {
 						UserID:      "<UserID>",
 						Name:        "<WalletName>",
 						Description: nil,
+						Currency:    "USD",
 					}).
 					Return(nil)
 			},
@@ -42,9 +47,10 @@ func (suite *CommandsTestSuite) TestWalletService_HandleCreate() {
 		{
 			name:   "GivenValidRequest_WhenCreateFails_ThenError",
 			userId: "<UserID>",
-			req: api_gen.WalletRequest{
+			req: api_gen.CreateWalletRequest{
 				Name:        "<WalletName>",
 				Description: nil,
+				Currency:    "usd",
 			},
 			mock: func() {
 				suite.mockWalletRepo.EXPECT().
@@ -52,12 +58,24 @@ func (suite *CommandsTestSuite) TestWalletService_HandleCreate() {
 						UserID:      "<UserID>",
 						Name:        "<WalletName>",
 						Description: nil,
+						Currency:    "USD",
 					}).
 					Return(errors.New("create error"))
 			},
 			wantErr:     true,
 			expectedErr: "create error",
 		},
+		{
+			name:   "GivenUnsupportedCurrency_WhenCreate_ThenError",
+			userId: "<UserID>",
+			req: api_gen.CreateWalletRequest{
+				Name:     "<WalletName>",
+				Currency: "XYZ",
+			},
+			mock:        func() {},
+			wantErr:     true,
+			expectedErr: "unsupported currency",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -73,64 +91,87 @@ func (suite *CommandsTestSuite) TestWalletService_HandleCreate() {
 	}
 }
 
-func (suite *CommandsTestSuite) TestWalletService_HandleDelete() {
+func (suite *CommandsTestSuite) TestWalletService_HandleClose() {
+	closedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sweepTo := "<SweepWalletID>"
+	closedWallet := &entity.Wallet{ID: "<WalletID>", UserID: "<UserID>", Name: "Old", Currency: money.Currency("THB"), ClosedAt: &closedAt}
 
 	testCases := []struct {
 		name        string
-		userId      string
-		walletId    string
+		sweepTo     *string
 		mock        func()
+		want        *api_gen.CloseWalletResponseData
+		wantSweep   bool
 		wantErr     bool
 		expectedErr string
 	}{
 		{
-			name:     "GivenValidWalletId_WhenDeleteSuccess_ThenSuccess",
-			userId:   "<UserID>",
-			walletId: "<WalletID>",
+			name: "GivenEmptyWallet_WhenCloseSuccess_ThenClosedWallet",
 			mock: func() {
 				suite.mockWalletRepo.EXPECT().
-					QueryByIdAndUser("<UserID>", "<WalletID>").
-					Return(nil, nil)
-				suite.mockWalletRepo.EXPECT().Delete("<WalletID>").Return(nil)
+					Close("<UserID>", "<WalletID>", nil).
+					Return(closedWallet, nil, nil)
 			},
-			wantErr:     false,
-			expectedErr: "",
+			want: &api_gen.CloseWalletResponseData{
+				Wallet: api_gen.WalletResponseData{Id: "<WalletID>", Name: "Old", Currency: "THB", ClosedAt: &closedAt},
+			},
+			wantErr: false,
 		},
 		{
-			name:     "GivenInvalidWalletId_WhenNotFound_ThenError",
-			userId:   "<UserID>",
-			walletId: "<WalletID>",
+			name:    "GivenSweepTarget_WhenCloseSuccess_ThenSweepTransactionReturned",
+			sweepTo: &sweepTo,
 			mock: func() {
 				suite.mockWalletRepo.EXPECT().
-					QueryByIdAndUser("<UserID>", "<WalletID>").
-					Return(nil, gorm.ErrRecordNotFound)
+					Close("<UserID>", "<WalletID>", &sweepTo).
+					Return(closedWallet, &entity.Transaction{ID: "<TransactionID>", From: null.StringFrom("<WalletID>").Ptr(), To: &sweepTo, Amount: money.MustParse("40"), Type: "transfer"}, nil)
+			},
+			want: &api_gen.CloseWalletResponseData{
+				Wallet: api_gen.WalletResponseData{Id: "<WalletID>", Name: "Old", Currency: "THB", ClosedAt: &closedAt},
+			},
+			wantSweep: true,
+			wantErr:   false,
+		},
+		{
+			name: "GivenFundedWallet_WhenCloseFail_ThenError",
+			mock: func() {
+				suite.mockWalletRepo.EXPECT().
+					Close("<UserID>", "<WalletID>", nil).
+					Return(nil, nil, consts.ErrWalletNotEmpty)
 			},
 			wantErr:     true,
-			expectedErr: "record not found",
+			expectedErr: "wallet balance is not zero",
 		},
 		{
-			name:     "GivenValidWalletId_WhenDeleteFail_ThenError",
-			userId:   "<UserID>",
-			walletId: "<WalletID>",
+			name: "GivenUnknownWallet_WhenClose_ThenNotFound",
 			mock: func() {
 				suite.mockWalletRepo.EXPECT().
-					QueryByIdAndUser("<UserID>", "<WalletID>").
-					Return(nil, nil)
-				suite.mockWalletRepo.EXPECT().Delete("<WalletID>").Return(errors.New("delete error"))
+					Close("<UserID>", "<WalletID>", nil).
+					Return(nil, nil, gorm.ErrRecordNotFound)
 			},
 			wantErr:     true,
-			expectedErr: "delete error",
+			expectedErr: "record not found",
 		},
 	}
 
 	for _, tc := range testCases {
 		suite.Run(tc.name, func() {
 			tc.mock()
-			err := suite.walletService.HandleDelete(tc.userId, tc.walletId)
+			result, err := suite.walletService.HandleClose("<UserID>", "<WalletID>", tc.sweepTo)
 			if tc.wantErr {
+				assert.Nil(suite.T(), result)
 				assert.EqualError(suite.T(), err, tc.expectedErr)
 			} else {
 				assert.NoError(suite.T(), err)
+				assert.Equal(suite.T(), tc.want.Wallet, result.Wallet)
+				if tc.wantSweep {
+					suite.Require().NotNil(result.SweepTransaction)
+					assert.Equal(suite.T(), "<TransactionID>", result.SweepTransaction.Id)
+					assert.Equal(suite.T(), "<WalletID>", result.SweepTransaction.FromWalletId)
+					assert.Equal(suite.T(), sweepTo, result.SweepTransaction.ToWalletId)
+					assert.Equal(suite.T(), money.MustParse("40"), result.SweepTransaction.Amount)
+				} else {
+					assert.Nil(suite.T(), result.SweepTransaction)
+				}
 			}
 		})
 	}
@@ -211,3 +252,129 @@ func (suite *CommandsTestSuite) TestWalletService_HandleUpdateInfo() {
 		})
 	}
 }
+
+func (suite *CommandsTestSuite) TestWalletService_HandleUpdateStatus() {
+	reason := "Chargeback investigation"
+	changedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	req := api_gen.UpdateWalletStatusRequest{Status: api_gen.UpdateWalletStatusRequestStatusDebitBlocked, Reason: &reason, Actor: "ops@example.com"}
+
+	testCases := []struct {
+		name        string
+		mock        func()
+		want        *api_gen.WalletStatusResponseData
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenStatus_WhenUpdateStatusSuccess_ThenStatusReturned",
+			mock: func() {
+				suite.mockWalletRepo.EXPECT().
+					UpdateStatus("<WalletID>", "debit_blocked", &reason, "ops@example.com").
+					Return(&entity.Wallet{ID: "<WalletID>", Status: "debit_blocked", StatusReason: &reason, StatusChangedBy: null.StringFrom("ops@example.com").Ptr(), StatusChangedAt: &changedAt}, nil)
+			},
+			want: &api_gen.WalletStatusResponseData{
+				WalletId:  "<WalletID>",
+				Status:    api_gen.WalletStatusResponseDataStatusDebitBlocked,
+				Reason:    &reason,
+				ChangedBy: null.StringFrom("ops@example.com").Ptr(),
+				ChangedAt: &changedAt,
+			},
+			wantErr: false,
+		},
+		{
+			name: "GivenUnknownWallet_WhenUpdateStatus_ThenNotFound",
+			mock: func() {
+				suite.mockWalletRepo.EXPECT().
+					UpdateStatus("<WalletID>", "debit_blocked", &reason, "ops@example.com").
+					Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr:     true,
+			expectedErr: "record not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			result, err := suite.walletService.HandleUpdateStatus("<WalletID>", req)
+			if tc.wantErr {
+				assert.Nil(suite.T(), result)
+				assert.EqualError(suite.T(), err, tc.expectedErr)
+			} else {
+				assert.NoError(suite.T(), err)
+				assert.Equal(suite.T(), tc.want, result)
+			}
+		})
+	}
+}
+
+func (suite *CommandsTestSuite) TestWalletService_HandleUpdateCreditLine() {
+	interestRate := money.MustParseRate("0.18")
+
+	testCases := []struct {
+		name        string
+		req         api_gen.UpdateCreditLineRequest
+		mock        func()
+		want        *api_gen.WalletCreditLineResponseData
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenLimitAndRate_WhenUpdateCreditLineSuccess_ThenCreditLineReturned",
+			req:  api_gen.UpdateCreditLineRequest{CreditLimit: money.MustParse("500"), InterestRate: &interestRate},
+			mock: func() {
+				suite.mockWalletRepo.EXPECT().
+					UpdateCreditLine("<WalletID>", money.MustParse("500"), money.MustParseRate("0.18")).
+					Return(&entity.Wallet{ID: "<WalletID>", Currency: money.Currency("THB"), Balance: money.MustParse("-120"), CreditLimit: money.MustParse("500"), InterestRate: money.MustParseRate("0.18")}, nil)
+			},
+			want: &api_gen.WalletCreditLineResponseData{
+				WalletId:     "<WalletID>",
+				Currency:     "THB",
+				CreditLimit:  money.MustParse("500"),
+				CreditUsed:   money.MustParse("120"),
+				InterestRate: money.MustParseRate("0.18"),
+			},
+			wantErr: false,
+		},
+		{
+			name: "GivenNoRate_WhenUpdateCreditLine_ThenInterestFree",
+			req:  api_gen.UpdateCreditLineRequest{CreditLimit: money.MustParse("500")},
+			mock: func() {
+				suite.mockWalletRepo.EXPECT().
+					UpdateCreditLine("<WalletID>", money.MustParse("500"), money.Rate(0)).
+					Return(&entity.Wallet{ID: "<WalletID>", Currency: money.Currency("THB"), Balance: money.MustParse("30"), CreditLimit: money.MustParse("500")}, nil)
+			},
+			want: &api_gen.WalletCreditLineResponseData{
+				WalletId:    "<WalletID>",
+				Currency:    "THB",
+				CreditLimit: money.MustParse("500"),
+			},
+			wantErr: false,
+		},
+		{
+			name: "GivenUnknownWallet_WhenUpdateCreditLine_ThenNotFound",
+			req:  api_gen.UpdateCreditLineRequest{CreditLimit: money.MustParse("500")},
+			mock: func() {
+				suite.mockWalletRepo.EXPECT().
+					UpdateCreditLine("<WalletID>", money.MustParse("500"), money.Rate(0)).
+					Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr:     true,
+			expectedErr: "record not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			result, err := suite.walletService.HandleUpdateCreditLine("<WalletID>", tc.req)
+			if tc.wantErr {
+				assert.Nil(suite.T(), result)
+				assert.EqualError(suite.T(), err, tc.expectedErr)
+			} else {
+				assert.NoError(suite.T(), err)
+				assert.Equal(suite.T(), tc.want, result)
+			}
+		})
+	}
+}
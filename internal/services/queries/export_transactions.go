@@ -0,0 +1,85 @@
+package queries
+
+import (
+	"io"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/export"
+	"github.com/slilp/go-wallet/internal/repositories"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+)
+
+//go:generate mockgen -source=./export_transactions.go -destination=./mocks/mock_export_transactions_service.go -package=mock_queries
+type ExportTransactionsService interface {
+	Handle(userId, walletId string, params api_gen.ExportWalletTransactionsParams, w io.Writer) error
+}
+
+type exportTransactionsService struct {
+	walletRepo      repositories.WalletRepository
+	transactionRepo repositories.TransactionRepository
+}
+
+func NewExportTransactionsService(walletRepo repositories.WalletRepository, transactionRepo repositories.TransactionRepository) ExportTransactionsService {
+	return &exportTransactionsService{walletRepo: walletRepo, transactionRepo: transactionRepo}
+}
+
+// Handle streams the matching transactions to w in the requested format,
+// oldest first unless params.Sort says otherwise. Nothing is written to w
+// when the parameters or wallet are rejected.
+func (s *exportTransactionsService) Handle(userId, walletId string, params api_gen.ExportWalletTransactionsParams, w io.Writer) error {
+	if params.CreatedFrom == nil || params.CreatedTo == nil {
+		return consts.ErrInvalidDateRange
+	}
+
+	filter, err := transactionFilterFromParams(api_gen.ListWalletTransactionsParams{
+		Reference:            params.Reference,
+		Type:                 (*api_gen.ListWalletTransactionsParamsType)(params.Type),
+		Direction:            (*api_gen.ListWalletTransactionsParamsDirection)(params.Direction),
+		CreatedFrom:          params.CreatedFrom,
+		CreatedTo:            params.CreatedTo,
+		MinAmount:            params.MinAmount,
+		MaxAmount:            params.MaxAmount,
+		CounterpartyWalletId: params.CounterpartyWalletId,
+		Memo:                 params.Memo,
+		Sort:                 (*api_gen.ListWalletTransactionsParamsSort)(params.Sort),
+	})
+	if err != nil {
+		return err
+	}
+	if params.Sort == nil {
+		filter.Sort = repositories.TransactionSortOldest
+	}
+
+	wallet, err := s.walletRepo.QueryByIdAndUser(userId, walletId)
+	if err != nil {
+		return err
+	}
+
+	var writer export.Writer
+	format := api_gen.ExportWalletTransactionsParamsFormatCsv
+	if params.Format != nil {
+		format = *params.Format
+	}
+	switch format {
+	case api_gen.ExportWalletTransactionsParamsFormatOfx:
+		writer = export.NewOFXWriter(w, export.Account{
+			WalletId: wallet.ID,
+			Currency: wallet.Currency.String(),
+			From:     *params.CreatedFrom,
+			To:       *params.CreatedTo,
+			Balance:  wallet.Balance,
+		})
+	case api_gen.ExportWalletTransactionsParamsFormatJsonl:
+		writer = export.NewJSONLinesWriter(w)
+	default:
+		writer = export.NewCSVWriter(w, wallet.ID)
+	}
+
+	if err := s.transactionRepo.Stream(wallet.ID, filter, func(tx entity.Transaction) error {
+		return writer.Write(transactionResponse(tx))
+	}); err != nil {
+		return err
+	}
+	return writer.Close()
+}
@@ -0,0 +1,141 @@
+package queries_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/aarondl/null/v9"
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func (suite *QueriesTestSuite) TestExportTransactionsService_Handle() {
+	createdFrom := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	createdTo := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	jsonl := api_gen.ExportWalletTransactionsParamsFormatJsonl
+	ofx := api_gen.ExportWalletTransactionsParamsFormatOfx
+	newest := api_gen.ExportWalletTransactionsParamsSortNewest
+	out := api_gen.ExportWalletTransactionsParamsDirectionOut
+	minAmount, maxAmount := "50", "10"
+	wallet := &entity.Wallet{ID: "<WalletID>", UserID: "<UserID>", Currency: "THB", Balance: money.MustParse("60")}
+	transactions := []entity.Transaction{
+		{ID: "TRN1", To: null.StringFrom("<WalletID>").Ptr(), Amount: money.MustParse("100"), Type: "deposit", CreatedAt: createdFrom.Add(time.Hour)},
+		{ID: "TRN2", From: null.StringFrom("<WalletID>").Ptr(), Amount: money.MustParse("-40"), Type: "withdraw", CreatedAt: createdFrom.Add(2 * time.Hour)},
+	}
+	stream := func(walletId string, filter repositories.TransactionFilter, fn func(entity.Transaction) error) error {
+		for _, tx := range transactions {
+			if err := fn(tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	testCases := []struct {
+		name        string
+		params      api_gen.ExportWalletTransactionsParams
+		setupMocks  func()
+		wantLines   int
+		wantContain string
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name:   "GivenDateRange_WhenExportCsv_ThenHeaderAndRowsOldestFirst",
+			params: api_gen.ExportWalletTransactionsParams{CreatedFrom: &createdFrom, CreatedTo: &createdTo},
+			setupMocks: func() {
+				suite.mockWalletRepo.EXPECT().QueryByIdAndUser("<UserID>", "<WalletID>").Return(wallet, nil)
+				suite.mockTransactionRepo.EXPECT().
+					Stream("<WalletID>", repositories.TransactionFilter{CreatedFrom: &createdFrom, CreatedTo: &createdTo, Sort: repositories.TransactionSortOldest}, gomock.Any()).
+					DoAndReturn(stream)
+			},
+			wantLines:   3,
+			wantContain: "TRN2,2024-01-01T02:00:00Z,withdraw,out,<WalletID>,,-40.00,,,-40.00",
+		},
+		{
+			name: "GivenFiltersAndJsonl_WhenExport_ThenFiltersPassedAndOneObjectPerLine",
+			params: api_gen.ExportWalletTransactionsParams{
+				Format: &jsonl, CreatedFrom: &createdFrom, CreatedTo: &createdTo, Direction: &out, Sort: &newest,
+			},
+			setupMocks: func() {
+				suite.mockWalletRepo.EXPECT().QueryByIdAndUser("<UserID>", "<WalletID>").Return(wallet, nil)
+				suite.mockTransactionRepo.EXPECT().
+					Stream("<WalletID>", repositories.TransactionFilter{
+						CreatedFrom: &createdFrom, CreatedTo: &createdTo,
+						Direction: null.StringFrom(repositories.TransactionDirectionOut).Ptr(),
+						Sort:      repositories.TransactionSortNewest,
+					}, gomock.Any()).
+					DoAndReturn(stream)
+			},
+			wantLines:   2,
+			wantContain: `"id":"TRN1"`,
+		},
+		{
+			name:   "GivenOfx_WhenExport_ThenStatementWithLedgerBalance",
+			params: api_gen.ExportWalletTransactionsParams{Format: &ofx, CreatedFrom: &createdFrom, CreatedTo: &createdTo},
+			setupMocks: func() {
+				suite.mockWalletRepo.EXPECT().QueryByIdAndUser("<UserID>", "<WalletID>").Return(wallet, nil)
+				suite.mockTransactionRepo.EXPECT().Stream("<WalletID>", gomock.Any(), gomock.Any()).DoAndReturn(stream)
+			},
+			wantContain: "<BALAMT>60.00</BALAMT>",
+		},
+		{
+			name:        "GivenMissingDateRange_WhenExport_ThenError",
+			params:      api_gen.ExportWalletTransactionsParams{CreatedFrom: &createdFrom},
+			setupMocks:  func() {},
+			wantErr:     true,
+			expectedErr: consts.ErrInvalidDateRange,
+		},
+		{
+			name:        "GivenInvertedAmountRange_WhenExport_ThenError",
+			params:      api_gen.ExportWalletTransactionsParams{CreatedFrom: &createdFrom, CreatedTo: &createdTo, MinAmount: &minAmount, MaxAmount: &maxAmount},
+			setupMocks:  func() {},
+			wantErr:     true,
+			expectedErr: consts.ErrInvalidAmountRange,
+		},
+		{
+			name:   "GivenUnknownWallet_WhenExport_ThenError",
+			params: api_gen.ExportWalletTransactionsParams{CreatedFrom: &createdFrom, CreatedTo: &createdTo},
+			setupMocks: func() {
+				suite.mockWalletRepo.EXPECT().QueryByIdAndUser("<UserID>", "<WalletID>").Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr:     true,
+			expectedErr: gorm.ErrRecordNotFound,
+		},
+		{
+			name:   "GivenStreamFails_WhenExport_ThenError",
+			params: api_gen.ExportWalletTransactionsParams{CreatedFrom: &createdFrom, CreatedTo: &createdTo},
+			setupMocks: func() {
+				suite.mockWalletRepo.EXPECT().QueryByIdAndUser("<UserID>", "<WalletID>").Return(wallet, nil)
+				suite.mockTransactionRepo.EXPECT().Stream("<WalletID>", gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+			},
+			wantErr:     true,
+			expectedErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.setupMocks()
+			var buf bytes.Buffer
+			err := suite.exportTransactionsService.Handle("<UserID>", "<WalletID>", tc.params, &buf)
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr.Error())
+				return
+			}
+
+			suite.NoError(err)
+			suite.Contains(buf.String(), tc.wantContain)
+			if tc.wantLines > 0 {
+				suite.Len(strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n"), tc.wantLines)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package queries
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/repositories"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=./find_recipient.go -destination=./mocks/mock_find_recipient_service.go -package=mock_queries
+type FindRecipientService interface {
+	Handle(email, handle *string) (*api_gen.RecipientResponseData, error)
+	HandleWallet(email, handle *string) (string, error)
+}
+
+type findRecipientService struct {
+	userRepo   repositories.UserRepository
+	walletRepo repositories.WalletRepository
+}
+
+func NewFindRecipientService(userRepo repositories.UserRepository, walletRepo repositories.WalletRepository) FindRecipientService {
+	return &findRecipientService{userRepo: userRepo, walletRepo: walletRepo}
+}
+
+// Handle only reveals a masked display name, enough for the sender to
+// recognise the recipient.
+func (r *findRecipientService) Handle(email, handle *string) (*api_gen.RecipientResponseData, error) {
+	user, _, err := r.find(email, handle)
+	if err != nil {
+		return nil, err
+	}
+	return &api_gen.RecipientResponseData{DisplayName: maskDisplayName(user.DisplayName)}, nil
+}
+
+// HandleWallet returns the wallet that receives transfers addressed to the
+// recipient.
+func (r *findRecipientService) HandleWallet(email, handle *string) (string, error) {
+	_, wallet, err := r.find(email, handle)
+	if err != nil {
+		return "", err
+	}
+	return wallet.ID, nil
+}
+
+// find resolves the recipient and its default wallet, falling back to the
+// recipient's oldest wallet when none is set.
+func (r *findRecipientService) find(email, handle *string) (*entity.User, *entity.Wallet, error) {
+	var user *entity.User
+	var err error
+	switch {
+	case email != nil && handle == nil:
+		user, err = r.userRepo.QueryByEmail(*email)
+	case handle != nil && email == nil:
+		user, err = r.userRepo.QueryByHandle(*handle)
+	default:
+		return nil, nil, consts.ErrInvalidRecipient
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, consts.ErrRecipientNotFound
+		}
+		return nil, nil, err
+	}
+
+	var wallet *entity.Wallet
+	if user.DefaultWalletID != nil {
+		wallet, err = r.walletRepo.QueryByIdAndUser(user.ID, *user.DefaultWalletID)
+	} else {
+		wallet, err = r.walletRepo.QueryOldest(user.ID)
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, consts.ErrRecipientHasNoWallet
+		}
+		return nil, nil, err
+	}
+	return user, wallet, nil
+}
+
+// maskDisplayName keeps the first letter of each word, e.g. "John Doe"
+// becomes "J*** D**".
+func maskDisplayName(name string) string {
+	words := strings.Fields(name)
+	for i, word := range words {
+		runes := []rune(word)
+		words[i] = string(runes[0]) + strings.Repeat("*", len(runes)-1)
+	}
+	return strings.Join(words, " ")
+}
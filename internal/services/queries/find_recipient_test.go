@@ -0,0 +1,150 @@
+package queries_test
+
+import (
+	"errors"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"gorm.io/gorm"
+)
+
+func (suite *QueriesTestSuite) TestFindRecipientService_Handle() {
+	email, handle := "friend@example.com", "Friend"
+	defaultWallet := "<DefaultWalletID>"
+
+	testCases := []struct {
+		name        string
+		email       *string
+		handle      *string
+		mock        func()
+		want        *api_gen.RecipientResponseData
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:  "GivenEmail_WhenRecipientHasWallet_ThenReturnMaskedName",
+			email: &email,
+			mock: func() {
+				suite.mockUserRepo.EXPECT().QueryByEmail(email).Return(&entity.User{ID: "<RecipientID>", DisplayName: "John  Doe"}, nil)
+				suite.mockWalletRepo.EXPECT().QueryOldest("<RecipientID>").Return(&entity.Wallet{ID: "<WalletID>"}, nil)
+			},
+			want:    &api_gen.RecipientResponseData{DisplayName: "J*** D**"},
+			wantErr: false,
+		},
+		{
+			name:   "GivenHandle_WhenRecipientHasDefaultWallet_ThenReturnMaskedName",
+			handle: &handle,
+			mock: func() {
+				suite.mockUserRepo.EXPECT().QueryByHandle(handle).Return(&entity.User{ID: "<RecipientID>", DisplayName: "Ånna", DefaultWalletID: &defaultWallet}, nil)
+				suite.mockWalletRepo.EXPECT().QueryByIdAndUser("<RecipientID>", defaultWallet).Return(&entity.Wallet{ID: defaultWallet}, nil)
+			},
+			want:    &api_gen.RecipientResponseData{DisplayName: "Å***"},
+			wantErr: false,
+		},
+		{
+			name:        "GivenEmailAndHandle_WhenFind_ThenError",
+			email:       &email,
+			handle:      &handle,
+			mock:        func() {},
+			wantErr:     true,
+			expectedErr: consts.ErrInvalidRecipient.Error(),
+		},
+		{
+			name:  "GivenUnknownEmail_WhenFind_ThenError",
+			email: &email,
+			mock: func() {
+				suite.mockUserRepo.EXPECT().QueryByEmail(email).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr:     true,
+			expectedErr: consts.ErrRecipientNotFound.Error(),
+		},
+		{
+			name:  "GivenRecipientWithoutWallets_WhenFind_ThenError",
+			email: &email,
+			mock: func() {
+				suite.mockUserRepo.EXPECT().QueryByEmail(email).Return(&entity.User{ID: "<RecipientID>", DisplayName: "John Doe"}, nil)
+				suite.mockWalletRepo.EXPECT().QueryOldest("<RecipientID>").Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr:     true,
+			expectedErr: consts.ErrRecipientHasNoWallet.Error(),
+		},
+		{
+			name:  "GivenEmail_WhenRepoReturnsError_ThenError",
+			email: &email,
+			mock: func() {
+				suite.mockUserRepo.EXPECT().QueryByEmail(email).Return(nil, errors.New("repo error"))
+			},
+			wantErr:     true,
+			expectedErr: "repo error",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			result, err := suite.findRecipientService.Handle(tc.email, tc.handle)
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(result)
+			} else {
+				suite.NoError(err)
+				suite.Equal(tc.want, result)
+			}
+		})
+	}
+}
+
+func (suite *QueriesTestSuite) TestFindRecipientService_HandleWallet() {
+	handle := "friend"
+	defaultWallet := "<DefaultWalletID>"
+
+	testCases := []struct {
+		name        string
+		mock        func()
+		want        string
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenDefaultWallet_WhenHandleWallet_ThenReturnDefaultWallet",
+			mock: func() {
+				suite.mockUserRepo.EXPECT().QueryByHandle(handle).Return(&entity.User{ID: "<RecipientID>", DefaultWalletID: &defaultWallet}, nil)
+				suite.mockWalletRepo.EXPECT().QueryByIdAndUser("<RecipientID>", defaultWallet).Return(&entity.Wallet{ID: defaultWallet}, nil)
+			},
+			want:    defaultWallet,
+			wantErr: false,
+		},
+		{
+			name: "GivenNoDefaultWallet_WhenHandleWallet_ThenReturnOldestWallet",
+			mock: func() {
+				suite.mockUserRepo.EXPECT().QueryByHandle(handle).Return(&entity.User{ID: "<RecipientID>"}, nil)
+				suite.mockWalletRepo.EXPECT().QueryOldest("<RecipientID>").Return(&entity.Wallet{ID: "<OldestWalletID>"}, nil)
+			},
+			want:    "<OldestWalletID>",
+			wantErr: false,
+		},
+		{
+			name: "GivenUnknownHandle_WhenHandleWallet_ThenError",
+			mock: func() {
+				suite.mockUserRepo.EXPECT().QueryByHandle(handle).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr:     true,
+			expectedErr: consts.ErrRecipientNotFound.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			result, err := suite.findRecipientService.HandleWallet(nil, &handle)
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Empty(result)
+			} else {
+				suite.NoError(err)
+				suite.Equal(tc.want, result)
+			}
+		})
+	}
+}
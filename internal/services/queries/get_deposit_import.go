@@ -0,0 +1,67 @@
+package queries
+
+import (
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/repositories"
+)
+
+//go:generate mockgen -source=./get_deposit_import.go -destination=./mocks/mock_get_deposit_import_service.go -package=mock_queries
+type GetDepositImportService interface {
+	Handle(importId string) (*api_gen.DepositImportResponseData, error)
+	HandleRows(importId string, status *string) ([]api_gen.DepositImportRowData, error)
+}
+
+type getDepositImportService struct {
+	depositImportRepo repositories.DepositImportRepository
+}
+
+func NewGetDepositImportService(depositImportRepo repositories.DepositImportRepository) GetDepositImportService {
+	return &getDepositImportService{depositImportRepo: depositImportRepo}
+}
+
+func (s *getDepositImportService) Handle(importId string) (*api_gen.DepositImportResponseData, error) {
+	depositImport, err := s.depositImportRepo.FindByID(importId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api_gen.DepositImportResponseData{
+		Id:            depositImport.ID,
+		Filename:      depositImport.Filename,
+		Status:        api_gen.DepositImportResponseDataStatus(depositImport.Status),
+		TotalRows:     depositImport.TotalRows,
+		InvalidRows:   depositImport.InvalidRows,
+		CompletedRows: depositImport.CompletedRows,
+		FailedRows:    depositImport.FailedRows,
+		CreatedAt:     depositImport.CreatedAt,
+		UpdatedAt:     depositImport.UpdatedAt,
+		CompletedAt:   depositImport.CompletedAt,
+	}, nil
+}
+
+// HandleRows returns the per-row report of an import, optionally limited to
+// one row status.
+func (s *getDepositImportService) HandleRows(importId string, status *string) ([]api_gen.DepositImportRowData, error) {
+	if _, err := s.depositImportRepo.FindByID(importId); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.depositImportRepo.ListRows(importId, status)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []api_gen.DepositImportRowData{}
+	for _, row := range rows {
+		result = append(result, api_gen.DepositImportRowData{
+			RowNumber:     row.RowNumber,
+			WalletId:      row.WalletID,
+			Amount:        row.Amount,
+			Reference:     row.Reference,
+			Status:        api_gen.DepositImportRowDataStatus(row.Status),
+			TransactionId: row.TransactionID,
+			ErrorMessage:  row.ErrorMessage,
+		})
+	}
+	return result, nil
+}
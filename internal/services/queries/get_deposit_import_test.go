@@ -0,0 +1,130 @@
+package queries_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+	"gorm.io/gorm"
+)
+
+func (suite *QueriesTestSuite) TestGetDepositImportService_Handle() {
+	createdAt := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		mock        func()
+		want        *api_gen.DepositImportResponseData
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "GivenImport_WhenGet_ThenReturnProgress",
+			mock: func() {
+				suite.mockDepositImportRepo.EXPECT().FindByID("<ImportID>").Return(&entity.DepositImport{
+					ID: "<ImportID>", Filename: "deposits.csv", Status: "running",
+					TotalRows: 10, CompletedRows: 4, FailedRows: 1, CreatedAt: createdAt, UpdatedAt: createdAt,
+				}, nil)
+			},
+			want: &api_gen.DepositImportResponseData{
+				Id: "<ImportID>", Filename: "deposits.csv", Status: api_gen.DepositImportResponseDataStatusRunning,
+				TotalRows: 10, CompletedRows: 4, FailedRows: 1, CreatedAt: createdAt, UpdatedAt: createdAt,
+			},
+		},
+		{
+			name: "GivenUnknownImport_WhenGet_ThenError",
+			mock: func() {
+				suite.mockDepositImportRepo.EXPECT().FindByID("<ImportID>").Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr:     true,
+			expectedErr: gorm.ErrRecordNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			result, err := suite.getDepositImportService.Handle("<ImportID>")
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr.Error())
+				suite.Nil(result)
+			} else {
+				suite.NoError(err)
+				suite.Equal(tc.want, result)
+			}
+		})
+	}
+}
+
+func (suite *QueriesTestSuite) TestGetDepositImportService_HandleRows() {
+	failed := "failed"
+	amount := money.MustParse("10")
+	transactionId := "<TransactionID>"
+	message := "wallet is frozen"
+
+	testCases := []struct {
+		name        string
+		status      *string
+		mock        func()
+		want        []api_gen.DepositImportRowData
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "GivenRows_WhenList_ThenReturnReport",
+			mock: func() {
+				suite.mockDepositImportRepo.EXPECT().FindByID("<ImportID>").Return(&entity.DepositImport{ID: "<ImportID>"}, nil)
+				suite.mockDepositImportRepo.EXPECT().ListRows("<ImportID>", nil).Return([]entity.DepositImportRow{
+					{RowNumber: 2, WalletID: "<WalletID>", Amount: &amount, Reference: "INV-1", Status: "completed", TransactionID: &transactionId},
+					{RowNumber: 3, WalletID: "<WalletID>", Amount: &amount, Reference: "INV-2", Status: "failed", ErrorMessage: &message},
+				}, nil)
+			},
+			want: []api_gen.DepositImportRowData{
+				{RowNumber: 2, WalletId: "<WalletID>", Amount: &amount, Reference: "INV-1", Status: api_gen.DepositImportRowDataStatusCompleted, TransactionId: &transactionId},
+				{RowNumber: 3, WalletId: "<WalletID>", Amount: &amount, Reference: "INV-2", Status: api_gen.DepositImportRowDataStatusFailed, ErrorMessage: &message},
+			},
+		},
+		{
+			name:   "GivenStatusFilterWithoutMatches_WhenList_ThenReturnEmpty",
+			status: &failed,
+			mock: func() {
+				suite.mockDepositImportRepo.EXPECT().FindByID("<ImportID>").Return(&entity.DepositImport{ID: "<ImportID>"}, nil)
+				suite.mockDepositImportRepo.EXPECT().ListRows("<ImportID>", &failed).Return(nil, nil)
+			},
+			want: []api_gen.DepositImportRowData{},
+		},
+		{
+			name: "GivenUnknownImport_WhenList_ThenError",
+			mock: func() {
+				suite.mockDepositImportRepo.EXPECT().FindByID("<ImportID>").Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr:     true,
+			expectedErr: gorm.ErrRecordNotFound,
+		},
+		{
+			name: "GivenListFails_WhenList_ThenError",
+			mock: func() {
+				suite.mockDepositImportRepo.EXPECT().FindByID("<ImportID>").Return(&entity.DepositImport{ID: "<ImportID>"}, nil)
+				suite.mockDepositImportRepo.EXPECT().ListRows("<ImportID>", nil).Return(nil, errors.New("db error"))
+			},
+			wantErr:     true,
+			expectedErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			result, err := suite.getDepositImportService.HandleRows("<ImportID>", tc.status)
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr.Error())
+				suite.Nil(result)
+			} else {
+				suite.NoError(err)
+				suite.Equal(tc.want, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package queries
+
+import (
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/repositories"
+)
+
+//go:generate mockgen -source=./get_user_settings.go -destination=./mocks/mock_get_user_settings_service.go -package=mock_queries
+type GetUserSettingsService interface {
+	Handle(userId string) (*api_gen.UserSettingsResponseData, error)
+}
+
+type getUserSettingsService struct {
+	userRepo repositories.UserRepository
+}
+
+func NewGetUserSettingsService(userRepo repositories.UserRepository) GetUserSettingsService {
+	return &getUserSettingsService{userRepo: userRepo}
+}
+
+func (r *getUserSettingsService) Handle(userId string) (*api_gen.UserSettingsResponseData, error) {
+	user, err := r.userRepo.QueryById(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api_gen.UserSettingsResponseData{
+		Email:           user.Email,
+		DisplayName:     user.DisplayName,
+		Handle:          user.Handle,
+		DefaultWalletId: user.DefaultWalletID,
+	}, nil
+}
@@ -0,0 +1,52 @@
+package queries_test
+
+import (
+	"errors"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+)
+
+func (suite *QueriesTestSuite) TestGetUserSettingsService_Handle() {
+	handle, walletId := "friend", "<WalletID>"
+
+	testCases := []struct {
+		name        string
+		mock        func()
+		want        *api_gen.UserSettingsResponseData
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenUser_WhenGetSettings_ThenReturnSettings",
+			mock: func() {
+				suite.mockUserRepo.EXPECT().QueryById("<UserID>").
+					Return(&entity.User{ID: "<UserID>", Email: "<Email>", DisplayName: "<DisplayName>", Handle: &handle, DefaultWalletID: &walletId}, nil)
+			},
+			want:    &api_gen.UserSettingsResponseData{Email: "<Email>", DisplayName: "<DisplayName>", Handle: &handle, DefaultWalletId: &walletId},
+			wantErr: false,
+		},
+		{
+			name: "GivenUser_WhenRepoReturnsError_ThenReturnError",
+			mock: func() {
+				suite.mockUserRepo.EXPECT().QueryById("<UserID>").Return(nil, errors.New("repo error"))
+			},
+			wantErr:     true,
+			expectedErr: "repo error",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			result, err := suite.getUserSettingsService.Handle("<UserID>")
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(result)
+			} else {
+				suite.NoError(err)
+				suite.Equal(tc.want, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package queries
+
+import (
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/repositories"
+)
+
+//go:generate mockgen -source=./list_exchange_rates.go -destination=./mocks/mock_list_exchange_rates_service.go -package=mock_queries
+type ListExchangeRatesService interface {
+	Handle() ([]api_gen.ExchangeRateResponseData, error)
+}
+
+type listExchangeRatesService struct {
+	exchangeRateRepo repositories.ExchangeRateRepository
+}
+
+func NewListExchangeRatesService(exchangeRateRepo repositories.ExchangeRateRepository) ListExchangeRatesService {
+	return &listExchangeRatesService{exchangeRateRepo: exchangeRateRepo}
+}
+
+func (s *listExchangeRatesService) Handle() ([]api_gen.ExchangeRateResponseData, error) {
+	rates, err := s.exchangeRateRepo.ListAll()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []api_gen.ExchangeRateResponseData{}
+	for _, rate := range rates {
+		result = append(result, api_gen.ExchangeRateResponseData{
+			BaseCurrency:  rate.BaseCurrency.String(),
+			QuoteCurrency: rate.QuoteCurrency.String(),
+			Rate:          rate.Rate,
+			Spread:        rate.Spread,
+			EffectiveRate: rate.EffectiveRate(),
+			UpdatedAt:     rate.UpdatedAt,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,67 @@
+package queries_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+)
+
+func (suite *QueriesTestSuite) TestListExchangeRatesService_Handle() {
+	updatedAt := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		mock        func()
+		want        []api_gen.ExchangeRateResponseData
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenRates_WhenListSuccess_ThenReturnRatesWithEffectiveRate",
+			mock: func() {
+				suite.mockExchangeRateRepo.EXPECT().ListAll().Return([]entity.ExchangeRate{
+					{BaseCurrency: "USD", QuoteCurrency: "THB", Rate: money.MustParseRate("35"), Spread: money.MustParseRate("0.01"), UpdatedAt: updatedAt},
+				}, nil)
+			},
+			want: []api_gen.ExchangeRateResponseData{
+				{
+					BaseCurrency:  "USD",
+					QuoteCurrency: "THB",
+					Rate:          money.MustParseRate("35"),
+					Spread:        money.MustParseRate("0.01"),
+					EffectiveRate: money.MustParseRate("34.65"),
+					UpdatedAt:     updatedAt,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GivenRepoError_WhenList_ThenReturnError",
+			mock: func() {
+				suite.mockExchangeRateRepo.EXPECT().ListAll().Return(nil, errors.New("repo error"))
+			},
+			want:        nil,
+			wantErr:     true,
+			expectedErr: "repo error",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+
+			result, err := suite.listExchangeRatesService.Handle()
+
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(result)
+			} else {
+				suite.NoError(err)
+				suite.Equal(tc.want, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package queries
+
+import (
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/repositories"
+)
+
+//go:generate mockgen -source=./list_payment_requests.go -destination=./mocks/mock_list_payment_requests_service.go -package=mock_queries
+type ListPaymentRequestsService interface {
+	Handle(userId string, incoming bool) ([]api_gen.PaymentRequestResponseData, error)
+}
+
+type listPaymentRequestsService struct {
+	paymentRequestRepo repositories.PaymentRequestRepository
+}
+
+func NewListPaymentRequestsService(paymentRequestRepo repositories.PaymentRequestRepository) ListPaymentRequestsService {
+	return &listPaymentRequestsService{paymentRequestRepo: paymentRequestRepo}
+}
+
+func (s *listPaymentRequestsService) Handle(userId string, incoming bool) ([]api_gen.PaymentRequestResponseData, error) {
+	requests, err := s.paymentRequestRepo.List(userId, incoming)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []api_gen.PaymentRequestResponseData{}
+	for _, request := range requests {
+		result = append(result, api_gen.PaymentRequestResponseData{
+			Id:             request.ID,
+			RequesterEmail: request.Requester.Email,
+			PayerEmail:     request.Payer.Email,
+			ToWalletId:     request.ToWalletID,
+			Amount:         request.Amount,
+			Note:           request.Note,
+			Status:         api_gen.PaymentRequestResponseDataStatus(request.Status),
+			TransactionId:  request.TransactionID,
+			ExpiresAt:      request.ExpiresAt,
+			CreatedAt:      request.CreatedAt,
+		})
+	}
+	return result, nil
+}
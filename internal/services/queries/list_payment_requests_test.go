@@ -0,0 +1,78 @@
+package queries_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+)
+
+func (suite *QueriesTestSuite) TestListPaymentRequestsService_Handle() {
+	expiresAt := time.Date(2030, 1, 8, 9, 0, 0, 0, time.UTC)
+	note := "Dinner"
+
+	testCases := []struct {
+		name        string
+		incoming    bool
+		mock        func()
+		want        []api_gen.PaymentRequestResponseData
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:     "GivenIncomingRequests_WhenListSuccess_ThenReturnRequests",
+			incoming: true,
+			mock: func() {
+				suite.mockPaymentRequestRepo.EXPECT().List("<UserID>", true).Return([]entity.PaymentRequest{
+					{
+						ID: "<RequestID>", RequesterID: "<RequesterID>", PayerID: "<UserID>", ToWalletID: "<ToWalletID>", Amount: money.MustParse("25"),
+						Note: &note, Status: "pending", ExpiresAt: expiresAt,
+						Requester: entity.User{Email: "requester@example.com"}, Payer: entity.User{Email: "payer@example.com"},
+					},
+				}, nil)
+			},
+			want: []api_gen.PaymentRequestResponseData{
+				{
+					Id: "<RequestID>", RequesterEmail: "requester@example.com", PayerEmail: "payer@example.com", ToWalletId: "<ToWalletID>",
+					Amount: money.MustParse("25"), Note: &note, Status: "pending", ExpiresAt: expiresAt,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:     "GivenNoOutgoingRequests_WhenListSuccess_ThenReturnEmpty",
+			incoming: false,
+			mock: func() {
+				suite.mockPaymentRequestRepo.EXPECT().List("<UserID>", false).Return(nil, nil)
+			},
+			want:    []api_gen.PaymentRequestResponseData{},
+			wantErr: false,
+		},
+		{
+			name:     "GivenUser_WhenRepoReturnsError_ThenReturnError",
+			incoming: true,
+			mock: func() {
+				suite.mockPaymentRequestRepo.EXPECT().List("<UserID>", true).Return(nil, errors.New("repo error"))
+			},
+			want:        nil,
+			wantErr:     true,
+			expectedErr: "repo error",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			result, err := suite.listPaymentRequestsService.Handle("<UserID>", tc.incoming)
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(result)
+			} else {
+				suite.NoError(err)
+				suite.Equal(tc.want, result)
+			}
+		})
+	}
+}
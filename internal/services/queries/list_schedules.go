@@ -0,0 +1,66 @@
+package queries
+
+import (
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/repositories"
+)
+
+//go:generate mockgen -source=./list_schedules.go -destination=./mocks/mock_list_schedules_service.go -package=mock_queries
+type ListSchedulesService interface {
+	Handle(userId string) ([]api_gen.ScheduleResponseData, error)
+	HandleRuns(userId, scheduleId string) ([]api_gen.ScheduleRunResponseData, error)
+}
+
+type listSchedulesService struct {
+	scheduleRepo repositories.ScheduleRepository
+}
+
+func NewListSchedulesService(scheduleRepo repositories.ScheduleRepository) ListSchedulesService {
+	return &listSchedulesService{scheduleRepo: scheduleRepo}
+}
+
+func (s *listSchedulesService) Handle(userId string) ([]api_gen.ScheduleResponseData, error) {
+	schedules, err := s.scheduleRepo.ListAll(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []api_gen.ScheduleResponseData{}
+	for _, schedule := range schedules {
+		result = append(result, api_gen.ScheduleResponseData{
+			Id:           schedule.ID,
+			FromWalletId: schedule.FromWalletID,
+			ToWalletId:   schedule.ToWalletID,
+			Amount:       schedule.Amount,
+			Frequency:    api_gen.ScheduleResponseDataFrequency(schedule.Frequency),
+			StartAt:      schedule.StartAt,
+			EndAt:        schedule.EndAt,
+			MaxRuns:      schedule.MaxRuns,
+			RunCount:     schedule.RunCount,
+			NextRunAt:    schedule.NextRunAt,
+			Status:       api_gen.ScheduleResponseDataStatus(schedule.Status),
+			CreatedAt:    schedule.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+func (s *listSchedulesService) HandleRuns(userId, scheduleId string) ([]api_gen.ScheduleRunResponseData, error) {
+	runs, err := s.scheduleRepo.ListRuns(userId, scheduleId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []api_gen.ScheduleRunResponseData{}
+	for _, run := range runs {
+		result = append(result, api_gen.ScheduleRunResponseData{
+			Id:            run.ID,
+			RunAt:         run.RunAt,
+			Status:        api_gen.ScheduleRunResponseDataStatus(run.Status),
+			TransactionId: run.TransactionID,
+			ErrorMessage:  run.ErrorMessage,
+			CreatedAt:     run.CreatedAt,
+		})
+	}
+	return result, nil
+}
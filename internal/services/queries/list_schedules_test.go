@@ -0,0 +1,81 @@
+package queries_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
+)
+
+func (suite *QueriesTestSuite) TestListSchedulesService_Handle() {
+	startAt := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	nextRunAt := time.Date(2030, 1, 8, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		mock        func()
+		want        []api_gen.ScheduleResponseData
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name: "GivenSchedules_WhenListSuccess_ThenReturnSchedules",
+			mock: func() {
+				suite.mockScheduleRepo.EXPECT().ListAll("<UserID>").Return([]entity.Schedule{
+					{
+						ID: "<ScheduleID>", FromWalletID: "<FromWalletID>", ToWalletID: "<ToWalletID>", Amount: money.MustParse("25"),
+						Frequency: "weekly", StartAt: startAt, RunCount: 1, NextRunAt: &nextRunAt, Status: "active",
+					},
+				}, nil)
+			},
+			want: []api_gen.ScheduleResponseData{
+				{
+					Id: "<ScheduleID>", FromWalletId: "<FromWalletID>", ToWalletId: "<ToWalletID>", Amount: money.MustParse("25"),
+					Frequency: "weekly", StartAt: startAt, RunCount: 1, NextRunAt: &nextRunAt, Status: "active",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GivenUser_WhenRepoReturnsError_ThenReturnError",
+			mock: func() {
+				suite.mockScheduleRepo.EXPECT().ListAll("<UserID>").Return(nil, errors.New("repo error"))
+			},
+			want:        nil,
+			wantErr:     true,
+			expectedErr: "repo error",
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			tc.mock()
+			result, err := suite.listSchedulesService.Handle("<UserID>")
+			if tc.wantErr {
+				suite.EqualError(err, tc.expectedErr)
+				suite.Nil(result)
+			} else {
+				suite.NoError(err)
+				suite.Equal(tc.want, result)
+			}
+		})
+	}
+}
+
+func (suite *QueriesTestSuite) TestListSchedulesService_HandleRuns() {
+	runAt := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	message := "insufficient balance"
+
+	suite.mockScheduleRepo.EXPECT().ListRuns("<UserID>", "<ScheduleID>").Return([]entity.ScheduleRun{
+		{ID: "<RunID>", ScheduleID: "<ScheduleID>", RunAt: runAt, Status: "failed", ErrorMessage: &message},
+	}, nil)
+
+	result, err := suite.listSchedulesService.HandleRuns("<UserID>", "<ScheduleID>")
+
+	suite.NoError(err)
+	suite.Equal([]api_gen.ScheduleRunResponseData{
+		{Id: "<RunID>", RunAt: runAt, Status: "failed", ErrorMessage: &message},
+	}, result)
+}
@@ -1,14 +1,21 @@
 package queries
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
 	"github.com/aarondl/null/v9"
 	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/consts"
+	"github.com/slilp/go-wallet/internal/money"
 	"github.com/slilp/go-wallet/internal/repositories"
+	"github.com/slilp/go-wallet/internal/repositories/entity"
 )
 
 //go:generate mockgen -source=./list_transactions.go -destination=./mocks/mock_list_transactions_service.go -package=mock_queries
 type ListTransactionsService interface {
-	Handle(userId, walletId string, page, limit int) (int64, []api_gen.TransactionResponseData, error)
+	Handle(userId, walletId string, params api_gen.ListWalletTransactionsParams, limit int) ([]api_gen.TransactionResponseData, *api_gen.CursorPageResponseData, error)
 }
 
 type listTransactionsService struct {
@@ -20,39 +27,161 @@ func NewListTransactionsService(walletRepo repositories.WalletRepository, transa
 	return &listTransactionsService{walletRepo: walletRepo, transactionRepo: transactionRepo}
 }
 
-func (s *listTransactionsService) Handle(userId, walletId string, page, limit int) (int64, []api_gen.TransactionResponseData, error) {
+func (s *listTransactionsService) Handle(userId, walletId string, params api_gen.ListWalletTransactionsParams, limit int) ([]api_gen.TransactionResponseData, *api_gen.CursorPageResponseData, error) {
 
-	_, err := s.walletRepo.QueryByIdAndUser(userId, walletId)
+	filter, err := transactionFilterFromParams(params)
 	if err != nil {
-		return 0, nil, err
+		return nil, nil, err
+	}
+
+	var cursor *repositories.TransactionCursor
+	if params.Cursor != nil {
+		cursor, err = decodeTransactionCursor(*params.Cursor, filter.Sort)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
-	totalCount, err := s.transactionRepo.CountByWalletId(walletId)
+	_, err = s.walletRepo.QueryByIdAndUser(userId, walletId)
 	if err != nil {
-		return 0, nil, err
+		return nil, nil, err
 	}
 
-	if totalCount == 0 {
-		return totalCount, []api_gen.TransactionResponseData{}, nil
+	pagination := &api_gen.CursorPageResponseData{Limit: limit}
+	if params.IncludeTotal != nil && *params.IncludeTotal {
+		totalCount, err := s.transactionRepo.CountByWalletId(walletId, filter)
+		if err != nil {
+			return nil, nil, err
+		}
+		total := int(totalCount)
+		pagination.TotalRecords = &total
 	}
 
-	transactions, err := s.transactionRepo.List(walletId, page, limit)
+	transactions, hasMore, err := s.transactionRepo.List(walletId, filter, cursor, limit)
 	if err != nil {
+		return nil, nil, err
+	}
 
-		return 0, nil, err
+	if len(transactions) > 0 {
+		first, last := transactions[0], transactions[len(transactions)-1]
+		backwards := cursor != nil && cursor.Before
+		if cursor != nil && (!backwards || hasMore) {
+			pagination.PrevCursor = encodeTransactionCursor(first, filter.Sort, true)
+		}
+		if backwards || hasMore {
+			pagination.NextCursor = encodeTransactionCursor(last, filter.Sort, false)
+		}
 	}
 
 	result := []api_gen.TransactionResponseData{}
 	for _, tx := range transactions {
-		result = append(result, api_gen.TransactionResponseData{
-			Id:           tx.ID,
-			FromWalletId: null.StringFromPtr(tx.From).String,
-			ToWalletId:   null.StringFromPtr(tx.To).String,
-			Amount:       tx.Amount,
-			Type:         api_gen.TransactionResponseDataType(tx.Type),
-			CreatedAt:    tx.CreatedAt,
-		})
+		result = append(result, transactionResponse(tx))
+	}
+
+	return result, pagination, nil
+}
+
+func transactionResponse(tx entity.Transaction) api_gen.TransactionResponseData {
+	var metadata *map[string]interface{}
+	if tx.Details.Metadata != nil {
+		m := map[string]interface{}(tx.Details.Metadata)
+		metadata = &m
+	}
+	return api_gen.TransactionResponseData{
+		Id:                    tx.ID,
+		FromWalletId:          null.StringFromPtr(tx.From).String,
+		ToWalletId:            null.StringFromPtr(tx.To).String,
+		FromBalanceAfter:      tx.FromBalanceAfter,
+		ToBalanceAfter:        tx.ToBalanceAfter,
+		Amount:                tx.Amount,
+		CreditedAmount:        tx.CreditedAmount,
+		ExchangeRate:          tx.ExchangeRate,
+		OriginalTransactionId: tx.OriginalTransactionID,
+		ParentTransactionId:   tx.ParentTransactionID,
+		RefundedAmount:        &tx.RefundedAmount,
+		Description:           tx.Details.Description,
+		Reference:             tx.Details.Reference,
+		Metadata:              metadata,
+		Type:                  api_gen.TransactionResponseDataType(tx.Type),
+		CreatedAt:             tx.CreatedAt,
+	}
+}
+
+func transactionFilterFromParams(params api_gen.ListWalletTransactionsParams) (repositories.TransactionFilter, error) {
+	filter := repositories.TransactionFilter{
+		Reference:            params.Reference,
+		CreatedFrom:          params.CreatedFrom,
+		CreatedTo:            params.CreatedTo,
+		CounterpartyWalletID: params.CounterpartyWalletId,
+		Memo:                 params.Memo,
+	}
+	if params.Type != nil {
+		filter.Type = null.StringFrom(string(*params.Type)).Ptr()
+	}
+	if params.Direction != nil {
+		filter.Direction = null.StringFrom(string(*params.Direction)).Ptr()
+	}
+	filter.Sort = repositories.TransactionSortNewest
+	if params.Sort != nil {
+		filter.Sort = string(*params.Sort)
+	}
+
+	if params.MinAmount != nil {
+		minAmount, err := money.Parse(*params.MinAmount)
+		if err != nil {
+			return filter, err
+		}
+		filter.MinAmount = &minAmount
+	}
+	if params.MaxAmount != nil {
+		maxAmount, err := money.Parse(*params.MaxAmount)
+		if err != nil {
+			return filter, err
+		}
+		filter.MaxAmount = &maxAmount
+	}
+
+	if filter.MinAmount != nil && filter.MaxAmount != nil && *filter.MinAmount > *filter.MaxAmount {
+		return filter, consts.ErrInvalidAmountRange
+	}
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && !filter.CreatedFrom.Before(*filter.CreatedTo) {
+		return filter, consts.ErrInvalidDateRange
+	}
+	return filter, nil
+}
+
+// transactionCursor is the payload behind the opaque cursors handed to
+// clients. It records the sort it was issued for so a cursor cannot be
+// replayed against a listing in a different order.
+type transactionCursor struct {
+	Sort      string       `json:"s"`
+	CreatedAt time.Time    `json:"t"`
+	ID        string       `json:"i"`
+	Amount    money.Amount `json:"a,omitempty"`
+	Before    bool         `json:"b,omitempty"`
+}
+
+func encodeTransactionCursor(tx entity.Transaction, sort string, before bool) *string {
+	payload, _ := json.Marshal(transactionCursor{Sort: sort, CreatedAt: tx.CreatedAt, ID: tx.ID, Amount: tx.Amount.Abs(), Before: before})
+	cursor := base64.RawURLEncoding.EncodeToString(payload)
+	return &cursor
+}
+
+func decodeTransactionCursor(cursor, sort string) (*repositories.TransactionCursor, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, consts.ErrInvalidCursor
+	}
+
+	var decoded transactionCursor
+	if err := json.Unmarshal(payload, &decoded); err != nil || decoded.ID == "" || decoded.Sort != sort {
+		return nil, consts.ErrInvalidCursor
 	}
 
-	return totalCount, result, nil
+	return &repositories.TransactionCursor{
+		CreatedAt: decoded.CreatedAt,
+		ID:        decoded.ID,
+		Amount:    decoded.Amount,
+		Before:    decoded.Before,
+	}, nil
 }
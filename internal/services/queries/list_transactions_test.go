@@ -6,27 +6,43 @@ import (
 
 	"github.com/aarondl/null/v9"
 	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/repositories"
 	"github.com/slilp/go-wallet/internal/repositories/entity"
 	"gorm.io/gorm"
 )
 
 func (suite *QueriesTestSuite) TestListTransactionsService_Handle() {
+	description := "Top up"
+	reference := "INV-1001"
+	counterparty := "<CounterpartyWalletID>"
+	memo := "rent"
+	minAmount, maxAmount := "10", "500.50"
+	createdFrom := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	createdTo := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	transferType := api_gen.ListWalletTransactionsParamsTypeTransfer
+	out := api_gen.ListWalletTransactionsParamsDirectionOut
+	largest := api_gen.ListWalletTransactionsParamsSortLargest
+	includeTotal := true
+	newest := repositories.TransactionFilter{Sort: repositories.TransactionSortNewest}
+	fromBalanceAfter, toBalanceAfter := money.MustParse("400"), money.MustParse("100")
 	testCases := []struct {
-		name        string
-		userId      string
-		walletId    string
-		page        int
-		limit       int
-		setupMocks  func()
-		want        []api_gen.TransactionResponseData
-		wantErr     bool
-		expectedErr string
+		name           string
+		userId         string
+		walletId       string
+		params         api_gen.ListWalletTransactionsParams
+		limit          int
+		setupMocks     func()
+		want           []api_gen.TransactionResponseData
+		wantTotal      *int
+		wantNextCursor bool
+		wantErr        bool
+		expectedErr    string
 	}{
 		{
 			name:     "GivenValidRequest_WhenSuccess_ThenReturnTransactions",
 			userId:   "<UserID>",
 			walletId: "<WalletID>",
-			page:     1,
 			limit:    10,
 			setupMocks: func() {
 				wallet := &entity.Wallet{
@@ -38,40 +54,106 @@ func (suite *QueriesTestSuite) TestListTransactionsService_Handle() {
 					QueryByIdAndUser("<UserID>", "<WalletID>").
 					Return(wallet, nil)
 
-				suite.mockTransactionRepo.EXPECT().
-					CountByWalletId("<WalletID>").
-					Return(int64(1), nil)
-
 				transactions := []entity.Transaction{
 					{
 						ID:        "<TransactionID>",
 						From:      null.StringFrom("<FromWalletID>").Ptr(),
 						To:        null.StringFrom("<ToWalletID>").Ptr(),
-						Amount:    100,
+						Amount:    money.MustParse("100"),
 						Type:      "transfer",
 						CreatedAt: time.Now(),
+
+						FromBalanceAfter: &fromBalanceAfter,
+						ToBalanceAfter:   &toBalanceAfter,
 					},
 				}
 				suite.mockTransactionRepo.EXPECT().
-					List("<WalletID>", 1, 10).
-					Return(transactions, nil)
+					List("<WalletID>", newest, nil, 10).
+					Return(transactions, true, nil)
 			},
 			want: []api_gen.TransactionResponseData{
 				{
 					Id:           "<TransactionID>",
 					FromWalletId: "<FromWalletID>",
 					ToWalletId:   "<ToWalletID>",
-					Amount:       100,
+					Amount:       money.MustParse("100"),
 					Type:         "transfer",
+
+					FromBalanceAfter: &fromBalanceAfter,
+					ToBalanceAfter:   &toBalanceAfter,
+				},
+			},
+			wantNextCursor: true,
+			wantErr:        false,
+		},
+		{
+			name:     "GivenReference_WhenSuccess_ThenReturnFilteredTransactionsWithDetails",
+			userId:   "<UserID>",
+			walletId: "<WalletID>",
+			params:   api_gen.ListWalletTransactionsParams{Reference: &reference},
+			limit:    10,
+			setupMocks: func() {
+				suite.mockWalletRepo.EXPECT().
+					QueryByIdAndUser("<UserID>", "<WalletID>").
+					Return(&entity.Wallet{ID: "<WalletID>", UserID: "<UserID>"}, nil)
+
+				filter := repositories.TransactionFilter{Reference: &reference, Sort: repositories.TransactionSortNewest}
+				suite.mockTransactionRepo.EXPECT().
+					List("<WalletID>", filter, nil, 10).
+					Return([]entity.Transaction{
+						{
+							ID:     "<TransactionID>",
+							To:     null.StringFrom("<WalletID>").Ptr(),
+							Amount: money.MustParse("100"),
+							Type:   "deposit",
+							Details: entity.TransactionDetails{
+								Description: &description,
+								Reference:   &reference,
+								Metadata:    entity.Metadata{"orderId": "A-1"},
+							},
+						},
+					}, false, nil)
+			},
+			want: []api_gen.TransactionResponseData{
+				{
+					Id:          "<TransactionID>",
+					ToWalletId:  "<WalletID>",
+					Amount:      money.MustParse("100"),
+					Type:        "deposit",
+					Description: &description,
+					Reference:   &reference,
+					Metadata:    &map[string]interface{}{"orderId": "A-1"},
 				},
 			},
 			wantErr: false,
 		},
+		{
+			name:     "GivenIncludeTotal_WhenSuccess_ThenReturnTotalRecords",
+			userId:   "<UserID>",
+			walletId: "<WalletID>",
+			params:   api_gen.ListWalletTransactionsParams{IncludeTotal: &includeTotal},
+			limit:    10,
+			setupMocks: func() {
+				suite.mockWalletRepo.EXPECT().
+					QueryByIdAndUser("<UserID>", "<WalletID>").
+					Return(&entity.Wallet{ID: "<WalletID>", UserID: "<UserID>"}, nil)
+
+				suite.mockTransactionRepo.EXPECT().
+					CountByWalletId("<WalletID>", newest).
+					Return(int64(0), nil)
+
+				suite.mockTransactionRepo.EXPECT().
+					List("<WalletID>", newest, nil, 10).
+					Return([]entity.Transaction{}, false, nil)
+			},
+			want:      []api_gen.TransactionResponseData{},
+			wantTotal: null.IntFrom(0).Ptr(),
+			wantErr:   false,
+		},
 		{
 			name:     "GivenValidRequest_WhenWalletNotFound_ThenReturnError",
 			userId:   "<UserID>",
 			walletId: "<NonExistentWalletID>",
-			page:     1,
 			limit:    10,
 			setupMocks: func() {
 				suite.mockWalletRepo.EXPECT().
@@ -86,7 +168,6 @@ func (suite *QueriesTestSuite) TestListTransactionsService_Handle() {
 			name:     "GivenValidRequest_WhenWalletRepoError_ThenReturnError",
 			userId:   "<UserID>",
 			walletId: "<WalletID>",
-			page:     1,
 			limit:    10,
 			setupMocks: func() {
 				suite.mockWalletRepo.EXPECT().
@@ -101,7 +182,6 @@ func (suite *QueriesTestSuite) TestListTransactionsService_Handle() {
 			name:     "GivenValidRequest_WhenTransactionRepoError_ThenReturnError",
 			userId:   "<UserID>",
 			walletId: "<WalletID>",
-			page:     1,
 			limit:    10,
 			setupMocks: func() {
 				wallet := &entity.Wallet{
@@ -114,12 +194,8 @@ func (suite *QueriesTestSuite) TestListTransactionsService_Handle() {
 					Return(wallet, nil)
 
 				suite.mockTransactionRepo.EXPECT().
-					CountByWalletId("<WalletID>").
-					Return(int64(1), nil)
-
-				suite.mockTransactionRepo.EXPECT().
-					List("<WalletID>", 1, 10).
-					Return(nil, errors.New("transaction query failed"))
+					List("<WalletID>", newest, nil, 10).
+					Return(nil, false, errors.New("transaction query failed"))
 			},
 			want:        nil,
 			wantErr:     true,
@@ -129,7 +205,6 @@ func (suite *QueriesTestSuite) TestListTransactionsService_Handle() {
 			name:     "GivenValidRequest_WhenNoTransactions_ThenReturnEmptyArray",
 			userId:   "<UserID>",
 			walletId: "<WalletID>",
-			page:     1,
 			limit:    10,
 			setupMocks: func() {
 				wallet := &entity.Wallet{
@@ -142,28 +217,111 @@ func (suite *QueriesTestSuite) TestListTransactionsService_Handle() {
 					Return(wallet, nil)
 
 				suite.mockTransactionRepo.EXPECT().
-					CountByWalletId("<WalletID>").
-					Return(int64(0), nil)
+					List("<WalletID>", newest, nil, 10).
+					Return([]entity.Transaction{}, false, nil)
+			},
+			want:    []api_gen.TransactionResponseData{},
+			wantErr: false,
+		},
+		{
+			name:     "GivenAllFilters_WhenSuccess_ThenFilterPassedToRepository",
+			userId:   "<UserID>",
+			walletId: "<WalletID>",
+			params: api_gen.ListWalletTransactionsParams{
+				Type:                 &transferType,
+				Direction:            &out,
+				CreatedFrom:          &createdFrom,
+				CreatedTo:            &createdTo,
+				MinAmount:            &minAmount,
+				MaxAmount:            &maxAmount,
+				CounterpartyWalletId: &counterparty,
+				Memo:                 &memo,
+				Sort:                 &largest,
+			},
+			limit: 10,
+			setupMocks: func() {
+				suite.mockWalletRepo.EXPECT().
+					QueryByIdAndUser("<UserID>", "<WalletID>").
+					Return(&entity.Wallet{ID: "<WalletID>", UserID: "<UserID>"}, nil)
+
+				min, max := money.MustParse("10"), money.MustParse("500.50")
+				filter := repositories.TransactionFilter{
+					Type:                 null.StringFrom("transfer").Ptr(),
+					Direction:            null.StringFrom("out").Ptr(),
+					CreatedFrom:          &createdFrom,
+					CreatedTo:            &createdTo,
+					MinAmount:            &min,
+					MaxAmount:            &max,
+					CounterpartyWalletID: &counterparty,
+					Memo:                 &memo,
+					Sort:                 repositories.TransactionSortLargest,
+				}
+				suite.mockTransactionRepo.EXPECT().
+					List("<WalletID>", filter, nil, 10).
+					Return([]entity.Transaction{}, false, nil)
 			},
 			want:    []api_gen.TransactionResponseData{},
 			wantErr: false,
 		},
+		{
+			name:        "GivenMinAmountAboveMaxAmount_WhenHandle_ThenInvalidAmountRange",
+			userId:      "<UserID>",
+			walletId:    "<WalletID>",
+			params:      api_gen.ListWalletTransactionsParams{MinAmount: &maxAmount, MaxAmount: &minAmount},
+			limit:       10,
+			setupMocks:  func() {},
+			wantErr:     true,
+			expectedErr: "minimum amount exceeds maximum amount",
+		},
+		{
+			name:        "GivenCreatedToBeforeCreatedFrom_WhenHandle_ThenInvalidDateRange",
+			userId:      "<UserID>",
+			walletId:    "<WalletID>",
+			params:      api_gen.ListWalletTransactionsParams{CreatedFrom: &createdTo, CreatedTo: &createdFrom},
+			limit:       10,
+			setupMocks:  func() {},
+			wantErr:     true,
+			expectedErr: "start of date range is not before its end",
+		},
+		{
+			name:        "GivenAmountFinerThanScale_WhenHandle_ThenScaleExceeded",
+			userId:      "<UserID>",
+			walletId:    "<WalletID>",
+			params:      api_gen.ListWalletTransactionsParams{MinAmount: null.StringFrom("1.001").Ptr()},
+			limit:       10,
+			setupMocks:  func() {},
+			wantErr:     true,
+			expectedErr: "amount exceeds supported decimal places",
+		},
+		{
+			name:        "GivenMalformedCursor_WhenHandle_ThenInvalidCursor",
+			userId:      "<UserID>",
+			walletId:    "<WalletID>",
+			params:      api_gen.ListWalletTransactionsParams{Cursor: null.StringFrom("not-a-cursor!").Ptr()},
+			limit:       10,
+			setupMocks:  func() {},
+			wantErr:     true,
+			expectedErr: "invalid pagination cursor",
+		},
 	}
 
 	for _, tc := range testCases {
 		suite.Run(tc.name, func() {
 			tc.setupMocks()
 
-			total, result, err := suite.listTransactionsService.Handle(tc.userId, tc.walletId, tc.page, tc.limit)
+			result, pagination, err := suite.listTransactionsService.Handle(tc.userId, tc.walletId, tc.params, tc.limit)
 
 			if tc.wantErr {
 				suite.Error(err)
 				suite.Contains(err.Error(), tc.expectedErr)
-				suite.Equal(int64(0), total)
+				suite.Nil(pagination)
 				suite.Nil(result)
 			} else {
 				suite.NoError(err)
-				suite.Equal(int64(len(tc.want)), total)
+				suite.Equal(tc.limit, pagination.Limit)
+				suite.Equal(tc.wantTotal, pagination.TotalRecords)
+				suite.Equal(tc.wantNextCursor, pagination.NextCursor != nil)
+				suite.Nil(pagination.PrevCursor)
 				suite.Equal(len(tc.want), len(result))
 				if len(result) > 0 {
 					suite.Equal(tc.want[0].Id, result[0].Id)
@@ -171,8 +329,76 @@ func (suite *QueriesTestSuite) TestListTransactionsService_Handle() {
 					suite.Equal(tc.want[0].ToWalletId, result[0].ToWalletId)
 					suite.Equal(tc.want[0].Amount, result[0].Amount)
 					suite.Equal(tc.want[0].Type, result[0].Type)
+					suite.Equal(tc.want[0].Description, result[0].Description)
+					suite.Equal(tc.want[0].Reference, result[0].Reference)
+					suite.Equal(tc.want[0].Metadata, result[0].Metadata)
 				}
 			}
 		})
 	}
 }
+
+func (suite *QueriesTestSuite) TestListTransactionsService_Cursors() {
+	newest := repositories.TransactionFilter{Sort: repositories.TransactionSortNewest}
+	created := time.Date(2024, 3, 15, 9, 30, 0, 123456000, time.UTC)
+	page := func(ids ...string) []entity.Transaction {
+		transactions := []entity.Transaction{}
+		for i, id := range ids {
+			transactions = append(transactions, entity.Transaction{ID: id, Amount: money.MustParse("10"), Type: "deposit", CreatedAt: created.Add(-time.Duration(i) * time.Minute)})
+		}
+		return transactions
+	}
+	suite.mockWalletRepo.EXPECT().
+		QueryByIdAndUser("<UserID>", "<WalletID>").
+		Return(&entity.Wallet{ID: "<WalletID>", UserID: "<UserID>"}, nil).
+		Times(4)
+
+	suite.Run("GivenFirstPageWithMore_WhenHandle_ThenOnlyNextCursor", func() {
+		suite.mockTransactionRepo.EXPECT().
+			List("<WalletID>", newest, nil, 2).
+			Return(page("<TransactionID4>", "<TransactionID3>"), true, nil)
+
+		_, pagination, err := suite.listTransactionsService.Handle("<UserID>", "<WalletID>", api_gen.ListWalletTransactionsParams{}, 2)
+
+		suite.NoError(err)
+		suite.Nil(pagination.PrevCursor)
+		suite.Require().NotNil(pagination.NextCursor)
+
+		suite.Run("GivenNextCursor_WhenHandle_ThenPageAfterLastRowWithBothCursors", func() {
+			suite.mockTransactionRepo.EXPECT().
+				List("<WalletID>", newest, &repositories.TransactionCursor{CreatedAt: created.Add(-time.Minute), ID: "<TransactionID3>", Amount: money.MustParse("10")}, 2).
+				Return(page("<TransactionID2>"), false, nil)
+
+			_, next, err := suite.listTransactionsService.Handle("<UserID>", "<WalletID>", api_gen.ListWalletTransactionsParams{Cursor: pagination.NextCursor}, 2)
+
+			suite.NoError(err)
+			suite.Nil(next.NextCursor)
+			suite.Require().NotNil(next.PrevCursor)
+
+			suite.Run("GivenPrevCursor_WhenHandle_ThenPageBeforeFirstRow", func() {
+				suite.mockTransactionRepo.EXPECT().
+					List("<WalletID>", newest, &repositories.TransactionCursor{CreatedAt: created, ID: "<TransactionID2>", Amount: money.MustParse("10"), Before: true}, 2).
+					Return(page("<TransactionID4>", "<TransactionID3>"), false, nil)
+
+				_, prev, err := suite.listTransactionsService.Handle("<UserID>", "<WalletID>", api_gen.ListWalletTransactionsParams{Cursor: next.PrevCursor}, 2)
+
+				suite.NoError(err)
+				suite.Nil(prev.PrevCursor)
+				suite.NotNil(prev.NextCursor)
+			})
+		})
+	})
+
+	suite.Run("GivenCursorFromOtherSort_WhenHandle_ThenInvalidCursor", func() {
+		suite.mockTransactionRepo.EXPECT().
+			List("<WalletID>", newest, nil, 1).
+			Return(page("<TransactionID1>"), true, nil)
+		_, pagination, err := suite.listTransactionsService.Handle("<UserID>", "<WalletID>", api_gen.ListWalletTransactionsParams{}, 1)
+		suite.NoError(err)
+
+		oldest := api_gen.ListWalletTransactionsParamsSortOldest
+		_, _, err = suite.listTransactionsService.Handle("<UserID>", "<WalletID>", api_gen.ListWalletTransactionsParams{Cursor: pagination.NextCursor, Sort: &oldest}, 1)
+
+		suite.EqualError(err, "invalid pagination cursor")
+	})
+}
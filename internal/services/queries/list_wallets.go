@@ -2,6 +2,7 @@ package queries
 
 import (
 	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/money"
 	"github.com/slilp/go-wallet/internal/repositories"
 	"github.com/slilp/go-wallet/internal/repositories/entity"
 )
@@ -13,10 +14,11 @@ type ListWalletsService interface {
 
 type listWalletsService struct {
 	walletRepo repositories.WalletRepository
+	holdRepo   repositories.HoldRepository
 }
 
-func NewListWalletsService(walletRepo repositories.WalletRepository) ListWalletsService {
-	return &listWalletsService{walletRepo: walletRepo}
+func NewListWalletsService(walletRepo repositories.WalletRepository, holdRepo repositories.HoldRepository) ListWalletsService {
+	return &listWalletsService{walletRepo: walletRepo, holdRepo: holdRepo}
 }
 
 func (r *listWalletsService) Handle(userId string) ([]api_gen.WalletResponseData, error) {
@@ -25,19 +27,44 @@ func (r *listWalletsService) Handle(userId string) ([]api_gen.WalletResponseData
 		return nil, err
 	}
 
-	return mapRepoToResponse(wallets), nil
+	held := map[string]money.Amount{}
+	if len(wallets) > 0 {
+		walletIds := make([]string, 0, len(wallets))
+		for _, wallet := range wallets {
+			walletIds = append(walletIds, wallet.ID)
+		}
+		held, err = r.holdRepo.SumActiveByWallets(walletIds)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return mapRepoToResponse(wallets, held), nil
 }
 
-func mapRepoToResponse(wallets []entity.Wallet) []api_gen.WalletResponseData {
+func mapRepoToResponse(wallets []entity.Wallet, held map[string]money.Amount) []api_gen.WalletResponseData {
 	response := []api_gen.WalletResponseData{}
 	for _, wallet := range wallets {
-		response = append(response, api_gen.WalletResponseData{
-			Id:          wallet.ID,
-			Balance:     wallet.Balance,
-			Name:        wallet.Name,
-			Description: wallet.Description,
-			UpdatedAt:   wallet.UpdatedAt,
-		})
+		data := api_gen.WalletResponseData{
+			Id:               wallet.ID,
+			Balance:          wallet.Balance,
+			AvailableBalance: wallet.Balance - held[wallet.ID],
+			Name:             wallet.Name,
+			Currency:         wallet.Currency.String(),
+			Description:      wallet.Description,
+			UpdatedAt:        wallet.UpdatedAt,
+			Status:           api_gen.WalletResponseDataStatus(wallet.Status),
+			ClosedAt:         wallet.ClosedAt,
+		}
+		if wallet.CreditLimit > 0 {
+			creditUsed := wallet.CreditUsed()
+			availableCredit := min(max(data.AvailableBalance+wallet.CreditLimit, 0), wallet.CreditLimit)
+			data.CreditLimit = &wallet.CreditLimit
+			data.CreditUsed = &creditUsed
+			data.AvailableCredit = &availableCredit
+			data.InterestRate = &wallet.InterestRate
+		}
+		response = append(response, data)
 	}
 	return response
 }
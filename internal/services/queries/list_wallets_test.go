@@ -6,14 +6,20 @@ import (
 
 	"github.com/aarondl/null/v9"
 	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/money"
 	"github.com/slilp/go-wallet/internal/repositories/entity"
 	mock_repositories "github.com/slilp/go-wallet/internal/repositories/mocks"
 )
 
 func (suite *QueriesTestSuite) TestListWalletsService_Handle() {
+	creditLimit := money.MustParse("500")
+	creditUsed := money.MustParse("120")
+	availableCredit := money.MustParse("350")
+	interestRate := money.MustParseRate("0.18")
+
 	testCases := []struct {
 		name        string
-		mock        func(*mock_repositories.MockWalletRepository)
+		mock        func(*mock_repositories.MockWalletRepository, *mock_repositories.MockHoldRepository)
 		want        []api_gen.WalletResponseData
 		wantErr     bool
 		expectedErr string
@@ -21,25 +27,66 @@ func (suite *QueriesTestSuite) TestListWalletsService_Handle() {
 	}{
 		{
 			name: "GivenValidUserId_WhenWalletsExist_ThenReturnWallets",
-			mock: func(mockWalletRepo *mock_repositories.MockWalletRepository) {
+			mock: func(mockWalletRepo *mock_repositories.MockWalletRepository, mockHoldRepo *mock_repositories.MockHoldRepository) {
 				wallets := []entity.Wallet{
 					{
 						ID:          "<WalletID>",
-						Balance:     1000,
+						Balance:     money.MustParse("1000"),
+						Currency:    "USD",
 						Name:        "<WalletName>",
 						Description: null.StringFrom("<WalletDescription>").Ptr(),
 						UpdatedAt:   time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
 					},
 				}
 				mockWalletRepo.EXPECT().ListAll("user1").Return(wallets, nil)
+				mockHoldRepo.EXPECT().SumActiveByWallets([]string{"<WalletID>"}).
+					Return(map[string]money.Amount{"<WalletID>": money.MustParse("250")}, nil)
+			},
+			want: []api_gen.WalletResponseData{
+				{
+					Id:               "<WalletID>",
+					Balance:          money.MustParse("1000"),
+					AvailableBalance: money.MustParse("750"),
+					Currency:         "USD",
+					Name:             "<WalletName>",
+					Description:      null.StringFrom("<WalletDescription>").Ptr(),
+					UpdatedAt:        time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			wantErr:     false,
+			expectedErr: "",
+			userId:      "user1",
+		},
+		{
+			name: "GivenWalletUsingCreditLine_WhenListWallets_ThenAvailableCreditReturned",
+			mock: func(mockWalletRepo *mock_repositories.MockWalletRepository, mockHoldRepo *mock_repositories.MockHoldRepository) {
+				wallets := []entity.Wallet{
+					{
+						ID:           "<WalletID>",
+						Balance:      money.MustParse("-120"),
+						Currency:     "THB",
+						Name:         "<WalletName>",
+						CreditLimit:  money.MustParse("500"),
+						InterestRate: money.MustParseRate("0.18"),
+						UpdatedAt:    time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+					},
+				}
+				mockWalletRepo.EXPECT().ListAll("user1").Return(wallets, nil)
+				mockHoldRepo.EXPECT().SumActiveByWallets([]string{"<WalletID>"}).
+					Return(map[string]money.Amount{"<WalletID>": money.MustParse("30")}, nil)
 			},
 			want: []api_gen.WalletResponseData{
 				{
-					Id:          "<WalletID>",
-					Balance:     1000,
-					Name:        "<WalletName>",
-					Description: null.StringFrom("<WalletDescription>").Ptr(),
-					UpdatedAt:   time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+					Id:               "<WalletID>",
+					Balance:          money.MustParse("-120"),
+					AvailableBalance: money.MustParse("-150"),
+					Currency:         "THB",
+					Name:             "<WalletName>",
+					UpdatedAt:        time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+					CreditLimit:      &creditLimit,
+					CreditUsed:       &creditUsed,
+					AvailableCredit:  &availableCredit,
+					InterestRate:     &interestRate,
 				},
 			},
 			wantErr:     false,
@@ -48,7 +95,7 @@ func (suite *QueriesTestSuite) TestListWalletsService_Handle() {
 		},
 		{
 			name: "GivenValidUserId_WhenNoWallets_ThenReturnEmptyList",
-			mock: func(mockWalletRepo *mock_repositories.MockWalletRepository) {
+			mock: func(mockWalletRepo *mock_repositories.MockWalletRepository, mockHoldRepo *mock_repositories.MockHoldRepository) {
 				mockWalletRepo.EXPECT().ListAll("<UserID>").Return([]entity.Wallet{}, nil)
 			},
 			want:        []api_gen.WalletResponseData{},
@@ -58,7 +105,7 @@ func (suite *QueriesTestSuite) TestListWalletsService_Handle() {
 		},
 		{
 			name: "GivenUserId_WhenRepoReturnsError_ThenReturnError",
-			mock: func(mockWalletRepo *mock_repositories.MockWalletRepository) {
+			mock: func(mockWalletRepo *mock_repositories.MockWalletRepository, mockHoldRepo *mock_repositories.MockHoldRepository) {
 				mockWalletRepo.EXPECT().ListAll("<UserID>").Return(nil, errors.New("repo error"))
 			},
 			want:        nil,
@@ -70,7 +117,7 @@ func (suite *QueriesTestSuite) TestListWalletsService_Handle() {
 
 	for _, tc := range testCases {
 		suite.Run(tc.name, func() {
-			tc.mock(suite.mockWalletRepo)
+			tc.mock(suite.mockWalletRepo, suite.mockHoldRepo)
 
 			result, err := suite.listWalletsService.Handle(tc.userId)
 
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./export_transactions.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./export_transactions.go -destination=./mocks/mock_export_transactions_service.go -package=mock_queries
+//
+
+// Package mock_queries is a generated GoMock package.
+package mock_queries
+
+import (
+	io "io"
+	reflect "reflect"
+
+	api_gen "github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockExportTransactionsService is a mock of ExportTransactionsService interface.
+type MockExportTransactionsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportTransactionsServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockExportTransactionsServiceMockRecorder is the mock recorder for MockExportTransactionsService.
+type MockExportTransactionsServiceMockRecorder struct {
+	mock *MockExportTransactionsService
+}
+
+// NewMockExportTransactionsService creates a new mock instance.
+func NewMockExportTransactionsService(ctrl *gomock.Controller) *MockExportTransactionsService {
+	mock := &MockExportTransactionsService{ctrl: ctrl}
+	mock.recorder = &MockExportTransactionsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExportTransactionsService) EXPECT() *MockExportTransactionsServiceMockRecorder {
+	return m.recorder
+}
+
+// Handle mocks base method.
+func (m *MockExportTransactionsService) Handle(userId, walletId string, params api_gen.ExportWalletTransactionsParams, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Handle", userId, walletId, params, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Handle indicates an expected call of Handle.
+func (mr *MockExportTransactionsServiceMockRecorder) Handle(userId, walletId, params, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Handle", reflect.TypeOf((*MockExportTransactionsService)(nil).Handle), userId, walletId, params, w)
+}
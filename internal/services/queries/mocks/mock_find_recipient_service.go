@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./find_recipient.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./find_recipient.go -destination=./mocks/mock_find_recipient_service.go -package=mock_queries
+//
+
+// Package mock_queries is a generated GoMock package.
+package mock_queries
+
+import (
+	reflect "reflect"
+
+	api_gen "github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFindRecipientService is a mock of FindRecipientService interface.
+type MockFindRecipientService struct {
+	ctrl     *gomock.Controller
+	recorder *MockFindRecipientServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockFindRecipientServiceMockRecorder is the mock recorder for MockFindRecipientService.
+type MockFindRecipientServiceMockRecorder struct {
+	mock *MockFindRecipientService
+}
+
+// NewMockFindRecipientService creates a new mock instance.
+func NewMockFindRecipientService(ctrl *gomock.Controller) *MockFindRecipientService {
+	mock := &MockFindRecipientService{ctrl: ctrl}
+	mock.recorder = &MockFindRecipientServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFindRecipientService) EXPECT() *MockFindRecipientServiceMockRecorder {
+	return m.recorder
+}
+
+// Handle mocks base method.
+func (m *MockFindRecipientService) Handle(email, handle *string) (*api_gen.RecipientResponseData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Handle", email, handle)
+	ret0, _ := ret[0].(*api_gen.RecipientResponseData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Handle indicates an expected call of Handle.
+func (mr *MockFindRecipientServiceMockRecorder) Handle(email, handle any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Handle", reflect.TypeOf((*MockFindRecipientService)(nil).Handle), email, handle)
+}
+
+// HandleWallet mocks base method.
+func (m *MockFindRecipientService) HandleWallet(email, handle *string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleWallet", email, handle)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleWallet indicates an expected call of HandleWallet.
+func (mr *MockFindRecipientServiceMockRecorder) HandleWallet(email, handle any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleWallet", reflect.TypeOf((*MockFindRecipientService)(nil).HandleWallet), email, handle)
+}
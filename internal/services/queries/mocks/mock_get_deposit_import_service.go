@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./get_deposit_import.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./get_deposit_import.go -destination=./mocks/mock_get_deposit_import_service.go -package=mock_queries
+//
+
+// Package mock_queries is a generated GoMock package.
+package mock_queries
+
+import (
+	reflect "reflect"
+
+	api_gen "github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGetDepositImportService is a mock of GetDepositImportService interface.
+type MockGetDepositImportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockGetDepositImportServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockGetDepositImportServiceMockRecorder is the mock recorder for MockGetDepositImportService.
+type MockGetDepositImportServiceMockRecorder struct {
+	mock *MockGetDepositImportService
+}
+
+// NewMockGetDepositImportService creates a new mock instance.
+func NewMockGetDepositImportService(ctrl *gomock.Controller) *MockGetDepositImportService {
+	mock := &MockGetDepositImportService{ctrl: ctrl}
+	mock.recorder = &MockGetDepositImportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGetDepositImportService) EXPECT() *MockGetDepositImportServiceMockRecorder {
+	return m.recorder
+}
+
+// Handle mocks base method.
+func (m *MockGetDepositImportService) Handle(importId string) (*api_gen.DepositImportResponseData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Handle", importId)
+	ret0, _ := ret[0].(*api_gen.DepositImportResponseData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Handle indicates an expected call of Handle.
+func (mr *MockGetDepositImportServiceMockRecorder) Handle(importId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Handle", reflect.TypeOf((*MockGetDepositImportService)(nil).Handle), importId)
+}
+
+// HandleRows mocks base method.
+func (m *MockGetDepositImportService) HandleRows(importId string, status *string) ([]api_gen.DepositImportRowData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleRows", importId, status)
+	ret0, _ := ret[0].([]api_gen.DepositImportRowData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleRows indicates an expected call of HandleRows.
+func (mr *MockGetDepositImportServiceMockRecorder) HandleRows(importId, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleRows", reflect.TypeOf((*MockGetDepositImportService)(nil).HandleRows), importId, status)
+}
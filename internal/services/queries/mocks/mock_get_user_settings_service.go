@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./get_user_settings.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./get_user_settings.go -destination=./mocks/mock_get_user_settings_service.go -package=mock_queries
+//
+
+// Package mock_queries is a generated GoMock package.
+package mock_queries
+
+import (
+	reflect "reflect"
+
+	api_gen "github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGetUserSettingsService is a mock of GetUserSettingsService interface.
+type MockGetUserSettingsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockGetUserSettingsServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockGetUserSettingsServiceMockRecorder is the mock recorder for MockGetUserSettingsService.
+type MockGetUserSettingsServiceMockRecorder struct {
+	mock *MockGetUserSettingsService
+}
+
+// NewMockGetUserSettingsService creates a new mock instance.
+func NewMockGetUserSettingsService(ctrl *gomock.Controller) *MockGetUserSettingsService {
+	mock := &MockGetUserSettingsService{ctrl: ctrl}
+	mock.recorder = &MockGetUserSettingsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGetUserSettingsService) EXPECT() *MockGetUserSettingsServiceMockRecorder {
+	return m.recorder
+}
+
+// Handle mocks base method.
+func (m *MockGetUserSettingsService) Handle(userId string) (*api_gen.UserSettingsResponseData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Handle", userId)
+	ret0, _ := ret[0].(*api_gen.UserSettingsResponseData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Handle indicates an expected call of Handle.
+func (mr *MockGetUserSettingsServiceMockRecorder) Handle(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Handle", reflect.TypeOf((*MockGetUserSettingsService)(nil).Handle), userId)
+}
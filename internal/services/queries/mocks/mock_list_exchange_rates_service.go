@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./list_exchange_rates.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./list_exchange_rates.go -destination=./mocks/mock_list_exchange_rates_service.go -package=mock_queries
+//
+
+// Package mock_queries is a generated GoMock package.
+package mock_queries
+
+import (
+	reflect "reflect"
+
+	api_gen "github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockListExchangeRatesService is a mock of ListExchangeRatesService interface.
+type MockListExchangeRatesService struct {
+	ctrl     *gomock.Controller
+	recorder *MockListExchangeRatesServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockListExchangeRatesServiceMockRecorder is the mock recorder for MockListExchangeRatesService.
+type MockListExchangeRatesServiceMockRecorder struct {
+	mock *MockListExchangeRatesService
+}
+
+// NewMockListExchangeRatesService creates a new mock instance.
+func NewMockListExchangeRatesService(ctrl *gomock.Controller) *MockListExchangeRatesService {
+	mock := &MockListExchangeRatesService{ctrl: ctrl}
+	mock.recorder = &MockListExchangeRatesServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockListExchangeRatesService) EXPECT() *MockListExchangeRatesServiceMockRecorder {
+	return m.recorder
+}
+
+// Handle mocks base method.
+func (m *MockListExchangeRatesService) Handle() ([]api_gen.ExchangeRateResponseData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Handle")
+	ret0, _ := ret[0].([]api_gen.ExchangeRateResponseData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Handle indicates an expected call of Handle.
+func (mr *MockListExchangeRatesServiceMockRecorder) Handle() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Handle", reflect.TypeOf((*MockListExchangeRatesService)(nil).Handle))
+}
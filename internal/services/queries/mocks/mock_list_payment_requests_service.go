@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./list_payment_requests.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./list_payment_requests.go -destination=./mocks/mock_list_payment_requests_service.go -package=mock_queries
+//
+
+// Package mock_queries is a generated GoMock package.
+package mock_queries
+
+import (
+	reflect "reflect"
+
+	api_gen "github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockListPaymentRequestsService is a mock of ListPaymentRequestsService interface.
+type MockListPaymentRequestsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockListPaymentRequestsServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockListPaymentRequestsServiceMockRecorder is the mock recorder for MockListPaymentRequestsService.
+type MockListPaymentRequestsServiceMockRecorder struct {
+	mock *MockListPaymentRequestsService
+}
+
+// NewMockListPaymentRequestsService creates a new mock instance.
+func NewMockListPaymentRequestsService(ctrl *gomock.Controller) *MockListPaymentRequestsService {
+	mock := &MockListPaymentRequestsService{ctrl: ctrl}
+	mock.recorder = &MockListPaymentRequestsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockListPaymentRequestsService) EXPECT() *MockListPaymentRequestsServiceMockRecorder {
+	return m.recorder
+}
+
+// Handle mocks base method.
+func (m *MockListPaymentRequestsService) Handle(userId string, incoming bool) ([]api_gen.PaymentRequestResponseData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Handle", userId, incoming)
+	ret0, _ := ret[0].([]api_gen.PaymentRequestResponseData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Handle indicates an expected call of Handle.
+func (mr *MockListPaymentRequestsServiceMockRecorder) Handle(userId, incoming any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Handle", reflect.TypeOf((*MockListPaymentRequestsService)(nil).Handle), userId, incoming)
+}
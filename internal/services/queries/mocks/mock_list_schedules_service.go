@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./list_schedules.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./list_schedules.go -destination=./mocks/mock_list_schedules_service.go -package=mock_queries
+//
+
+// Package mock_queries is a generated GoMock package.
+package mock_queries
+
+import (
+	reflect "reflect"
+
+	api_gen "github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockListSchedulesService is a mock of ListSchedulesService interface.
+type MockListSchedulesService struct {
+	ctrl     *gomock.Controller
+	recorder *MockListSchedulesServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockListSchedulesServiceMockRecorder is the mock recorder for MockListSchedulesService.
+type MockListSchedulesServiceMockRecorder struct {
+	mock *MockListSchedulesService
+}
+
+// NewMockListSchedulesService creates a new mock instance.
+func NewMockListSchedulesService(ctrl *gomock.Controller) *MockListSchedulesService {
+	mock := &MockListSchedulesService{ctrl: ctrl}
+	mock.recorder = &MockListSchedulesServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockListSchedulesService) EXPECT() *MockListSchedulesServiceMockRecorder {
+	return m.recorder
+}
+
+// Handle mocks base method.
+func (m *MockListSchedulesService) Handle(userId string) ([]api_gen.ScheduleResponseData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Handle", userId)
+	ret0, _ := ret[0].([]api_gen.ScheduleResponseData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Handle indicates an expected call of Handle.
+func (mr *MockListSchedulesServiceMockRecorder) Handle(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Handle", reflect.TypeOf((*MockListSchedulesService)(nil).Handle), userId)
+}
+
+// HandleRuns mocks base method.
+func (m *MockListSchedulesService) HandleRuns(userId, scheduleId string) ([]api_gen.ScheduleRunResponseData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleRuns", userId, scheduleId)
+	ret0, _ := ret[0].([]api_gen.ScheduleRunResponseData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleRuns indicates an expected call of HandleRuns.
+func (mr *MockListSchedulesServiceMockRecorder) HandleRuns(userId, scheduleId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleRuns", reflect.TypeOf((*MockListSchedulesService)(nil).HandleRuns), userId, scheduleId)
+}
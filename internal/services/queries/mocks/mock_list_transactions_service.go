@@ -41,17 +41,17 @@ func (m *MockListTransactionsService) EXPECT() *MockListTransactionsServiceMockR
 }
 
 // Handle mocks base method.
-func (m *MockListTransactionsService) Handle(userId, walletId string, page, limit int) (int64, []api_gen.TransactionResponseData, error) {
+func (m *MockListTransactionsService) Handle(userId, walletId string, params api_gen.ListWalletTransactionsParams, limit int) ([]api_gen.TransactionResponseData, *api_gen.CursorPageResponseData, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Handle", userId, walletId, page, limit)
-	ret0, _ := ret[0].(int64)
-	ret1, _ := ret[1].([]api_gen.TransactionResponseData)
+	ret := m.ctrl.Call(m, "Handle", userId, walletId, params, limit)
+	ret0, _ := ret[0].([]api_gen.TransactionResponseData)
+	ret1, _ := ret[1].(*api_gen.CursorPageResponseData)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // Handle indicates an expected call of Handle.
-func (mr *MockListTransactionsServiceMockRecorder) Handle(userId, walletId, page, limit any) *gomock.Call {
+func (mr *MockListTransactionsServiceMockRecorder) Handle(userId, walletId, params, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Handle", reflect.TypeOf((*MockListTransactionsService)(nil).Handle), userId, walletId, page, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Handle", reflect.TypeOf((*MockListTransactionsService)(nil).Handle), userId, walletId, params, limit)
 }
@@ -11,13 +11,25 @@ import (
 
 type QueriesTestSuite struct {
 	suite.Suite
-	loginService            queries.LoginService
-	listWalletsService      queries.ListWalletsService
-	listTransactionsService queries.ListTransactionsService
+	loginService               queries.LoginService
+	listWalletsService         queries.ListWalletsService
+	listTransactionsService    queries.ListTransactionsService
+	exportTransactionsService  queries.ExportTransactionsService
+	listExchangeRatesService   queries.ListExchangeRatesService
+	listSchedulesService       queries.ListSchedulesService
+	listPaymentRequestsService queries.ListPaymentRequestsService
+	findRecipientService       queries.FindRecipientService
+	getUserSettingsService     queries.GetUserSettingsService
+	getDepositImportService    queries.GetDepositImportService
 
-	mockUserRepo        *mock_repositories.MockUserRepository
-	mockWalletRepo      *mock_repositories.MockWalletRepository
-	mockTransactionRepo *mock_repositories.MockTransactionRepository
+	mockUserRepo           *mock_repositories.MockUserRepository
+	mockWalletRepo         *mock_repositories.MockWalletRepository
+	mockTransactionRepo    *mock_repositories.MockTransactionRepository
+	mockExchangeRateRepo   *mock_repositories.MockExchangeRateRepository
+	mockHoldRepo           *mock_repositories.MockHoldRepository
+	mockScheduleRepo       *mock_repositories.MockScheduleRepository
+	mockPaymentRequestRepo *mock_repositories.MockPaymentRequestRepository
+	mockDepositImportRepo  *mock_repositories.MockDepositImportRepository
 }
 
 func (suite *QueriesTestSuite) SetupTest() {
@@ -26,13 +38,30 @@ func (suite *QueriesTestSuite) SetupTest() {
 	mockUserRepo := mock_repositories.NewMockUserRepository(ctrl)
 	mockWalletRepo := mock_repositories.NewMockWalletRepository(ctrl)
 	mockTransactionRepo := mock_repositories.NewMockTransactionRepository(ctrl)
+	mockExchangeRateRepo := mock_repositories.NewMockExchangeRateRepository(ctrl)
 	suite.mockUserRepo = mockUserRepo
 	suite.mockWalletRepo = mockWalletRepo
 	suite.mockTransactionRepo = mockTransactionRepo
+	mockHoldRepo := mock_repositories.NewMockHoldRepository(ctrl)
+	suite.mockExchangeRateRepo = mockExchangeRateRepo
+	mockScheduleRepo := mock_repositories.NewMockScheduleRepository(ctrl)
+	suite.mockHoldRepo = mockHoldRepo
+	suite.mockScheduleRepo = mockScheduleRepo
+	mockPaymentRequestRepo := mock_repositories.NewMockPaymentRequestRepository(ctrl)
+	suite.mockPaymentRequestRepo = mockPaymentRequestRepo
+	mockDepositImportRepo := mock_repositories.NewMockDepositImportRepository(ctrl)
+	suite.mockDepositImportRepo = mockDepositImportRepo
 
 	suite.loginService = queries.NewLoginService(mockUserRepo)
-	suite.listWalletsService = queries.NewListWalletsService(mockWalletRepo)
+	suite.listWalletsService = queries.NewListWalletsService(mockWalletRepo, mockHoldRepo)
 	suite.listTransactionsService = queries.NewListTransactionsService(mockWalletRepo, mockTransactionRepo)
+	suite.exportTransactionsService = queries.NewExportTransactionsService(mockWalletRepo, mockTransactionRepo)
+	suite.listExchangeRatesService = queries.NewListExchangeRatesService(mockExchangeRateRepo)
+	suite.listSchedulesService = queries.NewListSchedulesService(mockScheduleRepo)
+	suite.listPaymentRequestsService = queries.NewListPaymentRequestsService(mockPaymentRequestRepo)
+	suite.findRecipientService = queries.NewFindRecipientService(mockUserRepo, mockWalletRepo)
+	suite.getUserSettingsService = queries.NewGetUserSettingsService(mockUserRepo)
+	suite.getDepositImportService = queries.NewGetDepositImportService(mockDepositImportRepo)
 }
 
 func TestQueriesTestSuite(t *testing.T) {
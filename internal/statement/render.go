@@ -0,0 +1,135 @@
+// Package statement renders wallet statements as CSV and PDF documents.
+package statement
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/money"
+)
+
+const (
+	dateFormat     = "2006-01-02"
+	dateTimeFormat = "2006-01-02 15:04:05"
+)
+
+var csvHeader = []string{"date", "transaction_id", "type", "description", "reference", "counterparty_wallet_id", "money_in", "money_out", "balance"}
+
+// WriteCSV writes one row per statement line, framed by opening and closing
+// balance rows so the file reconciles on its own.
+func WriteCSV(w io.Writer, s api_gen.StatementResponseData) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	if err := writer.Write(balanceRow(s.PeriodStart, "opening_balance", s.OpeningBalance)); err != nil {
+		return err
+	}
+	for _, line := range s.Lines {
+		moneyIn, moneyOut := split(line.Amount)
+		row := []string{
+			line.CreatedAt.UTC().Format(dateTimeFormat),
+			deref(line.TransactionId),
+			line.Type,
+			csvText(deref(line.Description)),
+			csvText(deref(line.Reference)),
+			deref(line.CounterpartyWalletId),
+			moneyIn,
+			moneyOut,
+			line.BalanceAfter.String(),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	if err := writer.Write(balanceRow(s.PeriodEnd, "closing_balance", s.ClosingBalance)); err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// WritePDF writes a plain A4 statement: a summary header followed by the
+// lines in a fixed-width table, continued over as many pages as needed.
+func WritePDF(w io.Writer, s api_gen.StatementResponseData) error {
+	text := []string{
+		"Wallet statement",
+		"",
+		fmt.Sprintf("Wallet:   %s", s.WalletId),
+		fmt.Sprintf("Currency: %s", s.Currency),
+		fmt.Sprintf("Period:   %s to %s", s.PeriodStart.UTC().Format(dateFormat), s.PeriodEnd.UTC().AddDate(0, 0, -1).Format(dateFormat)),
+		fmt.Sprintf("Status:   %s, generated %s UTC", status(s.Final), s.GeneratedAt.UTC().Format(dateTimeFormat)),
+		"",
+		fmt.Sprintf("Opening balance: %15s", s.OpeningBalance),
+		fmt.Sprintf("Money in:        %15s", s.TotalIn),
+		fmt.Sprintf("Money out:       %15s", s.TotalOut),
+		fmt.Sprintf("Closing balance: %15s", s.ClosingBalance),
+		"",
+		fmt.Sprintf("%-16s  %-12s  %-24s  %12s  %12s  %12s", "Date", "Type", "Description", "Money in", "Money out", "Balance"),
+		strings.Repeat("-", 100),
+	}
+	for _, line := range s.Lines {
+		moneyIn, moneyOut := split(line.Amount)
+		description := deref(line.Description)
+		if description == "" {
+			description = deref(line.TransactionId)
+		}
+		text = append(text, fmt.Sprintf("%-16s  %-12s  %-24s  %12s  %12s  %12s",
+			line.CreatedAt.UTC().Format("2006-01-02 15:04"), truncate(line.Type, 12), truncate(description, 24),
+			moneyIn, moneyOut, line.BalanceAfter))
+	}
+	if len(s.Lines) == 0 {
+		text = append(text, "No activity in this period.")
+	}
+
+	return writePDF(w, text)
+}
+
+func balanceRow(at time.Time, label string, balance money.Amount) []string {
+	return []string{at.UTC().Format(dateTimeFormat), "", label, "", "", "", "", "", balance.String()}
+}
+
+// split returns a signed amount as its money-in and money-out columns.
+func split(amount money.Amount) (string, string) {
+	if amount < 0 {
+		return "", amount.Abs().String()
+	}
+	return amount.String(), ""
+}
+
+func status(final bool) string {
+	if final {
+		return "final"
+	}
+	return "provisional"
+}
+
+// csvText quotes free text that a spreadsheet would otherwise evaluate as a
+// formula. Descriptions and references can be set by the counterparty.
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "~"
+}
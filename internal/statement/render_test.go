@@ -0,0 +1,109 @@
+package statement_test
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
+	"github.com/slilp/go-wallet/internal/money"
+	"github.com/slilp/go-wallet/internal/statement"
+)
+
+func sampleStatement(lines int) api_gen.StatementResponseData {
+	march := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	transactionId, description := "TRN1", "Lunch (split), café ☕"
+	s := api_gen.StatementResponseData{
+		WalletId:       "<WalletID>",
+		Currency:       "THB",
+		PeriodStart:    march,
+		PeriodEnd:      march.AddDate(0, 1, 0),
+		OpeningBalance: money.MustParse("100"),
+		ClosingBalance: money.MustParse("100"),
+		Final:          true,
+		GeneratedAt:    march.AddDate(0, 1, 1),
+		Lines:          []api_gen.StatementLineData{},
+	}
+	for i := 0; i < lines; i++ {
+		amount := money.MustParse("-12.50")
+		if i%2 == 0 {
+			amount = money.MustParse("20")
+		}
+		s.ClosingBalance += amount
+		s.Lines = append(s.Lines, api_gen.StatementLineData{
+			TransactionId: &transactionId,
+			Type:          "transfer",
+			Description:   &description,
+			Amount:        amount,
+			BalanceAfter:  s.ClosingBalance,
+			CreatedAt:     march.Add(time.Duration(i) * time.Hour),
+		})
+	}
+	return s
+}
+
+func (suite *StatementTestSuite) TestWriteCSV() {
+	var buf bytes.Buffer
+	suite.NoError(statement.WriteCSV(&buf, sampleStatement(2)))
+
+	suite.Equal(strings.Join([]string{
+		"date,transaction_id,type,description,reference,counterparty_wallet_id,money_in,money_out,balance",
+		"2024-03-01 00:00:00,,opening_balance,,,,,,100.00",
+		`2024-03-01 00:00:00,TRN1,transfer,"Lunch (split), café ☕",,,20.00,,120.00`,
+		`2024-03-01 01:00:00,TRN1,transfer,"Lunch (split), café ☕",,,,12.50,107.50`,
+		"2024-04-01 00:00:00,,closing_balance,,,,,,107.50",
+		"",
+	}, "\n"), buf.String())
+}
+
+func (suite *StatementTestSuite) TestWriteCSV_EscapesFormulas() {
+	s := sampleStatement(1)
+	description, reference := "=cmd|' /C calc'!A0", "@SUM(A1)"
+	s.Lines[0].Description = &description
+	s.Lines[0].Reference = &reference
+
+	var buf bytes.Buffer
+	suite.NoError(statement.WriteCSV(&buf, s))
+	suite.Contains(buf.String(), `TRN1,transfer,'=cmd|' /C calc'!A0,'@SUM(A1),,20.00,,120.00`)
+}
+
+func (suite *StatementTestSuite) TestWritePDF() {
+	testCases := []struct {
+		name      string
+		lines     int
+		wantPages int
+	}{
+		{name: "GivenNoLines_ThenSinglePage", lines: 0, wantPages: 1},
+		{name: "GivenManyLines_ThenContinuedOverPages", lines: 150, wantPages: 3},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			var buf bytes.Buffer
+			suite.NoError(statement.WritePDF(&buf, sampleStatement(tc.lines)))
+			doc := buf.String()
+
+			suite.True(strings.HasPrefix(doc, "%PDF-1.4\n"))
+			suite.True(strings.HasSuffix(doc, "%%EOF\n"))
+			suite.Contains(doc, fmt.Sprintf("/Count %d", tc.wantPages))
+			suite.Contains(doc, fmt.Sprintf("(Page %d of %d) '", tc.wantPages, tc.wantPages))
+
+			// Every xref entry must point at the object it numbers.
+			startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindStringSubmatch(doc)
+			suite.Require().Len(startxref, 2)
+			offset, _ := strconv.Atoi(startxref[1])
+			suite.True(strings.HasPrefix(doc[offset:], "xref\n"))
+			for i, entry := range regexp.MustCompile(`(\d{10}) 00000 n`).FindAllStringSubmatch(doc, -1) {
+				at, _ := strconv.Atoi(entry[1])
+				suite.True(strings.HasPrefix(doc[at:], fmt.Sprintf("%d 0 obj\n", i+1)))
+			}
+
+			if tc.lines > 0 {
+				suite.Contains(doc, `Lunch \(split\), caf\351 ?`)
+			}
+		})
+	}
+}
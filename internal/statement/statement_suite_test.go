@@ -0,0 +1,16 @@
+package statement_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StatementTestSuite struct {
+	suite.Suite
+}
+
+func TestStatementTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(StatementTestSuite))
+}
@@ -9,19 +9,14 @@ import (
 	"github.com/slilp/go-wallet/internal/api/restapis/api_gen"
 )
 
-func GetPaginationParams(pageQuery, limitQuery *int) (int, int) {
-	page := 1
+func GetLimitParam(limitQuery *int) int {
 	limit := 20
 
-	if pageQuery != nil {
-		page = *pageQuery
-	}
-
 	if limitQuery != nil {
 		limit = *limitQuery
 	}
 
-	return page, limit
+	return limit
 }
 
 func BindAndValidateRequestBody(ctx *gin.Context, req interface{}, validate *validator.Validate) bool {
@@ -29,7 +24,13 @@ func BindAndValidateRequestBody(ctx *gin.Context, req interface{}, validate *val
 		ctx.JSON(http.StatusBadRequest, api_gen.ErrorResponse{ErrorCode: "400", ErrorMessage: err.Error()})
 		return false
 	}
-	if err := validate.Struct(req); err != nil {
+	return ValidateRequestParams(ctx, req, validate)
+}
+
+// ValidateRequestParams validates already bound request parameters, such as
+// generated query parameter structs, and writes a 400 response if they fail.
+func ValidateRequestParams(ctx *gin.Context, params interface{}, validate *validator.Validate) bool {
+	if err := validate.Struct(params); err != nil {
 		var errors []string
 		for _, err := range err.(validator.ValidationErrors) {
 			errors = append(errors, err.Field()+" "+err.Tag()+" "+err.Param())